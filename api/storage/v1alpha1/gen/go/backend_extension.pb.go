@@ -0,0 +1,2609 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: backend_extension.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NvmeMultipathPolicy is the multipath policy of namespaces of a controller
+type NvmeMultipathPolicy int32
+
+const (
+	// SPDK default
+	NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_UNSPECIFIED    NvmeMultipathPolicy = 0
+	NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE NvmeMultipathPolicy = 1
+	NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE  NvmeMultipathPolicy = 2
+)
+
+// Enum value maps for NvmeMultipathPolicy.
+var (
+	NvmeMultipathPolicy_name = map[int32]string{
+		0: "NVME_MULTIPATH_POLICY_UNSPECIFIED",
+		1: "NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE",
+		2: "NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE",
+	}
+	NvmeMultipathPolicy_value = map[string]int32{
+		"NVME_MULTIPATH_POLICY_UNSPECIFIED":    0,
+		"NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE": 1,
+		"NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE":  2,
+	}
+)
+
+func (x NvmeMultipathPolicy) Enum() *NvmeMultipathPolicy {
+	p := new(NvmeMultipathPolicy)
+	*p = x
+	return p
+}
+
+func (x NvmeMultipathPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NvmeMultipathPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_backend_extension_proto_enumTypes[0].Descriptor()
+}
+
+func (NvmeMultipathPolicy) Type() protoreflect.EnumType {
+	return &file_backend_extension_proto_enumTypes[0]
+}
+
+func (x NvmeMultipathPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NvmeMultipathPolicy.Descriptor instead.
+func (NvmeMultipathPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{0}
+}
+
+// NvmeMultipathSelector is the path selector of the active-active policy
+type NvmeMultipathSelector int32
+
+const (
+	// SPDK default
+	NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_UNSPECIFIED NvmeMultipathSelector = 0
+	NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_ROUND_ROBIN NvmeMultipathSelector = 1
+	NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH NvmeMultipathSelector = 2
+)
+
+// Enum value maps for NvmeMultipathSelector.
+var (
+	NvmeMultipathSelector_name = map[int32]string{
+		0: "NVME_MULTIPATH_SELECTOR_UNSPECIFIED",
+		1: "NVME_MULTIPATH_SELECTOR_ROUND_ROBIN",
+		2: "NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH",
+	}
+	NvmeMultipathSelector_value = map[string]int32{
+		"NVME_MULTIPATH_SELECTOR_UNSPECIFIED": 0,
+		"NVME_MULTIPATH_SELECTOR_ROUND_ROBIN": 1,
+		"NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH": 2,
+	}
+)
+
+func (x NvmeMultipathSelector) Enum() *NvmeMultipathSelector {
+	p := new(NvmeMultipathSelector)
+	*p = x
+	return p
+}
+
+func (x NvmeMultipathSelector) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NvmeMultipathSelector) Descriptor() protoreflect.EnumDescriptor {
+	return file_backend_extension_proto_enumTypes[1].Descriptor()
+}
+
+func (NvmeMultipathSelector) Type() protoreflect.EnumType {
+	return &file_backend_extension_proto_enumTypes[1]
+}
+
+func (x NvmeMultipathSelector) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NvmeMultipathSelector.Descriptor instead.
+func (NvmeMultipathSelector) EnumDescriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{1}
+}
+
+// AioEngine is the I/O engine SPDK accesses the file of an Aio controller with
+type AioEngine int32
+
+const (
+	// AIO_ENGINE_LIBAIO on create and update
+	AioEngine_AIO_ENGINE_UNSPECIFIED AioEngine = 0
+	// SPDK AIO bdev using Linux libaio
+	AioEngine_AIO_ENGINE_LIBAIO AioEngine = 1
+	// SPDK uring bdev using io_uring, SPDK has to be built with it
+	AioEngine_AIO_ENGINE_IO_URING AioEngine = 2
+)
+
+// Enum value maps for AioEngine.
+var (
+	AioEngine_name = map[int32]string{
+		0: "AIO_ENGINE_UNSPECIFIED",
+		1: "AIO_ENGINE_LIBAIO",
+		2: "AIO_ENGINE_IO_URING",
+	}
+	AioEngine_value = map[string]int32{
+		"AIO_ENGINE_UNSPECIFIED": 0,
+		"AIO_ENGINE_LIBAIO":      1,
+		"AIO_ENGINE_IO_URING":    2,
+	}
+)
+
+func (x AioEngine) Enum() *AioEngine {
+	p := new(AioEngine)
+	*p = x
+	return p
+}
+
+func (x AioEngine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AioEngine) Descriptor() protoreflect.EnumDescriptor {
+	return file_backend_extension_proto_enumTypes[2].Descriptor()
+}
+
+func (AioEngine) Type() protoreflect.EnumType {
+	return &file_backend_extension_proto_enumTypes[2]
+}
+
+func (x AioEngine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AioEngine.Descriptor instead.
+func (AioEngine) EnumDescriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{2}
+}
+
+// NvmePathExtension is the state of an NvmePath in SPDK
+type NvmePathExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// state of the path in SPDK e.g. enabled, resetting or failed,
+	// missing if SPDK has no such path and discovery for paths to
+	// discovery controllers, which SPDK does not report state of
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// connected reports if the path is connected to the remote controller
+	Connected bool `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	// current reports if the path is used for I/O by multipath
+	Current bool `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	// accessible reports if namespaces are accessible through the path,
+	// i.e. ANA state of the path is optimized or non-optimized
+	Accessible bool `protobuf:"varint,4,opt,name=accessible,proto3" json:"accessible,omitempty"`
+}
+
+func (x *NvmePathExtension) Reset() {
+	*x = NvmePathExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NvmePathExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NvmePathExtension) ProtoMessage() {}
+
+func (x *NvmePathExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NvmePathExtension.ProtoReflect.Descriptor instead.
+func (*NvmePathExtension) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NvmePathExtension) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *NvmePathExtension) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *NvmePathExtension) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *NvmePathExtension) GetAccessible() bool {
+	if x != nil {
+		return x.Accessible
+	}
+	return false
+}
+
+type ExtendedNvmePath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NvmePath  *_go.NvmePath      `protobuf:"bytes,1,opt,name=nvme_path,json=nvmePath,proto3" json:"nvme_path,omitempty"`
+	Extension *NvmePathExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *ExtendedNvmePath) Reset() {
+	*x = ExtendedNvmePath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedNvmePath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedNvmePath) ProtoMessage() {}
+
+func (x *ExtendedNvmePath) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedNvmePath.ProtoReflect.Descriptor instead.
+func (*ExtendedNvmePath) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExtendedNvmePath) GetNvmePath() *_go.NvmePath {
+	if x != nil {
+		return x.NvmePath
+	}
+	return nil
+}
+
+func (x *ExtendedNvmePath) GetExtension() *NvmePathExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type ListExtendedNvmePathsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListExtendedNvmePathsRequest) Reset() {
+	*x = ListExtendedNvmePathsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNvmePathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNvmePathsRequest) ProtoMessage() {}
+
+func (x *ListExtendedNvmePathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNvmePathsRequest.ProtoReflect.Descriptor instead.
+func (*ListExtendedNvmePathsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListExtendedNvmePathsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListExtendedNvmePathsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExtendedNvmePathsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExtendedNvmePathsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtendedNvmePaths []*ExtendedNvmePath `protobuf:"bytes,1,rep,name=extended_nvme_paths,json=extendedNvmePaths,proto3" json:"extended_nvme_paths,omitempty"`
+	NextPageToken     string              `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListExtendedNvmePathsResponse) Reset() {
+	*x = ListExtendedNvmePathsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNvmePathsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNvmePathsResponse) ProtoMessage() {}
+
+func (x *ListExtendedNvmePathsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNvmePathsResponse.ProtoReflect.Descriptor instead.
+func (*ListExtendedNvmePathsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListExtendedNvmePathsResponse) GetExtendedNvmePaths() []*ExtendedNvmePath {
+	if x != nil {
+		return x.ExtendedNvmePaths
+	}
+	return nil
+}
+
+func (x *ListExtendedNvmePathsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetExtendedNvmePathRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetExtendedNvmePathRequest) Reset() {
+	*x = GetExtendedNvmePathRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExtendedNvmePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExtendedNvmePathRequest) ProtoMessage() {}
+
+func (x *GetExtendedNvmePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExtendedNvmePathRequest.ProtoReflect.Descriptor instead.
+func (*GetExtendedNvmePathRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetExtendedNvmePathRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// NvmeRemoteControllerExtension is multipath policy and reconnect settings
+// of an NvmeRemoteController. The policy is set on namespaces right away.
+// SPDK applies reconnect settings only when paths are attached, so they
+// cannot be updated while the controller has paths.
+type NvmeRemoteControllerExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// multipath policy, requires NVME_MULTIPATH_MULTIPATH controller
+	MultipathPolicy NvmeMultipathPolicy `protobuf:"varint,1,opt,name=multipath_policy,json=multipathPolicy,proto3,enum=opi_spdk_bridge.storage.v1.NvmeMultipathPolicy" json:"multipath_policy,omitempty"`
+	// path selector, requires NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE policy
+	MultipathSelector NvmeMultipathSelector `protobuf:"varint,2,opt,name=multipath_selector,json=multipathSelector,proto3,enum=opi_spdk_bridge.storage.v1.NvmeMultipathSelector" json:"multipath_selector,omitempty"`
+	// how long SPDK tries to reconnect a lost path before deleting it,
+	// -1 means forever and 0 no reconnect
+	CtrlrLossTimeoutSec int64 `protobuf:"varint,3,opt,name=ctrlr_loss_timeout_sec,json=ctrlrLossTimeoutSec,proto3" json:"ctrlr_loss_timeout_sec,omitempty"`
+	// delay between reconnect attempts
+	ReconnectDelaySec int64 `protobuf:"varint,4,opt,name=reconnect_delay_sec,json=reconnectDelaySec,proto3" json:"reconnect_delay_sec,omitempty"`
+	// how long I/O waits for a lost path to reconnect before it fails,
+	// 0 means until the path is deleted
+	FastIoFailTimeoutSec int64 `protobuf:"varint,5,opt,name=fast_io_fail_timeout_sec,json=fastIoFailTimeoutSec,proto3" json:"fast_io_fail_timeout_sec,omitempty"`
+}
+
+func (x *NvmeRemoteControllerExtension) Reset() {
+	*x = NvmeRemoteControllerExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NvmeRemoteControllerExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NvmeRemoteControllerExtension) ProtoMessage() {}
+
+func (x *NvmeRemoteControllerExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NvmeRemoteControllerExtension.ProtoReflect.Descriptor instead.
+func (*NvmeRemoteControllerExtension) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NvmeRemoteControllerExtension) GetMultipathPolicy() NvmeMultipathPolicy {
+	if x != nil {
+		return x.MultipathPolicy
+	}
+	return NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_UNSPECIFIED
+}
+
+func (x *NvmeRemoteControllerExtension) GetMultipathSelector() NvmeMultipathSelector {
+	if x != nil {
+		return x.MultipathSelector
+	}
+	return NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_UNSPECIFIED
+}
+
+func (x *NvmeRemoteControllerExtension) GetCtrlrLossTimeoutSec() int64 {
+	if x != nil {
+		return x.CtrlrLossTimeoutSec
+	}
+	return 0
+}
+
+func (x *NvmeRemoteControllerExtension) GetReconnectDelaySec() int64 {
+	if x != nil {
+		return x.ReconnectDelaySec
+	}
+	return 0
+}
+
+func (x *NvmeRemoteControllerExtension) GetFastIoFailTimeoutSec() int64 {
+	if x != nil {
+		return x.FastIoFailTimeoutSec
+	}
+	return 0
+}
+
+type ExtendedNvmeRemoteController struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NvmeRemoteController *_go.NvmeRemoteController      `protobuf:"bytes,1,opt,name=nvme_remote_controller,json=nvmeRemoteController,proto3" json:"nvme_remote_controller,omitempty"`
+	Extension            *NvmeRemoteControllerExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *ExtendedNvmeRemoteController) Reset() {
+	*x = ExtendedNvmeRemoteController{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedNvmeRemoteController) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedNvmeRemoteController) ProtoMessage() {}
+
+func (x *ExtendedNvmeRemoteController) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedNvmeRemoteController.ProtoReflect.Descriptor instead.
+func (*ExtendedNvmeRemoteController) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExtendedNvmeRemoteController) GetNvmeRemoteController() *_go.NvmeRemoteController {
+	if x != nil {
+		return x.NvmeRemoteController
+	}
+	return nil
+}
+
+func (x *ExtendedNvmeRemoteController) GetExtension() *NvmeRemoteControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type CreateExtendedNvmeRemoteControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NvmeRemoteController   *_go.NvmeRemoteController      `protobuf:"bytes,1,opt,name=nvme_remote_controller,json=nvmeRemoteController,proto3" json:"nvme_remote_controller,omitempty"`
+	NvmeRemoteControllerId string                         `protobuf:"bytes,2,opt,name=nvme_remote_controller_id,json=nvmeRemoteControllerId,proto3" json:"nvme_remote_controller_id,omitempty"`
+	Extension              *NvmeRemoteControllerExtension `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) Reset() {
+	*x = CreateExtendedNvmeRemoteControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExtendedNvmeRemoteControllerRequest) ProtoMessage() {}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExtendedNvmeRemoteControllerRequest.ProtoReflect.Descriptor instead.
+func (*CreateExtendedNvmeRemoteControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) GetNvmeRemoteController() *_go.NvmeRemoteController {
+	if x != nil {
+		return x.NvmeRemoteController
+	}
+	return nil
+}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) GetNvmeRemoteControllerId() string {
+	if x != nil {
+		return x.NvmeRemoteControllerId
+	}
+	return ""
+}
+
+func (x *CreateExtendedNvmeRemoteControllerRequest) GetExtension() *NvmeRemoteControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type UpdateExtendedNvmeRemoteControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object's `name` field is used to identify the object to be updated.
+	NvmeRemoteController *_go.NvmeRemoteController      `protobuf:"bytes,1,opt,name=nvme_remote_controller,json=nvmeRemoteController,proto3" json:"nvme_remote_controller,omitempty"`
+	Extension            *NvmeRemoteControllerExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+	// The list of fields of ExtendedNvmeRemoteController to update,
+	// e.g. extension.reconnect_delay_sec. Only fields of the extension
+	// can be updated.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) Reset() {
+	*x = UpdateExtendedNvmeRemoteControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExtendedNvmeRemoteControllerRequest) ProtoMessage() {}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExtendedNvmeRemoteControllerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExtendedNvmeRemoteControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) GetNvmeRemoteController() *_go.NvmeRemoteController {
+	if x != nil {
+		return x.NvmeRemoteController
+	}
+	return nil
+}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) GetExtension() *NvmeRemoteControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+func (x *UpdateExtendedNvmeRemoteControllerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ListExtendedNvmeRemoteControllersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) Reset() {
+	*x = ListExtendedNvmeRemoteControllersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNvmeRemoteControllersRequest) ProtoMessage() {}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNvmeRemoteControllersRequest.ProtoReflect.Descriptor instead.
+func (*ListExtendedNvmeRemoteControllersRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExtendedNvmeRemoteControllersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExtendedNvmeRemoteControllersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtendedNvmeRemoteControllers []*ExtendedNvmeRemoteController `protobuf:"bytes,1,rep,name=extended_nvme_remote_controllers,json=extendedNvmeRemoteControllers,proto3" json:"extended_nvme_remote_controllers,omitempty"`
+	NextPageToken                 string                          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListExtendedNvmeRemoteControllersResponse) Reset() {
+	*x = ListExtendedNvmeRemoteControllersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNvmeRemoteControllersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNvmeRemoteControllersResponse) ProtoMessage() {}
+
+func (x *ListExtendedNvmeRemoteControllersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNvmeRemoteControllersResponse.ProtoReflect.Descriptor instead.
+func (*ListExtendedNvmeRemoteControllersResponse) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListExtendedNvmeRemoteControllersResponse) GetExtendedNvmeRemoteControllers() []*ExtendedNvmeRemoteController {
+	if x != nil {
+		return x.ExtendedNvmeRemoteControllers
+	}
+	return nil
+}
+
+func (x *ListExtendedNvmeRemoteControllersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetExtendedNvmeRemoteControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetExtendedNvmeRemoteControllerRequest) Reset() {
+	*x = GetExtendedNvmeRemoteControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExtendedNvmeRemoteControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExtendedNvmeRemoteControllerRequest) ProtoMessage() {}
+
+func (x *GetExtendedNvmeRemoteControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExtendedNvmeRemoteControllerRequest.ProtoReflect.Descriptor instead.
+func (*GetExtendedNvmeRemoteControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetExtendedNvmeRemoteControllerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// NullDebugExtension is metadata and T10 DIF format of a NullDebug, as
+// reported by SPDK. Metadata is interleaved with data, so block_size of the
+// NullDebug includes md_size. DIF insert and strip is not supported per
+// NullDebug, SPDK does it in the NVMe/TCP transport for all namespaces,
+// which the bridge enables with -tcp_dif_insert_or_strip.
+type NullDebugExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// size of metadata of each block in bytes, 0, 8, 16, 32, 64 or 128
+	MdSize int64 `protobuf:"varint,1,opt,name=md_size,json=mdSize,proto3" json:"md_size,omitempty"`
+	// type of T10 DIF protection, 0 (no DIF) to 3, requires md_size
+	DifType int32 `protobuf:"varint,2,opt,name=dif_type,json=difType,proto3" json:"dif_type,omitempty"`
+	// dif_is_head_of_md places DIF at the beginning of metadata instead of
+	// its end
+	DifIsHeadOfMd bool `protobuf:"varint,3,opt,name=dif_is_head_of_md,json=difIsHeadOfMd,proto3" json:"dif_is_head_of_md,omitempty"`
+}
+
+func (x *NullDebugExtension) Reset() {
+	*x = NullDebugExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NullDebugExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NullDebugExtension) ProtoMessage() {}
+
+func (x *NullDebugExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NullDebugExtension.ProtoReflect.Descriptor instead.
+func (*NullDebugExtension) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NullDebugExtension) GetMdSize() int64 {
+	if x != nil {
+		return x.MdSize
+	}
+	return 0
+}
+
+func (x *NullDebugExtension) GetDifType() int32 {
+	if x != nil {
+		return x.DifType
+	}
+	return 0
+}
+
+func (x *NullDebugExtension) GetDifIsHeadOfMd() bool {
+	if x != nil {
+		return x.DifIsHeadOfMd
+	}
+	return false
+}
+
+type ExtendedNullDebug struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NullDebug *_go.NullDebug      `protobuf:"bytes,1,opt,name=null_debug,json=nullDebug,proto3" json:"null_debug,omitempty"`
+	Extension *NullDebugExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *ExtendedNullDebug) Reset() {
+	*x = ExtendedNullDebug{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedNullDebug) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedNullDebug) ProtoMessage() {}
+
+func (x *ExtendedNullDebug) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedNullDebug.ProtoReflect.Descriptor instead.
+func (*ExtendedNullDebug) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExtendedNullDebug) GetNullDebug() *_go.NullDebug {
+	if x != nil {
+		return x.NullDebug
+	}
+	return nil
+}
+
+func (x *ExtendedNullDebug) GetExtension() *NullDebugExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type CreateExtendedNullDebugRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NullDebug   *_go.NullDebug      `protobuf:"bytes,1,opt,name=null_debug,json=nullDebug,proto3" json:"null_debug,omitempty"`
+	NullDebugId string              `protobuf:"bytes,2,opt,name=null_debug_id,json=nullDebugId,proto3" json:"null_debug_id,omitempty"`
+	Extension   *NullDebugExtension `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *CreateExtendedNullDebugRequest) Reset() {
+	*x = CreateExtendedNullDebugRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateExtendedNullDebugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExtendedNullDebugRequest) ProtoMessage() {}
+
+func (x *CreateExtendedNullDebugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExtendedNullDebugRequest.ProtoReflect.Descriptor instead.
+func (*CreateExtendedNullDebugRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateExtendedNullDebugRequest) GetNullDebug() *_go.NullDebug {
+	if x != nil {
+		return x.NullDebug
+	}
+	return nil
+}
+
+func (x *CreateExtendedNullDebugRequest) GetNullDebugId() string {
+	if x != nil {
+		return x.NullDebugId
+	}
+	return ""
+}
+
+func (x *CreateExtendedNullDebugRequest) GetExtension() *NullDebugExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type ListExtendedNullDebugsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListExtendedNullDebugsRequest) Reset() {
+	*x = ListExtendedNullDebugsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNullDebugsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNullDebugsRequest) ProtoMessage() {}
+
+func (x *ListExtendedNullDebugsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNullDebugsRequest.ProtoReflect.Descriptor instead.
+func (*ListExtendedNullDebugsRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListExtendedNullDebugsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListExtendedNullDebugsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExtendedNullDebugsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExtendedNullDebugsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtendedNullDebugs []*ExtendedNullDebug `protobuf:"bytes,1,rep,name=extended_null_debugs,json=extendedNullDebugs,proto3" json:"extended_null_debugs,omitempty"`
+	NextPageToken      string               `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListExtendedNullDebugsResponse) Reset() {
+	*x = ListExtendedNullDebugsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedNullDebugsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedNullDebugsResponse) ProtoMessage() {}
+
+func (x *ListExtendedNullDebugsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedNullDebugsResponse.ProtoReflect.Descriptor instead.
+func (*ListExtendedNullDebugsResponse) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListExtendedNullDebugsResponse) GetExtendedNullDebugs() []*ExtendedNullDebug {
+	if x != nil {
+		return x.ExtendedNullDebugs
+	}
+	return nil
+}
+
+func (x *ListExtendedNullDebugsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetExtendedNullDebugRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetExtendedNullDebugRequest) Reset() {
+	*x = GetExtendedNullDebugRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExtendedNullDebugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExtendedNullDebugRequest) ProtoMessage() {}
+
+func (x *GetExtendedNullDebugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExtendedNullDebugRequest.ProtoReflect.Descriptor instead.
+func (*GetExtendedNullDebugRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetExtendedNullDebugRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// AioControllerExtension is the I/O engine of an AioController. Changing
+// the engine recreates the bdev, like changing its file.
+type AioControllerExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine AioEngine `protobuf:"varint,1,opt,name=engine,proto3,enum=opi_spdk_bridge.storage.v1.AioEngine" json:"engine,omitempty"`
+}
+
+func (x *AioControllerExtension) Reset() {
+	*x = AioControllerExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AioControllerExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AioControllerExtension) ProtoMessage() {}
+
+func (x *AioControllerExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AioControllerExtension.ProtoReflect.Descriptor instead.
+func (*AioControllerExtension) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AioControllerExtension) GetEngine() AioEngine {
+	if x != nil {
+		return x.Engine
+	}
+	return AioEngine_AIO_ENGINE_UNSPECIFIED
+}
+
+type ExtendedAioController struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AioController *_go.AioController      `protobuf:"bytes,1,opt,name=aio_controller,json=aioController,proto3" json:"aio_controller,omitempty"`
+	Extension     *AioControllerExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *ExtendedAioController) Reset() {
+	*x = ExtendedAioController{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedAioController) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedAioController) ProtoMessage() {}
+
+func (x *ExtendedAioController) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedAioController.ProtoReflect.Descriptor instead.
+func (*ExtendedAioController) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExtendedAioController) GetAioController() *_go.AioController {
+	if x != nil {
+		return x.AioController
+	}
+	return nil
+}
+
+func (x *ExtendedAioController) GetExtension() *AioControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type CreateExtendedAioControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AioController   *_go.AioController      `protobuf:"bytes,1,opt,name=aio_controller,json=aioController,proto3" json:"aio_controller,omitempty"`
+	AioControllerId string                  `protobuf:"bytes,2,opt,name=aio_controller_id,json=aioControllerId,proto3" json:"aio_controller_id,omitempty"`
+	Extension       *AioControllerExtension `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *CreateExtendedAioControllerRequest) Reset() {
+	*x = CreateExtendedAioControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateExtendedAioControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExtendedAioControllerRequest) ProtoMessage() {}
+
+func (x *CreateExtendedAioControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExtendedAioControllerRequest.ProtoReflect.Descriptor instead.
+func (*CreateExtendedAioControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateExtendedAioControllerRequest) GetAioController() *_go.AioController {
+	if x != nil {
+		return x.AioController
+	}
+	return nil
+}
+
+func (x *CreateExtendedAioControllerRequest) GetAioControllerId() string {
+	if x != nil {
+		return x.AioControllerId
+	}
+	return ""
+}
+
+func (x *CreateExtendedAioControllerRequest) GetExtension() *AioControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+type UpdateExtendedAioControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object's `name` field is used to identify the object to be updated.
+	AioController *_go.AioController      `protobuf:"bytes,1,opt,name=aio_controller,json=aioController,proto3" json:"aio_controller,omitempty"`
+	Extension     *AioControllerExtension `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+	// The list of fields of ExtendedAioController to update, e.g.
+	// aio_controller.filename or extension.engine.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// If set to true, and the object is not found, a new object will be created.
+	// In this situation, `update_mask` is ignored.
+	AllowMissing bool `protobuf:"varint,4,opt,name=allow_missing,json=allowMissing,proto3" json:"allow_missing,omitempty"`
+}
+
+func (x *UpdateExtendedAioControllerRequest) Reset() {
+	*x = UpdateExtendedAioControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateExtendedAioControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExtendedAioControllerRequest) ProtoMessage() {}
+
+func (x *UpdateExtendedAioControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExtendedAioControllerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExtendedAioControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateExtendedAioControllerRequest) GetAioController() *_go.AioController {
+	if x != nil {
+		return x.AioController
+	}
+	return nil
+}
+
+func (x *UpdateExtendedAioControllerRequest) GetExtension() *AioControllerExtension {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+func (x *UpdateExtendedAioControllerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateExtendedAioControllerRequest) GetAllowMissing() bool {
+	if x != nil {
+		return x.AllowMissing
+	}
+	return false
+}
+
+type ListExtendedAioControllersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListExtendedAioControllersRequest) Reset() {
+	*x = ListExtendedAioControllersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedAioControllersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedAioControllersRequest) ProtoMessage() {}
+
+func (x *ListExtendedAioControllersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedAioControllersRequest.ProtoReflect.Descriptor instead.
+func (*ListExtendedAioControllersRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListExtendedAioControllersRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListExtendedAioControllersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExtendedAioControllersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExtendedAioControllersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtendedAioControllers []*ExtendedAioController `protobuf:"bytes,1,rep,name=extended_aio_controllers,json=extendedAioControllers,proto3" json:"extended_aio_controllers,omitempty"`
+	NextPageToken          string                   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListExtendedAioControllersResponse) Reset() {
+	*x = ListExtendedAioControllersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExtendedAioControllersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtendedAioControllersResponse) ProtoMessage() {}
+
+func (x *ListExtendedAioControllersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtendedAioControllersResponse.ProtoReflect.Descriptor instead.
+func (*ListExtendedAioControllersResponse) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListExtendedAioControllersResponse) GetExtendedAioControllers() []*ExtendedAioController {
+	if x != nil {
+		return x.ExtendedAioControllers
+	}
+	return nil
+}
+
+func (x *ListExtendedAioControllersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetExtendedAioControllerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetExtendedAioControllerRequest) Reset() {
+	*x = GetExtendedAioControllerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_extension_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExtendedAioControllerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExtendedAioControllerRequest) ProtoMessage() {}
+
+func (x *GetExtendedAioControllerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_extension_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExtendedAioControllerRequest.ProtoReflect.Descriptor instead.
+func (*GetExtendedAioControllerRequest) Descriptor() ([]byte, []int) {
+	return file_backend_extension_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetExtendedAioControllerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_backend_extension_proto protoreflect.FileDescriptor
+
+var file_backend_extension_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x6f, 0x70, 0x69, 0x5f, 0x73,
+	0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x69, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x16, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x63,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x95, 0x01, 0x0a, 0x11,
+	0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x19, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x09,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x42,
+	0x03, 0xe0, 0x41, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12,
+	0x1d, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x23,
+	0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69,
+	0x62, 0x6c, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x10, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x39, 0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x08, 0x6e, 0x76, 0x6d, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x4b, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x97, 0x01, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x23, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x1d, 0x0a, 0x1b, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x4e, 0x76,
+	0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x1d, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x13,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e,
+	0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x55, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x37, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x23,
+	0xe0, 0x41, 0x02, 0xfa, 0x41, 0x1d, 0x0a, 0x1b, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x4e, 0x76, 0x6d, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xfa, 0x02, 0x0a, 0x1d, 0x4e, 0x76,
+	0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x10, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74, 0x68,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74,
+	0x68, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x60, 0x0a, 0x12, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74, 0x68, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74,
+	0x68, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x16, 0x63, 0x74, 0x72,
+	0x6c, 0x72, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f,
+	0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x63, 0x74, 0x72, 0x6c, 0x72,
+	0x4c, 0x6f, 0x73, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x12, 0x2e,
+	0x0a, 0x13, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61,
+	0x79, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x12, 0x36,
+	0x0a, 0x18, 0x66, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x6f, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x14, 0x66, 0x61, 0x73, 0x74, 0x49, 0x6f, 0x46, 0x61, 0x69, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x22, 0xd7, 0x01, 0x0a, 0x1c, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x5e, 0x0a, 0x16, 0x6e, 0x76, 0x6d, 0x65, 0x5f,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d,
+	0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x52, 0x14, 0x6e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x57, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0xa4, 0x02, 0x0a, 0x29, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63,
+	0x0a, 0x16, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x14, 0x6e,
+	0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x19, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x6e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x12, 0x57,
+	0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x39, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa6, 0x02, 0x0a, 0x29, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x16, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x42,
+	0x03, 0xe0, 0x41, 0x02, 0x52, 0x14, 0x6e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x57, 0x0a, 0x09, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61,
+	0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b,
+	0x22, 0xaf, 0x01, 0x0a, 0x28, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a,
+	0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2f, 0xe0,
+	0x41, 0x02, 0xfa, 0x41, 0x29, 0x0a, 0x27, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0xd7, 0x01, 0x0a, 0x29, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x81, 0x01, 0x0a, 0x20, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x76,
+	0x6d, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x1d, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e,
+	0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6d, 0x0a, 0x26,
+	0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x2f, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x29, 0x0a, 0x27, 0x6f, 0x70,
+	0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2f, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x12,
+	0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x07, 0x6d, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x03, 0xe0, 0x41, 0x05, 0x52, 0x06, 0x6d, 0x64, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1e, 0x0a, 0x08, 0x64, 0x69, 0x66, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x05, 0x52, 0x07, 0x64, 0x69, 0x66, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x2d, 0x0a, 0x11, 0x64, 0x69, 0x66, 0x5f, 0x69, 0x73, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x5f,
+	0x6f, 0x66, 0x5f, 0x6d, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x42, 0x03, 0xe0, 0x41, 0x05,
+	0x52, 0x0d, 0x64, 0x69, 0x66, 0x49, 0x73, 0x48, 0x65, 0x61, 0x64, 0x4f, 0x66, 0x4d, 0x64, 0x22,
+	0x9f, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x3c, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x12, 0x4c, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0xd5, 0x01, 0x0a, 0x1e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75,
+	0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x09, 0x6e, 0x75,
+	0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x49, 0x64, 0x12, 0x4c, 0x0a, 0x09, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x6c, 0x6c,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x99, 0x01, 0x0a, 0x1d, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x06, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x24, 0xe0, 0x41, 0x02,
+	0xfa, 0x41, 0x1e, 0x0a, 0x1c, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75,
+	0x67, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa9, 0x01, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x14, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e,
+	0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x57, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x38, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x24,
+	0xe0, 0x41, 0x02, 0xfa, 0x41, 0x1e, 0x0a, 0x1c, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x4e, 0x75, 0x6c, 0x6c, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x57, 0x0a, 0x16, 0x41, 0x69,
+	0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x69, 0x6f, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x22, 0xb3, 0x01, 0x0a, 0x15, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x48, 0x0a,
+	0x0e, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x6f, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x0d, 0x61, 0x69, 0x6f, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x50, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xf1, 0x01, 0x0a, 0x22, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x4d, 0x0a, 0x0e, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69,
+	0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x42, 0x03, 0xe0, 0x41, 0x02,
+	0x52, 0x0d, 0x61, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12,
+	0x2a, 0x0a, 0x11, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x12, 0x50, 0x0a, 0x09, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa7, 0x02,
+	0x0a, 0x22, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0e, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f,
+	0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x42,
+	0x03, 0xe0, 0x41, 0x02, 0x52, 0x0d, 0x61, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x12, 0x50, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61,
+	0x73, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0xa1, 0x01, 0x0a, 0x21, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a,
+	0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x28, 0xe0,
+	0x41, 0x02, 0xfa, 0x41, 0x22, 0x0a, 0x20, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb9, 0x01, 0x0a, 0x22,
+	0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x6b, 0x0a, 0x18, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61,
+	0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x16, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5f, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x28, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x22,
+	0x0a, 0x20, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2f, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a, 0x8f, 0x01, 0x0a, 0x13, 0x4e, 0x76, 0x6d,
+	0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74, 0x68, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x25, 0x0a, 0x21, 0x4e, 0x56, 0x4d, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x41,
+	0x54, 0x48, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x4e, 0x56, 0x4d, 0x45, 0x5f,
+	0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x41, 0x54, 0x48, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x12, 0x27, 0x0a, 0x23, 0x4e, 0x56, 0x4d, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50,
+	0x41, 0x54, 0x48, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56,
+	0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x2a, 0x92, 0x01, 0x0a, 0x15, 0x4e,
+	0x76, 0x6d, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x74, 0x68, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x23, 0x4e, 0x56, 0x4d, 0x45, 0x5f, 0x4d, 0x55, 0x4c,
+	0x54, 0x49, 0x50, 0x41, 0x54, 0x48, 0x5f, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x27, 0x0a,
+	0x23, 0x4e, 0x56, 0x4d, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x41, 0x54, 0x48, 0x5f,
+	0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52,
+	0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x27, 0x0a, 0x23, 0x4e, 0x56, 0x4d, 0x45, 0x5f, 0x4d,
+	0x55, 0x4c, 0x54, 0x49, 0x50, 0x41, 0x54, 0x48, 0x5f, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f,
+	0x52, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x5f, 0x44, 0x45, 0x50, 0x54, 0x48, 0x10, 0x02, 0x2a,
+	0x57, 0x0a, 0x09, 0x41, 0x69, 0x6f, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x16,
+	0x41, 0x49, 0x4f, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x49, 0x4f, 0x5f,
+	0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x4c, 0x49, 0x42, 0x41, 0x49, 0x4f, 0x10, 0x01, 0x12,
+	0x17, 0x0a, 0x13, 0x41, 0x49, 0x4f, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x49, 0x4f,
+	0x5f, 0x55, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x32, 0xba, 0x02, 0x0a, 0x17, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x97, 0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x38,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73,
+	0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x09, 0xda, 0x41, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x84,
+	0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76,
+	0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x36, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e,
+	0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x07, 0xda, 0x41,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0xce, 0x06, 0x0a, 0x23, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xe4, 0x01,
+	0x0a, 0x22, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x12, 0x45, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x3d, 0xda, 0x41, 0x3a, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2c, 0x6e, 0x76, 0x6d, 0x65, 0x5f,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x12, 0xd6, 0x01, 0x0a, 0x22, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x45, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x38, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x2f, 0xda, 0x41,
+	0x2c, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x2c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0xbb, 0x01,
+	0x0a, 0x21, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76,
+	0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x73, 0x12, 0x44, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d,
+	0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x09, 0xda, 0x41, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0xa8, 0x01, 0x0a, 0x1f,
+	0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12,
+	0x42, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x07, 0xda,
+	0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0xef, 0x03, 0x0a, 0x18, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0xab, 0x01, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12,
+	0x3a, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x22, 0x25, 0xda, 0x41, 0x22, 0x6e,
+	0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x2c, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x69,
+	0x64, 0x12, 0x9a, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x73, 0x12, 0x39, 0x2e, 0x6f,
+	0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70,
+	0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x09, 0xda, 0x41, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x87,
+	0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75,
+	0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x37, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70,
+	0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x75, 0x6c, 0x6c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x22,
+	0x07, 0xda, 0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0xdb, 0x05, 0x0a, 0x1c, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xbf, 0x01, 0x0a, 0x1b, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3e, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41,
+	0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x2d, 0xda, 0x41,
+	0x2a, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2c,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2c, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x12, 0xb9, 0x01, 0x0a, 0x1b,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69,
+	0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3e, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x27,
+	0xda, 0x41, 0x24, 0x61, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2c, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0xa6, 0x01, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x3d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41,
+	0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x09, 0xda, 0x41, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x93, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3b, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0x07, 0xda,
+	0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x69, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f,
+	0x6f, 0x70, 0x69, 0x2d, 0x73, 0x70, 0x64, 0x6b, 0x2d, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_backend_extension_proto_rawDescOnce sync.Once
+	file_backend_extension_proto_rawDescData = file_backend_extension_proto_rawDesc
+)
+
+func file_backend_extension_proto_rawDescGZIP() []byte {
+	file_backend_extension_proto_rawDescOnce.Do(func() {
+		file_backend_extension_proto_rawDescData = protoimpl.X.CompressGZIP(file_backend_extension_proto_rawDescData)
+	})
+	return file_backend_extension_proto_rawDescData
+}
+
+var file_backend_extension_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_backend_extension_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_backend_extension_proto_goTypes = []interface{}{
+	(NvmeMultipathPolicy)(0),                          // 0: opi_spdk_bridge.storage.v1.NvmeMultipathPolicy
+	(NvmeMultipathSelector)(0),                        // 1: opi_spdk_bridge.storage.v1.NvmeMultipathSelector
+	(AioEngine)(0),                                    // 2: opi_spdk_bridge.storage.v1.AioEngine
+	(*NvmePathExtension)(nil),                         // 3: opi_spdk_bridge.storage.v1.NvmePathExtension
+	(*ExtendedNvmePath)(nil),                          // 4: opi_spdk_bridge.storage.v1.ExtendedNvmePath
+	(*ListExtendedNvmePathsRequest)(nil),              // 5: opi_spdk_bridge.storage.v1.ListExtendedNvmePathsRequest
+	(*ListExtendedNvmePathsResponse)(nil),             // 6: opi_spdk_bridge.storage.v1.ListExtendedNvmePathsResponse
+	(*GetExtendedNvmePathRequest)(nil),                // 7: opi_spdk_bridge.storage.v1.GetExtendedNvmePathRequest
+	(*NvmeRemoteControllerExtension)(nil),             // 8: opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension
+	(*ExtendedNvmeRemoteController)(nil),              // 9: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController
+	(*CreateExtendedNvmeRemoteControllerRequest)(nil), // 10: opi_spdk_bridge.storage.v1.CreateExtendedNvmeRemoteControllerRequest
+	(*UpdateExtendedNvmeRemoteControllerRequest)(nil), // 11: opi_spdk_bridge.storage.v1.UpdateExtendedNvmeRemoteControllerRequest
+	(*ListExtendedNvmeRemoteControllersRequest)(nil),  // 12: opi_spdk_bridge.storage.v1.ListExtendedNvmeRemoteControllersRequest
+	(*ListExtendedNvmeRemoteControllersResponse)(nil), // 13: opi_spdk_bridge.storage.v1.ListExtendedNvmeRemoteControllersResponse
+	(*GetExtendedNvmeRemoteControllerRequest)(nil),    // 14: opi_spdk_bridge.storage.v1.GetExtendedNvmeRemoteControllerRequest
+	(*NullDebugExtension)(nil),                        // 15: opi_spdk_bridge.storage.v1.NullDebugExtension
+	(*ExtendedNullDebug)(nil),                         // 16: opi_spdk_bridge.storage.v1.ExtendedNullDebug
+	(*CreateExtendedNullDebugRequest)(nil),            // 17: opi_spdk_bridge.storage.v1.CreateExtendedNullDebugRequest
+	(*ListExtendedNullDebugsRequest)(nil),             // 18: opi_spdk_bridge.storage.v1.ListExtendedNullDebugsRequest
+	(*ListExtendedNullDebugsResponse)(nil),            // 19: opi_spdk_bridge.storage.v1.ListExtendedNullDebugsResponse
+	(*GetExtendedNullDebugRequest)(nil),               // 20: opi_spdk_bridge.storage.v1.GetExtendedNullDebugRequest
+	(*AioControllerExtension)(nil),                    // 21: opi_spdk_bridge.storage.v1.AioControllerExtension
+	(*ExtendedAioController)(nil),                     // 22: opi_spdk_bridge.storage.v1.ExtendedAioController
+	(*CreateExtendedAioControllerRequest)(nil),        // 23: opi_spdk_bridge.storage.v1.CreateExtendedAioControllerRequest
+	(*UpdateExtendedAioControllerRequest)(nil),        // 24: opi_spdk_bridge.storage.v1.UpdateExtendedAioControllerRequest
+	(*ListExtendedAioControllersRequest)(nil),         // 25: opi_spdk_bridge.storage.v1.ListExtendedAioControllersRequest
+	(*ListExtendedAioControllersResponse)(nil),        // 26: opi_spdk_bridge.storage.v1.ListExtendedAioControllersResponse
+	(*GetExtendedAioControllerRequest)(nil),           // 27: opi_spdk_bridge.storage.v1.GetExtendedAioControllerRequest
+	(*_go.NvmePath)(nil),                              // 28: opi_api.storage.v1.NvmePath
+	(*_go.NvmeRemoteController)(nil),                  // 29: opi_api.storage.v1.NvmeRemoteController
+	(*fieldmaskpb.FieldMask)(nil),                     // 30: google.protobuf.FieldMask
+	(*_go.NullDebug)(nil),                             // 31: opi_api.storage.v1.NullDebug
+	(*_go.AioController)(nil),                         // 32: opi_api.storage.v1.AioController
+}
+var file_backend_extension_proto_depIdxs = []int32{
+	28, // 0: opi_spdk_bridge.storage.v1.ExtendedNvmePath.nvme_path:type_name -> opi_api.storage.v1.NvmePath
+	3,  // 1: opi_spdk_bridge.storage.v1.ExtendedNvmePath.extension:type_name -> opi_spdk_bridge.storage.v1.NvmePathExtension
+	4,  // 2: opi_spdk_bridge.storage.v1.ListExtendedNvmePathsResponse.extended_nvme_paths:type_name -> opi_spdk_bridge.storage.v1.ExtendedNvmePath
+	0,  // 3: opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension.multipath_policy:type_name -> opi_spdk_bridge.storage.v1.NvmeMultipathPolicy
+	1,  // 4: opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension.multipath_selector:type_name -> opi_spdk_bridge.storage.v1.NvmeMultipathSelector
+	29, // 5: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController.nvme_remote_controller:type_name -> opi_api.storage.v1.NvmeRemoteController
+	8,  // 6: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController.extension:type_name -> opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension
+	29, // 7: opi_spdk_bridge.storage.v1.CreateExtendedNvmeRemoteControllerRequest.nvme_remote_controller:type_name -> opi_api.storage.v1.NvmeRemoteController
+	8,  // 8: opi_spdk_bridge.storage.v1.CreateExtendedNvmeRemoteControllerRequest.extension:type_name -> opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension
+	29, // 9: opi_spdk_bridge.storage.v1.UpdateExtendedNvmeRemoteControllerRequest.nvme_remote_controller:type_name -> opi_api.storage.v1.NvmeRemoteController
+	8,  // 10: opi_spdk_bridge.storage.v1.UpdateExtendedNvmeRemoteControllerRequest.extension:type_name -> opi_spdk_bridge.storage.v1.NvmeRemoteControllerExtension
+	30, // 11: opi_spdk_bridge.storage.v1.UpdateExtendedNvmeRemoteControllerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 12: opi_spdk_bridge.storage.v1.ListExtendedNvmeRemoteControllersResponse.extended_nvme_remote_controllers:type_name -> opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController
+	31, // 13: opi_spdk_bridge.storage.v1.ExtendedNullDebug.null_debug:type_name -> opi_api.storage.v1.NullDebug
+	15, // 14: opi_spdk_bridge.storage.v1.ExtendedNullDebug.extension:type_name -> opi_spdk_bridge.storage.v1.NullDebugExtension
+	31, // 15: opi_spdk_bridge.storage.v1.CreateExtendedNullDebugRequest.null_debug:type_name -> opi_api.storage.v1.NullDebug
+	15, // 16: opi_spdk_bridge.storage.v1.CreateExtendedNullDebugRequest.extension:type_name -> opi_spdk_bridge.storage.v1.NullDebugExtension
+	16, // 17: opi_spdk_bridge.storage.v1.ListExtendedNullDebugsResponse.extended_null_debugs:type_name -> opi_spdk_bridge.storage.v1.ExtendedNullDebug
+	2,  // 18: opi_spdk_bridge.storage.v1.AioControllerExtension.engine:type_name -> opi_spdk_bridge.storage.v1.AioEngine
+	32, // 19: opi_spdk_bridge.storage.v1.ExtendedAioController.aio_controller:type_name -> opi_api.storage.v1.AioController
+	21, // 20: opi_spdk_bridge.storage.v1.ExtendedAioController.extension:type_name -> opi_spdk_bridge.storage.v1.AioControllerExtension
+	32, // 21: opi_spdk_bridge.storage.v1.CreateExtendedAioControllerRequest.aio_controller:type_name -> opi_api.storage.v1.AioController
+	21, // 22: opi_spdk_bridge.storage.v1.CreateExtendedAioControllerRequest.extension:type_name -> opi_spdk_bridge.storage.v1.AioControllerExtension
+	32, // 23: opi_spdk_bridge.storage.v1.UpdateExtendedAioControllerRequest.aio_controller:type_name -> opi_api.storage.v1.AioController
+	21, // 24: opi_spdk_bridge.storage.v1.UpdateExtendedAioControllerRequest.extension:type_name -> opi_spdk_bridge.storage.v1.AioControllerExtension
+	30, // 25: opi_spdk_bridge.storage.v1.UpdateExtendedAioControllerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	22, // 26: opi_spdk_bridge.storage.v1.ListExtendedAioControllersResponse.extended_aio_controllers:type_name -> opi_spdk_bridge.storage.v1.ExtendedAioController
+	5,  // 27: opi_spdk_bridge.storage.v1.ExtendedNvmePathService.ListExtendedNvmePaths:input_type -> opi_spdk_bridge.storage.v1.ListExtendedNvmePathsRequest
+	7,  // 28: opi_spdk_bridge.storage.v1.ExtendedNvmePathService.GetExtendedNvmePath:input_type -> opi_spdk_bridge.storage.v1.GetExtendedNvmePathRequest
+	10, // 29: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.CreateExtendedNvmeRemoteController:input_type -> opi_spdk_bridge.storage.v1.CreateExtendedNvmeRemoteControllerRequest
+	11, // 30: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.UpdateExtendedNvmeRemoteController:input_type -> opi_spdk_bridge.storage.v1.UpdateExtendedNvmeRemoteControllerRequest
+	12, // 31: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.ListExtendedNvmeRemoteControllers:input_type -> opi_spdk_bridge.storage.v1.ListExtendedNvmeRemoteControllersRequest
+	14, // 32: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.GetExtendedNvmeRemoteController:input_type -> opi_spdk_bridge.storage.v1.GetExtendedNvmeRemoteControllerRequest
+	17, // 33: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.CreateExtendedNullDebug:input_type -> opi_spdk_bridge.storage.v1.CreateExtendedNullDebugRequest
+	18, // 34: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.ListExtendedNullDebugs:input_type -> opi_spdk_bridge.storage.v1.ListExtendedNullDebugsRequest
+	20, // 35: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.GetExtendedNullDebug:input_type -> opi_spdk_bridge.storage.v1.GetExtendedNullDebugRequest
+	23, // 36: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.CreateExtendedAioController:input_type -> opi_spdk_bridge.storage.v1.CreateExtendedAioControllerRequest
+	24, // 37: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.UpdateExtendedAioController:input_type -> opi_spdk_bridge.storage.v1.UpdateExtendedAioControllerRequest
+	25, // 38: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.ListExtendedAioControllers:input_type -> opi_spdk_bridge.storage.v1.ListExtendedAioControllersRequest
+	27, // 39: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.GetExtendedAioController:input_type -> opi_spdk_bridge.storage.v1.GetExtendedAioControllerRequest
+	6,  // 40: opi_spdk_bridge.storage.v1.ExtendedNvmePathService.ListExtendedNvmePaths:output_type -> opi_spdk_bridge.storage.v1.ListExtendedNvmePathsResponse
+	4,  // 41: opi_spdk_bridge.storage.v1.ExtendedNvmePathService.GetExtendedNvmePath:output_type -> opi_spdk_bridge.storage.v1.ExtendedNvmePath
+	9,  // 42: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.CreateExtendedNvmeRemoteController:output_type -> opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController
+	9,  // 43: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.UpdateExtendedNvmeRemoteController:output_type -> opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController
+	13, // 44: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.ListExtendedNvmeRemoteControllers:output_type -> opi_spdk_bridge.storage.v1.ListExtendedNvmeRemoteControllersResponse
+	9,  // 45: opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService.GetExtendedNvmeRemoteController:output_type -> opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteController
+	16, // 46: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.CreateExtendedNullDebug:output_type -> opi_spdk_bridge.storage.v1.ExtendedNullDebug
+	19, // 47: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.ListExtendedNullDebugs:output_type -> opi_spdk_bridge.storage.v1.ListExtendedNullDebugsResponse
+	16, // 48: opi_spdk_bridge.storage.v1.ExtendedNullDebugService.GetExtendedNullDebug:output_type -> opi_spdk_bridge.storage.v1.ExtendedNullDebug
+	22, // 49: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.CreateExtendedAioController:output_type -> opi_spdk_bridge.storage.v1.ExtendedAioController
+	22, // 50: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.UpdateExtendedAioController:output_type -> opi_spdk_bridge.storage.v1.ExtendedAioController
+	26, // 51: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.ListExtendedAioControllers:output_type -> opi_spdk_bridge.storage.v1.ListExtendedAioControllersResponse
+	22, // 52: opi_spdk_bridge.storage.v1.ExtendedAioControllerService.GetExtendedAioController:output_type -> opi_spdk_bridge.storage.v1.ExtendedAioController
+	40, // [40:53] is the sub-list for method output_type
+	27, // [27:40] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_backend_extension_proto_init() }
+func file_backend_extension_proto_init() {
+	if File_backend_extension_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_backend_extension_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NvmePathExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendedNvmePath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNvmePathsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNvmePathsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExtendedNvmePathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NvmeRemoteControllerExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendedNvmeRemoteController); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateExtendedNvmeRemoteControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateExtendedNvmeRemoteControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNvmeRemoteControllersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNvmeRemoteControllersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExtendedNvmeRemoteControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NullDebugExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendedNullDebug); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateExtendedNullDebugRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNullDebugsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedNullDebugsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExtendedNullDebugRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AioControllerExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendedAioController); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateExtendedAioControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateExtendedAioControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedAioControllersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExtendedAioControllersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_extension_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExtendedAioControllerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_backend_extension_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_backend_extension_proto_goTypes,
+		DependencyIndexes: file_backend_extension_proto_depIdxs,
+		EnumInfos:         file_backend_extension_proto_enumTypes,
+		MessageInfos:      file_backend_extension_proto_msgTypes,
+	}.Build()
+	File_backend_extension_proto = out.File
+	file_backend_extension_proto_rawDesc = nil
+	file_backend_extension_proto_goTypes = nil
+	file_backend_extension_proto_depIdxs = nil
+}
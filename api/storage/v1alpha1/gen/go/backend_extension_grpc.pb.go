@@ -0,0 +1,719 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: backend_extension.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExtendedNvmePathService_ListExtendedNvmePaths_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedNvmePathService/ListExtendedNvmePaths"
+	ExtendedNvmePathService_GetExtendedNvmePath_FullMethodName   = "/opi_spdk_bridge.storage.v1.ExtendedNvmePathService/GetExtendedNvmePath"
+)
+
+// ExtendedNvmePathServiceClient is the client API for ExtendedNvmePathService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedNvmePathServiceClient interface {
+	ListExtendedNvmePaths(ctx context.Context, in *ListExtendedNvmePathsRequest, opts ...grpc.CallOption) (*ListExtendedNvmePathsResponse, error)
+	GetExtendedNvmePath(ctx context.Context, in *GetExtendedNvmePathRequest, opts ...grpc.CallOption) (*ExtendedNvmePath, error)
+}
+
+type extendedNvmePathServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedNvmePathServiceClient(cc grpc.ClientConnInterface) ExtendedNvmePathServiceClient {
+	return &extendedNvmePathServiceClient{cc}
+}
+
+func (c *extendedNvmePathServiceClient) ListExtendedNvmePaths(ctx context.Context, in *ListExtendedNvmePathsRequest, opts ...grpc.CallOption) (*ListExtendedNvmePathsResponse, error) {
+	out := new(ListExtendedNvmePathsResponse)
+	err := c.cc.Invoke(ctx, ExtendedNvmePathService_ListExtendedNvmePaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNvmePathServiceClient) GetExtendedNvmePath(ctx context.Context, in *GetExtendedNvmePathRequest, opts ...grpc.CallOption) (*ExtendedNvmePath, error) {
+	out := new(ExtendedNvmePath)
+	err := c.cc.Invoke(ctx, ExtendedNvmePathService_GetExtendedNvmePath_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedNvmePathServiceServer is the server API for ExtendedNvmePathService service.
+// All implementations must embed UnimplementedExtendedNvmePathServiceServer
+// for forward compatibility
+type ExtendedNvmePathServiceServer interface {
+	ListExtendedNvmePaths(context.Context, *ListExtendedNvmePathsRequest) (*ListExtendedNvmePathsResponse, error)
+	GetExtendedNvmePath(context.Context, *GetExtendedNvmePathRequest) (*ExtendedNvmePath, error)
+	mustEmbedUnimplementedExtendedNvmePathServiceServer()
+}
+
+// UnimplementedExtendedNvmePathServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedNvmePathServiceServer struct {
+}
+
+func (UnimplementedExtendedNvmePathServiceServer) ListExtendedNvmePaths(context.Context, *ListExtendedNvmePathsRequest) (*ListExtendedNvmePathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExtendedNvmePaths not implemented")
+}
+func (UnimplementedExtendedNvmePathServiceServer) GetExtendedNvmePath(context.Context, *GetExtendedNvmePathRequest) (*ExtendedNvmePath, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExtendedNvmePath not implemented")
+}
+func (UnimplementedExtendedNvmePathServiceServer) mustEmbedUnimplementedExtendedNvmePathServiceServer() {
+}
+
+// UnsafeExtendedNvmePathServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedNvmePathServiceServer will
+// result in compilation errors.
+type UnsafeExtendedNvmePathServiceServer interface {
+	mustEmbedUnimplementedExtendedNvmePathServiceServer()
+}
+
+func RegisterExtendedNvmePathServiceServer(s grpc.ServiceRegistrar, srv ExtendedNvmePathServiceServer) {
+	s.RegisterService(&ExtendedNvmePathService_ServiceDesc, srv)
+}
+
+func _ExtendedNvmePathService_ListExtendedNvmePaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExtendedNvmePathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmePathServiceServer).ListExtendedNvmePaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmePathService_ListExtendedNvmePaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmePathServiceServer).ListExtendedNvmePaths(ctx, req.(*ListExtendedNvmePathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNvmePathService_GetExtendedNvmePath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExtendedNvmePathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmePathServiceServer).GetExtendedNvmePath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmePathService_GetExtendedNvmePath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmePathServiceServer).GetExtendedNvmePath(ctx, req.(*GetExtendedNvmePathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedNvmePathService_ServiceDesc is the grpc.ServiceDesc for ExtendedNvmePathService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedNvmePathService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.ExtendedNvmePathService",
+	HandlerType: (*ExtendedNvmePathServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListExtendedNvmePaths",
+			Handler:    _ExtendedNvmePathService_ListExtendedNvmePaths_Handler,
+		},
+		{
+			MethodName: "GetExtendedNvmePath",
+			Handler:    _ExtendedNvmePathService_GetExtendedNvmePath_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_extension.proto",
+}
+
+const (
+	ExtendedNvmeRemoteControllerService_CreateExtendedNvmeRemoteController_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService/CreateExtendedNvmeRemoteController"
+	ExtendedNvmeRemoteControllerService_UpdateExtendedNvmeRemoteController_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService/UpdateExtendedNvmeRemoteController"
+	ExtendedNvmeRemoteControllerService_ListExtendedNvmeRemoteControllers_FullMethodName  = "/opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService/ListExtendedNvmeRemoteControllers"
+	ExtendedNvmeRemoteControllerService_GetExtendedNvmeRemoteController_FullMethodName    = "/opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService/GetExtendedNvmeRemoteController"
+)
+
+// ExtendedNvmeRemoteControllerServiceClient is the client API for ExtendedNvmeRemoteControllerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedNvmeRemoteControllerServiceClient interface {
+	CreateExtendedNvmeRemoteController(ctx context.Context, in *CreateExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error)
+	UpdateExtendedNvmeRemoteController(ctx context.Context, in *UpdateExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error)
+	ListExtendedNvmeRemoteControllers(ctx context.Context, in *ListExtendedNvmeRemoteControllersRequest, opts ...grpc.CallOption) (*ListExtendedNvmeRemoteControllersResponse, error)
+	GetExtendedNvmeRemoteController(ctx context.Context, in *GetExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error)
+}
+
+type extendedNvmeRemoteControllerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedNvmeRemoteControllerServiceClient(cc grpc.ClientConnInterface) ExtendedNvmeRemoteControllerServiceClient {
+	return &extendedNvmeRemoteControllerServiceClient{cc}
+}
+
+func (c *extendedNvmeRemoteControllerServiceClient) CreateExtendedNvmeRemoteController(ctx context.Context, in *CreateExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error) {
+	out := new(ExtendedNvmeRemoteController)
+	err := c.cc.Invoke(ctx, ExtendedNvmeRemoteControllerService_CreateExtendedNvmeRemoteController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNvmeRemoteControllerServiceClient) UpdateExtendedNvmeRemoteController(ctx context.Context, in *UpdateExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error) {
+	out := new(ExtendedNvmeRemoteController)
+	err := c.cc.Invoke(ctx, ExtendedNvmeRemoteControllerService_UpdateExtendedNvmeRemoteController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNvmeRemoteControllerServiceClient) ListExtendedNvmeRemoteControllers(ctx context.Context, in *ListExtendedNvmeRemoteControllersRequest, opts ...grpc.CallOption) (*ListExtendedNvmeRemoteControllersResponse, error) {
+	out := new(ListExtendedNvmeRemoteControllersResponse)
+	err := c.cc.Invoke(ctx, ExtendedNvmeRemoteControllerService_ListExtendedNvmeRemoteControllers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNvmeRemoteControllerServiceClient) GetExtendedNvmeRemoteController(ctx context.Context, in *GetExtendedNvmeRemoteControllerRequest, opts ...grpc.CallOption) (*ExtendedNvmeRemoteController, error) {
+	out := new(ExtendedNvmeRemoteController)
+	err := c.cc.Invoke(ctx, ExtendedNvmeRemoteControllerService_GetExtendedNvmeRemoteController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedNvmeRemoteControllerServiceServer is the server API for ExtendedNvmeRemoteControllerService service.
+// All implementations must embed UnimplementedExtendedNvmeRemoteControllerServiceServer
+// for forward compatibility
+type ExtendedNvmeRemoteControllerServiceServer interface {
+	CreateExtendedNvmeRemoteController(context.Context, *CreateExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error)
+	UpdateExtendedNvmeRemoteController(context.Context, *UpdateExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error)
+	ListExtendedNvmeRemoteControllers(context.Context, *ListExtendedNvmeRemoteControllersRequest) (*ListExtendedNvmeRemoteControllersResponse, error)
+	GetExtendedNvmeRemoteController(context.Context, *GetExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error)
+	mustEmbedUnimplementedExtendedNvmeRemoteControllerServiceServer()
+}
+
+// UnimplementedExtendedNvmeRemoteControllerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedNvmeRemoteControllerServiceServer struct {
+}
+
+func (UnimplementedExtendedNvmeRemoteControllerServiceServer) CreateExtendedNvmeRemoteController(context.Context, *CreateExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExtendedNvmeRemoteController not implemented")
+}
+func (UnimplementedExtendedNvmeRemoteControllerServiceServer) UpdateExtendedNvmeRemoteController(context.Context, *UpdateExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExtendedNvmeRemoteController not implemented")
+}
+func (UnimplementedExtendedNvmeRemoteControllerServiceServer) ListExtendedNvmeRemoteControllers(context.Context, *ListExtendedNvmeRemoteControllersRequest) (*ListExtendedNvmeRemoteControllersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExtendedNvmeRemoteControllers not implemented")
+}
+func (UnimplementedExtendedNvmeRemoteControllerServiceServer) GetExtendedNvmeRemoteController(context.Context, *GetExtendedNvmeRemoteControllerRequest) (*ExtendedNvmeRemoteController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExtendedNvmeRemoteController not implemented")
+}
+func (UnimplementedExtendedNvmeRemoteControllerServiceServer) mustEmbedUnimplementedExtendedNvmeRemoteControllerServiceServer() {
+}
+
+// UnsafeExtendedNvmeRemoteControllerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedNvmeRemoteControllerServiceServer will
+// result in compilation errors.
+type UnsafeExtendedNvmeRemoteControllerServiceServer interface {
+	mustEmbedUnimplementedExtendedNvmeRemoteControllerServiceServer()
+}
+
+func RegisterExtendedNvmeRemoteControllerServiceServer(s grpc.ServiceRegistrar, srv ExtendedNvmeRemoteControllerServiceServer) {
+	s.RegisterService(&ExtendedNvmeRemoteControllerService_ServiceDesc, srv)
+}
+
+func _ExtendedNvmeRemoteControllerService_CreateExtendedNvmeRemoteController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtendedNvmeRemoteControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).CreateExtendedNvmeRemoteController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmeRemoteControllerService_CreateExtendedNvmeRemoteController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).CreateExtendedNvmeRemoteController(ctx, req.(*CreateExtendedNvmeRemoteControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNvmeRemoteControllerService_UpdateExtendedNvmeRemoteController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExtendedNvmeRemoteControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).UpdateExtendedNvmeRemoteController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmeRemoteControllerService_UpdateExtendedNvmeRemoteController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).UpdateExtendedNvmeRemoteController(ctx, req.(*UpdateExtendedNvmeRemoteControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNvmeRemoteControllerService_ListExtendedNvmeRemoteControllers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExtendedNvmeRemoteControllersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).ListExtendedNvmeRemoteControllers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmeRemoteControllerService_ListExtendedNvmeRemoteControllers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).ListExtendedNvmeRemoteControllers(ctx, req.(*ListExtendedNvmeRemoteControllersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNvmeRemoteControllerService_GetExtendedNvmeRemoteController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExtendedNvmeRemoteControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).GetExtendedNvmeRemoteController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNvmeRemoteControllerService_GetExtendedNvmeRemoteController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNvmeRemoteControllerServiceServer).GetExtendedNvmeRemoteController(ctx, req.(*GetExtendedNvmeRemoteControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedNvmeRemoteControllerService_ServiceDesc is the grpc.ServiceDesc for ExtendedNvmeRemoteControllerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedNvmeRemoteControllerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.ExtendedNvmeRemoteControllerService",
+	HandlerType: (*ExtendedNvmeRemoteControllerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateExtendedNvmeRemoteController",
+			Handler:    _ExtendedNvmeRemoteControllerService_CreateExtendedNvmeRemoteController_Handler,
+		},
+		{
+			MethodName: "UpdateExtendedNvmeRemoteController",
+			Handler:    _ExtendedNvmeRemoteControllerService_UpdateExtendedNvmeRemoteController_Handler,
+		},
+		{
+			MethodName: "ListExtendedNvmeRemoteControllers",
+			Handler:    _ExtendedNvmeRemoteControllerService_ListExtendedNvmeRemoteControllers_Handler,
+		},
+		{
+			MethodName: "GetExtendedNvmeRemoteController",
+			Handler:    _ExtendedNvmeRemoteControllerService_GetExtendedNvmeRemoteController_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_extension.proto",
+}
+
+const (
+	ExtendedNullDebugService_CreateExtendedNullDebug_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedNullDebugService/CreateExtendedNullDebug"
+	ExtendedNullDebugService_ListExtendedNullDebugs_FullMethodName  = "/opi_spdk_bridge.storage.v1.ExtendedNullDebugService/ListExtendedNullDebugs"
+	ExtendedNullDebugService_GetExtendedNullDebug_FullMethodName    = "/opi_spdk_bridge.storage.v1.ExtendedNullDebugService/GetExtendedNullDebug"
+)
+
+// ExtendedNullDebugServiceClient is the client API for ExtendedNullDebugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedNullDebugServiceClient interface {
+	CreateExtendedNullDebug(ctx context.Context, in *CreateExtendedNullDebugRequest, opts ...grpc.CallOption) (*ExtendedNullDebug, error)
+	ListExtendedNullDebugs(ctx context.Context, in *ListExtendedNullDebugsRequest, opts ...grpc.CallOption) (*ListExtendedNullDebugsResponse, error)
+	GetExtendedNullDebug(ctx context.Context, in *GetExtendedNullDebugRequest, opts ...grpc.CallOption) (*ExtendedNullDebug, error)
+}
+
+type extendedNullDebugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedNullDebugServiceClient(cc grpc.ClientConnInterface) ExtendedNullDebugServiceClient {
+	return &extendedNullDebugServiceClient{cc}
+}
+
+func (c *extendedNullDebugServiceClient) CreateExtendedNullDebug(ctx context.Context, in *CreateExtendedNullDebugRequest, opts ...grpc.CallOption) (*ExtendedNullDebug, error) {
+	out := new(ExtendedNullDebug)
+	err := c.cc.Invoke(ctx, ExtendedNullDebugService_CreateExtendedNullDebug_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNullDebugServiceClient) ListExtendedNullDebugs(ctx context.Context, in *ListExtendedNullDebugsRequest, opts ...grpc.CallOption) (*ListExtendedNullDebugsResponse, error) {
+	out := new(ListExtendedNullDebugsResponse)
+	err := c.cc.Invoke(ctx, ExtendedNullDebugService_ListExtendedNullDebugs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNullDebugServiceClient) GetExtendedNullDebug(ctx context.Context, in *GetExtendedNullDebugRequest, opts ...grpc.CallOption) (*ExtendedNullDebug, error) {
+	out := new(ExtendedNullDebug)
+	err := c.cc.Invoke(ctx, ExtendedNullDebugService_GetExtendedNullDebug_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedNullDebugServiceServer is the server API for ExtendedNullDebugService service.
+// All implementations must embed UnimplementedExtendedNullDebugServiceServer
+// for forward compatibility
+type ExtendedNullDebugServiceServer interface {
+	CreateExtendedNullDebug(context.Context, *CreateExtendedNullDebugRequest) (*ExtendedNullDebug, error)
+	ListExtendedNullDebugs(context.Context, *ListExtendedNullDebugsRequest) (*ListExtendedNullDebugsResponse, error)
+	GetExtendedNullDebug(context.Context, *GetExtendedNullDebugRequest) (*ExtendedNullDebug, error)
+	mustEmbedUnimplementedExtendedNullDebugServiceServer()
+}
+
+// UnimplementedExtendedNullDebugServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedNullDebugServiceServer struct {
+}
+
+func (UnimplementedExtendedNullDebugServiceServer) CreateExtendedNullDebug(context.Context, *CreateExtendedNullDebugRequest) (*ExtendedNullDebug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExtendedNullDebug not implemented")
+}
+func (UnimplementedExtendedNullDebugServiceServer) ListExtendedNullDebugs(context.Context, *ListExtendedNullDebugsRequest) (*ListExtendedNullDebugsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExtendedNullDebugs not implemented")
+}
+func (UnimplementedExtendedNullDebugServiceServer) GetExtendedNullDebug(context.Context, *GetExtendedNullDebugRequest) (*ExtendedNullDebug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExtendedNullDebug not implemented")
+}
+func (UnimplementedExtendedNullDebugServiceServer) mustEmbedUnimplementedExtendedNullDebugServiceServer() {
+}
+
+// UnsafeExtendedNullDebugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedNullDebugServiceServer will
+// result in compilation errors.
+type UnsafeExtendedNullDebugServiceServer interface {
+	mustEmbedUnimplementedExtendedNullDebugServiceServer()
+}
+
+func RegisterExtendedNullDebugServiceServer(s grpc.ServiceRegistrar, srv ExtendedNullDebugServiceServer) {
+	s.RegisterService(&ExtendedNullDebugService_ServiceDesc, srv)
+}
+
+func _ExtendedNullDebugService_CreateExtendedNullDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtendedNullDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNullDebugServiceServer).CreateExtendedNullDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNullDebugService_CreateExtendedNullDebug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNullDebugServiceServer).CreateExtendedNullDebug(ctx, req.(*CreateExtendedNullDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNullDebugService_ListExtendedNullDebugs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExtendedNullDebugsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNullDebugServiceServer).ListExtendedNullDebugs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNullDebugService_ListExtendedNullDebugs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNullDebugServiceServer).ListExtendedNullDebugs(ctx, req.(*ListExtendedNullDebugsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNullDebugService_GetExtendedNullDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExtendedNullDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNullDebugServiceServer).GetExtendedNullDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedNullDebugService_GetExtendedNullDebug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNullDebugServiceServer).GetExtendedNullDebug(ctx, req.(*GetExtendedNullDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedNullDebugService_ServiceDesc is the grpc.ServiceDesc for ExtendedNullDebugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedNullDebugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.ExtendedNullDebugService",
+	HandlerType: (*ExtendedNullDebugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateExtendedNullDebug",
+			Handler:    _ExtendedNullDebugService_CreateExtendedNullDebug_Handler,
+		},
+		{
+			MethodName: "ListExtendedNullDebugs",
+			Handler:    _ExtendedNullDebugService_ListExtendedNullDebugs_Handler,
+		},
+		{
+			MethodName: "GetExtendedNullDebug",
+			Handler:    _ExtendedNullDebugService_GetExtendedNullDebug_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_extension.proto",
+}
+
+const (
+	ExtendedAioControllerService_CreateExtendedAioController_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedAioControllerService/CreateExtendedAioController"
+	ExtendedAioControllerService_UpdateExtendedAioController_FullMethodName = "/opi_spdk_bridge.storage.v1.ExtendedAioControllerService/UpdateExtendedAioController"
+	ExtendedAioControllerService_ListExtendedAioControllers_FullMethodName  = "/opi_spdk_bridge.storage.v1.ExtendedAioControllerService/ListExtendedAioControllers"
+	ExtendedAioControllerService_GetExtendedAioController_FullMethodName    = "/opi_spdk_bridge.storage.v1.ExtendedAioControllerService/GetExtendedAioController"
+)
+
+// ExtendedAioControllerServiceClient is the client API for ExtendedAioControllerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedAioControllerServiceClient interface {
+	CreateExtendedAioController(ctx context.Context, in *CreateExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error)
+	UpdateExtendedAioController(ctx context.Context, in *UpdateExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error)
+	ListExtendedAioControllers(ctx context.Context, in *ListExtendedAioControllersRequest, opts ...grpc.CallOption) (*ListExtendedAioControllersResponse, error)
+	GetExtendedAioController(ctx context.Context, in *GetExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error)
+}
+
+type extendedAioControllerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedAioControllerServiceClient(cc grpc.ClientConnInterface) ExtendedAioControllerServiceClient {
+	return &extendedAioControllerServiceClient{cc}
+}
+
+func (c *extendedAioControllerServiceClient) CreateExtendedAioController(ctx context.Context, in *CreateExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error) {
+	out := new(ExtendedAioController)
+	err := c.cc.Invoke(ctx, ExtendedAioControllerService_CreateExtendedAioController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAioControllerServiceClient) UpdateExtendedAioController(ctx context.Context, in *UpdateExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error) {
+	out := new(ExtendedAioController)
+	err := c.cc.Invoke(ctx, ExtendedAioControllerService_UpdateExtendedAioController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAioControllerServiceClient) ListExtendedAioControllers(ctx context.Context, in *ListExtendedAioControllersRequest, opts ...grpc.CallOption) (*ListExtendedAioControllersResponse, error) {
+	out := new(ListExtendedAioControllersResponse)
+	err := c.cc.Invoke(ctx, ExtendedAioControllerService_ListExtendedAioControllers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAioControllerServiceClient) GetExtendedAioController(ctx context.Context, in *GetExtendedAioControllerRequest, opts ...grpc.CallOption) (*ExtendedAioController, error) {
+	out := new(ExtendedAioController)
+	err := c.cc.Invoke(ctx, ExtendedAioControllerService_GetExtendedAioController_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedAioControllerServiceServer is the server API for ExtendedAioControllerService service.
+// All implementations must embed UnimplementedExtendedAioControllerServiceServer
+// for forward compatibility
+type ExtendedAioControllerServiceServer interface {
+	CreateExtendedAioController(context.Context, *CreateExtendedAioControllerRequest) (*ExtendedAioController, error)
+	UpdateExtendedAioController(context.Context, *UpdateExtendedAioControllerRequest) (*ExtendedAioController, error)
+	ListExtendedAioControllers(context.Context, *ListExtendedAioControllersRequest) (*ListExtendedAioControllersResponse, error)
+	GetExtendedAioController(context.Context, *GetExtendedAioControllerRequest) (*ExtendedAioController, error)
+	mustEmbedUnimplementedExtendedAioControllerServiceServer()
+}
+
+// UnimplementedExtendedAioControllerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedAioControllerServiceServer struct {
+}
+
+func (UnimplementedExtendedAioControllerServiceServer) CreateExtendedAioController(context.Context, *CreateExtendedAioControllerRequest) (*ExtendedAioController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExtendedAioController not implemented")
+}
+func (UnimplementedExtendedAioControllerServiceServer) UpdateExtendedAioController(context.Context, *UpdateExtendedAioControllerRequest) (*ExtendedAioController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExtendedAioController not implemented")
+}
+func (UnimplementedExtendedAioControllerServiceServer) ListExtendedAioControllers(context.Context, *ListExtendedAioControllersRequest) (*ListExtendedAioControllersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExtendedAioControllers not implemented")
+}
+func (UnimplementedExtendedAioControllerServiceServer) GetExtendedAioController(context.Context, *GetExtendedAioControllerRequest) (*ExtendedAioController, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExtendedAioController not implemented")
+}
+func (UnimplementedExtendedAioControllerServiceServer) mustEmbedUnimplementedExtendedAioControllerServiceServer() {
+}
+
+// UnsafeExtendedAioControllerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedAioControllerServiceServer will
+// result in compilation errors.
+type UnsafeExtendedAioControllerServiceServer interface {
+	mustEmbedUnimplementedExtendedAioControllerServiceServer()
+}
+
+func RegisterExtendedAioControllerServiceServer(s grpc.ServiceRegistrar, srv ExtendedAioControllerServiceServer) {
+	s.RegisterService(&ExtendedAioControllerService_ServiceDesc, srv)
+}
+
+func _ExtendedAioControllerService_CreateExtendedAioController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtendedAioControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAioControllerServiceServer).CreateExtendedAioController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAioControllerService_CreateExtendedAioController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAioControllerServiceServer).CreateExtendedAioController(ctx, req.(*CreateExtendedAioControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAioControllerService_UpdateExtendedAioController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExtendedAioControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAioControllerServiceServer).UpdateExtendedAioController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAioControllerService_UpdateExtendedAioController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAioControllerServiceServer).UpdateExtendedAioController(ctx, req.(*UpdateExtendedAioControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAioControllerService_ListExtendedAioControllers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExtendedAioControllersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAioControllerServiceServer).ListExtendedAioControllers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAioControllerService_ListExtendedAioControllers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAioControllerServiceServer).ListExtendedAioControllers(ctx, req.(*ListExtendedAioControllersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAioControllerService_GetExtendedAioController_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExtendedAioControllerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAioControllerServiceServer).GetExtendedAioController(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAioControllerService_GetExtendedAioController_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAioControllerServiceServer).GetExtendedAioController(ctx, req.(*GetExtendedAioControllerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedAioControllerService_ServiceDesc is the grpc.ServiceDesc for ExtendedAioControllerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedAioControllerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.ExtendedAioControllerService",
+	HandlerType: (*ExtendedAioControllerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateExtendedAioController",
+			Handler:    _ExtendedAioControllerService_CreateExtendedAioController_Handler,
+		},
+		{
+			MethodName: "UpdateExtendedAioController",
+			Handler:    _ExtendedAioControllerService_UpdateExtendedAioController_Handler,
+		},
+		{
+			MethodName: "ListExtendedAioControllers",
+			Handler:    _ExtendedAioControllerService_ListExtendedAioControllers_Handler,
+		},
+		{
+			MethodName: "GetExtendedAioController",
+			Handler:    _ExtendedAioControllerService_GetExtendedAioController_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_extension.proto",
+}
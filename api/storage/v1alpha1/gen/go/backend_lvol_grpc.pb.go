@@ -0,0 +1,705 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: backend_lvol.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LvolService_CreateLvolStore_FullMethodName = "/opi_spdk_bridge.storage.v1.LvolService/CreateLvolStore"
+	LvolService_DeleteLvolStore_FullMethodName = "/opi_spdk_bridge.storage.v1.LvolService/DeleteLvolStore"
+	LvolService_UpdateLvolStore_FullMethodName = "/opi_spdk_bridge.storage.v1.LvolService/UpdateLvolStore"
+	LvolService_ListLvolStores_FullMethodName  = "/opi_spdk_bridge.storage.v1.LvolService/ListLvolStores"
+	LvolService_GetLvolStore_FullMethodName    = "/opi_spdk_bridge.storage.v1.LvolService/GetLvolStore"
+	LvolService_CreateLvol_FullMethodName      = "/opi_spdk_bridge.storage.v1.LvolService/CreateLvol"
+	LvolService_DeleteLvol_FullMethodName      = "/opi_spdk_bridge.storage.v1.LvolService/DeleteLvol"
+	LvolService_UpdateLvol_FullMethodName      = "/opi_spdk_bridge.storage.v1.LvolService/UpdateLvol"
+	LvolService_ListLvols_FullMethodName       = "/opi_spdk_bridge.storage.v1.LvolService/ListLvols"
+	LvolService_GetLvol_FullMethodName         = "/opi_spdk_bridge.storage.v1.LvolService/GetLvol"
+	LvolService_LvolStats_FullMethodName       = "/opi_spdk_bridge.storage.v1.LvolService/LvolStats"
+	LvolService_CreateSnapshot_FullMethodName  = "/opi_spdk_bridge.storage.v1.LvolService/CreateSnapshot"
+	LvolService_DeleteSnapshot_FullMethodName  = "/opi_spdk_bridge.storage.v1.LvolService/DeleteSnapshot"
+	LvolService_ListSnapshots_FullMethodName   = "/opi_spdk_bridge.storage.v1.LvolService/ListSnapshots"
+	LvolService_GetSnapshot_FullMethodName     = "/opi_spdk_bridge.storage.v1.LvolService/GetSnapshot"
+	LvolService_CreateClone_FullMethodName     = "/opi_spdk_bridge.storage.v1.LvolService/CreateClone"
+	LvolService_InflateClone_FullMethodName    = "/opi_spdk_bridge.storage.v1.LvolService/InflateClone"
+)
+
+// LvolServiceClient is the client API for LvolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LvolServiceClient interface {
+	CreateLvolStore(ctx context.Context, in *CreateLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error)
+	DeleteLvolStore(ctx context.Context, in *DeleteLvolStoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateLvolStore(ctx context.Context, in *UpdateLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error)
+	ListLvolStores(ctx context.Context, in *ListLvolStoresRequest, opts ...grpc.CallOption) (*ListLvolStoresResponse, error)
+	GetLvolStore(ctx context.Context, in *GetLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error)
+	CreateLvol(ctx context.Context, in *CreateLvolRequest, opts ...grpc.CallOption) (*Lvol, error)
+	DeleteLvol(ctx context.Context, in *DeleteLvolRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateLvol(ctx context.Context, in *UpdateLvolRequest, opts ...grpc.CallOption) (*Lvol, error)
+	ListLvols(ctx context.Context, in *ListLvolsRequest, opts ...grpc.CallOption) (*ListLvolsResponse, error)
+	GetLvol(ctx context.Context, in *GetLvolRequest, opts ...grpc.CallOption) (*Lvol, error)
+	LvolStats(ctx context.Context, in *LvolStatsRequest, opts ...grpc.CallOption) (*LvolStatsResponse, error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	CreateClone(ctx context.Context, in *CreateCloneRequest, opts ...grpc.CallOption) (*Lvol, error)
+	InflateClone(ctx context.Context, in *InflateCloneRequest, opts ...grpc.CallOption) (*Lvol, error)
+}
+
+type lvolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLvolServiceClient(cc grpc.ClientConnInterface) LvolServiceClient {
+	return &lvolServiceClient{cc}
+}
+
+func (c *lvolServiceClient) CreateLvolStore(ctx context.Context, in *CreateLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error) {
+	out := new(LvolStore)
+	err := c.cc.Invoke(ctx, LvolService_CreateLvolStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) DeleteLvolStore(ctx context.Context, in *DeleteLvolStoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LvolService_DeleteLvolStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) UpdateLvolStore(ctx context.Context, in *UpdateLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error) {
+	out := new(LvolStore)
+	err := c.cc.Invoke(ctx, LvolService_UpdateLvolStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) ListLvolStores(ctx context.Context, in *ListLvolStoresRequest, opts ...grpc.CallOption) (*ListLvolStoresResponse, error) {
+	out := new(ListLvolStoresResponse)
+	err := c.cc.Invoke(ctx, LvolService_ListLvolStores_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) GetLvolStore(ctx context.Context, in *GetLvolStoreRequest, opts ...grpc.CallOption) (*LvolStore, error) {
+	out := new(LvolStore)
+	err := c.cc.Invoke(ctx, LvolService_GetLvolStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) CreateLvol(ctx context.Context, in *CreateLvolRequest, opts ...grpc.CallOption) (*Lvol, error) {
+	out := new(Lvol)
+	err := c.cc.Invoke(ctx, LvolService_CreateLvol_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) DeleteLvol(ctx context.Context, in *DeleteLvolRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LvolService_DeleteLvol_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) UpdateLvol(ctx context.Context, in *UpdateLvolRequest, opts ...grpc.CallOption) (*Lvol, error) {
+	out := new(Lvol)
+	err := c.cc.Invoke(ctx, LvolService_UpdateLvol_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) ListLvols(ctx context.Context, in *ListLvolsRequest, opts ...grpc.CallOption) (*ListLvolsResponse, error) {
+	out := new(ListLvolsResponse)
+	err := c.cc.Invoke(ctx, LvolService_ListLvols_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) GetLvol(ctx context.Context, in *GetLvolRequest, opts ...grpc.CallOption) (*Lvol, error) {
+	out := new(Lvol)
+	err := c.cc.Invoke(ctx, LvolService_GetLvol_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) LvolStats(ctx context.Context, in *LvolStatsRequest, opts ...grpc.CallOption) (*LvolStatsResponse, error) {
+	out := new(LvolStatsResponse)
+	err := c.cc.Invoke(ctx, LvolService_LvolStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, LvolService_CreateSnapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LvolService_DeleteSnapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, LvolService_ListSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, LvolService_GetSnapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) CreateClone(ctx context.Context, in *CreateCloneRequest, opts ...grpc.CallOption) (*Lvol, error) {
+	out := new(Lvol)
+	err := c.cc.Invoke(ctx, LvolService_CreateClone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lvolServiceClient) InflateClone(ctx context.Context, in *InflateCloneRequest, opts ...grpc.CallOption) (*Lvol, error) {
+	out := new(Lvol)
+	err := c.cc.Invoke(ctx, LvolService_InflateClone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LvolServiceServer is the server API for LvolService service.
+// All implementations must embed UnimplementedLvolServiceServer
+// for forward compatibility
+type LvolServiceServer interface {
+	CreateLvolStore(context.Context, *CreateLvolStoreRequest) (*LvolStore, error)
+	DeleteLvolStore(context.Context, *DeleteLvolStoreRequest) (*emptypb.Empty, error)
+	UpdateLvolStore(context.Context, *UpdateLvolStoreRequest) (*LvolStore, error)
+	ListLvolStores(context.Context, *ListLvolStoresRequest) (*ListLvolStoresResponse, error)
+	GetLvolStore(context.Context, *GetLvolStoreRequest) (*LvolStore, error)
+	CreateLvol(context.Context, *CreateLvolRequest) (*Lvol, error)
+	DeleteLvol(context.Context, *DeleteLvolRequest) (*emptypb.Empty, error)
+	UpdateLvol(context.Context, *UpdateLvolRequest) (*Lvol, error)
+	ListLvols(context.Context, *ListLvolsRequest) (*ListLvolsResponse, error)
+	GetLvol(context.Context, *GetLvolRequest) (*Lvol, error)
+	LvolStats(context.Context, *LvolStatsRequest) (*LvolStatsResponse, error)
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error)
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*emptypb.Empty, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error)
+	CreateClone(context.Context, *CreateCloneRequest) (*Lvol, error)
+	InflateClone(context.Context, *InflateCloneRequest) (*Lvol, error)
+	mustEmbedUnimplementedLvolServiceServer()
+}
+
+// UnimplementedLvolServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLvolServiceServer struct {
+}
+
+func (UnimplementedLvolServiceServer) CreateLvolStore(context.Context, *CreateLvolStoreRequest) (*LvolStore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLvolStore not implemented")
+}
+func (UnimplementedLvolServiceServer) DeleteLvolStore(context.Context, *DeleteLvolStoreRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLvolStore not implemented")
+}
+func (UnimplementedLvolServiceServer) UpdateLvolStore(context.Context, *UpdateLvolStoreRequest) (*LvolStore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLvolStore not implemented")
+}
+func (UnimplementedLvolServiceServer) ListLvolStores(context.Context, *ListLvolStoresRequest) (*ListLvolStoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLvolStores not implemented")
+}
+func (UnimplementedLvolServiceServer) GetLvolStore(context.Context, *GetLvolStoreRequest) (*LvolStore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLvolStore not implemented")
+}
+func (UnimplementedLvolServiceServer) CreateLvol(context.Context, *CreateLvolRequest) (*Lvol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLvol not implemented")
+}
+func (UnimplementedLvolServiceServer) DeleteLvol(context.Context, *DeleteLvolRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLvol not implemented")
+}
+func (UnimplementedLvolServiceServer) UpdateLvol(context.Context, *UpdateLvolRequest) (*Lvol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLvol not implemented")
+}
+func (UnimplementedLvolServiceServer) ListLvols(context.Context, *ListLvolsRequest) (*ListLvolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLvols not implemented")
+}
+func (UnimplementedLvolServiceServer) GetLvol(context.Context, *GetLvolRequest) (*Lvol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLvol not implemented")
+}
+func (UnimplementedLvolServiceServer) LvolStats(context.Context, *LvolStatsRequest) (*LvolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LvolStats not implemented")
+}
+func (UnimplementedLvolServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedLvolServiceServer) DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
+func (UnimplementedLvolServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedLvolServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedLvolServiceServer) CreateClone(context.Context, *CreateCloneRequest) (*Lvol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClone not implemented")
+}
+func (UnimplementedLvolServiceServer) InflateClone(context.Context, *InflateCloneRequest) (*Lvol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InflateClone not implemented")
+}
+func (UnimplementedLvolServiceServer) mustEmbedUnimplementedLvolServiceServer() {}
+
+// UnsafeLvolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LvolServiceServer will
+// result in compilation errors.
+type UnsafeLvolServiceServer interface {
+	mustEmbedUnimplementedLvolServiceServer()
+}
+
+func RegisterLvolServiceServer(s grpc.ServiceRegistrar, srv LvolServiceServer) {
+	s.RegisterService(&LvolService_ServiceDesc, srv)
+}
+
+func _LvolService_CreateLvolStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLvolStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).CreateLvolStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_CreateLvolStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).CreateLvolStore(ctx, req.(*CreateLvolStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_DeleteLvolStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLvolStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).DeleteLvolStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_DeleteLvolStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).DeleteLvolStore(ctx, req.(*DeleteLvolStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_UpdateLvolStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLvolStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).UpdateLvolStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_UpdateLvolStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).UpdateLvolStore(ctx, req.(*UpdateLvolStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_ListLvolStores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLvolStoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).ListLvolStores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_ListLvolStores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).ListLvolStores(ctx, req.(*ListLvolStoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_GetLvolStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLvolStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).GetLvolStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_GetLvolStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).GetLvolStore(ctx, req.(*GetLvolStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_CreateLvol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLvolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).CreateLvol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_CreateLvol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).CreateLvol(ctx, req.(*CreateLvolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_DeleteLvol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLvolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).DeleteLvol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_DeleteLvol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).DeleteLvol(ctx, req.(*DeleteLvolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_UpdateLvol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLvolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).UpdateLvol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_UpdateLvol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).UpdateLvol(ctx, req.(*UpdateLvolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_ListLvols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLvolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).ListLvols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_ListLvols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).ListLvols(ctx, req.(*ListLvolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_GetLvol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLvolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).GetLvol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_GetLvol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).GetLvol(ctx, req.(*GetLvolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_LvolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LvolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).LvolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_LvolStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).LvolStats(ctx, req.(*LvolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_DeleteSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_CreateClone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCloneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).CreateClone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_CreateClone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).CreateClone(ctx, req.(*CreateCloneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LvolService_InflateClone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InflateCloneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LvolServiceServer).InflateClone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LvolService_InflateClone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LvolServiceServer).InflateClone(ctx, req.(*InflateCloneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LvolService_ServiceDesc is the grpc.ServiceDesc for LvolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LvolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.LvolService",
+	HandlerType: (*LvolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLvolStore",
+			Handler:    _LvolService_CreateLvolStore_Handler,
+		},
+		{
+			MethodName: "DeleteLvolStore",
+			Handler:    _LvolService_DeleteLvolStore_Handler,
+		},
+		{
+			MethodName: "UpdateLvolStore",
+			Handler:    _LvolService_UpdateLvolStore_Handler,
+		},
+		{
+			MethodName: "ListLvolStores",
+			Handler:    _LvolService_ListLvolStores_Handler,
+		},
+		{
+			MethodName: "GetLvolStore",
+			Handler:    _LvolService_GetLvolStore_Handler,
+		},
+		{
+			MethodName: "CreateLvol",
+			Handler:    _LvolService_CreateLvol_Handler,
+		},
+		{
+			MethodName: "DeleteLvol",
+			Handler:    _LvolService_DeleteLvol_Handler,
+		},
+		{
+			MethodName: "UpdateLvol",
+			Handler:    _LvolService_UpdateLvol_Handler,
+		},
+		{
+			MethodName: "ListLvols",
+			Handler:    _LvolService_ListLvols_Handler,
+		},
+		{
+			MethodName: "GetLvol",
+			Handler:    _LvolService_GetLvol_Handler,
+		},
+		{
+			MethodName: "LvolStats",
+			Handler:    _LvolService_LvolStats_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _LvolService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _LvolService_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _LvolService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _LvolService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "CreateClone",
+			Handler:    _LvolService_CreateClone_Handler,
+		},
+		{
+			MethodName: "InflateClone",
+			Handler:    _LvolService_InflateClone_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_lvol.proto",
+}
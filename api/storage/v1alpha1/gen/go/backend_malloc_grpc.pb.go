@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: backend_malloc.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MallocVolumeService_CreateMallocVolume_FullMethodName = "/opi_spdk_bridge.storage.v1.MallocVolumeService/CreateMallocVolume"
+	MallocVolumeService_DeleteMallocVolume_FullMethodName = "/opi_spdk_bridge.storage.v1.MallocVolumeService/DeleteMallocVolume"
+	MallocVolumeService_UpdateMallocVolume_FullMethodName = "/opi_spdk_bridge.storage.v1.MallocVolumeService/UpdateMallocVolume"
+	MallocVolumeService_ListMallocVolumes_FullMethodName  = "/opi_spdk_bridge.storage.v1.MallocVolumeService/ListMallocVolumes"
+	MallocVolumeService_GetMallocVolume_FullMethodName    = "/opi_spdk_bridge.storage.v1.MallocVolumeService/GetMallocVolume"
+	MallocVolumeService_MallocVolumeStats_FullMethodName  = "/opi_spdk_bridge.storage.v1.MallocVolumeService/MallocVolumeStats"
+)
+
+// MallocVolumeServiceClient is the client API for MallocVolumeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MallocVolumeServiceClient interface {
+	CreateMallocVolume(ctx context.Context, in *CreateMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error)
+	DeleteMallocVolume(ctx context.Context, in *DeleteMallocVolumeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateMallocVolume(ctx context.Context, in *UpdateMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error)
+	ListMallocVolumes(ctx context.Context, in *ListMallocVolumesRequest, opts ...grpc.CallOption) (*ListMallocVolumesResponse, error)
+	GetMallocVolume(ctx context.Context, in *GetMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error)
+	MallocVolumeStats(ctx context.Context, in *MallocVolumeStatsRequest, opts ...grpc.CallOption) (*MallocVolumeStatsResponse, error)
+}
+
+type mallocVolumeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMallocVolumeServiceClient(cc grpc.ClientConnInterface) MallocVolumeServiceClient {
+	return &mallocVolumeServiceClient{cc}
+}
+
+func (c *mallocVolumeServiceClient) CreateMallocVolume(ctx context.Context, in *CreateMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error) {
+	out := new(MallocVolume)
+	err := c.cc.Invoke(ctx, MallocVolumeService_CreateMallocVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mallocVolumeServiceClient) DeleteMallocVolume(ctx context.Context, in *DeleteMallocVolumeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MallocVolumeService_DeleteMallocVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mallocVolumeServiceClient) UpdateMallocVolume(ctx context.Context, in *UpdateMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error) {
+	out := new(MallocVolume)
+	err := c.cc.Invoke(ctx, MallocVolumeService_UpdateMallocVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mallocVolumeServiceClient) ListMallocVolumes(ctx context.Context, in *ListMallocVolumesRequest, opts ...grpc.CallOption) (*ListMallocVolumesResponse, error) {
+	out := new(ListMallocVolumesResponse)
+	err := c.cc.Invoke(ctx, MallocVolumeService_ListMallocVolumes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mallocVolumeServiceClient) GetMallocVolume(ctx context.Context, in *GetMallocVolumeRequest, opts ...grpc.CallOption) (*MallocVolume, error) {
+	out := new(MallocVolume)
+	err := c.cc.Invoke(ctx, MallocVolumeService_GetMallocVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mallocVolumeServiceClient) MallocVolumeStats(ctx context.Context, in *MallocVolumeStatsRequest, opts ...grpc.CallOption) (*MallocVolumeStatsResponse, error) {
+	out := new(MallocVolumeStatsResponse)
+	err := c.cc.Invoke(ctx, MallocVolumeService_MallocVolumeStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MallocVolumeServiceServer is the server API for MallocVolumeService service.
+// All implementations must embed UnimplementedMallocVolumeServiceServer
+// for forward compatibility
+type MallocVolumeServiceServer interface {
+	CreateMallocVolume(context.Context, *CreateMallocVolumeRequest) (*MallocVolume, error)
+	DeleteMallocVolume(context.Context, *DeleteMallocVolumeRequest) (*emptypb.Empty, error)
+	UpdateMallocVolume(context.Context, *UpdateMallocVolumeRequest) (*MallocVolume, error)
+	ListMallocVolumes(context.Context, *ListMallocVolumesRequest) (*ListMallocVolumesResponse, error)
+	GetMallocVolume(context.Context, *GetMallocVolumeRequest) (*MallocVolume, error)
+	MallocVolumeStats(context.Context, *MallocVolumeStatsRequest) (*MallocVolumeStatsResponse, error)
+	mustEmbedUnimplementedMallocVolumeServiceServer()
+}
+
+// UnimplementedMallocVolumeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMallocVolumeServiceServer struct {
+}
+
+func (UnimplementedMallocVolumeServiceServer) CreateMallocVolume(context.Context, *CreateMallocVolumeRequest) (*MallocVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMallocVolume not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) DeleteMallocVolume(context.Context, *DeleteMallocVolumeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMallocVolume not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) UpdateMallocVolume(context.Context, *UpdateMallocVolumeRequest) (*MallocVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMallocVolume not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) ListMallocVolumes(context.Context, *ListMallocVolumesRequest) (*ListMallocVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMallocVolumes not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) GetMallocVolume(context.Context, *GetMallocVolumeRequest) (*MallocVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMallocVolume not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) MallocVolumeStats(context.Context, *MallocVolumeStatsRequest) (*MallocVolumeStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MallocVolumeStats not implemented")
+}
+func (UnimplementedMallocVolumeServiceServer) mustEmbedUnimplementedMallocVolumeServiceServer() {}
+
+// UnsafeMallocVolumeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MallocVolumeServiceServer will
+// result in compilation errors.
+type UnsafeMallocVolumeServiceServer interface {
+	mustEmbedUnimplementedMallocVolumeServiceServer()
+}
+
+func RegisterMallocVolumeServiceServer(s grpc.ServiceRegistrar, srv MallocVolumeServiceServer) {
+	s.RegisterService(&MallocVolumeService_ServiceDesc, srv)
+}
+
+func _MallocVolumeService_CreateMallocVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMallocVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).CreateMallocVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_CreateMallocVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).CreateMallocVolume(ctx, req.(*CreateMallocVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MallocVolumeService_DeleteMallocVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMallocVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).DeleteMallocVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_DeleteMallocVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).DeleteMallocVolume(ctx, req.(*DeleteMallocVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MallocVolumeService_UpdateMallocVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMallocVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).UpdateMallocVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_UpdateMallocVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).UpdateMallocVolume(ctx, req.(*UpdateMallocVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MallocVolumeService_ListMallocVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMallocVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).ListMallocVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_ListMallocVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).ListMallocVolumes(ctx, req.(*ListMallocVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MallocVolumeService_GetMallocVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMallocVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).GetMallocVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_GetMallocVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).GetMallocVolume(ctx, req.(*GetMallocVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MallocVolumeService_MallocVolumeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MallocVolumeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MallocVolumeServiceServer).MallocVolumeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MallocVolumeService_MallocVolumeStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MallocVolumeServiceServer).MallocVolumeStats(ctx, req.(*MallocVolumeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MallocVolumeService_ServiceDesc is the grpc.ServiceDesc for MallocVolumeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MallocVolumeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.MallocVolumeService",
+	HandlerType: (*MallocVolumeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMallocVolume",
+			Handler:    _MallocVolumeService_CreateMallocVolume_Handler,
+		},
+		{
+			MethodName: "DeleteMallocVolume",
+			Handler:    _MallocVolumeService_DeleteMallocVolume_Handler,
+		},
+		{
+			MethodName: "UpdateMallocVolume",
+			Handler:    _MallocVolumeService_UpdateMallocVolume_Handler,
+		},
+		{
+			MethodName: "ListMallocVolumes",
+			Handler:    _MallocVolumeService_ListMallocVolumes_Handler,
+		},
+		{
+			MethodName: "GetMallocVolume",
+			Handler:    _MallocVolumeService_GetMallocVolume_Handler,
+		},
+		{
+			MethodName: "MallocVolumeStats",
+			Handler:    _MallocVolumeService_MallocVolumeStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend_malloc.proto",
+}
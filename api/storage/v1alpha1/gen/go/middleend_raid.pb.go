@@ -0,0 +1,1126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: middleend_raid.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	_go1 "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RaidLevel int32
+
+const (
+	RaidLevel_RAID_LEVEL_UNSPECIFIED RaidLevel = 0
+	// RAID_LEVEL_RAID0 stripes data across members
+	RaidLevel_RAID_LEVEL_RAID0 RaidLevel = 1
+	// RAID_LEVEL_RAID1 mirrors data to all members
+	RaidLevel_RAID_LEVEL_RAID1 RaidLevel = 2
+	// RAID_LEVEL_CONCAT appends capacity of members one after another
+	RaidLevel_RAID_LEVEL_CONCAT RaidLevel = 3
+)
+
+// Enum value maps for RaidLevel.
+var (
+	RaidLevel_name = map[int32]string{
+		0: "RAID_LEVEL_UNSPECIFIED",
+		1: "RAID_LEVEL_RAID0",
+		2: "RAID_LEVEL_RAID1",
+		3: "RAID_LEVEL_CONCAT",
+	}
+	RaidLevel_value = map[string]int32{
+		"RAID_LEVEL_UNSPECIFIED": 0,
+		"RAID_LEVEL_RAID0":       1,
+		"RAID_LEVEL_RAID1":       2,
+		"RAID_LEVEL_CONCAT":      3,
+	}
+)
+
+func (x RaidLevel) Enum() *RaidLevel {
+	p := new(RaidLevel)
+	*p = x
+	return p
+}
+
+func (x RaidLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RaidLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_middleend_raid_proto_enumTypes[0].Descriptor()
+}
+
+func (RaidLevel) Type() protoreflect.EnumType {
+	return &file_middleend_raid_proto_enumTypes[0]
+}
+
+func (x RaidLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RaidLevel.Descriptor instead.
+func (RaidLevel) EnumDescriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{0}
+}
+
+type RaidVolumeState int32
+
+const (
+	RaidVolumeState_RAID_VOLUME_STATE_UNSPECIFIED RaidVolumeState = 0
+	// RAID_VOLUME_STATE_ONLINE volume is serving I/O
+	RaidVolumeState_RAID_VOLUME_STATE_ONLINE RaidVolumeState = 1
+	// RAID_VOLUME_STATE_CONFIGURING volume waits for its members to appear
+	RaidVolumeState_RAID_VOLUME_STATE_CONFIGURING RaidVolumeState = 2
+	// RAID_VOLUME_STATE_OFFLINE volume lost more members than it tolerates
+	RaidVolumeState_RAID_VOLUME_STATE_OFFLINE RaidVolumeState = 3
+)
+
+// Enum value maps for RaidVolumeState.
+var (
+	RaidVolumeState_name = map[int32]string{
+		0: "RAID_VOLUME_STATE_UNSPECIFIED",
+		1: "RAID_VOLUME_STATE_ONLINE",
+		2: "RAID_VOLUME_STATE_CONFIGURING",
+		3: "RAID_VOLUME_STATE_OFFLINE",
+	}
+	RaidVolumeState_value = map[string]int32{
+		"RAID_VOLUME_STATE_UNSPECIFIED": 0,
+		"RAID_VOLUME_STATE_ONLINE":      1,
+		"RAID_VOLUME_STATE_CONFIGURING": 2,
+		"RAID_VOLUME_STATE_OFFLINE":     3,
+	}
+)
+
+func (x RaidVolumeState) Enum() *RaidVolumeState {
+	p := new(RaidVolumeState)
+	*p = x
+	return p
+}
+
+func (x RaidVolumeState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RaidVolumeState) Descriptor() protoreflect.EnumDescriptor {
+	return file_middleend_raid_proto_enumTypes[1].Descriptor()
+}
+
+func (RaidVolumeState) Type() protoreflect.EnumType {
+	return &file_middleend_raid_proto_enumTypes[1]
+}
+
+func (x RaidVolumeState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RaidVolumeState.Descriptor instead.
+func (RaidVolumeState) EnumDescriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{1}
+}
+
+type RaidMemberStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// volume_id is the name of the member bdev
+	VolumeId *_go.ObjectKey `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// configured is false for a member missing or failed in SPDK
+	Configured bool `protobuf:"varint,2,opt,name=configured,proto3" json:"configured,omitempty"`
+}
+
+func (x *RaidMemberStatus) Reset() {
+	*x = RaidMemberStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaidMemberStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidMemberStatus) ProtoMessage() {}
+
+func (x *RaidMemberStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidMemberStatus.ProtoReflect.Descriptor instead.
+func (*RaidMemberStatus) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RaidMemberStatus) GetVolumeId() *_go.ObjectKey {
+	if x != nil {
+		return x.VolumeId
+	}
+	return nil
+}
+
+func (x *RaidMemberStatus) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+type RaidRebuildStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// target_volume_id is the name of the member being rebuilt
+	TargetVolumeId *_go.ObjectKey `protobuf:"bytes,1,opt,name=target_volume_id,json=targetVolumeId,proto3" json:"target_volume_id,omitempty"`
+	Percent        int32          `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+}
+
+func (x *RaidRebuildStatus) Reset() {
+	*x = RaidRebuildStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaidRebuildStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidRebuildStatus) ProtoMessage() {}
+
+func (x *RaidRebuildStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidRebuildStatus.ProtoReflect.Descriptor instead.
+func (*RaidRebuildStatus) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RaidRebuildStatus) GetTargetVolumeId() *_go.ObjectKey {
+	if x != nil {
+		return x.TargetVolumeId
+	}
+	return nil
+}
+
+func (x *RaidRebuildStatus) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type RaidVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name is an opaque object handle that is not user settable.
+	// name will be returned with created object
+	// user can only set {resource}_id on the Create request object
+	Name      string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RaidLevel RaidLevel `protobuf:"varint,2,opt,name=raid_level,json=raidLevel,proto3,enum=opi_spdk_bridge.storage.v1.RaidLevel" json:"raid_level,omitempty"`
+	// member_volume_ids are names of bdevs composed into the volume,
+	// a bdev can be a member of one RaidVolume only
+	MemberVolumeIds []*_go.ObjectKey `protobuf:"bytes,3,rep,name=member_volume_ids,json=memberVolumeIds,proto3" json:"member_volume_ids,omitempty"`
+	// strip_size_kb is required by RAID0 and concat and must not be set for RAID1
+	StripSizeKb int32           `protobuf:"varint,4,opt,name=strip_size_kb,json=stripSizeKb,proto3" json:"strip_size_kb,omitempty"`
+	Uuid        *_go.Uuid       `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	State       RaidVolumeState `protobuf:"varint,6,opt,name=state,proto3,enum=opi_spdk_bridge.storage.v1.RaidVolumeState" json:"state,omitempty"`
+	// members report health of member_volume_ids in the same order
+	Members []*RaidMemberStatus `protobuf:"bytes,7,rep,name=members,proto3" json:"members,omitempty"`
+	// rebuild is set while a RAID1 member is being rebuilt
+	Rebuild *RaidRebuildStatus `protobuf:"bytes,8,opt,name=rebuild,proto3" json:"rebuild,omitempty"`
+}
+
+func (x *RaidVolume) Reset() {
+	*x = RaidVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaidVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidVolume) ProtoMessage() {}
+
+func (x *RaidVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidVolume.ProtoReflect.Descriptor instead.
+func (*RaidVolume) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RaidVolume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RaidVolume) GetRaidLevel() RaidLevel {
+	if x != nil {
+		return x.RaidLevel
+	}
+	return RaidLevel_RAID_LEVEL_UNSPECIFIED
+}
+
+func (x *RaidVolume) GetMemberVolumeIds() []*_go.ObjectKey {
+	if x != nil {
+		return x.MemberVolumeIds
+	}
+	return nil
+}
+
+func (x *RaidVolume) GetStripSizeKb() int32 {
+	if x != nil {
+		return x.StripSizeKb
+	}
+	return 0
+}
+
+func (x *RaidVolume) GetUuid() *_go.Uuid {
+	if x != nil {
+		return x.Uuid
+	}
+	return nil
+}
+
+func (x *RaidVolume) GetState() RaidVolumeState {
+	if x != nil {
+		return x.State
+	}
+	return RaidVolumeState_RAID_VOLUME_STATE_UNSPECIFIED
+}
+
+func (x *RaidVolume) GetMembers() []*RaidMemberStatus {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RaidVolume) GetRebuild() *RaidRebuildStatus {
+	if x != nil {
+		return x.Rebuild
+	}
+	return nil
+}
+
+type CreateRaidVolumeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RaidVolume   *RaidVolume `protobuf:"bytes,1,opt,name=raid_volume,json=raidVolume,proto3" json:"raid_volume,omitempty"`
+	RaidVolumeId string      `protobuf:"bytes,2,opt,name=raid_volume_id,json=raidVolumeId,proto3" json:"raid_volume_id,omitempty"`
+}
+
+func (x *CreateRaidVolumeRequest) Reset() {
+	*x = CreateRaidVolumeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRaidVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRaidVolumeRequest) ProtoMessage() {}
+
+func (x *CreateRaidVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRaidVolumeRequest.ProtoReflect.Descriptor instead.
+func (*CreateRaidVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRaidVolumeRequest) GetRaidVolume() *RaidVolume {
+	if x != nil {
+		return x.RaidVolume
+	}
+	return nil
+}
+
+func (x *CreateRaidVolumeRequest) GetRaidVolumeId() string {
+	if x != nil {
+		return x.RaidVolumeId
+	}
+	return ""
+}
+
+type DeleteRaidVolumeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If set to true, and the resource is not found, the request will succeed
+	// but no action will be taken on the server
+	AllowMissing bool `protobuf:"varint,2,opt,name=allow_missing,json=allowMissing,proto3" json:"allow_missing,omitempty"`
+}
+
+func (x *DeleteRaidVolumeRequest) Reset() {
+	*x = DeleteRaidVolumeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRaidVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRaidVolumeRequest) ProtoMessage() {}
+
+func (x *DeleteRaidVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRaidVolumeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRaidVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteRaidVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteRaidVolumeRequest) GetAllowMissing() bool {
+	if x != nil {
+		return x.AllowMissing
+	}
+	return false
+}
+
+type ListRaidVolumesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListRaidVolumesRequest) Reset() {
+	*x = ListRaidVolumesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRaidVolumesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRaidVolumesRequest) ProtoMessage() {}
+
+func (x *ListRaidVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRaidVolumesRequest.ProtoReflect.Descriptor instead.
+func (*ListRaidVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRaidVolumesRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListRaidVolumesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRaidVolumesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListRaidVolumesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RaidVolumes   []*RaidVolume `protobuf:"bytes,1,rep,name=raid_volumes,json=raidVolumes,proto3" json:"raid_volumes,omitempty"`
+	NextPageToken string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListRaidVolumesResponse) Reset() {
+	*x = ListRaidVolumesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRaidVolumesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRaidVolumesResponse) ProtoMessage() {}
+
+func (x *ListRaidVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRaidVolumesResponse.ProtoReflect.Descriptor instead.
+func (*ListRaidVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRaidVolumesResponse) GetRaidVolumes() []*RaidVolume {
+	if x != nil {
+		return x.RaidVolumes
+	}
+	return nil
+}
+
+func (x *ListRaidVolumesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetRaidVolumeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetRaidVolumeRequest) Reset() {
+	*x = GetRaidVolumeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRaidVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaidVolumeRequest) ProtoMessage() {}
+
+func (x *GetRaidVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaidVolumeRequest.ProtoReflect.Descriptor instead.
+func (*GetRaidVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRaidVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RaidVolumeStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RaidVolumeId *_go.ObjectKey `protobuf:"bytes,1,opt,name=raid_volume_id,json=raidVolumeId,proto3" json:"raid_volume_id,omitempty"`
+}
+
+func (x *RaidVolumeStatsRequest) Reset() {
+	*x = RaidVolumeStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaidVolumeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidVolumeStatsRequest) ProtoMessage() {}
+
+func (x *RaidVolumeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidVolumeStatsRequest.ProtoReflect.Descriptor instead.
+func (*RaidVolumeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RaidVolumeStatsRequest) GetRaidVolumeId() *_go.ObjectKey {
+	if x != nil {
+		return x.RaidVolumeId
+	}
+	return nil
+}
+
+type RaidVolumeStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *_go1.VolumeStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *RaidVolumeStatsResponse) Reset() {
+	*x = RaidVolumeStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleend_raid_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaidVolumeStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidVolumeStatsResponse) ProtoMessage() {}
+
+func (x *RaidVolumeStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_middleend_raid_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidVolumeStatsResponse.ProtoReflect.Descriptor instead.
+func (*RaidVolumeStatsResponse) Descriptor() ([]byte, []int) {
+	return file_middleend_raid_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RaidVolumeStatsResponse) GetStats() *_go1.VolumeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+var File_middleend_raid_proto protoreflect.FileDescriptor
+
+var file_middleend_raid_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x61, 0x69, 0x64,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x6f, 0x70, 0x69, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a, 0x75, 0x75, 0x69, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x10, 0x52, 0x61, 0x69, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x65, 0x64, 0x22, 0x75, 0x0a, 0x11, 0x52, 0x61, 0x69, 0x64, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x46, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52,
+	0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xad, 0x04, 0x0a, 0x0a, 0x52, 0x61,
+	0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0a,
+	0x72, 0x61, 0x69, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61,
+	0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x09, 0x72, 0x61,
+	0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x4d, 0x0a, 0x11, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79,
+	0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x49, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x70, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6b, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73,
+	0x74, 0x72, 0x69, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x4b, 0x62, 0x12, 0x30, 0x0a, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x75, 0x69,
+	0x64, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x46, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x4b, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x12, 0x4c, 0x0a, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x61, 0x69, 0x64, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x3a,
+	0x38, 0xea, 0x41, 0x35, 0x0a, 0x21, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x6f, 0x70,
+	0x69, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x52, 0x61, 0x69,
+	0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x10, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
+	0x2f, 0x7b, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x7d, 0x22, 0x8d, 0x01, 0x0a, 0x17, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x0b, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0a, 0x72, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x61, 0x69,
+	0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x7d, 0x0a, 0x17, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x29, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x23, 0x0a, 0x21, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x6f, 0x70, 0x69, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2e, 0x6f,
+	0x72, 0x67, 0x2f, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0x97, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x29, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x23, 0x0a, 0x21, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x6f, 0x70, 0x69, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2e,
+	0x6f, 0x72, 0x67, 0x2f, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x8c, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49,
+	0x0a, 0x0c, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x0b, 0x72, 0x61,
+	0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x55, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x29, 0xe0, 0x41, 0x02, 0xfa, 0x41, 0x23, 0x0a,
+	0x21, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x6f, 0x70, 0x69, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x61, 0x0a, 0x16, 0x52, 0x61, 0x69, 0x64,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x47, 0x0a, 0x0e, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0c, 0x72,
+	0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x50, 0x0a, 0x17, 0x52,
+	0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2a, 0x6a, 0x0a,
+	0x09, 0x52, 0x61, 0x69, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x41,
+	0x49, 0x44, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x4c,
+	0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x41, 0x49, 0x44, 0x30, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
+	0x52, 0x41, 0x49, 0x44, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x41, 0x49, 0x44, 0x31,
+	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c,
+	0x5f, 0x43, 0x4f, 0x4e, 0x43, 0x41, 0x54, 0x10, 0x03, 0x2a, 0x94, 0x01, 0x0a, 0x0f, 0x52, 0x61,
+	0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a,
+	0x1d, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1c, 0x0a, 0x18, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4f, 0x4e, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x21,
+	0x0a, 0x1d, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x49, 0x4e, 0x47, 0x10,
+	0x02, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x56, 0x4f, 0x4c, 0x55, 0x4d, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4f, 0x46, 0x46, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x03,
+	0x32, 0x91, 0x05, 0x0a, 0x1a, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x65, 0x6e, 0x64, 0x52, 0x61,
+	0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x8e, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x33, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x22, 0x1d, 0xda, 0x41, 0x1a, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x2c, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x12, 0x68, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x33, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x07, 0xda, 0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x85, 0x01, 0x0a, 0x0f, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x32,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x09, 0xda, 0x41, 0x06, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x72, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x30, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x07, 0xda,
+	0x41, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x7c, 0x0a, 0x0f, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x32, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x69, 0x64, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x69, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x6f, 0x70,
+	0x69, 0x2d, 0x73, 0x70, 0x64, 0x6b, 0x2d, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_middleend_raid_proto_rawDescOnce sync.Once
+	file_middleend_raid_proto_rawDescData = file_middleend_raid_proto_rawDesc
+)
+
+func file_middleend_raid_proto_rawDescGZIP() []byte {
+	file_middleend_raid_proto_rawDescOnce.Do(func() {
+		file_middleend_raid_proto_rawDescData = protoimpl.X.CompressGZIP(file_middleend_raid_proto_rawDescData)
+	})
+	return file_middleend_raid_proto_rawDescData
+}
+
+var file_middleend_raid_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_middleend_raid_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_middleend_raid_proto_goTypes = []interface{}{
+	(RaidLevel)(0),                  // 0: opi_spdk_bridge.storage.v1.RaidLevel
+	(RaidVolumeState)(0),            // 1: opi_spdk_bridge.storage.v1.RaidVolumeState
+	(*RaidMemberStatus)(nil),        // 2: opi_spdk_bridge.storage.v1.RaidMemberStatus
+	(*RaidRebuildStatus)(nil),       // 3: opi_spdk_bridge.storage.v1.RaidRebuildStatus
+	(*RaidVolume)(nil),              // 4: opi_spdk_bridge.storage.v1.RaidVolume
+	(*CreateRaidVolumeRequest)(nil), // 5: opi_spdk_bridge.storage.v1.CreateRaidVolumeRequest
+	(*DeleteRaidVolumeRequest)(nil), // 6: opi_spdk_bridge.storage.v1.DeleteRaidVolumeRequest
+	(*ListRaidVolumesRequest)(nil),  // 7: opi_spdk_bridge.storage.v1.ListRaidVolumesRequest
+	(*ListRaidVolumesResponse)(nil), // 8: opi_spdk_bridge.storage.v1.ListRaidVolumesResponse
+	(*GetRaidVolumeRequest)(nil),    // 9: opi_spdk_bridge.storage.v1.GetRaidVolumeRequest
+	(*RaidVolumeStatsRequest)(nil),  // 10: opi_spdk_bridge.storage.v1.RaidVolumeStatsRequest
+	(*RaidVolumeStatsResponse)(nil), // 11: opi_spdk_bridge.storage.v1.RaidVolumeStatsResponse
+	(*_go.ObjectKey)(nil),           // 12: opi_api.common.v1.ObjectKey
+	(*_go.Uuid)(nil),                // 13: opi_api.common.v1.Uuid
+	(*_go1.VolumeStats)(nil),        // 14: opi_api.storage.v1.VolumeStats
+	(*emptypb.Empty)(nil),           // 15: google.protobuf.Empty
+}
+var file_middleend_raid_proto_depIdxs = []int32{
+	12, // 0: opi_spdk_bridge.storage.v1.RaidMemberStatus.volume_id:type_name -> opi_api.common.v1.ObjectKey
+	12, // 1: opi_spdk_bridge.storage.v1.RaidRebuildStatus.target_volume_id:type_name -> opi_api.common.v1.ObjectKey
+	0,  // 2: opi_spdk_bridge.storage.v1.RaidVolume.raid_level:type_name -> opi_spdk_bridge.storage.v1.RaidLevel
+	12, // 3: opi_spdk_bridge.storage.v1.RaidVolume.member_volume_ids:type_name -> opi_api.common.v1.ObjectKey
+	13, // 4: opi_spdk_bridge.storage.v1.RaidVolume.uuid:type_name -> opi_api.common.v1.Uuid
+	1,  // 5: opi_spdk_bridge.storage.v1.RaidVolume.state:type_name -> opi_spdk_bridge.storage.v1.RaidVolumeState
+	2,  // 6: opi_spdk_bridge.storage.v1.RaidVolume.members:type_name -> opi_spdk_bridge.storage.v1.RaidMemberStatus
+	3,  // 7: opi_spdk_bridge.storage.v1.RaidVolume.rebuild:type_name -> opi_spdk_bridge.storage.v1.RaidRebuildStatus
+	4,  // 8: opi_spdk_bridge.storage.v1.CreateRaidVolumeRequest.raid_volume:type_name -> opi_spdk_bridge.storage.v1.RaidVolume
+	4,  // 9: opi_spdk_bridge.storage.v1.ListRaidVolumesResponse.raid_volumes:type_name -> opi_spdk_bridge.storage.v1.RaidVolume
+	12, // 10: opi_spdk_bridge.storage.v1.RaidVolumeStatsRequest.raid_volume_id:type_name -> opi_api.common.v1.ObjectKey
+	14, // 11: opi_spdk_bridge.storage.v1.RaidVolumeStatsResponse.stats:type_name -> opi_api.storage.v1.VolumeStats
+	5,  // 12: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.CreateRaidVolume:input_type -> opi_spdk_bridge.storage.v1.CreateRaidVolumeRequest
+	6,  // 13: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.DeleteRaidVolume:input_type -> opi_spdk_bridge.storage.v1.DeleteRaidVolumeRequest
+	7,  // 14: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.ListRaidVolumes:input_type -> opi_spdk_bridge.storage.v1.ListRaidVolumesRequest
+	9,  // 15: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.GetRaidVolume:input_type -> opi_spdk_bridge.storage.v1.GetRaidVolumeRequest
+	10, // 16: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.RaidVolumeStats:input_type -> opi_spdk_bridge.storage.v1.RaidVolumeStatsRequest
+	4,  // 17: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.CreateRaidVolume:output_type -> opi_spdk_bridge.storage.v1.RaidVolume
+	15, // 18: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.DeleteRaidVolume:output_type -> google.protobuf.Empty
+	8,  // 19: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.ListRaidVolumes:output_type -> opi_spdk_bridge.storage.v1.ListRaidVolumesResponse
+	4,  // 20: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.GetRaidVolume:output_type -> opi_spdk_bridge.storage.v1.RaidVolume
+	11, // 21: opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService.RaidVolumeStats:output_type -> opi_spdk_bridge.storage.v1.RaidVolumeStatsResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_middleend_raid_proto_init() }
+func file_middleend_raid_proto_init() {
+	if File_middleend_raid_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_middleend_raid_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaidMemberStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaidRebuildStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaidVolume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRaidVolumeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRaidVolumeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRaidVolumesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRaidVolumesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRaidVolumeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaidVolumeStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleend_raid_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaidVolumeStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_middleend_raid_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_middleend_raid_proto_goTypes,
+		DependencyIndexes: file_middleend_raid_proto_depIdxs,
+		EnumInfos:         file_middleend_raid_proto_enumTypes,
+		MessageInfos:      file_middleend_raid_proto_msgTypes,
+	}.Build()
+	File_middleend_raid_proto = out.File
+	file_middleend_raid_proto_rawDesc = nil
+	file_middleend_raid_proto_goTypes = nil
+	file_middleend_raid_proto_depIdxs = nil
+}
@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: middleend_raid.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MiddleendRaidVolumeService_CreateRaidVolume_FullMethodName = "/opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService/CreateRaidVolume"
+	MiddleendRaidVolumeService_DeleteRaidVolume_FullMethodName = "/opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService/DeleteRaidVolume"
+	MiddleendRaidVolumeService_ListRaidVolumes_FullMethodName  = "/opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService/ListRaidVolumes"
+	MiddleendRaidVolumeService_GetRaidVolume_FullMethodName    = "/opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService/GetRaidVolume"
+	MiddleendRaidVolumeService_RaidVolumeStats_FullMethodName  = "/opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService/RaidVolumeStats"
+)
+
+// MiddleendRaidVolumeServiceClient is the client API for MiddleendRaidVolumeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MiddleendRaidVolumeServiceClient interface {
+	CreateRaidVolume(ctx context.Context, in *CreateRaidVolumeRequest, opts ...grpc.CallOption) (*RaidVolume, error)
+	DeleteRaidVolume(ctx context.Context, in *DeleteRaidVolumeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListRaidVolumes(ctx context.Context, in *ListRaidVolumesRequest, opts ...grpc.CallOption) (*ListRaidVolumesResponse, error)
+	GetRaidVolume(ctx context.Context, in *GetRaidVolumeRequest, opts ...grpc.CallOption) (*RaidVolume, error)
+	RaidVolumeStats(ctx context.Context, in *RaidVolumeStatsRequest, opts ...grpc.CallOption) (*RaidVolumeStatsResponse, error)
+}
+
+type middleendRaidVolumeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMiddleendRaidVolumeServiceClient(cc grpc.ClientConnInterface) MiddleendRaidVolumeServiceClient {
+	return &middleendRaidVolumeServiceClient{cc}
+}
+
+func (c *middleendRaidVolumeServiceClient) CreateRaidVolume(ctx context.Context, in *CreateRaidVolumeRequest, opts ...grpc.CallOption) (*RaidVolume, error) {
+	out := new(RaidVolume)
+	err := c.cc.Invoke(ctx, MiddleendRaidVolumeService_CreateRaidVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *middleendRaidVolumeServiceClient) DeleteRaidVolume(ctx context.Context, in *DeleteRaidVolumeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MiddleendRaidVolumeService_DeleteRaidVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *middleendRaidVolumeServiceClient) ListRaidVolumes(ctx context.Context, in *ListRaidVolumesRequest, opts ...grpc.CallOption) (*ListRaidVolumesResponse, error) {
+	out := new(ListRaidVolumesResponse)
+	err := c.cc.Invoke(ctx, MiddleendRaidVolumeService_ListRaidVolumes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *middleendRaidVolumeServiceClient) GetRaidVolume(ctx context.Context, in *GetRaidVolumeRequest, opts ...grpc.CallOption) (*RaidVolume, error) {
+	out := new(RaidVolume)
+	err := c.cc.Invoke(ctx, MiddleendRaidVolumeService_GetRaidVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *middleendRaidVolumeServiceClient) RaidVolumeStats(ctx context.Context, in *RaidVolumeStatsRequest, opts ...grpc.CallOption) (*RaidVolumeStatsResponse, error) {
+	out := new(RaidVolumeStatsResponse)
+	err := c.cc.Invoke(ctx, MiddleendRaidVolumeService_RaidVolumeStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MiddleendRaidVolumeServiceServer is the server API for MiddleendRaidVolumeService service.
+// All implementations must embed UnimplementedMiddleendRaidVolumeServiceServer
+// for forward compatibility
+type MiddleendRaidVolumeServiceServer interface {
+	CreateRaidVolume(context.Context, *CreateRaidVolumeRequest) (*RaidVolume, error)
+	DeleteRaidVolume(context.Context, *DeleteRaidVolumeRequest) (*emptypb.Empty, error)
+	ListRaidVolumes(context.Context, *ListRaidVolumesRequest) (*ListRaidVolumesResponse, error)
+	GetRaidVolume(context.Context, *GetRaidVolumeRequest) (*RaidVolume, error)
+	RaidVolumeStats(context.Context, *RaidVolumeStatsRequest) (*RaidVolumeStatsResponse, error)
+	mustEmbedUnimplementedMiddleendRaidVolumeServiceServer()
+}
+
+// UnimplementedMiddleendRaidVolumeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMiddleendRaidVolumeServiceServer struct {
+}
+
+func (UnimplementedMiddleendRaidVolumeServiceServer) CreateRaidVolume(context.Context, *CreateRaidVolumeRequest) (*RaidVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRaidVolume not implemented")
+}
+func (UnimplementedMiddleendRaidVolumeServiceServer) DeleteRaidVolume(context.Context, *DeleteRaidVolumeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRaidVolume not implemented")
+}
+func (UnimplementedMiddleendRaidVolumeServiceServer) ListRaidVolumes(context.Context, *ListRaidVolumesRequest) (*ListRaidVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRaidVolumes not implemented")
+}
+func (UnimplementedMiddleendRaidVolumeServiceServer) GetRaidVolume(context.Context, *GetRaidVolumeRequest) (*RaidVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRaidVolume not implemented")
+}
+func (UnimplementedMiddleendRaidVolumeServiceServer) RaidVolumeStats(context.Context, *RaidVolumeStatsRequest) (*RaidVolumeStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaidVolumeStats not implemented")
+}
+func (UnimplementedMiddleendRaidVolumeServiceServer) mustEmbedUnimplementedMiddleendRaidVolumeServiceServer() {
+}
+
+// UnsafeMiddleendRaidVolumeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MiddleendRaidVolumeServiceServer will
+// result in compilation errors.
+type UnsafeMiddleendRaidVolumeServiceServer interface {
+	mustEmbedUnimplementedMiddleendRaidVolumeServiceServer()
+}
+
+func RegisterMiddleendRaidVolumeServiceServer(s grpc.ServiceRegistrar, srv MiddleendRaidVolumeServiceServer) {
+	s.RegisterService(&MiddleendRaidVolumeService_ServiceDesc, srv)
+}
+
+func _MiddleendRaidVolumeService_CreateRaidVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRaidVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiddleendRaidVolumeServiceServer).CreateRaidVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiddleendRaidVolumeService_CreateRaidVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiddleendRaidVolumeServiceServer).CreateRaidVolume(ctx, req.(*CreateRaidVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiddleendRaidVolumeService_DeleteRaidVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRaidVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiddleendRaidVolumeServiceServer).DeleteRaidVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiddleendRaidVolumeService_DeleteRaidVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiddleendRaidVolumeServiceServer).DeleteRaidVolume(ctx, req.(*DeleteRaidVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiddleendRaidVolumeService_ListRaidVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRaidVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiddleendRaidVolumeServiceServer).ListRaidVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiddleendRaidVolumeService_ListRaidVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiddleendRaidVolumeServiceServer).ListRaidVolumes(ctx, req.(*ListRaidVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiddleendRaidVolumeService_GetRaidVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRaidVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiddleendRaidVolumeServiceServer).GetRaidVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiddleendRaidVolumeService_GetRaidVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiddleendRaidVolumeServiceServer).GetRaidVolume(ctx, req.(*GetRaidVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiddleendRaidVolumeService_RaidVolumeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaidVolumeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiddleendRaidVolumeServiceServer).RaidVolumeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiddleendRaidVolumeService_RaidVolumeStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiddleendRaidVolumeServiceServer).RaidVolumeStats(ctx, req.(*RaidVolumeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MiddleendRaidVolumeService_ServiceDesc is the grpc.ServiceDesc for MiddleendRaidVolumeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MiddleendRaidVolumeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.storage.v1.MiddleendRaidVolumeService",
+	HandlerType: (*MiddleendRaidVolumeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRaidVolume",
+			Handler:    _MiddleendRaidVolumeService_CreateRaidVolume_Handler,
+		},
+		{
+			MethodName: "DeleteRaidVolume",
+			Handler:    _MiddleendRaidVolumeService_DeleteRaidVolume_Handler,
+		},
+		{
+			MethodName: "ListRaidVolumes",
+			Handler:    _MiddleendRaidVolumeService_ListRaidVolumes_Handler,
+		},
+		{
+			MethodName: "GetRaidVolume",
+			Handler:    _MiddleendRaidVolumeService_GetRaidVolume_Handler,
+		},
+		{
+			MethodName: "RaidVolumeStats",
+			Handler:    _MiddleendRaidVolumeService_RaidVolumeStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "middleend_raid.proto",
+}
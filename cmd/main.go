@@ -4,20 +4,36 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/opiproject/gospdk/spdk"
 
+	"github.com/opiproject/opi-spdk-bridge/pkg/authz"
 	"github.com/opiproject/opi-spdk-bridge/pkg/backend"
 	"github.com/opiproject/opi-spdk-bridge/pkg/frontend"
 	"github.com/opiproject/opi-spdk-bridge/pkg/kvm"
+	"github.com/opiproject/opi-spdk-bridge/pkg/logging"
+	"github.com/opiproject/opi-spdk-bridge/pkg/metrics"
 	"github.com/opiproject/opi-spdk-bridge/pkg/middleend"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"github.com/opiproject/opi-spdk-bridge/pkg/tlsconfig"
+	"github.com/opiproject/opi-spdk-bridge/pkg/tracing"
 
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -29,68 +45,340 @@ func splitBusesBySeparator(str string) []string {
 	return []string{}
 }
 
-func main() {
-	var port int
-	flag.IntVar(&port, "port", 50051, "The Server port")
+type reconciler interface {
+	Reconcile(mode server.ReconcileMode) (*server.ReconcileResult, error)
+}
+
+// reconcile converges resources known to the bridge with objects configured
+// in SPDK. Consumers are reconciled before the volumes they are built on.
+func reconcile(mode server.ReconcileMode, reconcilers ...reconciler) {
+	total := &server.ReconcileResult{}
+	for _, r := range reconcilers {
+		result, err := r.Reconcile(mode)
+		if err != nil {
+			log.Fatalf("failed to reconcile with SPDK: %v", err)
+		}
+		total.Merge(result)
+	}
+	log.Printf("Reconcile finished in %v mode: %d resources missing in SPDK, %d SPDK objects not managed",
+		mode, len(total.Missing), len(total.Orphaned))
+}
+
+// openStore opens store persisting resources in dir, in memory one if dir is empty
+func openStore(dir string) store.Store {
+	if dir == "" {
+		return store.NewMemoryStore()
+	}
+	st, err := store.NewFileStore(dir)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	return st
+}
+
+// setUpLogging makes logger configured by opts the default one, including
+// records of the standard log package
+func setUpLogging(opts logging.Options) {
+	opts.Writer = os.Stderr
+	logger, err := logging.New(opts)
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	slog.SetDefault(logger)
+	// gospdk logs every JSON-RPC message with the standard log, which
+	// SetDefault sends to logger at info level
+	log.SetOutput(logging.StdLogWriter(logger))
+}
+
+// newTracerProvider creates and installs TracerProvider exporting spans
+// as configured by opts, nothing is traced if exporter is empty
+func newTracerProvider(opts tracing.Options) *sdktrace.TracerProvider {
+	if opts.Exporter == "" {
+		return nil
+	}
+	opts.Writer = os.Stdout
+	tp, err := tracing.NewTracerProvider(context.Background(), opts)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	tracing.Install(tp)
+	return tp
+}
+
+// listen returns listeners of the API, the TCP one and
+// the unix socket one if configured
+func listen(port int, unixSocket string) []net.Listener {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	listeners := []net.Listener{lis}
+	if unixSocket != "" {
+		unixLis, err := tlsconfig.ListenUnix(unixSocket)
+		if err != nil {
+			log.Fatalf("failed to listen on unix socket: %v", err)
+		}
+		listeners = append(listeners, unixLis)
+	}
+	return listeners
+}
+
+// serverOptions returns options making gRPC server secure connections by
+// TLS, log requests, trace them, collect metrics of them and authorize
+// them, if enabled
+func serverOptions(opts *options, tp *sdktrace.TracerProvider, m *metrics.Metrics) []grpc.ServerOption {
+	var serverOpts []grpc.ServerOption
+	if opts.tls.Enabled() {
+		r, err := tlsconfig.NewReloader(opts.tls)
+		if err != nil {
+			log.Fatalf("failed to set up TLS: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(tlsconfig.NewServerCredentials(r)))
+	}
+	var interceptors []grpc.UnaryServerInterceptor
+	if tp != nil {
+		interceptors = append(interceptors, tracing.UnaryServerInterceptor())
+	}
+	// logged after the span is started, so records carry its trace ID
+	interceptors = append(interceptors, logging.UnaryServerInterceptor())
+	if m != nil {
+		interceptors = append(interceptors, m.UnaryServerInterceptor())
+	}
+	// authorized last, so denied requests are logged and counted
+	if opts.authzPolicy != "" {
+		policy, err := authz.LoadPolicy(opts.authzPolicy)
+		if err != nil {
+			log.Fatalf("failed to load authorization policy: %v", err)
+		}
+		interceptors = append(interceptors, authz.UnaryServerInterceptor(policy))
+	}
+	return append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
+}
+
+// stopOnSignal stops s gracefully on SIGINT or SIGTERM
+func stopOnSignal(s *grpc.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("Received %v, stopping server", sig)
+		s.GracefulStop()
+	}()
+}
 
-	var spdkAddress string
-	flag.StringVar(&spdkAddress, "spdk_addr", "/var/tmp/spdk.sock", "Points to SPDK unix socket/tcp socket to interact with")
+// serve serves gRPC API of s on lis until s is stopped
+func serve(s *grpc.Server, lis net.Listener) {
+	log.Printf("Server listening at %v", lis.Addr())
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
 
-	var useKvm bool
-	flag.BoolVar(&useKvm, "kvm", false, "Automates interaction with QEMU to plug/unplug SPDK devices")
+// serveMetrics exports metrics over HTTP on address
+func serveMetrics(address string, m *metrics.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	metricsServer := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Metrics served at %v/metrics", address)
+	if err := metricsServer.ListenAndServe(); err != nil {
+		log.Fatalf("failed to serve metrics: %v", err)
+	}
+}
 
-	var qmpAddress string
-	flag.StringVar(&qmpAddress, "qmp_addr", "127.0.0.1:5555", "Points to QMP unix socket/tcp socket to interact with. Valid only with -kvm option")
+// options are command line options of the bridge
+type options struct {
+	port                   int
+	spdkAddress            string
+	useKvm                 bool
+	qmpAddress             string
+	ctrlrDir               string
+	buses                  []string
+	tcpTransportListenAddr string
+	tcpDifInsertOrStrip    bool
+	storeDir               string
+	reconcileMode          server.ReconcileMode
+	pageTokenTTL           time.Duration
+	pageTokenLimit         int
+	metricsAddress         string
+	iostatInterval         time.Duration
+	tracing                tracing.Options
+	logging                logging.Options
+	tls                    tlsconfig.Options
+	unixSocket             string
+	authzPolicy            string
+	pskDir                 string
+	discoveryInterval      time.Duration
+}
 
-	var ctrlrDir string
-	flag.StringVar(&ctrlrDir, "ctrlr_dir", "", "Directory with created SPDK device unix sockets (-S option in SPDK). Valid only with -kvm option")
+// parseOptions parses and validates command line options
+func parseOptions() *options {
+	opts := &options{}
+	flag.IntVar(&opts.port, "port", 50051, "The Server port")
+	flag.StringVar(&opts.spdkAddress, "spdk_addr", "/var/tmp/spdk.sock", "Points to SPDK unix socket/tcp socket to interact with")
+	flag.BoolVar(&opts.useKvm, "kvm", false, "Automates interaction with QEMU to plug/unplug SPDK devices")
+	flag.StringVar(&opts.qmpAddress, "qmp_addr", "127.0.0.1:5555", "Points to QMP unix socket/tcp socket to interact with. Valid only with -kvm option")
+	flag.StringVar(&opts.ctrlrDir, "ctrlr_dir", "", "Directory with created SPDK device unix sockets (-S option in SPDK). Valid only with -kvm option")
 
 	var busesStr string
 	flag.StringVar(&busesStr, "buses", "", "QEMU PCI buses IDs separated by `:` to attach Nvme/virtio-blk devices on. e.g. \"pci.opi.0:pci.opi.1\". Valid only with -kvm option")
 
-	var tcpTransportListenAddr string
-	flag.StringVar(&tcpTransportListenAddr, "tcp_trid", "127.0.0.1:4420", "ipv4 address:port (aka traddr:trsvcid) or ipv6 [address]:port tuple (aka [traddr]:trsvcid) to listen on for Nvme/TCP transport")
+	flag.StringVar(&opts.tcpTransportListenAddr, "tcp_trid", "127.0.0.1:4420", "ipv4 address:port (aka traddr:trsvcid) or ipv6 [address]:port tuple (aka [traddr]:trsvcid) to listen on for Nvme/TCP transport")
+	flag.BoolVar(&opts.tcpDifInsertOrStrip, "tcp_dif_insert_or_strip", false, "Make SPDK insert and strip T10 DIF of all namespaces exposed through Nvme/TCP transport, creating the transport on startup if it does not exist. Not valid with -kvm option")
+	flag.StringVar(&opts.pskDir, "psk_dir", backend.DefaultKeyDir, "Directory PSKs of Nvme remote controllers are written to for SPDK, one file accessible by the owner only per controller")
+	flag.DurationVar(&opts.discoveryInterval, "discovery_interval", backend.DefaultDiscoveryInterval, "How often NVMe-oF subsystems discovered through discovery controllers are tracked as managed Nvme remote controllers and paths")
+	flag.StringVar(&opts.storeDir, "store_dir", "", "Directory to persist created resources in, so they survive bridge restarts. Resources are kept in memory only if empty")
+
+	var reconcileModeStr string
+	flag.StringVar(&reconcileModeStr, "reconcile", "report", "How to resolve differences between bridge resources and SPDK objects on startup: report, adopt (import SPDK objects) or cleanup (delete SPDK objects). Resources missing in SPDK are dropped in adopt and cleanup modes")
+
+	flag.DurationVar(&opts.pageTokenTTL, "page_token_ttl", server.DefaultPageTokenTTL, "How long List page tokens stay valid")
+	flag.IntVar(&opts.pageTokenLimit, "page_token_limit", server.DefaultPageTokenLimit, "Maximum number of List page tokens kept, the oldest ones are dropped first")
+	flag.StringVar(&opts.metricsAddress, "metrics_addr", "", "HTTP address e.g. \":9090\" to export Prometheus metrics on at /metrics. Metrics are not collected if empty")
+	flag.DurationVar(&opts.iostatInterval, "iostat_interval", 30*time.Second, "How often IO statistics of managed volumes are read from SPDK for metrics. Valid only with -metrics_addr option")
+	flag.StringVar(&opts.tracing.Exporter, "trace_exporter", "", "Where to export OpenTelemetry traces to: otlp (collector at -otlp_endpoint) or stdout. Requests are not traced if empty")
+	flag.StringVar(&opts.tracing.Endpoint, "otlp_endpoint", "localhost:4317", "host:port of OTLP/gRPC collector to export traces to. Valid only with -trace_exporter=otlp")
+	flag.BoolVar(&opts.tracing.Insecure, "otlp_insecure", false, "Connect to OTLP collector without TLS. Valid only with -trace_exporter=otlp")
+	flag.StringVar(&opts.tls.CertFile, "tls_cert", "", "PEM file with server certificate chain. Enables TLS of the API, certificates rotated on disk are used for new connections without restart")
+	flag.StringVar(&opts.tls.KeyFile, "tls_key", "", "PEM file with private key of the server certificate. Valid only with -tls_cert option")
+	flag.StringVar(&opts.tls.ClientCAFile, "tls_client_ca", "", "PEM file with CAs client certificates are verified with. Enables mutual TLS, valid only with -tls_cert option")
+	flag.StringVar(&opts.authzPolicy, "authz_policy", "", "JSON file with authorization policy mapping client identities to allowed services, methods and resources. All requests are allowed if empty")
+	flag.StringVar(&opts.unixSocket, "unix_socket", "", "Path of unix socket to serve the API on for local callers too, without TLS. Not served if empty")
+
+	var logLevelStr string
+	flag.StringVar(&logLevelStr, "log_level", "info", "Minimum level of logged records: debug, info, warn or error. Requests and responses are logged at debug level with secrets redacted")
+	flag.StringVar(&opts.logging.Format, "log_format", logging.FormatText, "Format of logged records: text or json")
 	flag.Parse()
 
-	buses := splitBusesBySeparator(busesStr)
+	opts.buses = splitBusesBySeparator(busesStr)
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	var err error
+	opts.logging.Level, err = logging.ParseLevel(logLevelStr)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Fatalf("invalid -log_level option: %v", err)
+	}
+	opts.reconcileMode, err = server.ParseReconcileMode(reconcileModeStr)
+	if err != nil {
+		log.Fatalf("invalid -reconcile option: %v", err)
+	}
+	if opts.pageTokenTTL <= 0 || opts.pageTokenLimit <= 0 {
+		log.Fatalf("invalid page token options: -page_token_ttl and -page_token_limit have to be positive")
 	}
-	s := grpc.NewServer()
+	if opts.useKvm && opts.tcpDifInsertOrStrip {
+		log.Fatalf("invalid -tcp_dif_insert_or_strip option: not valid with -kvm option")
+	}
+	if opts.iostatInterval <= 0 {
+		log.Fatalf("invalid -iostat_interval option: has to be positive")
+	}
+	return opts
+}
+
+func main() {
+	opts := parseOptions()
+	setUpLogging(opts.logging)
 
-	jsonRPC := spdk.NewSpdkJSONRPC(spdkAddress)
-	backendServer := backend.NewServer(jsonRPC)
-	middleendServer := middleend.NewServer(jsonRPC)
+	listeners := listen(opts.port, opts.unixSocket)
+	tp := newTracerProvider(opts.tracing)
+	var m *metrics.Metrics
+	if opts.metricsAddress != "" {
+		m = metrics.New()
+	}
+	s := grpc.NewServer(serverOptions(opts, tp, m)...)
+
+	st := openStore(opts.storeDir)
+
+	var jsonRPC spdk.JSONRPC = spdk.NewSpdkJSONRPC(opts.spdkAddress)
+	if m != nil {
+		jsonRPC = m.InstrumentSpdk(jsonRPC)
+	}
+	backendServer := backend.NewServer(jsonRPC, st)
+	backendServer.KeyDir = opts.pskDir
+	middleendServer := middleend.NewServer(jsonRPC, st)
+	// volumes used by resources of any service cannot be deleted
+	consumers := &server.VolumeConsumers{}
+	backendServer.Consumers = consumers
+	middleendServer.Consumers = consumers
+	consumers.Register(backendServer.VolumeConsumers()...)
+	consumers.Register(middleendServer.VolumeConsumers()...)
+	// volume_id of requests can name volume resources of any service
+	volumes := &server.VolumeIndex{}
+	backendServer.VolumeIndex = volumes
+	middleendServer.VolumeIndex = volumes
+	volumes.Register(backendServer.VolumeProviders()...)
+	volumes.Register(middleendServer.VolumeProviders()...)
 
-	if useKvm {
+	var frontendServer *frontend.Server
+	if opts.useKvm {
 		log.Println("Creating KVM server.")
-		frontendServer := frontend.NewServerWithSubsystemListener(jsonRPC,
-			kvm.NewVfiouserSubsystemListener(ctrlrDir))
-		kvmServer := kvm.NewServer(frontendServer, qmpAddress, ctrlrDir, buses)
+		frontendServer = frontend.NewServerWithSubsystemListener(jsonRPC, st,
+			kvm.NewVfiouserSubsystemListener(opts.ctrlrDir))
+		kvmServer := kvm.NewServer(frontendServer, opts.qmpAddress, opts.ctrlrDir, opts.buses)
+		if m != nil {
+			kvmServer.QmpObserver = m.ObserveQmpCommand
+		}
+		consumers.Register(kvmServer.VolumeConsumers()...)
 
 		pb.RegisterFrontendNvmeServiceServer(s, kvmServer)
 		pb.RegisterFrontendVirtioBlkServiceServer(s, kvmServer)
 		pb.RegisterFrontendVirtioScsiServiceServer(s, kvmServer)
 	} else {
-		frontendServer := frontend.NewServerWithSubsystemListener(jsonRPC,
-			frontend.NewTCPSubsystemListener(tcpTransportListenAddr))
+		frontendServer = frontend.NewServerWithSubsystemListener(jsonRPC, st,
+			frontend.NewTCPSubsystemListener(opts.tcpTransportListenAddr))
+		if opts.tcpDifInsertOrStrip {
+			if err := frontendServer.EnableTCPDifInsertOrStrip(); err != nil {
+				log.Fatalf("unable to enable dif_insert_or_strip of Nvme/TCP transport: %v", err)
+			}
+		}
+		consumers.Register(frontendServer.VolumeConsumers()...)
 		pb.RegisterFrontendNvmeServiceServer(s, frontendServer)
 		pb.RegisterFrontendVirtioBlkServiceServer(s, frontendServer)
 		pb.RegisterFrontendVirtioScsiServiceServer(s, frontendServer)
 	}
 
+	// page tokens are shared, so one limit bounds memory of all services
+	pageTokens := server.NewPageTokens(opts.pageTokenTTL, opts.pageTokenLimit)
+	backendServer.Pagination = pageTokens
+	middleendServer.Pagination = pageTokens
+	frontendServer.Pagination = pageTokens
+	frontendServer.Consumers = consumers
+	frontendServer.VolumeIndex = volumes
+
 	pb.RegisterNvmeRemoteControllerServiceServer(s, backendServer)
 	pb.RegisterNullDebugServiceServer(s, backendServer)
 	pb.RegisterAioControllerServiceServer(s, backendServer)
+	px.RegisterMallocVolumeServiceServer(s, backendServer)
+	px.RegisterLvolServiceServer(s, backendServer)
+	px.RegisterExtendedNvmePathServiceServer(s, backendServer)
+	px.RegisterExtendedNvmeRemoteControllerServiceServer(s, backendServer)
+	px.RegisterExtendedNullDebugServiceServer(s, backendServer)
+	px.RegisterExtendedAioControllerServiceServer(s, backendServer)
 	pb.RegisterMiddleendEncryptionServiceServer(s, middleendServer)
 	pb.RegisterMiddleendQosVolumeServiceServer(s, middleendServer)
+	px.RegisterMiddleendRaidVolumeServiceServer(s, middleendServer)
 
 	reflection.Register(s)
 
-	log.Printf("Server listening at %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	reconcile(opts.reconcileMode, frontendServer, middleendServer, backendServer)
+	go backendServer.WatchDiscovery(context.Background(), opts.discoveryInterval)
+
+	if m != nil {
+		m.AddResourceCounters(backendServer, middleendServer, frontendServer)
+		go m.ScrapeIostat(context.Background(), jsonRPC, opts.iostatInterval, backendServer, middleendServer)
+		go serveMetrics(opts.metricsAddress, m)
+	}
+
+	stopOnSignal(s)
+	for _, lis := range listeners[1:] {
+		go serve(s, lis)
+	}
+	serve(s, listeners[0])
+	if tp != nil {
+		// flush spans still waiting to be exported
+		if err := tp.Shutdown(context.Background()); err != nil {
+			log.Printf("failed to shut down tracing: %v", err)
+		}
 	}
 }
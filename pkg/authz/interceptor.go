@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package authz authorizes requests to the bridge API
+package authz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+// UnaryServerInterceptor returns gRPC interceptor rejecting requests not
+// allowed by policy with PermissionDenied. Every decision is logged.
+func UnaryServerInterceptor(policy *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (interface{}, error) {
+		var resources []string
+		if m, ok := req.(proto.Message); ok {
+			resources = resourceNames(m)
+		}
+		id := identityOf(ctx)
+		principal, err := policy.authorize(id, info.FullMethod, resources)
+		if err != nil {
+			slog.WarnContext(ctx, "Request denied", "resources", resources, "sans", id.sans,
+				"token", id.tokenSHA256 != "", "local", id.local, "reason", err)
+			return nil, status.Errorf(codes.PermissionDenied, "%v is not allowed", info.FullMethod)
+		}
+		slog.InfoContext(ctx, "Request allowed", "principal", principal, "resources", resources)
+		return h(ctx, req)
+	}
+}
+
+// identityOf returns identity of the client of request ctx belongs to
+func identityOf(ctx context.Context) identity {
+	var id identity
+	if p, ok := peer.FromContext(ctx); ok {
+		id.local = p.Addr != nil && p.Addr.Network() == "unix"
+		// only verified certificates identify clients
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok &&
+			len(info.State.VerifiedChains) > 0 && len(info.State.VerifiedChains[0]) > 0 {
+			leaf := info.State.VerifiedChains[0][0]
+			id.sans = append(id.sans, leaf.DNSNames...)
+			id.sans = append(id.sans, leaf.EmailAddresses...)
+			for _, ip := range leaf.IPAddresses {
+				id.sans = append(id.sans, ip.String())
+			}
+			for _, uri := range leaf.URIs {
+				id.sans = append(id.sans, uri.String())
+			}
+		}
+	}
+	for _, value := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		if token, ok := cutPrefixFold(value, "Bearer "); ok && token != "" {
+			hash := sha256.Sum256([]byte(token))
+			id.tokenSHA256 = hex.EncodeToString(hash[:])
+		}
+	}
+	return id
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return strings.TrimSpace(s[len(prefix):]), true
+}
+
+// resourceNames returns names of resources request m operates on: name or
+// parent of Get, Delete, List and similar requests, name of the resource
+// of Update requests and name the resource created by Create requests
+// gets from its ID, as well as all resources the request refers to by
+// ObjectKey, e.g. volume_id of a namespace or member_volume_ids of a raid
+func resourceNames(m proto.Message) []string {
+	msg := m.ProtoReflect()
+	create := strings.HasPrefix(string(msg.Descriptor().Name()), "Create")
+	var names []string
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			names = append(names, objectKeys(msg, fd)...)
+		case fd.Kind() == protoreflect.StringKind:
+			value := msg.Get(fd).String()
+			switch {
+			case value == "":
+			case fd.Name() == "name" || fd.Name() == "parent":
+				names = append(names, value)
+			case create && strings.HasSuffix(string(fd.Name()), "_id"):
+				names = append(names, server.ResourceIDToVolumeName(value))
+			}
+		case fd.Kind() == protoreflect.MessageKind && msg.Has(fd):
+			nested := msg.Get(fd).Message()
+			if nameFd := nested.Descriptor().Fields().ByName("name"); !create && nameFd != nil && nameFd.Kind() == protoreflect.StringKind {
+				if value := nested.Get(nameFd).String(); value != "" {
+					names = append(names, value)
+				}
+			}
+			names = append(names, objectKeys(msg, fd)...)
+		}
+	}
+	return names
+}
+
+// objectKeys returns values of ObjectKeys in field fd of msg, searching
+// nested messages and repeated fields
+func objectKeys(msg protoreflect.Message, fd protoreflect.FieldDescriptor) []string {
+	if fd.Kind() != protoreflect.MessageKind || fd.IsMap() || !msg.Has(fd) {
+		return nil
+	}
+	var nested []protoreflect.Message
+	if fd.IsList() {
+		list := msg.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			nested = append(nested, list.Get(i).Message())
+		}
+	} else {
+		nested = append(nested, msg.Get(fd).Message())
+	}
+	var keys []string
+	for _, m := range nested {
+		if key, ok := m.Interface().(*pc.ObjectKey); ok {
+			if key.Value != "" {
+				keys = append(keys, key.Value)
+			}
+			continue
+		}
+		fields := m.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			keys = append(keys, objectKeys(m, fields.Get(i))...)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package authz authorizes requests to the bridge API
+package authz
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestResourceNames(t *testing.T) {
+	tests := map[string]struct {
+		in       proto.Message
+		expected []string
+	}{
+		"create with id": {
+			in: &pb.CreateNvmeNamespaceRequest{
+				NvmeNamespaceId: "tenant1-ns0",
+				NvmeNamespace:   &pb.NvmeNamespace{Name: "ignored"},
+			},
+			expected: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+		},
+		"create without id": {
+			in:       &pb.CreateNvmeNamespaceRequest{NvmeNamespace: &pb.NvmeNamespace{Name: "ignored"}},
+			expected: nil,
+		},
+		"get": {
+			in:       &pb.GetNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			expected: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+		},
+		"update": {
+			in:       &pb.UpdateNvmeNamespaceRequest{NvmeNamespace: &pb.NvmeNamespace{Name: "//storage.opiproject.org/volumes/tenant1-ns0"}},
+			expected: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+		},
+		"list": {
+			in:       &pb.ListNvmeNamespacesRequest{Parent: "tenant1", PageSize: 10},
+			expected: []string{"tenant1"},
+		},
+		"create with references": {
+			in: &pb.CreateNvmeNamespaceRequest{
+				NvmeNamespaceId: "tenant1-ns0",
+				NvmeNamespace: &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{
+					SubsystemId: &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant1-subsys0"},
+					VolumeId:    &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant1-vol0"},
+				}},
+			},
+			expected: []string{
+				"//storage.opiproject.org/volumes/tenant1-subsys0",
+				"//storage.opiproject.org/volumes/tenant1-vol0",
+				"//storage.opiproject.org/volumes/tenant1-ns0",
+			},
+		},
+		"update with references": {
+			in: &pb.UpdateNvmeNamespaceRequest{NvmeNamespace: &pb.NvmeNamespace{
+				Name: "//storage.opiproject.org/volumes/tenant1-ns0",
+				Spec: &pb.NvmeNamespaceSpec{VolumeId: &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant2-vol0"}},
+			}},
+			expected: []string{"//storage.opiproject.org/volumes/tenant1-ns0", "//storage.opiproject.org/volumes/tenant2-vol0"},
+		},
+		"repeated references": {
+			in: &px.CreateRaidVolumeRequest{
+				RaidVolumeId: "tenant1-raid0",
+				RaidVolume: &px.RaidVolume{MemberVolumeIds: []*pc.ObjectKey{
+					{Value: "//storage.opiproject.org/volumes/tenant1-vol0"},
+					{Value: "//storage.opiproject.org/volumes/tenant1-vol1"},
+				}},
+			},
+			expected: []string{
+				"//storage.opiproject.org/volumes/tenant1-vol0",
+				"//storage.opiproject.org/volumes/tenant1-vol1",
+				"//storage.opiproject.org/volumes/tenant1-raid0",
+			},
+		},
+		"stats": {
+			in:       &pb.NvmeNamespaceStatsRequest{NamespaceId: &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant1-ns0"}},
+			expected: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if names := resourceNames(tt.in); !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, received: %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	policy, err := LoadPolicy(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantCert := &x509.Certificate{DNSNames: []string{"tenant-agent.example.com"}}
+	tests := map[string]struct {
+		peer *peer.Peer
+		md   metadata.MD
+		in   proto.Message
+		code codes.Code
+	}{
+		"tenant certificate": {
+			peer: &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")},
+				AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{tenantCert}},
+				}},
+			},
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			code: codes.OK,
+		},
+		"unverified tenant certificate": {
+			peer: &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")},
+				AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{tenantCert},
+				}},
+			},
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			code: codes.PermissionDenied,
+		},
+		"tenant token": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			md:   metadata.Pairs("authorization", "Bearer tenant-token"),
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			code: codes.OK,
+		},
+		"wrong token": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			md:   metadata.Pairs("authorization", "Bearer admin-token"),
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			code: codes.PermissionDenied,
+		},
+		"tenant token of other tenant resource": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			md:   metadata.Pairs("authorization", "Bearer tenant-token"),
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant2-ns0"},
+			code: codes.PermissionDenied,
+		},
+		"tenant token of other tenant volume": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			md:   metadata.Pairs("authorization", "Bearer tenant-token"),
+			in: &pb.CreateNvmeNamespaceRequest{
+				NvmeNamespaceId: "tenant1-ns0",
+				NvmeNamespace: &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{
+					VolumeId: &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant2-vol0"},
+				}},
+			},
+			code: codes.PermissionDenied,
+		},
+		"tenant token of own volume": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			md:   metadata.Pairs("authorization", "Bearer tenant-token"),
+			in: &pb.CreateNvmeNamespaceRequest{
+				NvmeNamespaceId: "tenant1-ns0",
+				NvmeNamespace: &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{
+					VolumeId: &pc.ObjectKey{Value: "//storage.opiproject.org/volumes/tenant1-vol0"},
+				}},
+			},
+			code: codes.OK,
+		},
+		"local client": {
+			peer: &peer.Peer{Addr: &net.UnixAddr{Name: "@", Net: "unix"}},
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant2-ns0"},
+			code: codes.OK,
+		},
+		"anonymous client": {
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}},
+			in:   &pb.DeleteNvmeNamespaceRequest{Name: "//storage.opiproject.org/volumes/tenant1-ns0"},
+			code: codes.PermissionDenied,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), tt.peer)
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			method := strings.TrimSuffix(string(tt.in.ProtoReflect().Descriptor().Name()), "Request")
+			info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.FrontendNvmeService/" + method}
+			called := false
+
+			_, err := UnaryServerInterceptor(policy)(ctx, tt.in, info, func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("Expected code %v, received: %v", tt.code, err)
+			}
+			if called != (tt.code == codes.OK) {
+				t.Errorf("Expected handler called %v, received: %v", tt.code == codes.OK, called)
+			}
+		})
+	}
+}
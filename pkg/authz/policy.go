@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package authz authorizes requests to the bridge API
+package authz
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Policy maps identities of clients to requests they are allowed to make.
+// Requests not allowed by any principal matching the client are denied.
+type Policy struct {
+	Principals []Principal `json:"principals"`
+}
+
+// Principal is a client identified by any of its identities
+type Principal struct {
+	// Name identifies the principal in logs
+	Name string `json:"name"`
+	// SANs are subject alternative names (DNS names, URIs, IP or email
+	// addresses) of mutual TLS client certificates of the principal
+	SANs []string `json:"sans,omitempty"`
+	// TokenSHA256 are hex encoded SHA-256 hashes of bearer tokens
+	// of the principal, sent in authorization metadata
+	TokenSHA256 []string `json:"token_sha256,omitempty"`
+	// Local matches clients connected over unix socket
+	Local bool `json:"local,omitempty"`
+	// Allow lists requests the principal may make
+	Allow []Rule `json:"allow"`
+}
+
+// Rule allows calling methods of a service
+type Rule struct {
+	// Service is a full gRPC service name e.g.
+	// opi_api.storage.v1.FrontendNvmeService, * matches all services
+	Service string `json:"service"`
+	// Methods are names of allowed methods e.g. CreateNvmeNamespace,
+	// all methods of the service are allowed if empty
+	Methods []string `json:"methods,omitempty"`
+	// ResourcePrefixes restrict requests to resources with names starting
+	// with one of the prefixes, requests without resource name (like
+	// Create without ID or List without parent) are not allowed then
+	ResourcePrefixes []string `json:"resource_prefixes,omitempty"`
+}
+
+// LoadPolicy reads policy from JSON file
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	policy := &Policy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("invalid policy %v: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %v: %w", file, err)
+	}
+	return policy, nil
+}
+
+func (p *Policy) validate() error {
+	for i, principal := range p.Principals {
+		if principal.Name == "" {
+			return fmt.Errorf("principal %d has no name", i)
+		}
+		if len(principal.SANs) == 0 && len(principal.TokenSHA256) == 0 && !principal.Local {
+			return fmt.Errorf("principal %v has no identity", principal.Name)
+		}
+		for _, hash := range principal.TokenSHA256 {
+			if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != 32 {
+				return fmt.Errorf("principal %v has invalid token SHA-256 %q", principal.Name, hash)
+			}
+		}
+		for _, rule := range principal.Allow {
+			if rule.Service == "" {
+				return fmt.Errorf("principal %v has rule without service", principal.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// identity of a client making a request
+type identity struct {
+	sans        []string
+	tokenSHA256 string
+	local       bool
+}
+
+func (p *Principal) matches(id identity) bool {
+	if p.Local && id.local {
+		return true
+	}
+	for _, hash := range p.TokenSHA256 {
+		if id.tokenSHA256 != "" && strings.EqualFold(hash, id.tokenSHA256) {
+			return true
+		}
+	}
+	for _, san := range p.SANs {
+		for _, clientSAN := range id.sans {
+			if san == clientSAN {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Rule) allows(service, method string, resources []string) bool {
+	if r.Service != "*" && r.Service != service {
+		return false
+	}
+	if len(r.Methods) > 0 && !contains(r.Methods, method) {
+		return false
+	}
+	if len(r.ResourcePrefixes) == 0 {
+		return true
+	}
+	if len(resources) == 0 {
+		return false
+	}
+	for _, resource := range resources {
+		if !hasAnyPrefix(resource, r.ResourcePrefixes) {
+			return false
+		}
+	}
+	return true
+}
+
+// errNoPrincipal is returned for clients not matching any principal
+var errNoPrincipal = errors.New("client does not match any principal")
+
+// authorize returns the principal allowed to call fullMethod on resources
+// with identity id
+func (p *Policy) authorize(id identity, fullMethod string, resources []string) (string, error) {
+	service, method := splitMethod(fullMethod)
+	matched := false
+	for i := range p.Principals {
+		principal := &p.Principals[i]
+		if !principal.matches(id) {
+			continue
+		}
+		matched = true
+		for j := range principal.Allow {
+			if principal.Allow[j].allows(service, method, resources) {
+				return principal.Name, nil
+			}
+		}
+	}
+	if !matched {
+		return "", errNoPrincipal
+	}
+	return "", fmt.Errorf("no rule allows %v", fullMethod)
+}
+
+// splitMethod splits full gRPC method name /service/method
+func splitMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package authz authorizes requests to the bridge API
+package authz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// SHA-256 of token "tenant-token"
+	tenantTokenSHA256 = "4f2571e0f820b886744ba8c1a90a7825b1044f5c506536ac8a011d7407b3f3eb"
+	testPolicy        = `{
+  "principals": [
+    {
+      "name": "tenant-agent",
+      "sans": ["tenant-agent.example.com"],
+      "token_sha256": ["` + tenantTokenSHA256 + `"],
+      "allow": [
+        {
+          "service": "opi_api.storage.v1.FrontendNvmeService",
+          "methods": ["CreateNvmeNamespace", "DeleteNvmeNamespace", "ListNvmeNamespaces"],
+          "resource_prefixes": ["//storage.opiproject.org/volumes/tenant1-"]
+        }
+      ]
+    },
+    {
+      "name": "admin",
+      "sans": ["spiffe://opi/admin"],
+      "local": true,
+      "allow": [{"service": "*"}]
+    }
+  ]
+}`
+)
+
+func writePolicy(t *testing.T, policy string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(file, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy string
+		errMsg string
+	}{
+		"valid policy": {
+			policy: testPolicy,
+			errMsg: "",
+		},
+		"unknown field": {
+			policy: `{"principals": [{"name": "a", "local": true, "roles": ["admin"]}]}`,
+			errMsg: `unknown field "roles"`,
+		},
+		"no identity": {
+			policy: `{"principals": [{"name": "a", "allow": [{"service": "*"}]}]}`,
+			errMsg: "principal a has no identity",
+		},
+		"no name": {
+			policy: `{"principals": [{"local": true}]}`,
+			errMsg: "principal 0 has no name",
+		},
+		"invalid token hash": {
+			policy: `{"principals": [{"name": "a", "token_sha256": ["secret"]}]}`,
+			errMsg: `principal a has invalid token SHA-256 "secret"`,
+		},
+		"rule without service": {
+			policy: `{"principals": [{"name": "a", "local": true, "allow": [{"methods": ["GetNvmePath"]}]}]}`,
+			errMsg: "principal a has rule without service",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy, err := LoadPolicy(writePolicy(t, tt.policy))
+
+			if tt.errMsg == "" {
+				if err != nil || len(policy.Principals) != 2 {
+					t.Errorf("Expected policy with 2 principals, received: %v, %v", policy, err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error %v, received: %v", tt.errMsg, err)
+			}
+		})
+	}
+
+	if _, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error of missing policy file")
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	policy, err := LoadPolicy(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := identity{sans: []string{"tenant-agent.example.com"}}
+	tests := map[string]struct {
+		id        identity
+		method    string
+		resources []string
+		principal string
+	}{
+		"tenant creates own namespace": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.FrontendNvmeService/CreateNvmeNamespace",
+			resources: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+			principal: "tenant-agent",
+		},
+		"tenant identified by token": {
+			id:        identity{tokenSHA256: strings.ToUpper(tenantTokenSHA256)},
+			method:    "/opi_api.storage.v1.FrontendNvmeService/DeleteNvmeNamespace",
+			resources: []string{"//storage.opiproject.org/volumes/tenant1-ns0"},
+			principal: "tenant-agent",
+		},
+		"tenant creates namespace of another tenant": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.FrontendNvmeService/CreateNvmeNamespace",
+			resources: []string{"//storage.opiproject.org/volumes/tenant2-ns0"},
+			principal: "",
+		},
+		"tenant creates namespace without ID": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.FrontendNvmeService/CreateNvmeNamespace",
+			resources: nil,
+			principal: "",
+		},
+		"tenant creates remote controller": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.NvmeRemoteControllerService/CreateNvmeRemoteController",
+			resources: []string{"//storage.opiproject.org/volumes/tenant1-ctrl"},
+			principal: "",
+		},
+		"tenant creates encrypted volume": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.MiddleendEncryptionService/CreateEncryptedVolume",
+			resources: []string{"//storage.opiproject.org/volumes/tenant1-crypto"},
+			principal: "",
+		},
+		"tenant calls method not listed": {
+			id:        tenant,
+			method:    "/opi_api.storage.v1.FrontendNvmeService/CreateNvmeSubsystem",
+			resources: []string{"//storage.opiproject.org/volumes/tenant1-subsys"},
+			principal: "",
+		},
+		"admin creates encrypted volume": {
+			id:        identity{sans: []string{"spiffe://opi/admin"}},
+			method:    "/opi_api.storage.v1.MiddleendEncryptionService/CreateEncryptedVolume",
+			resources: nil,
+			principal: "admin",
+		},
+		"local client": {
+			id:        identity{local: true},
+			method:    "/opi_api.storage.v1.NullDebugService/ListNullDebugs",
+			resources: nil,
+			principal: "admin",
+		},
+		"unknown client": {
+			id:        identity{sans: []string{"intruder.example.com"}},
+			method:    "/opi_api.storage.v1.NullDebugService/ListNullDebugs",
+			resources: nil,
+			principal: "",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			principal, err := policy.authorize(tt.id, tt.method, tt.resources)
+
+			if principal != tt.principal {
+				t.Errorf("Expected principal %q, received: %q", tt.principal, principal)
+			}
+			if (err == nil) != (tt.principal != "") {
+				t.Errorf("Expected allowed %v, received error: %v", tt.principal != "", err)
+			}
+		})
+	}
+}
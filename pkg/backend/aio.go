@@ -8,15 +8,16 @@ package backend
 import (
 	"context"
 	"fmt"
-	"log"
+	"os"
 	"path"
-	"sort"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
@@ -26,286 +27,433 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortAioControllers(controllers []*pb.AioController) {
-	sort.Slice(controllers, func(i int, j int) bool {
-		return controllers[i].Name < controllers[j].Name
-	})
+// CreateAioController creates an Aio controller with libaio engine
+func (s *Server) CreateAioController(ctx context.Context, in *pb.CreateAioControllerRequest) (*pb.AioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, err := s.createAioController(ctx, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.AioController, nil
 }
 
-// CreateAioController creates an Aio controller
-func (s *Server) CreateAioController(_ context.Context, in *pb.CreateAioControllerRequest) (*pb.AioController, error) {
-	log.Printf("CreateAioController: Received from client: %v", in)
+// CreateExtendedAioController creates an Aio controller with engine of its
+// extension
+func (s *Server) CreateExtendedAioController(ctx context.Context, in *px.CreateExtendedAioControllerRequest) (*px.ExtendedAioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	return s.createAioController(ctx, &pb.CreateAioControllerRequest{
+		AioController:   in.AioController,
+		AioControllerId: in.AioControllerId,
+	}, in.Extension)
+}
+
+// createAioController creates an Aio controller with engine set in extension
+func (s *Server) createAioController(ctx context.Context, in *pb.CreateAioControllerRequest, extension *px.AioControllerExtension) (*px.ExtendedAioController, error) {
 	// see https://google.aip.dev/133#user-specified-ids
 	resourceID := resourceid.NewSystemGenerated()
 	if in.AioControllerId != "" {
 		err := resourceid.ValidateUserSettable(in.AioControllerId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.AioControllerId, in.AioController.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.AioControllerId, "name", in.AioController.Name)
 		resourceID = in.AioControllerId
 	}
 	in.AioController.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.AioController.Name)
+	defer unlock()
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	volume, ok := s.Volumes.AioVolumes[in.AioController.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing AioController with id %v", in.AioController.Name)
-		return volume, nil
+		slog.InfoContext(ctx, "Already existing AioController", "name", in.AioController.Name)
+		return &px.ExtendedAioController{AioController: volume, Extension: aioEngineExtension(s.aioEngine(volume.Name))}, nil
 	}
 	// not found, so create a new one
-	params := spdk.BdevAioCreateParams{
-		Name:      resourceID,
-		BlockSize: 512,
-		Filename:  in.AioController.Filename,
-	}
-	var result spdk.BdevAioCreateResult
-	err := s.rpc.Call("bdev_aio_create", &params, &result)
+	engine, err := aioEngineFromExtension(extension)
 	if err != nil {
-		log.Printf("error: %v", err)
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if result == "" {
-		msg := fmt.Sprintf("Could not create Aio Dev: %s", params.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	if in.AioController.BlockSize == 0 {
+		in.AioController.BlockSize = aioDefaultBlockSize
+	}
+	if err := s.createAioBdev(ctx, in.AioController, engine); err != nil {
+		return nil, err
 	}
-	response := server.ProtoClone(in.AioController)
-	s.Volumes.AioVolumes[in.AioController.Name] = response
-	log.Printf("CreateAioController: Sending to client: %v", response)
+	if err := s.saveAioEngine(in.AioController.Name, engine); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	controller := server.ProtoClone(in.AioController)
+	if err := store.Save(s.store, in.AioController.Name, controller); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.AioVolumes[in.AioController.Name] = controller
+	s.mu.Unlock()
+	response := &px.ExtendedAioController{AioController: server.ProtoClone(controller), Extension: aioEngineExtension(engine)}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // DeleteAioController deletes an Aio controller
-func (s *Server) DeleteAioController(_ context.Context, in *pb.DeleteAioControllerRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteAioController: Received from client: %v", in)
+func (s *Server) DeleteAioController(ctx context.Context, in *pb.DeleteAioControllerRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.AioVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	resourceID := path.Base(volume.Name)
-	params := spdk.BdevAioDeleteParams{
-		Name: resourceID,
-	}
-	var result spdk.BdevAioDeleteResult
-	err := s.rpc.Call("bdev_aio_delete", &params, &result)
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, path.Base(volume.Name))
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if !result {
-		msg := fmt.Sprintf("Could not delete Aio Dev: %s", params.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	defer released()
+	if err := s.deleteAioBdev(ctx, path.Base(volume.Name), s.aioEngine(volume.Name)); err != nil {
+		return nil, err
+	}
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := s.removeAioEngine(volume.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
 	}
+	s.mu.Lock()
 	delete(s.Volumes.AioVolumes, volume.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
-// UpdateAioController updates an Aio controller
-func (s *Server) UpdateAioController(_ context.Context, in *pb.UpdateAioControllerRequest) (*pb.AioController, error) {
-	log.Printf("UpdateAioController: Received from client: %v", in)
+// UpdateAioController updates an Aio controller, keeping its engine
+func (s *Server) UpdateAioController(ctx context.Context, in *pb.UpdateAioControllerRequest) (*pb.AioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// update_mask = 2
+	if err := fieldmask.Validate(in.UpdateMask, in.AioController); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, err := s.updateAioController(ctx, &px.UpdateExtendedAioControllerRequest{
+		AioController: in.AioController,
+		UpdateMask:    extendedAioControllerMask(in.UpdateMask),
+		AllowMissing:  in.AllowMissing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.AioController, nil
+}
+
+// UpdateExtendedAioController updates an Aio controller and its engine
+func (s *Server) UpdateExtendedAioController(ctx context.Context, in *px.UpdateExtendedAioControllerRequest) (*px.ExtendedAioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	return s.updateAioController(ctx, in)
+}
+
+// updateAioController updates an Aio controller and the engine set in
+// extension, update_mask paths are fields of ExtendedAioController
+func (s *Server) updateAioController(ctx context.Context, in *px.UpdateExtendedAioControllerRequest) (*px.ExtendedAioController, error) {
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.AioController.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.AioController.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.AioVolumes[in.AioController.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
-			log.Printf("Got AllowMissing, create a new resource, don't return error when resource not found")
-			params := spdk.BdevAioCreateParams{
-				Name:      path.Base(in.AioController.Name),
-				BlockSize: 512,
-				Filename:  in.AioController.Filename,
-			}
-			var result spdk.BdevAioCreateResult
-			err := s.rpc.Call("bdev_aio_create", &params, &result)
+			slog.InfoContext(ctx, "Got AllowMissing, create a new resource, don't return error when resource not found")
+			engine, err := aioEngineFromExtension(in.Extension)
 			if err != nil {
-				log.Printf("error: %v", err)
+				err = status.Errorf(codes.InvalidArgument, "%v", err)
+				slog.ErrorContext(ctx, "Request failed", "err", err)
+				return nil, err
+			}
+			if in.AioController.BlockSize == 0 {
+				in.AioController.BlockSize = aioDefaultBlockSize
+			}
+			if err := s.createAioBdev(ctx, in.AioController, engine); err != nil {
 				return nil, err
 			}
-			log.Printf("Received from SPDK: %v", result)
-			if result == "" {
-				msg := fmt.Sprintf("Could not create Aio Dev: %s", params.Name)
-				log.Print(msg)
-				return nil, status.Errorf(codes.InvalidArgument, msg)
+			if err := s.saveAioEngine(in.AioController.Name, engine); err != nil {
+				slog.ErrorContext(ctx, "Request failed", "err", err)
+				return nil, err
 			}
-			response := server.ProtoClone(in.AioController)
-			s.Volumes.AioVolumes[in.AioController.Name] = response
-			log.Printf("CreateAioController: Sending to client: %v", response)
+			controller := server.ProtoClone(in.AioController)
+			if err := store.Save(s.store, in.AioController.Name, controller); err != nil {
+				slog.ErrorContext(ctx, "Request failed", "err", err)
+				return nil, err
+			}
+			s.mu.Lock()
+			s.Volumes.AioVolumes[in.AioController.Name] = controller
+			s.mu.Unlock()
+			response := &px.ExtendedAioController{AioController: server.ProtoClone(controller), Extension: aioEngineExtension(engine)}
+			slog.DebugContext(ctx, "Sending to client", "response", response)
 			return response, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.AioController.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
-	// update_mask = 2
-	if err := fieldmask.Validate(in.UpdateMask, in.AioController); err != nil {
-		log.Printf("error: %v", err)
+	patch := &px.ExtendedAioController{AioController: in.AioController, Extension: in.Extension}
+	if err := fieldmask.Validate(in.UpdateMask, patch); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	params1 := spdk.BdevAioDeleteParams{
-		Name: resourceID,
-	}
-	var result1 spdk.BdevAioDeleteResult
-	err1 := s.rpc.Call("bdev_aio_delete", &params1, &result1)
-	if err1 != nil {
-		log.Printf("error: %v", err1)
-		return nil, err1
+	engine := s.aioEngine(volume.Name)
+	extended := &px.ExtendedAioController{AioController: server.ProtoClone(volume), Extension: aioEngineExtension(engine)}
+	fieldmask.Update(in.UpdateMask, extended, patch)
+	updated := extended.AioController
+	updatedEngine, err := aioEngineFromExtension(extended.Extension)
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result1)
-	if !result1 {
-		msg := fmt.Sprintf("Could not delete Aio Dev: %s", params1.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	recreate := aioNeedsRecreate(volume, updated) || updatedEngine != engine
+	if recreate {
+		// the bdev is deleted and created again
+		released, err := s.Consumers.ReleaseBdev(ctx, volume.Name, resourceID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		defer released()
+		if err := s.replaceAioBdev(ctx, volume, engine, updated, updatedEngine); err != nil {
+			return nil, err
+		}
+	} else if err := s.rescanAioBdev(ctx, resourceID, engine); err != nil {
+		return nil, err
 	}
-	params2 := spdk.BdevAioCreateParams{
-		Name:      resourceID,
-		BlockSize: 512,
-		Filename:  in.AioController.Filename,
-	}
-	var result2 spdk.BdevAioCreateResult
-	err2 := s.rpc.Call("bdev_aio_create", &params2, &result2)
-	if err2 != nil {
-		log.Printf("error: %v", err2)
-		return nil, err2
-	}
-	log.Printf("Received from SPDK: %v", result2)
-	if result2 == "" {
-		msg := fmt.Sprintf("Could not create Aio Dev: %s", params2.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	controller, err := s.saveUpdatedAio(ctx, updated, updatedEngine)
+	if err != nil {
+		if recreate {
+			// SPDK has to keep the bdev of the stored resource
+			err = s.revertAioBdev(ctx, volume, engine, updatedEngine, err)
+		}
+		return nil, err
 	}
-	response := server.ProtoClone(in.AioController)
-	s.Volumes.AioVolumes[in.AioController.Name] = response
+	response := &px.ExtendedAioController{AioController: controller, Extension: aioEngineExtension(updatedEngine)}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // ListAioControllers lists Aio controllers
-func (s *Server) ListAioControllers(_ context.Context, in *pb.ListAioControllersRequest) (*pb.ListAioControllersResponse, error) {
-	log.Printf("ListAioControllers: Received from client: %v", in)
+func (s *Server) ListAioControllers(ctx context.Context, in *pb.ListAioControllersRequest) (*pb.ListAioControllersResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.AioController{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
-	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", nil, &result)
+	extended, err := s.aioControllers(ctx)
 	if err != nil {
-		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-	result, hasMoreElements := server.LimitPagination(result, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
+	Blobarray := make([]*pb.AioController, len(extended))
+	for i := range extended {
+		Blobarray[i] = extended[i].AioController
+	}
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.AioController).GetName)
+	return &pb.ListAioControllersResponse{AioControllers: Blobarray, NextPageToken: token}, nil
+}
+
+// ListExtendedAioControllers lists Aio controllers with their engine
+func (s *Server) ListExtendedAioControllers(ctx context.Context, in *px.ListExtendedAioControllersRequest) (*px.ListExtendedAioControllersResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.ExtendedAioController{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	Blobarray, err := s.aioControllers(ctx)
+	if err != nil {
+		return nil, err
 	}
-	Blobarray := make([]*pb.AioController, len(result))
+	Blobarray, token := server.Paginate(opts, Blobarray, func(a *px.ExtendedAioController) string { return a.AioController.Name })
+	return &px.ListExtendedAioControllersResponse{ExtendedAioControllers: Blobarray, NextPageToken: token}, nil
+}
+
+// aioControllers returns bdevs of SPDK as Aio controllers with their engine
+func (s *Server) aioControllers(ctx context.Context) ([]*px.ExtendedAioController, error) {
+	var result []spdk.BdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	volumes := make([]*px.ExtendedAioController, len(result))
 	for i := range result {
 		r := &result[i]
-		Blobarray[i] = &pb.AioController{Name: r.Name, BlockSize: r.BlockSize, BlocksCount: r.NumBlocks}
+		volumes[i] = &px.ExtendedAioController{
+			AioController: &pb.AioController{Name: r.Name, BlockSize: r.BlockSize, BlocksCount: r.NumBlocks},
+			Extension:     aioEngineExtension(s.aioEngine(server.ResourceIDToVolumeName(r.Name))),
+		}
 	}
-	sortAioControllers(Blobarray)
-	return &pb.ListAioControllersResponse{AioControllers: Blobarray, NextPageToken: token}, nil
+	return volumes, nil
 }
 
 // GetAioController gets an Aio controller
-func (s *Server) GetAioController(_ context.Context, in *pb.GetAioControllerRequest) (*pb.AioController, error) {
-	log.Printf("GetAioController: Received from client: %v", in)
+func (s *Server) GetAioController(ctx context.Context, in *pb.GetAioControllerRequest) (*pb.AioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.AioVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	return s.getAioController(ctx, volume)
+}
+
+// GetExtendedAioController gets an Aio controller with its engine
+func (s *Server) GetExtendedAioController(ctx context.Context, in *px.GetExtendedAioControllerRequest) (*px.ExtendedAioController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.AioVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	controller, err := s.getAioController(ctx, volume)
+	if err != nil {
+		return nil, err
+	}
+	response := &px.ExtendedAioController{AioController: controller, Extension: aioEngineExtension(s.aioEngine(volume.Name))}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// getAioController returns volume with geometry of its bdev in SPDK
+func (s *Server) getAioController(ctx context.Context, volume *pb.AioController) (*pb.AioController, error) {
 	resourceID := path.Base(volume.Name)
 	params := spdk.BdevGetBdevsParams{
 		Name: resourceID,
 	}
 	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.AioController{Name: result[0].Name, BlockSize: result[0].BlockSize, BlocksCount: result[0].NumBlocks}, nil
 }
 
 // AioControllerStats gets an Aio controller stats
-func (s *Server) AioControllerStats(_ context.Context, in *pb.AioControllerStatsRequest) (*pb.AioControllerStatsResponse, error) {
-	log.Printf("AioControllerStats: Received from client: %v", in)
+func (s *Server) AioControllerStats(ctx context.Context, in *pb.AioControllerStatsRequest) (*pb.AioControllerStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Handle.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.AioVolumes[in.Handle.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Handle.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
@@ -314,15 +462,15 @@ func (s *Server) AioControllerStats(_ context.Context, in *pb.AioControllerStats
 	}
 	// See https://mholt.github.io/json-to-go/
 	var result spdk.BdevGetIostatResult
-	err := s.rpc.Call("bdev_get_iostat", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result.Bdevs) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.AioControllerStatsResponse{Stats: &pb.VolumeStats{
@@ -337,3 +485,180 @@ func (s *Server) AioControllerStats(_ context.Context, in *pb.AioControllerStats
 		UnmapLatencyTicks: int32(result.Bdevs[0].UnmapLatencyTicks),
 	}}, nil
 }
+
+// aioNeedsRecreate reports if AIO bdev of volume has to be recreated to
+// apply updated, other changes are applied in place
+func aioNeedsRecreate(volume *pb.AioController, updated *pb.AioController) bool {
+	return updated.Filename != volume.Filename || updated.BlockSize != volume.BlockSize
+}
+
+// validateAioFile checks that file of volume can back a bdev of its block
+// size, before the bdev of a volume is replaced by one SPDK cannot create.
+// The bridge runs next to SPDK, so it sees the same files. Sizes of block
+// devices are checked by SPDK.
+func validateAioFile(volume *pb.AioController) error {
+	info, err := os.Stat(volume.Filename)
+	if err != nil {
+		return err
+	}
+	if info.Mode().IsRegular() && (info.Size() == 0 || info.Size()%volume.BlockSize != 0) {
+		return fmt.Errorf("size %d of %s is not a multiple of block_size %d", info.Size(), volume.Filename, volume.BlockSize)
+	}
+	return nil
+}
+
+// createAioBdev creates bdev of volume with engine
+func (s *Server) createAioBdev(ctx context.Context, volume *pb.AioController, engine string) error {
+	if err := validateAioBlockSize(volume.BlockSize); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return err
+	}
+	methods, _ := aioEngines(engine)
+	params := spdk.BdevAioCreateParams{
+		Name:      path.Base(volume.Name),
+		BlockSize: int(volume.BlockSize),
+		Filename:  volume.Filename,
+	}
+	var result spdk.BdevAioCreateResult
+	err := server.Call(ctx, s.rpc, methods.create, &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", methods.create, "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Aio Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// deleteAioBdev deletes bdev name of engine
+func (s *Server) deleteAioBdev(ctx context.Context, name string, engine string) error {
+	methods, _ := aioEngines(engine)
+	params := spdk.BdevAioDeleteParams{
+		Name: name,
+	}
+	var result spdk.BdevAioDeleteResult
+	err := server.Call(ctx, s.rpc, methods.delete, &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", methods.delete, "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Aio Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// rescanAioBdev makes SPDK pick up the current size of the file of bdev
+// name of engine, consumers of the bdev see it grow
+func (s *Server) rescanAioBdev(ctx context.Context, name string, engine string) error {
+	methods, _ := aioEngines(engine)
+	params := bdevAioRescanParams{
+		Name: name,
+	}
+	var result bdevAioRescanResult
+	err := server.Call(ctx, s.rpc, methods.rescan, &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", methods.rescan, "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not rescan Aio Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// replaceAioBdev recreates bdev of volume of engine as updated of
+// updatedEngine. Bdevs with consumers are not recreated, since the
+// consumers would be dropped with them, nor are bdevs of files SPDK cannot
+// use. When creating the new bdev fails, the old one is restored.
+func (s *Server) replaceAioBdev(ctx context.Context, volume *pb.AioController, engine string,
+	updated *pb.AioController, updatedEngine string) error {
+	if err := validateAioBlockSize(updated.BlockSize); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return err
+	}
+	if aioNeedsRecreate(volume, updated) {
+		if err := validateAioFile(updated); err != nil {
+			err = status.Errorf(codes.InvalidArgument, "%v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return err
+		}
+	}
+	resourceID := path.Base(volume.Name)
+	bdev, err := s.getBdev(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+	if bdev.Claimed {
+		err := status.Errorf(codes.FailedPrecondition, "AioController %s is in use, only its size can be updated", volume.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return err
+	}
+	if err := s.deleteAioBdev(ctx, resourceID, engine); err != nil {
+		return err
+	}
+	if err := s.createAioBdev(ctx, updated, updatedEngine); err != nil {
+		return s.restoreAioBdev(ctx, volume, engine, err)
+	}
+	return nil
+}
+
+// restoreAioBdev creates bdev of volume of engine again after its update
+// failed with err. The resource is kept even if the bdev cannot be
+// restored, the returned error tells the client that its bdev is missing.
+func (s *Server) restoreAioBdev(ctx context.Context, volume *pb.AioController, engine string, err error) error {
+	if rerr := s.createAioBdev(ctx, volume, engine); rerr != nil {
+		slog.ErrorContext(ctx, "Unable to restore Aio Dev after failed update", "name", volume.Name, "err", rerr)
+		return status.Errorf(codes.Internal, "bdev of AioController %s is missing after failed update: %v",
+			volume.Name, status.Convert(err).Message())
+	}
+	return err
+}
+
+// revertAioBdev replaces bdev of updatedEngine created for an update of
+// volume, which failed with err afterwards, by the bdev of volume of engine
+func (s *Server) revertAioBdev(ctx context.Context, volume *pb.AioController, engine string, updatedEngine string, err error) error {
+	if serr := s.saveAioEngine(volume.Name, engine); serr != nil {
+		slog.ErrorContext(ctx, "Unable to restore engine of Aio Dev after failed update", "name", volume.Name, "err", serr)
+	}
+	if derr := s.deleteAioBdev(ctx, path.Base(volume.Name), updatedEngine); derr != nil {
+		slog.ErrorContext(ctx, "Unable to revert Aio Dev after failed update", "name", volume.Name, "err", derr)
+		return err
+	}
+	return s.restoreAioBdev(ctx, volume, engine, err)
+}
+
+// saveUpdatedAio stores updated volume of engine with the size of its bdev,
+// which follows the file the client may have grown
+func (s *Server) saveUpdatedAio(ctx context.Context, updated *pb.AioController, engine string) (*pb.AioController, error) {
+	bdev, err := s.getBdev(ctx, path.Base(updated.Name))
+	if err != nil {
+		return nil, err
+	}
+	updated.BlocksCount = bdev.NumBlocks
+	if err := s.saveAioEngine(updated.Name, engine); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response := server.ProtoClone(updated)
+	if err := store.Save(s.store, updated.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.AioVolumes[updated.Name] = response
+	s.mu.Unlock()
+	return response, nil
+}
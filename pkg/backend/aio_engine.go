@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+
+	"go.einride.tech/aip/fieldmask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// aioEngineKind is the kind of engines of AioControllers in the store
+const aioEngineKind = "opi_spdk_bridge.AioEngine"
+
+const (
+	// aioEngineLibaio backs volumes with SPDK AIO bdevs using Linux libaio
+	aioEngineLibaio = "libaio"
+	// aioEngineUring backs volumes with SPDK uring bdevs using io_uring
+	aioEngineUring = "io_uring"
+)
+
+const (
+	// aioDefaultBlockSize is block size of volumes created without one
+	aioDefaultBlockSize = 512
+	aioMinBlockSize     = 512
+	aioMaxBlockSize     = 64 * 1024
+)
+
+// aioEngineMethods are SPDK methods managing bdevs of an engine
+type aioEngineMethods struct {
+	create string
+	delete string
+	rescan string
+}
+
+// aioEngines maps engines to SPDK methods managing their bdevs, parameters
+// and results of the methods are the same for all engines
+func aioEngines(engine string) (aioEngineMethods, bool) {
+	switch engine {
+	case aioEngineLibaio:
+		return aioEngineMethods{create: "bdev_aio_create", delete: "bdev_aio_delete", rescan: "bdev_aio_rescan"}, true
+	case aioEngineUring:
+		return aioEngineMethods{create: "bdev_uring_create", delete: "bdev_uring_delete", rescan: "bdev_uring_rescan"}, true
+	}
+	return aioEngineMethods{}, false
+}
+
+// aioEngineFromExtension returns engine set in extension of a volume,
+// libaio if none is set
+func aioEngineFromExtension(extension *px.AioControllerExtension) (string, error) {
+	switch extension.GetEngine() {
+	case px.AioEngine_AIO_ENGINE_UNSPECIFIED, px.AioEngine_AIO_ENGINE_LIBAIO:
+		return aioEngineLibaio, nil
+	case px.AioEngine_AIO_ENGINE_IO_URING:
+		return aioEngineUring, nil
+	}
+	return "", fmt.Errorf("unknown engine %v", extension.GetEngine())
+}
+
+// aioEngineExtension returns engine as extension of a volume
+func aioEngineExtension(engine string) *px.AioControllerExtension {
+	if engine == aioEngineUring {
+		return &px.AioControllerExtension{Engine: px.AioEngine_AIO_ENGINE_IO_URING}
+	}
+	return &px.AioControllerExtension{Engine: px.AioEngine_AIO_ENGINE_LIBAIO}
+}
+
+// extendedAioControllerMask returns paths of mask of an AioController as
+// paths of the AioController in ExtendedAioController, its extension is
+// kept
+func extendedAioControllerMask(mask *fieldmaskpb.FieldMask) *fieldmaskpb.FieldMask {
+	if len(mask.GetPaths()) == 0 {
+		return mask
+	}
+	paths := make([]string, len(mask.Paths))
+	for i, p := range mask.Paths {
+		if p == fieldmask.WildcardPath {
+			paths[i] = "aio_controller"
+		} else {
+			paths[i] = "aio_controller." + p
+		}
+	}
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// validateAioBlockSize checks block size of a file backed volume, 4K
+// native files need 4096 at least
+func validateAioBlockSize(blockSize int64) error {
+	if blockSize < aioMinBlockSize || blockSize > aioMaxBlockSize || blockSize&(blockSize-1) != 0 {
+		return fmt.Errorf("block_size has to be a power of 2 from %d to %d, got %d", aioMinBlockSize, aioMaxBlockSize, blockSize)
+	}
+	return nil
+}
+
+// aioEngine returns engine of volume name
+func (s *Server) aioEngine(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if engine, ok := s.aioVolumeEngines[name]; ok {
+		return engine
+	}
+	return aioEngineLibaio
+}
+
+// saveAioEngine stores engine of volume name, libaio is not stored
+func (s *Server) saveAioEngine(name string, engine string) error {
+	if engine == aioEngineLibaio {
+		return s.removeAioEngine(name)
+	}
+	if err := s.store.Put(aioEngineKind, name, []byte(engine)); err != nil {
+		return status.Errorf(codes.Internal, "unable to store engine of %s: %v", name, err)
+	}
+	s.mu.Lock()
+	s.aioVolumeEngines[name] = engine
+	s.mu.Unlock()
+	return nil
+}
+
+// removeAioEngine removes engine of volume name from the store
+func (s *Server) removeAioEngine(name string) error {
+	if err := s.store.Delete(aioEngineKind, name); err != nil {
+		return status.Errorf(codes.Internal, "unable to remove engine of %s from store: %v", name, err)
+	}
+	s.mu.Lock()
+	delete(s.aioVolumeEngines, name)
+	s.mu.Unlock()
+	return nil
+}
+
+// restoreAioEngines loads engines of volumes from the store
+func (s *Server) restoreAioEngines() error {
+	entries, err := s.store.List(aioEngineKind)
+	if err != nil {
+		return err
+	}
+	for name, data := range entries {
+		s.aioVolumeEngines[name] = string(data)
+	}
+	return nil
+}
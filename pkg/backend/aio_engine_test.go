@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+)
+
+func TestValidateAioBlockSize(t *testing.T) {
+	tests := map[string]struct {
+		blockSize int64
+		err       bool
+	}{
+		"512":         {512, false},
+		"4K native":   {4096, false},
+		"64K":         {64 * 1024, false},
+		"zero":        {0, true},
+		"too small":   {256, true},
+		"too large":   {128 * 1024, true},
+		"not power 2": {3072, true},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := validateAioBlockSize(tt.blockSize)
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBackEnd_ExtendedAioControllerEngine(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":4096,"num_blocks":12,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15"}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":4096,"num_blocks":12,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15"}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":4096,"num_blocks":12,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15"}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+	})
+	defer testEnv.Close()
+	volume := &pb.AioController{BlockSize: 4096, BlocksCount: 12, Filename: "/tmp/aio_bdev_file"}
+	uring := &px.AioControllerExtension{Engine: px.AioEngine_AIO_ENGINE_IO_URING}
+	libaio := &px.AioControllerExtension{Engine: px.AioEngine_AIO_ENGINE_LIBAIO}
+
+	// an unknown engine is rejected before SPDK is called
+	_, err := testEnv.client.CreateExtendedAioController(testEnv.ctx, &px.CreateExtendedAioControllerRequest{
+		AioControllerId: "invalid", AioController: volume, Extension: &px.AioControllerExtension{Engine: 7}})
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "unknown engine 7" {
+		t.Errorf("Expected InvalidArgument for unknown engine, received: %v", err)
+	}
+
+	created, err := testEnv.client.CreateExtendedAioController(testEnv.ctx, &px.CreateExtendedAioControllerRequest{
+		AioControllerId: testAioVolumeID, AioController: volume, Extension: uring})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(created.Extension, uring) {
+		t.Errorf("Expected engine %v, received: %v", uring, created.Extension)
+	}
+	name := created.AioController.Name
+	if engine := testEnv.opiSpdkServer.aioEngine(name); engine != aioEngineUring {
+		t.Errorf("Expected stored engine %v, received: %v", aioEngineUring, engine)
+	}
+
+	got, err := testEnv.client.GetExtendedAioController(testEnv.ctx, &px.GetExtendedAioControllerRequest{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got.Extension, uring) {
+		t.Errorf("Expected engine %v, received: %v", uring, got.Extension)
+	}
+
+	// changing the engine recreates the bdev
+	updated, err := testEnv.client.UpdateExtendedAioController(testEnv.ctx, &px.UpdateExtendedAioControllerRequest{
+		AioController: &pb.AioController{Name: name}, Extension: libaio,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"extension.engine"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(updated.Extension, libaio) {
+		t.Errorf("Expected engine %v, received: %v", libaio, updated.Extension)
+	}
+	if _, ok := testEnv.opiSpdkServer.aioVolumeEngines[name]; ok {
+		t.Error("Expected libaio engine not to be stored")
+	}
+
+	if _, err := testEnv.client.DeleteAioController(testEnv.ctx,
+		&pb.DeleteAioControllerRequest{Name: name}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := testEnv.opiSpdkServer.aioVolumeEngines[name]; ok {
+		t.Error("Expected engine to be removed with the volume")
+	}
+}
@@ -7,6 +7,8 @@ package backend
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -105,6 +107,33 @@ func TestBackEnd_CreateAioController(t *testing.T) {
 			"",
 			true,
 		},
+		"block size not power of 2": {
+			testAioVolumeID,
+			&pb.AioController{BlockSize: 520, BlocksCount: 12, Filename: testAioVolume.Filename},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"block_size has to be a power of 2 from 512 to 65536, got 520",
+			false,
+		},
+		"block size too large": {
+			testAioVolumeID,
+			&pb.AioController{BlockSize: 128 * 1024, BlocksCount: 12, Filename: testAioVolume.Filename},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"block_size has to be a power of 2 from 512 to 65536, got 131072",
+			false,
+		},
+		"4K native block size": {
+			testAioVolumeID,
+			&pb.AioController{BlockSize: 4096, BlocksCount: 12, Filename: testAioVolume.Filename},
+			&pb.AioController{BlockSize: 4096, BlocksCount: 12, Filename: testAioVolume.Filename},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			codes.OK,
+			"",
+			false,
+		},
 	}
 
 	// run tests
@@ -142,6 +171,17 @@ func TestBackEnd_CreateAioController(t *testing.T) {
 }
 
 func TestBackEnd_UpdateAioController(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "aio_bdev_file2")
+	if err := os.WriteFile(file, make([]byte, 512*24), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	unaligned := filepath.Join(t.TempDir(), "aio_bdev_file3")
+	if err := os.WriteFile(unaligned, make([]byte, 1000), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing")
+	moved := &pb.AioController{Name: testAioVolumeName, Filename: file}
+	filenameMask := &fieldmaskpb.FieldMask{Paths: []string{"filename"}}
 	tests := map[string]struct {
 		mask    *fieldmaskpb.FieldMask
 		in      *pb.AioController
@@ -150,6 +190,7 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 		errCode codes.Code
 		errMsg  string
 		missing bool
+		kept    bool
 	}{
 		"invalid fieldmask": {
 			&fieldmaskpb.FieldMask{Paths: []string{"*", "author"}},
@@ -159,87 +200,147 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			codes.Unknown,
 			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
 			false,
+			true,
 		},
-		"delete fails": {
+		"rescan fails": {
 			nil,
 			&testAioVolume,
 			nil,
 			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.InvalidArgument,
-			fmt.Sprintf("Could not delete Aio Dev: %s", testAioVolumeID),
+			fmt.Sprintf("Could not rescan Aio Dev: %s", testAioVolumeID),
 			false,
+			true,
 		},
-		"delete empty": {
+		"rescan empty": {
 			nil,
 			&testAioVolume,
 			nil,
 			[]string{""},
 			codes.Unknown,
-			fmt.Sprintf("bdev_aio_delete: %v", "EOF"),
+			fmt.Sprintf("bdev_aio_rescan: %v", "EOF"),
 			false,
+			true,
 		},
-		"delete ID mismatch": {
+		"rescan ID mismatch": {
 			nil,
 			&testAioVolume,
 			nil,
 			[]string{`{"id":0,"error":{"code":0,"message":""},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_aio_delete: %v", "json response ID mismatch"),
+			fmt.Sprintf("bdev_aio_rescan: %v", "json response ID mismatch"),
 			false,
+			true,
 		},
-		"delete exception": {
+		"rescan exception": {
 			nil,
 			&testAioVolume,
 			nil,
 			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_aio_delete: %v", "json response error: myopierr"),
+			fmt.Sprintf("bdev_aio_rescan: %v", "json response error: myopierr"),
 			false,
+			true,
 		},
-		"delete ok create fails": {
+		"rescan ok bdev not found": {
 			nil,
 			&testAioVolume,
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
 			codes.InvalidArgument,
-			fmt.Sprintf("Could not create Aio Dev: %v", testAioVolumeID),
+			fmt.Sprintf("expecting exactly %d result, got %d", 1, 0),
 			false,
+			true,
 		},
-		"delete ok create empty": {
+		"valid request with valid SPDK response": {
 			nil,
 			&testAioVolume,
+			&pb.AioController{Name: testAioVolumeName, BlockSize: 512, BlocksCount: 24, Filename: "/tmp/aio_bdev_file"},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`},
+			codes.OK,
+			"",
+			false,
+			true,
+		},
+		"filename change of bdev in use": {
+			filenameMask,
+			moved,
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, ""},
-			codes.Unknown,
-			fmt.Sprintf("bdev_aio_create: %v", "EOF"),
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":12,"claimed":true}]}`},
+			codes.FailedPrecondition,
+			fmt.Sprintf("AioController %s is in use, only its size can be updated", testAioVolumeName),
 			false,
+			true,
 		},
-		"delete ok create ID mismatch": {
+		"filename change delete fails": {
+			filenameMask,
+			moved,
 			nil,
-			&testAioVolume,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`, `{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Aio Dev: %s", testAioVolumeID),
+			false,
+			true,
+		},
+		"filename change create fails and is rolled back": {
+			filenameMask,
+			moved,
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":0,"error":{"code":0,"message":""},"result":""}`},
-			codes.Unknown,
-			fmt.Sprintf("bdev_aio_create: %v", "json response ID mismatch"),
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Aio Dev: %s", testAioVolumeID),
 			false,
+			true,
 		},
-		"delete ok create exception": {
+		"filename change create and rollback fail": {
+			filenameMask,
+			moved,
 			nil,
-			&testAioVolume,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.Internal,
+			fmt.Sprintf("bdev of AioController %s is missing after failed update: Could not create Aio Dev: %s", testAioVolumeName, testAioVolumeID),
+			false,
+			true,
+		},
+		"filename change reverted when new bdev is not found": {
+			filenameMask,
+			moved,
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
-			codes.Unknown,
-			fmt.Sprintf("bdev_aio_create: %v", "json response error: myopierr"),
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`, `{"id":%d,"error":{"code":0,"message":""},"result":[]}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly %d result, got %d", 1, 0),
 			false,
+			true,
 		},
-		"valid request with valid SPDK response": {
+		"filename change to missing file": {
+			filenameMask,
+			&pb.AioController{Name: testAioVolumeName, Filename: missing},
 			nil,
-			&testAioVolume,
-			&testAioVolume,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("stat %s: no such file or directory", missing),
+			false,
+			true,
+		},
+		"filename change to file of other block size": {
+			filenameMask,
+			&pb.AioController{Name: testAioVolumeName, Filename: unaligned},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("size %d of %s is not a multiple of block_size %d", 1000, unaligned, 512),
+			false,
+			true,
+		},
+		"filename change": {
+			filenameMask,
+			moved,
+			&pb.AioController{Name: testAioVolumeName, BlockSize: 512, BlocksCount: 24, Filename: file},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`, `{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":24,"claimed":false}]}`},
 			codes.OK,
 			"",
 			false,
+			true,
 		},
 		"valid request with unknown key": {
 			nil,
@@ -254,6 +355,7 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			codes.NotFound,
 			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
 			false,
+			true,
 		},
 		"unknown key with missing allowed": {
 			nil,
@@ -273,6 +375,7 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			codes.OK,
 			"",
 			true,
+			true,
 		},
 		"malformed name": {
 			nil,
@@ -282,6 +385,7 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			codes.Unknown,
 			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
 			false,
+			true,
 		},
 	}
 
@@ -292,7 +396,7 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			defer testEnv.Close()
 
 			testAioVolume.Name = testAioVolumeName
-			testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName] = &testAioVolume
+			testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName] = server.ProtoClone(&testAioVolume)
 
 			request := &pb.UpdateAioControllerRequest{AioController: tt.in, UpdateMask: tt.mask, AllowMissing: tt.missing}
 			response, err := testEnv.client.UpdateAioController(testEnv.ctx, request)
@@ -311,6 +415,10 @@ func TestBackEnd_UpdateAioController(t *testing.T) {
 			} else {
 				t.Error("expected grpc error status")
 			}
+
+			if _, ok := testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName]; ok != tt.kept {
+				t.Error("volume kept: expected", tt.kept, "received", ok)
+			}
 		})
 	}
 }
@@ -469,9 +577,11 @@ func TestBackEnd_ListAioControllers(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
-
 			request := &pb.ListAioControllersRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&pb.AioController{Name: "Malloc0"}, "Malloc0")
+			}
 			response, err := testEnv.client.ListAioControllers(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetAioControllers(), tt.out) {
@@ -6,17 +6,32 @@
 package backend
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sync"
+	"time"
+
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// TODO: can we combine all of volume types into a single list?
-//		 maybe create a volume abstraction like bdev in SPDK?
-
 // VolumeParameters contains all BackEnd volume related structures
 type VolumeParameters struct {
-	AioVolumes  map[string]*pb.AioController
-	NullVolumes map[string]*pb.NullDebug
+	AioVolumes    map[string]*pb.AioController
+	NullVolumes   map[string]*pb.NullDebug
+	MallocVolumes map[string]*px.MallocVolume
+
+	LvolStores map[string]*px.LvolStore
+	Lvols      map[string]*px.Lvol
+	Snapshots  map[string]*px.Snapshot
 
 	NvmeControllers map[string]*pb.NvmeRemoteController
 	NvmePaths       map[string]*pb.NvmePath
@@ -27,23 +42,261 @@ type Server struct {
 	pb.UnimplementedNvmeRemoteControllerServiceServer
 	pb.UnimplementedNullDebugServiceServer
 	pb.UnimplementedAioControllerServiceServer
+	px.UnimplementedMallocVolumeServiceServer
+	px.UnimplementedLvolServiceServer
+	px.UnimplementedExtendedNvmePathServiceServer
+	px.UnimplementedExtendedNvmeRemoteControllerServiceServer
+	px.UnimplementedExtendedNullDebugServiceServer
+	px.UnimplementedExtendedAioControllerServiceServer
 
 	rpc        spdk.JSONRPC
+	store      store.Store
 	Volumes    VolumeParameters
-	Pagination map[string]int
+	Pagination *server.PageTokens
+	// KeyDir is the directory PSKs of remote controllers are written to
+	KeyDir string
+	// PathConnectTimeout is how long UpdateNvmePath waits for a new path
+	// to connect
+	PathConnectTimeout time.Duration
+	// Consumers is the registry of resources using volumes, shared with
+	// other services. Volumes in use cannot be deleted.
+	Consumers *server.VolumeConsumers
+	// VolumeIndex resolves names of volume resources of any service in
+	// volume_id of requests to SPDK bdevs
+	VolumeIndex *server.VolumeIndex
+
+	// tunings are multipath policy and reconnect settings of controllers
+	tunings map[string]nvmeControllerTuning
+	// aioVolumeEngines are I/O engines of AioControllers other than libaio
+	aioVolumeEngines map[string]string
+	// discovered are resources managed by discovery controllers
+	discovered map[string]nvmeDiscovered
+
+	// mu guards resource maps, names serializes
+	// requests working with the same resource
+	mu    sync.RWMutex
+	names server.NameLocker
 }
 
 // NewServer creates initialized instance of BackEnd server communicating
-// with provided jsonRPC
-func NewServer(jsonRPC spdk.JSONRPC) *Server {
-	return &Server{
-		rpc: jsonRPC,
+// with provided jsonRPC. Resources kept in store are restored on creation
+// and every change is written through to it.
+func NewServer(jsonRPC spdk.JSONRPC, st store.Store) *Server {
+	if st == nil {
+		log.Panic("nil for Store is not allowed")
+	}
+	s := &Server{
+		rpc:   jsonRPC,
+		store: st,
 		Volumes: VolumeParameters{
 			AioVolumes:      make(map[string]*pb.AioController),
 			NullVolumes:     make(map[string]*pb.NullDebug),
+			MallocVolumes:   make(map[string]*px.MallocVolume),
+			LvolStores:      make(map[string]*px.LvolStore),
+			Lvols:           make(map[string]*px.Lvol),
+			Snapshots:       make(map[string]*px.Snapshot),
 			NvmeControllers: make(map[string]*pb.NvmeRemoteController),
 			NvmePaths:       make(map[string]*pb.NvmePath),
 		},
-		Pagination: make(map[string]int),
+		Pagination: server.NewPageTokens(server.DefaultPageTokenTTL, server.DefaultPageTokenLimit),
+		KeyDir:     DefaultKeyDir,
+
+		PathConnectTimeout: DefaultPathConnectTimeout,
+		tunings:            make(map[string]nvmeControllerTuning),
+		aioVolumeEngines:   make(map[string]string),
+		discovered:         make(map[string]nvmeDiscovered),
+	}
+	if err := s.restore(); err != nil {
+		log.Panicf("unable to restore backend resources from store: %v", err)
+	}
+	return s
+}
+
+func (s *Server) restore() error {
+	if err := store.Load(s.store, s.Volumes.AioVolumes); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.NullVolumes); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.MallocVolumes); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.LvolStores); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.Lvols); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.Snapshots); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.NvmeControllers); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Volumes.NvmePaths); err != nil {
+		return err
+	}
+	if err := s.restoreTunings(); err != nil {
+		return err
+	}
+	if err := s.restoreDiscovered(); err != nil {
+		return err
+	}
+	return s.restoreAioEngines()
+}
+
+// ResourceCounts returns number of resources of each kind kept by the server
+func (s *Server) ResourceCounts() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]int{
+		"aio_controller":         len(s.Volumes.AioVolumes),
+		"null_debug":             len(s.Volumes.NullVolumes),
+		"malloc_volume":          len(s.Volumes.MallocVolumes),
+		"lvol_store":             len(s.Volumes.LvolStores),
+		"lvol":                   len(s.Volumes.Lvols),
+		"snapshot":               len(s.Volumes.Snapshots),
+		"nvme_remote_controller": len(s.Volumes.NvmeControllers),
+		"nvme_path":              len(s.Volumes.NvmePaths),
+	}
+}
+
+// ManagedVolumes returns names of SPDK bdevs created by the server
+func (s *Server) ManagedVolumes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.Volumes.AioVolumes)+len(s.Volumes.NullVolumes)+len(s.Volumes.MallocVolumes)+len(s.Volumes.Lvols))
+	for _, volume := range s.Volumes.AioVolumes {
+		names = append(names, path.Base(volume.Name))
+	}
+	for _, volume := range s.Volumes.NullVolumes {
+		names = append(names, path.Base(volume.Name))
+	}
+	for _, volume := range s.Volumes.MallocVolumes {
+		names = append(names, path.Base(volume.Name))
+	}
+	for _, volume := range s.Volumes.Lvols {
+		names = append(names, lvolBdevName(volume))
+	}
+	return names
+}
+
+// VolumeConsumers returns kinds of backend resources using volumes
+func (s *Server) VolumeConsumers() []server.VolumeConsumer {
+	return []server.VolumeConsumer{
+		{
+			Kind: "LvolStore",
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				uses := make(map[string][]string)
+				for _, lvs := range s.Volumes.LvolStores {
+					bdev := lvs.GetVolumeId().GetValue()
+					uses[bdev] = append(uses[bdev], lvs.Name)
+				}
+				return uses
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteLvolStore(ctx, &px.DeleteLvolStoreRequest{Name: name})
+				return err
+			},
+		},
+	}
+}
+
+// VolumeProviders returns kinds of backend resources other resources can
+// be built on. Namespaces of Nvme remote controllers are named after their
+// bdevs, e.g. volumes/nvme0n1 for namespace 1 of controller nvme0.
+func (s *Server) VolumeProviders() []server.VolumeProvider {
+	return []server.VolumeProvider{
+		{
+			Kind: "AioController",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, ok := s.Volumes.AioVolumes[name]
+				return path.Base(name), ok
+			},
+		},
+		{
+			Kind: "NullDebug",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, ok := s.Volumes.NullVolumes[name]
+				return path.Base(name), ok
+			},
+		},
+		{
+			Kind: "MallocVolume",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, ok := s.Volumes.MallocVolumes[name]
+				return path.Base(name), ok
+			},
+		},
+		{
+			Kind: "Lvol",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				lvol, ok := s.Volumes.Lvols[name]
+				if !ok {
+					return "", false
+				}
+				return lvolBdevName(lvol), true
+			},
+		},
+		{
+			Kind: "Snapshot",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				snapshot, ok := s.Volumes.Snapshots[name]
+				if !ok {
+					return "", false
+				}
+				return snapshotBdevName(snapshot), true
+			},
+		},
+		{
+			Kind: "NvmeRemoteNamespace",
+			Bdev: func(name string) (string, bool) {
+				bdev := path.Base(name)
+				if name != server.ResourceIDToVolumeName(bdev) {
+					return "", false
+				}
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				for controller := range s.Volumes.NvmeControllers {
+					if isControllerBdev(path.Base(controller), bdev) {
+						return bdev, true
+					}
+				}
+				return "", false
+			},
+		},
+	}
+}
+
+// getBdev gets bdev name from SPDK
+func (s *Server) getBdev(ctx context.Context, name string) (*bdevGetBdevsResult, error) {
+	params := spdk.BdevGetBdevsParams{
+		Name: name,
+	}
+	var result []bdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if len(result) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
+	return &result[0], nil
 }
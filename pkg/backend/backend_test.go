@@ -7,17 +7,27 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 )
 
 // TODO: move test infrastructure code to a separate (test/server) package to avoid duplication
@@ -26,6 +36,12 @@ type backendClient struct {
 	pb.NvmeRemoteControllerServiceClient
 	pb.NullDebugServiceClient
 	pb.AioControllerServiceClient
+	px.MallocVolumeServiceClient
+	px.LvolServiceClient
+	px.ExtendedNvmePathServiceClient
+	px.ExtendedNvmeRemoteControllerServiceClient
+	px.ExtendedNullDebugServiceClient
+	px.ExtendedAioControllerServiceClient
 }
 
 type testEnv struct {
@@ -36,6 +52,7 @@ type testEnv struct {
 	ctx           context.Context
 	conn          *grpc.ClientConn
 	jsonRPC       spdk.JSONRPC
+	keyDir        string
 }
 
 func (e *testEnv) Close() {
@@ -43,6 +60,9 @@ func (e *testEnv) Close() {
 	if err := os.RemoveAll(e.testSocket); err != nil {
 		log.Fatal(err)
 	}
+	if err := os.RemoveAll(e.keyDir); err != nil {
+		log.Fatal(err)
+	}
 	server.CloseGrpcConnection(e.conn)
 }
 
@@ -50,7 +70,13 @@ func createTestEnvironment(spdkResponses []string) *testEnv {
 	env := &testEnv{}
 	env.testSocket = server.GenerateSocketName("backend")
 	env.ln, env.jsonRPC = server.CreateTestSpdkServer(env.testSocket, spdkResponses)
-	env.opiSpdkServer = NewServer(env.jsonRPC)
+	env.opiSpdkServer = NewServer(env.jsonRPC, store.NewMemoryStore())
+	keyDir, err := os.MkdirTemp("", "opi-keys")
+	if err != nil {
+		log.Fatal(err)
+	}
+	env.keyDir = keyDir
+	env.opiSpdkServer.KeyDir = filepath.Join(keyDir, "keys")
 
 	ctx := context.Background()
 	conn, err := grpc.DialContext(ctx,
@@ -68,6 +94,12 @@ func createTestEnvironment(spdkResponses []string) *testEnv {
 		pb.NewNvmeRemoteControllerServiceClient(env.conn),
 		pb.NewNullDebugServiceClient(env.conn),
 		pb.NewAioControllerServiceClient(env.conn),
+		px.NewMallocVolumeServiceClient(env.conn),
+		px.NewLvolServiceClient(env.conn),
+		px.NewExtendedNvmePathServiceClient(env.conn),
+		px.NewExtendedNvmeRemoteControllerServiceClient(env.conn),
+		px.NewExtendedNullDebugServiceClient(env.conn),
+		px.NewExtendedAioControllerServiceClient(env.conn),
 	}
 
 	return env
@@ -79,6 +111,12 @@ func dialer(opiSpdkServer *Server) func(context.Context, string) (net.Conn, erro
 	pb.RegisterNvmeRemoteControllerServiceServer(server, opiSpdkServer)
 	pb.RegisterNullDebugServiceServer(server, opiSpdkServer)
 	pb.RegisterAioControllerServiceServer(server, opiSpdkServer)
+	px.RegisterMallocVolumeServiceServer(server, opiSpdkServer)
+	px.RegisterLvolServiceServer(server, opiSpdkServer)
+	px.RegisterExtendedNvmePathServiceServer(server, opiSpdkServer)
+	px.RegisterExtendedNvmeRemoteControllerServiceServer(server, opiSpdkServer)
+	px.RegisterExtendedNullDebugServiceServer(server, opiSpdkServer)
+	px.RegisterExtendedAioControllerServiceServer(server, opiSpdkServer)
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -90,3 +128,173 @@ func dialer(opiSpdkServer *Server) func(context.Context, string) (net.Conn, erro
 		return listener.Dial()
 	}
 }
+
+func TestBackEnd_NewServerRestoresFromStore(t *testing.T) {
+	st := store.NewMemoryStore()
+	aio := &pb.AioController{Name: testAioVolumeName, BlockSize: 512, BlocksCount: 12, Filename: "/tmp/aio_bdev_file"}
+	if err := store.Save(st, aio.Name, aio); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewServer(spdk.NewSpdkJSONRPC(server.GenerateSocketName("backend")), st)
+
+	if !proto.Equal(s.Volumes.AioVolumes[aio.Name], aio) {
+		t.Errorf("Expected %v to be restored, received: %v", aio, s.Volumes.AioVolumes)
+	}
+	if len(s.Volumes.NullVolumes) != 0 {
+		t.Errorf("Expected no NullDebugs, received: %v", s.Volumes.NullVolumes)
+	}
+}
+
+func TestBackEnd_DeleteConsumedVolume(t *testing.T) {
+	raids := map[string][]string{
+		testAioVolumeID:       {"//storage.opiproject.org/volumes/raid0"},
+		testNvmeCtrlID + "n1": {"//storage.opiproject.org/volumes/raid1"},
+	}
+	tests := map[string]struct {
+		delete  func(ctx context.Context, testEnv *testEnv) error
+		spdk    []string
+		cascade bool
+		errCode codes.Code
+		errMsg  string
+		deleted []string
+	}{
+		"aio controller": {
+			func(ctx context.Context, testEnv *testEnv) error {
+				_, err := testEnv.client.DeleteAioController(ctx, &pb.DeleteAioControllerRequest{Name: testAioVolumeName})
+				return err
+			},
+			[]string{},
+			false,
+			codes.FailedPrecondition,
+			fmt.Sprintf("%v is used by [RaidVolume %v]", testAioVolumeName, raids[testAioVolumeID][0]),
+			nil,
+		},
+		"aio controller with cascade": {
+			func(ctx context.Context, testEnv *testEnv) error {
+				_, err := testEnv.client.DeleteAioController(ctx, &pb.DeleteAioControllerRequest{Name: testAioVolumeName})
+				return err
+			},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			true,
+			codes.OK,
+			"",
+			raids[testAioVolumeID],
+		},
+		"last nvme path": {
+			func(ctx context.Context, testEnv *testEnv) error {
+				_, err := testEnv.client.DeleteNvmePath(ctx, &pb.DeleteNvmePathRequest{Name: testNvmePathName})
+				return err
+			},
+			[]string{},
+			false,
+			codes.FailedPrecondition,
+			fmt.Sprintf("%v is used by [RaidVolume %v]", testNvmePathName, raids[testNvmeCtrlID+"n1"][0]),
+			nil,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			var deleted []string
+			testEnv.opiSpdkServer.Consumers = &server.VolumeConsumers{}
+			testEnv.opiSpdkServer.Consumers.Register(server.VolumeConsumer{
+				Kind: "RaidVolume",
+				Uses: func() map[string][]string { return raids },
+				Delete: func(_ context.Context, name string) error {
+					deleted = append(deleted, name)
+					return nil
+				},
+			})
+			aio := server.ProtoClone(&testAioVolume)
+			aio.Name = testAioVolumeName
+			testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName] = aio
+			controller := server.ProtoClone(&testNvmeCtrl)
+			controller.Name = testNvmeCtrlName
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = controller
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = server.ProtoClone(&testNvmePathNamed)
+
+			ctx := testEnv.ctx
+			if tt.cascade {
+				ctx = metadata.AppendToOutgoingContext(ctx, server.CascadeMetadataKey, "true")
+			}
+			err := tt.delete(ctx, testEnv)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+			if !reflect.DeepEqual(deleted, tt.deleted) {
+				t.Error("deleted: expected", tt.deleted, "received", deleted)
+			}
+		})
+	}
+}
+
+func TestBackEnd_VolumeProviders(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		kind   string
+		bdev   string
+		exists bool
+	}{
+		"aio controller": {
+			testAioVolumeName, "AioController", testAioVolumeID, true,
+		},
+		"lvol": {
+			testLvolName, "Lvol", testLvolStoreID + "/" + testLvolID, true,
+		},
+		"snapshot": {
+			testSnapshotName, "Snapshot", testLvolStoreID + "/" + testSnapshotID, true,
+		},
+		"remote controller namespace": {
+			server.ResourceIDToVolumeName(testNvmeCtrlID + "n1"), "NvmeRemoteNamespace", testNvmeCtrlID + "n1", true,
+		},
+		"remote controller": {
+			testNvmeCtrlName, "", "", false,
+		},
+		"invalid namespace": {
+			server.ResourceIDToVolumeName(testNvmeCtrlID + "nx"), "", "", false,
+		},
+		"lvol store": {
+			testLvolStoreName, "", "", false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment([]string{})
+			defer testEnv.Close()
+
+			volumes := &server.VolumeIndex{}
+			volumes.Register(testEnv.opiSpdkServer.VolumeProviders()...)
+			testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName] = server.ProtoClone(&testAioVolume)
+			testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = server.ProtoClone(&testLvolStore)
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+			testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = server.ProtoClone(&testSnapshot)
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = server.ProtoClone(&testNvmeCtrl)
+
+			volume, ok := volumes.Lookup(tt.in)
+
+			if ok != tt.exists {
+				t.Error("exists: expected", tt.exists, "received", ok)
+			}
+			if volume.Kind != tt.kind || volume.Bdev != tt.bdev {
+				t.Error("volume: expected", tt.kind, tt.bdev, "received", volume.Kind, volume.Bdev)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implements the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+const concurrentWorkers = 32
+
+func newConcurrentTestSpdkStub() *server.TestSpdkStub {
+	stub := server.NewTestSpdkStub(map[string]string{
+		"bdev_aio_create":             `"mytest"`,
+		"bdev_aio_delete":             `true`,
+		"bdev_aio_rescan":             `true`,
+		"bdev_null_create":            `"mytest"`,
+		"bdev_null_delete":            `true`,
+		"bdev_get_bdevs":              `[{"name":"mytest","block_size":512,"num_blocks":64}]`,
+		"bdev_get_iostat":             `{"tick_rate":3300000000,"ticks":1,"bdevs":[{"name":"mytest","bytes_read":1,"num_read_ops":1}]}`,
+		"bdev_lvol_get_lvstores":      `[]`,
+		"bdev_nvme_attach_controller": `["mytest"]`,
+		"bdev_nvme_detach_controller": `true`,
+		"bdev_nvme_get_controllers":   `[{"name":"mytest","ctrlrs":[{"trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}}]}]`,
+		"bdev_nvme_get_io_paths":      `{"poll_groups":[]}`,
+		"nvmf_get_stats":              `{"tick_rate":3300000000,"poll_groups":[]}`,
+	})
+	stub.Delay = time.Millisecond
+	return stub
+}
+
+// checkConcurrentError fails on errors which cannot be explained
+// by other requests running in parallel on the same resources
+func checkConcurrentError(t *testing.T, method string, err error) {
+	switch status.Code(err) {
+	case codes.OK, codes.NotFound, codes.FailedPrecondition:
+	default:
+		t.Errorf("%v: unexpected error: %v", method, err)
+	}
+}
+
+func TestBackEnd_ConcurrentRequests(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	testEnv.opiSpdkServer.rpc = newConcurrentTestSpdkStub()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// few IDs only, so workers contend on the same resources
+			id := fmt.Sprintf("volume-%d", i%4)
+			name := server.ResourceIDToVolumeName(id)
+
+			_, err := testEnv.client.CreateAioController(testEnv.ctx, &pb.CreateAioControllerRequest{
+				AioControllerId: id, AioController: &pb.AioController{Filename: "/tmp/aio_bdev_file"}})
+			checkConcurrentError(t, "CreateAioController", err)
+			_, err = testEnv.client.GetAioController(testEnv.ctx, &pb.GetAioControllerRequest{Name: name})
+			checkConcurrentError(t, "GetAioController", err)
+			_, err = testEnv.client.AioControllerStats(testEnv.ctx, &pb.AioControllerStatsRequest{Handle: &pc.ObjectKey{Value: name}})
+			checkConcurrentError(t, "AioControllerStats", err)
+			_, err = testEnv.client.UpdateAioController(testEnv.ctx, &pb.UpdateAioControllerRequest{
+				AioController: &pb.AioController{Name: name, Filename: "/tmp/aio_bdev_file"}})
+			checkConcurrentError(t, "UpdateAioController", err)
+			list, err := testEnv.client.ListAioControllers(testEnv.ctx, &pb.ListAioControllersRequest{Parent: "todo", PageSize: 1})
+			checkConcurrentError(t, "ListAioControllers", err)
+			_, err = testEnv.client.ListAioControllers(testEnv.ctx, &pb.ListAioControllersRequest{Parent: "todo", PageToken: list.GetNextPageToken()})
+			checkConcurrentError(t, "ListAioControllers", err)
+			_, err = testEnv.client.DeleteAioController(testEnv.ctx, &pb.DeleteAioControllerRequest{Name: name, AllowMissing: true})
+			checkConcurrentError(t, "DeleteAioController", err)
+
+			_, err = testEnv.client.CreateNullDebug(testEnv.ctx, &pb.CreateNullDebugRequest{
+				NullDebugId: id, NullDebug: &pb.NullDebug{BlockSize: 512, BlocksCount: 64}})
+			checkConcurrentError(t, "CreateNullDebug", err)
+			_, err = testEnv.client.GetNullDebug(testEnv.ctx, &pb.GetNullDebugRequest{Name: name})
+			checkConcurrentError(t, "GetNullDebug", err)
+			_, err = testEnv.client.NullDebugStats(testEnv.ctx, &pb.NullDebugStatsRequest{Handle: &pc.ObjectKey{Value: name}})
+			checkConcurrentError(t, "NullDebugStats", err)
+			_, err = testEnv.client.UpdateNullDebug(testEnv.ctx, &pb.UpdateNullDebugRequest{
+				NullDebug: &pb.NullDebug{Name: name, BlockSize: 512, BlocksCount: 64}})
+			checkConcurrentError(t, "UpdateNullDebug", err)
+			_, err = testEnv.client.ListNullDebugs(testEnv.ctx, &pb.ListNullDebugsRequest{Parent: "todo", PageSize: 1})
+			checkConcurrentError(t, "ListNullDebugs", err)
+			_, err = testEnv.client.DeleteNullDebug(testEnv.ctx, &pb.DeleteNullDebugRequest{Name: name, AllowMissing: true})
+			checkConcurrentError(t, "DeleteNullDebug", err)
+
+			pathID := fmt.Sprintf("path-%d", i%8)
+			pathName := server.ResourceIDToVolumeName(pathID)
+			_, err = testEnv.client.CreateNvmeRemoteController(testEnv.ctx, &pb.CreateNvmeRemoteControllerRequest{
+				NvmeRemoteControllerId: id,
+				NvmeRemoteController:   &pb.NvmeRemoteController{Multipath: pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH}})
+			checkConcurrentError(t, "CreateNvmeRemoteController", err)
+			_, err = testEnv.client.CreateNvmePath(testEnv.ctx, &pb.CreateNvmePathRequest{
+				NvmePathId: pathID,
+				NvmePath: &pb.NvmePath{
+					ControllerId: &pc.ObjectKey{Value: name},
+					Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+					Adrfam:       pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
+					Traddr:       "127.0.0.1",
+					Trsvcid:      4444,
+					Subnqn:       "nqn.2016-06.io.spdk:cnode1",
+				}})
+			checkConcurrentError(t, "CreateNvmePath", err)
+			_, err = testEnv.client.GetNvmeRemoteController(testEnv.ctx, &pb.GetNvmeRemoteControllerRequest{Name: name})
+			checkConcurrentError(t, "GetNvmeRemoteController", err)
+			_, err = testEnv.client.ListNvmeRemoteControllers(testEnv.ctx, &pb.ListNvmeRemoteControllersRequest{Parent: "todo", PageSize: 1})
+			checkConcurrentError(t, "ListNvmeRemoteControllers", err)
+			_, err = testEnv.client.GetNvmePath(testEnv.ctx, &pb.GetNvmePathRequest{Name: pathName})
+			checkConcurrentError(t, "GetNvmePath", err)
+			_, err = testEnv.client.ListNvmePaths(testEnv.ctx, &pb.ListNvmePathsRequest{Parent: name, PageSize: 1})
+			checkConcurrentError(t, "ListNvmePaths", err)
+			_, err = testEnv.client.NvmePathStats(testEnv.ctx, &pb.NvmePathStatsRequest{Id: &pc.ObjectKey{Value: pathName}})
+			checkConcurrentError(t, "NvmePathStats", err)
+			_, err = testEnv.client.DeleteNvmePath(testEnv.ctx, &pb.DeleteNvmePathRequest{Name: pathName, AllowMissing: true})
+			checkConcurrentError(t, "DeleteNvmePath", err)
+			_, err = testEnv.client.DeleteNvmeRemoteController(testEnv.ctx, &pb.DeleteNvmeRemoteControllerRequest{Name: name, AllowMissing: true})
+			checkConcurrentError(t, "DeleteNvmeRemoteController", err)
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := testEnv.opiSpdkServer.Reconcile(server.ReconcileReport); err != nil {
+		t.Errorf("expected no error, received: %v", err)
+	}
+}
+
+func TestBackEnd_ConcurrentCreateSameID(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	stub := newConcurrentTestSpdkStub()
+	testEnv.opiSpdkServer.rpc = stub
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := testEnv.client.CreateAioController(testEnv.ctx, &pb.CreateAioControllerRequest{
+				AioControllerId: "mytest", AioController: &pb.AioController{Filename: "/tmp/aio_bdev_file"}})
+			if err != nil {
+				t.Errorf("CreateAioController: expected no error, received: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := testEnv.client.CreateNullDebug(testEnv.ctx, &pb.CreateNullDebugRequest{
+				NullDebugId: "mytest", NullDebug: &pb.NullDebug{BlockSize: 512, BlocksCount: 64}})
+			if err != nil {
+				t.Errorf("CreateNullDebug: expected no error, received: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, method := range []string{"bdev_aio_create", "bdev_null_create"} {
+		if calls := stub.Calls(method); calls != 1 {
+			t.Errorf("expected %v to reach SPDK once, received: %v", method, calls)
+		}
+	}
+}
+
+func TestBackEnd_ConcurrentCreateLvolDeleteLvolStore(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	stub := server.NewTestSpdkStub(map[string]string{
+		"bdev_lvol_create":         `"b4d7f6b0-8d2a-4b0a-9c3e-3f1c6a1e2d40"`,
+		"bdev_lvol_delete_lvstore": `true`,
+	})
+	stub.Delay = time.Millisecond
+	testEnv.opiSpdkServer.rpc = stub
+	volumes := &testEnv.opiSpdkServer.Volumes
+	lvsName := server.ResourceIDToVolumeName("lvs0")
+
+	for i := 0; i < concurrentWorkers; i++ {
+		lvolID := fmt.Sprintf("lvol-%d", i)
+		testEnv.opiSpdkServer.mu.Lock()
+		volumes.LvolStores[lvsName] = &px.LvolStore{Name: lvsName}
+		testEnv.opiSpdkServer.mu.Unlock()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := testEnv.client.CreateLvol(testEnv.ctx, &px.CreateLvolRequest{
+				LvolId: lvolID, Lvol: &px.Lvol{LvolStoreId: &pc.ObjectKey{Value: lvsName}, SizeMib: 1}})
+			checkConcurrentError(t, "CreateLvol", err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := testEnv.client.DeleteLvolStore(testEnv.ctx, &px.DeleteLvolStoreRequest{Name: lvsName})
+			checkConcurrentError(t, "DeleteLvolStore", err)
+		}()
+		wg.Wait()
+
+		testEnv.opiSpdkServer.mu.RLock()
+		_, created := volumes.Lvols[server.ResourceIDToVolumeName(lvolID)]
+		_, kept := volumes.LvolStores[lvsName]
+		testEnv.opiSpdkServer.mu.RUnlock()
+		if created && !kept {
+			t.Fatalf("Expected LvolStore %v kept while it has Lvol %v", lvsName, lvolID)
+		}
+	}
+}
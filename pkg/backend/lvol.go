@@ -0,0 +1,458 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+
+	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/fieldmask"
+	"go.einride.tech/aip/resourceid"
+	"go.einride.tech/aip/resourcename"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// mib is the unit SPDK sizes logical volumes in
+const mib = 1024 * 1024
+
+// CreateLvol creates a logical volume in a logical volume store
+func (s *Server) CreateLvol(ctx context.Context, in *px.CreateLvolRequest) (*px.Lvol, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.LvolId != "" {
+		err := resourceid.ValidateUserSettable(in.LvolId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.LvolId, "name", in.Lvol.Name)
+		resourceID = in.LvolId
+	}
+	in.Lvol.Name = server.ResourceIDToVolumeName(resourceID)
+	// the store is locked too, so it cannot be deleted while the volume is created
+	unlock := s.names.Lock(lvolNames(in.Lvol)...)
+	defer unlock()
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Lvol.Name]
+	s.mu.RUnlock()
+	if ok && lvol.LvolStoreId.GetValue() != in.Lvol.LvolStoreId.GetValue() {
+		err := status.Errorf(codes.AlreadyExists, "Lvol %s already exists in LvolStore %s", in.Lvol.Name, lvol.LvolStoreId.GetValue())
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if ok {
+		slog.InfoContext(ctx, "Already existing Lvol", "name", in.Lvol.Name)
+		return lvol, nil
+	}
+	// not found, so create a new one
+	return s.createLvol(ctx, in.Lvol)
+}
+
+// DeleteLvol deletes a logical volume
+func (s *Server) DeleteLvol(ctx context.Context, in *px.DeleteLvolRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			return &emptypb.Empty{}, nil
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, lvolBdevName(lvol))
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
+	params := bdevLvolDeleteParams{
+		Name: lvolBdevName(lvol),
+	}
+	var result bdevLvolDeleteResult
+	err = server.Call(ctx, s.rpc, "bdev_lvol_delete", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_delete", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Lvol: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	if err := store.Remove(s.store, lvol.Name, lvol); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.Volumes.Lvols, lvol.Name)
+	s.mu.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateLvol updates a logical volume. Only size_mib can be changed, the
+// logical volume is resized in place and keeps its data.
+func (s *Server) UpdateLvol(ctx context.Context, in *px.UpdateLvolRequest) (*px.Lvol, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Lvol.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// the store is locked too, as a missing volume is created in it
+	unlock := s.names.Lock(lvolNames(in.Lvol)...)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Lvol.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			slog.InfoContext(ctx, "Got AllowMissing, create a new resource, don't return error when resource not found")
+			return s.createLvol(ctx, in.Lvol)
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Lvol.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// update_mask = 2
+	if err := fieldmask.Validate(in.UpdateMask, in.Lvol); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	updated := server.ProtoClone(lvol)
+	fieldmask.Update(in.UpdateMask, updated, in.Lvol)
+	// output only fields are ignored
+	updated.Uuid = lvol.Uuid
+	clearLvolCapacity(updated)
+	if proto.Equal(updated, lvol) {
+		return server.ProtoClone(lvol), nil
+	}
+	resized := server.ProtoClone(lvol)
+	resized.SizeMib = updated.SizeMib
+	if !proto.Equal(updated, resized) {
+		err := status.Errorf(codes.InvalidArgument, "only size_mib of Lvol %s can be updated", lvol.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := verifyLvol(resized); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	params := bdevLvolResizeParams{
+		Name:      lvolBdevName(lvol),
+		SizeInMib: resized.SizeMib,
+	}
+	var result bdevLvolResizeResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_resize", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_resize", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not resize Lvol: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	if err := store.Save(s.store, resized.Name, resized); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.Lvols[resized.Name] = resized
+	s.mu.Unlock()
+	return server.ProtoClone(resized), nil
+}
+
+// ListLvols lists logical volumes of all logical volume stores
+func (s *Server) ListLvols(ctx context.Context, in *px.ListLvolsRequest) (*px.ListLvolsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.Lvol{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	var result []bdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	lvstores, err := s.getLvolStores(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	clusterSizes := make(map[string]int64, len(lvstores))
+	for i := range lvstores {
+		clusterSizes[lvstores[i].UUID] = lvstores[i].ClusterSize
+	}
+	Blobarray := []*px.Lvol{}
+	s.mu.RLock()
+	for i := range result {
+		r := &result[i]
+		lvsName, lvolName, ok := splitLvolAlias(r.ProductName, r.Aliases)
+		if !ok || r.DriverSpecific.Lvol.Snapshot {
+			continue
+		}
+		lvol := &px.Lvol{
+			Name:          server.ResourceIDToVolumeName(lvolName),
+			LvolStoreId:   &pc.ObjectKey{Value: server.ResourceIDToVolumeName(lvsName)},
+			ThinProvision: r.DriverSpecific.Lvol.ThinProvision,
+			SnapshotId:    s.cloneSnapshotID(server.ResourceIDToVolumeName(lvolName), r.DriverSpecific.Lvol.BaseSnapshot),
+		}
+		setLvolCapacity(lvol, r, clusterSizes[r.DriverSpecific.Lvol.LvolStoreUUID])
+		Blobarray = append(Blobarray, lvol)
+	}
+	s.mu.RUnlock()
+	Blobarray, token := server.Paginate(opts, Blobarray, (*px.Lvol).GetName)
+	return &px.ListLvolsResponse{Lvols: Blobarray, NextPageToken: token}, nil
+}
+
+// GetLvol gets a logical volume with its size and allocated capacity
+func (s *Server) GetLvol(ctx context.Context, in *px.GetLvolRequest) (*px.Lvol, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	bdev, err := s.getBdev(ctx, lvolBdevName(lvol))
+	if err != nil {
+		return nil, err
+	}
+	lvstores, err := s.getLvolStores(ctx, path.Base(lvol.LvolStoreId.Value))
+	if err != nil {
+		return nil, err
+	}
+	if len(lvstores) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(lvstores))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(lvol)
+	setLvolCapacity(response, bdev, lvstores[0].ClusterSize)
+	return response, nil
+}
+
+// LvolStats gets a logical volume stats
+func (s *Server) LvolStats(ctx context.Context, in *px.LvolStatsRequest) (*px.LvolStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Handle.Value); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Handle.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Handle.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := spdk.BdevGetIostatParams{
+		Name: lvolBdevName(lvol),
+	}
+	var result spdk.BdevGetIostatResult
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if len(result.Bdevs) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	return &px.LvolStatsResponse{Handle: in.Handle, Stats: &pb.VolumeStats{
+		ReadBytesCount:    int32(result.Bdevs[0].BytesRead),
+		ReadOpsCount:      int32(result.Bdevs[0].NumReadOps),
+		WriteBytesCount:   int32(result.Bdevs[0].BytesWritten),
+		WriteOpsCount:     int32(result.Bdevs[0].NumWriteOps),
+		UnmapBytesCount:   int32(result.Bdevs[0].BytesUnmapped),
+		UnmapOpsCount:     int32(result.Bdevs[0].NumUnmapOps),
+		ReadLatencyTicks:  int32(result.Bdevs[0].ReadLatencyTicks),
+		WriteLatencyTicks: int32(result.Bdevs[0].WriteLatencyTicks),
+		UnmapLatencyTicks: int32(result.Bdevs[0].UnmapLatencyTicks),
+	}}, nil
+}
+
+// verifyLvol checks lvol before it is created or resized
+func verifyLvol(lvol *px.Lvol) error {
+	switch {
+	case lvol.GetLvolStoreId().GetValue() == "":
+		return errors.New("lvol_store_id of Lvol has to be set")
+	case lvol.SizeMib <= 0:
+		return fmt.Errorf("size_mib has to be positive, got %d", lvol.SizeMib)
+	}
+	return nil
+}
+
+// lvolNames returns names of lvol and its store, if set
+func lvolNames(lvol *px.Lvol) []string {
+	if lvol.LvolStoreId == nil {
+		return []string{lvol.Name}
+	}
+	return []string{lvol.Name, lvol.LvolStoreId.Value}
+}
+
+// createLvol creates logical volume lvol in its logical volume store and
+// keeps lvol
+func (s *Server) createLvol(ctx context.Context, lvol *px.Lvol) (*px.Lvol, error) {
+	if lvol.SnapshotId != nil {
+		err := status.Errorf(codes.InvalidArgument, "Lvol %s of a Snapshot has to be created by CreateClone", lvol.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := verifyLvol(lvol); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.mu.RLock()
+	lvs, ok := s.Volumes.LvolStores[lvol.LvolStoreId.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", lvol.LvolStoreId.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevLvolCreateParams{
+		LvolName:      path.Base(lvol.Name),
+		SizeInMib:     lvol.SizeMib,
+		ThinProvision: lvol.ThinProvision,
+		LvsName:       path.Base(lvs.Name),
+	}
+	var result bdevLvolCreateResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_create", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_create", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Lvol: %s", params.LvolName)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(lvol)
+	clearLvolCapacity(response)
+	response.Uuid = &pc.Uuid{Value: string(result)}
+	if err := store.Save(s.store, lvol.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.Lvols[lvol.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// lvolBdevName returns name SPDK knows logical volume lvol by. Bdev of a
+// logical volume is named by its UUID, but also has alias
+// "<lvol store name>/<lvol name>".
+func lvolBdevName(lvol *px.Lvol) string {
+	return path.Base(lvol.LvolStoreId.GetValue()) + "/" + path.Base(lvol.Name)
+}
+
+// splitLvolAlias returns names of logical volume store and logical volume
+// of a bdev with productName and aliases, ok is false if the bdev is not a
+// logical volume
+func splitLvolAlias(productName string, aliases []string) (lvsName string, lvolName string, ok bool) {
+	if productName != lvolProductName || len(aliases) == 0 {
+		return "", "", false
+	}
+	return strings.Cut(aliases[0], "/")
+}
+
+// setLvolCapacity sets UUID, size and allocated capacity of lvol reported
+// by SPDK in bdev of a logical volume store with clusterSize
+func setLvolCapacity(lvol *px.Lvol, bdev *bdevGetBdevsResult, clusterSize int64) {
+	lvol.Uuid = &pc.Uuid{Value: bdev.UUID}
+	lvol.BlockSize = bdev.BlockSize
+	lvol.BlocksCount = bdev.NumBlocks
+	lvol.SizeMib = bdev.BlockSize * bdev.NumBlocks / mib
+	lvol.AllocatedBytes = bdev.DriverSpecific.Lvol.NumAllocatedClusters * clusterSize
+}
+
+// clearLvolCapacity clears size reported by SPDK, which is only returned
+// by Get and List and never kept
+func clearLvolCapacity(lvol *px.Lvol) {
+	lvol.BlockSize = 0
+	lvol.BlocksCount = 0
+	lvol.AllocatedBytes = 0
+}
@@ -0,0 +1,412 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+
+	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/resourceid"
+	"go.einride.tech/aip/resourcename"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateSnapshot creates a read-only snapshot of a logical volume
+func (s *Server) CreateSnapshot(ctx context.Context, in *px.CreateSnapshotRequest) (*px.Snapshot, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.SnapshotId != "" {
+		err := resourceid.ValidateUserSettable(in.SnapshotId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.SnapshotId, "name", in.Snapshot.Name)
+		resourceID = in.SnapshotId
+	}
+	in.Snapshot.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.Snapshot.Name)
+	defer unlock()
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	snapshot, ok := s.Volumes.Snapshots[in.Snapshot.Name]
+	s.mu.RUnlock()
+	if ok && snapshot.LvolId.GetValue() != in.Snapshot.LvolId.GetValue() {
+		err := status.Errorf(codes.AlreadyExists, "Snapshot %s already exists of Lvol %s", in.Snapshot.Name, snapshot.LvolId.GetValue())
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if ok {
+		slog.InfoContext(ctx, "Already existing Snapshot", "name", in.Snapshot.Name)
+		return snapshot, nil
+	}
+	// not found, so create a new one
+	if in.Snapshot.GetLvolId().GetValue() == "" {
+		err := status.Errorf(codes.InvalidArgument, "lvol_id of Snapshot has to be set")
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Snapshot.LvolId.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Snapshot.LvolId.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevLvolSnapshotParams{
+		LvolName:     lvolBdevName(lvol),
+		SnapshotName: resourceID,
+	}
+	var result bdevLvolSnapshotResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_snapshot", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_snapshot", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Snapshot: %s", params.SnapshotName)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := &px.Snapshot{
+		Name:        in.Snapshot.Name,
+		LvolId:      &pc.ObjectKey{Value: lvol.Name},
+		LvolStoreId: lvol.LvolStoreId,
+		SizeMib:     lvol.SizeMib,
+		Uuid:        &pc.Uuid{Value: string(result)},
+	}
+	if err := store.Save(s.store, response.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.Snapshots[response.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// DeleteSnapshot deletes a snapshot, which has to have no clones left
+func (s *Server) DeleteSnapshot(ctx context.Context, in *px.DeleteSnapshotRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	snapshot, ok := s.Volumes.Snapshots[in.Name]
+	clones := s.clonesOf(in.Name)
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			return &emptypb.Empty{}, nil
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if len(clones) != 0 {
+		err := status.Errorf(codes.FailedPrecondition, "Snapshot %s still has clones %v", in.Name, clones)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, snapshotBdevName(snapshot))
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
+	params := bdevLvolDeleteParams{
+		Name: snapshotBdevName(snapshot),
+	}
+	var result bdevLvolDeleteResult
+	err = server.Call(ctx, s.rpc, "bdev_lvol_delete", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_delete", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Snapshot: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	if err := store.Remove(s.store, snapshot.Name, snapshot); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.Volumes.Snapshots, snapshot.Name)
+	s.mu.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+// ListSnapshots lists snapshots with their clones
+func (s *Server) ListSnapshots(ctx context.Context, in *px.ListSnapshotsRequest) (*px.ListSnapshotsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.Snapshot{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	Blobarray := []*px.Snapshot{}
+	s.mu.RLock()
+	for _, snapshot := range s.Volumes.Snapshots {
+		Blobarray = append(Blobarray, s.snapshotWithClones(snapshot))
+	}
+	s.mu.RUnlock()
+	Blobarray, token := server.Paginate(opts, Blobarray, (*px.Snapshot).GetName)
+	return &px.ListSnapshotsResponse{Snapshots: Blobarray, NextPageToken: token}, nil
+}
+
+// GetSnapshot gets a snapshot with its clones
+func (s *Server) GetSnapshot(ctx context.Context, in *px.GetSnapshotRequest) (*px.Snapshot, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot, ok := s.Volumes.Snapshots[in.Name]
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	return s.snapshotWithClones(snapshot), nil
+}
+
+// CreateClone creates a thin provisioned logical volume from a snapshot
+func (s *Server) CreateClone(ctx context.Context, in *px.CreateCloneRequest) (*px.Lvol, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.LvolId != "" {
+		err := resourceid.ValidateUserSettable(in.LvolId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.LvolId, "name", in.Lvol.Name)
+		resourceID = in.LvolId
+	}
+	in.Lvol.Name = server.ResourceIDToVolumeName(resourceID)
+	if in.Lvol.GetSnapshotId().GetValue() == "" {
+		err := status.Errorf(codes.InvalidArgument, "snapshot_id of Lvol has to be set")
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if in.Lvol.SnapshotId.Value == in.Lvol.Name {
+		err := status.Errorf(codes.InvalidArgument, "Lvol %s cannot be a clone of itself", in.Lvol.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// the snapshot must not be deleted while it is cloned
+	unlock := s.names.Lock(in.Lvol.Name, in.Lvol.SnapshotId.Value)
+	defer unlock()
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Lvol.Name]
+	s.mu.RUnlock()
+	if ok && lvol.SnapshotId.GetValue() != in.Lvol.SnapshotId.GetValue() {
+		err := status.Errorf(codes.AlreadyExists, "Lvol %s already exists and is not a clone of Snapshot %s", in.Lvol.Name, in.Lvol.SnapshotId.GetValue())
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if ok {
+		slog.InfoContext(ctx, "Already existing Lvol", "name", in.Lvol.Name)
+		return lvol, nil
+	}
+	// not found, so create a new one
+	s.mu.RLock()
+	snapshot, ok := s.Volumes.Snapshots[in.Lvol.SnapshotId.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Lvol.SnapshotId.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevLvolCloneParams{
+		SnapshotName: snapshotBdevName(snapshot),
+		CloneName:    resourceID,
+	}
+	var result bdevLvolCloneResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_clone", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_clone", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Clone: %s", params.CloneName)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := &px.Lvol{
+		Name:          in.Lvol.Name,
+		LvolStoreId:   snapshot.LvolStoreId,
+		SizeMib:       snapshot.SizeMib,
+		ThinProvision: true,
+		Uuid:          &pc.Uuid{Value: string(result)},
+		SnapshotId:    &pc.ObjectKey{Value: snapshot.Name},
+	}
+	if err := store.Save(s.store, response.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.Lvols[response.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// InflateClone allocates all clusters of a clone and copies data of its
+// snapshot, after that the clone is a thick logical volume of its own
+func (s *Server) InflateClone(ctx context.Context, in *px.InflateCloneRequest) (*px.Lvol, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	lvol, ok := s.Volumes.Lvols[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if lvol.SnapshotId == nil {
+		err := status.Errorf(codes.FailedPrecondition, "Lvol %s is not a clone", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevLvolInflateParams{
+		Name: lvolBdevName(lvol),
+	}
+	var result bdevLvolInflateResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_inflate", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_inflate", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not inflate Clone: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(lvol)
+	response.SnapshotId = nil
+	response.ThinProvision = false
+	if err := store.Save(s.store, response.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.Lvols[response.Name] = response
+	s.mu.Unlock()
+	return server.ProtoClone(response), nil
+}
+
+// snapshotBdevName returns name SPDK knows snapshot by, see lvolBdevName
+func snapshotBdevName(snapshot *px.Snapshot) string {
+	return path.Base(snapshot.LvolStoreId.GetValue()) + "/" + path.Base(snapshot.Name)
+}
+
+// clonesOf returns names of logical volumes cloned from snapshot name,
+// caller holds s.mu
+func (s *Server) clonesOf(name string) []string {
+	var clones []string
+	for _, lvol := range s.Volumes.Lvols {
+		if lvol.SnapshotId.GetValue() == name {
+			clones = append(clones, lvol.Name)
+		}
+	}
+	sort.Strings(clones)
+	return clones
+}
+
+// snapshotWithClones returns copy of snapshot with its clones, caller
+// holds s.mu
+func (s *Server) snapshotWithClones(snapshot *px.Snapshot) *px.Snapshot {
+	response := server.ProtoClone(snapshot)
+	for _, clone := range s.clonesOf(snapshot.Name) {
+		response.CloneIds = append(response.CloneIds, &pc.ObjectKey{Value: clone})
+	}
+	return response
+}
+
+// cloneSnapshotID returns snapshot_id of logical volume name which SPDK
+// reports to be backed by baseSnapshot. Snapshotted logical volumes are
+// backed by their snapshot in SPDK too, but are not clones of it. Caller
+// holds s.mu.
+func (s *Server) cloneSnapshotID(name string, baseSnapshot string) *pc.ObjectKey {
+	if baseSnapshot == "" {
+		return nil
+	}
+	snapshotName := server.ResourceIDToVolumeName(baseSnapshot)
+	if snapshot, ok := s.Volumes.Snapshots[snapshotName]; ok && snapshot.LvolId.GetValue() == name {
+		return nil
+	}
+	return &pc.ObjectKey{Value: snapshotName}
+}
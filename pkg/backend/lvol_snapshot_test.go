@@ -0,0 +1,534 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testSnapshotID   = "golden"
+	testSnapshotName = server.ResourceIDToVolumeName(testSnapshotID)
+	testSnapshot     = px.Snapshot{
+		Name:        testSnapshotName,
+		LvolId:      &pc.ObjectKey{Value: testLvolName},
+		LvolStoreId: &pc.ObjectKey{Value: testLvolStoreName},
+		SizeMib:     16,
+		Uuid:        &pc.Uuid{Value: "3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93"},
+	}
+	testCloneID   = "vm1-disk"
+	testCloneName = server.ResourceIDToVolumeName(testCloneID)
+	testClone     = px.Lvol{
+		Name:          testCloneName,
+		LvolStoreId:   &pc.ObjectKey{Value: testLvolStoreName},
+		SizeMib:       16,
+		ThinProvision: true,
+		Uuid:          &pc.Uuid{Value: "9d4e1f2a-7b3c-4e5d-8a6f-0c1b2d3e4f50"},
+		SnapshotId:    &pc.ObjectKey{Value: testSnapshotName},
+	}
+)
+
+func TestBackEnd_CreateSnapshot(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		in      *px.Snapshot
+		out     *px.Snapshot
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"illegal resource_id": {
+			"CapitalLettersNotAllowed",
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: testLvolName}},
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("user-settable ID must only contain lowercase, numbers and hyphens (%v)", "got: 'C' in position 0"),
+			false,
+		},
+		"missing lvol": {
+			testSnapshotID,
+			&px.Snapshot{},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"lvol_id of Snapshot has to be set",
+			false,
+		},
+		"unknown lvol": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")}},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: testLvolName}},
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Snapshot: %v", testSnapshotID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: testLvolName}},
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_snapshot: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: testLvolName}},
+			&testSnapshot,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: testLvolName}},
+			&testSnapshot,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+		"already exists of other lvol": {
+			testSnapshotID,
+			&px.Snapshot{LvolId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("other-lvol")}},
+			nil,
+			[]string{},
+			codes.AlreadyExists,
+			fmt.Sprintf("Snapshot %v already exists of Lvol %v", testSnapshotName, testLvolName),
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+			if tt.exist {
+				testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = &testSnapshot
+			}
+
+			request := &px.CreateSnapshotRequest{Snapshot: tt.in, SnapshotId: tt.id}
+			response, err := testEnv.client.CreateSnapshot(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_DeleteSnapshot(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *emptypb.Empty
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+		clones  bool
+	}{
+		"snapshot with clones": {
+			testSnapshotID,
+			nil,
+			[]string{},
+			codes.FailedPrecondition,
+			fmt.Sprintf("Snapshot %v still has clones [%v]", testSnapshotName, testCloneName),
+			false,
+			true,
+		},
+		"valid request with invalid SPDK response": {
+			testSnapshotID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			"Could not delete Snapshot: lvs0/golden",
+			false,
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testSnapshotID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_delete: %v", "json response error: myopierr"),
+			false,
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testSnapshotID,
+			&emptypb.Empty{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+			false,
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+			false,
+		},
+		"unknown key with missing allowed": {
+			"unknown-id",
+			&emptypb.Empty{},
+			[]string{},
+			codes.OK,
+			"",
+			true,
+			false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = server.ProtoClone(&testSnapshot)
+			if tt.clones {
+				testEnv.opiSpdkServer.Volumes.Lvols[testCloneName] = server.ProtoClone(&testClone)
+			}
+
+			request := &px.DeleteSnapshotRequest{Name: server.ResourceIDToVolumeName(tt.in), AllowMissing: tt.missing}
+			response, err := testEnv.client.DeleteSnapshot(testEnv.ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if reflect.TypeOf(response) != reflect.TypeOf(tt.out) {
+				t.Error("response: expected", reflect.TypeOf(tt.out), "received", reflect.TypeOf(response))
+			}
+		})
+	}
+}
+
+func TestBackEnd_GetSnapshot(t *testing.T) {
+	withClones := server.ProtoClone(&testSnapshot)
+	withClones.CloneIds = []*pc.ObjectKey{{Value: testCloneName}}
+	tests := map[string]struct {
+		in      string
+		out     *px.Snapshot
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request": {
+			testSnapshotID,
+			withClones,
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment([]string{})
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = server.ProtoClone(&testSnapshot)
+			testEnv.opiSpdkServer.Volumes.Lvols[testCloneName] = server.ProtoClone(&testClone)
+
+			request := &px.GetSnapshotRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.GetSnapshot(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_ListSnapshots(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+
+	testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = server.ProtoClone(&testSnapshot)
+
+	request := &px.ListSnapshotsRequest{Parent: "todo"}
+	response, err := testEnv.client.ListSnapshots(testEnv.ctx, request)
+	if err != nil {
+		t.Fatalf("expected no error, received: %v", err)
+	}
+	if !server.EqualProtoSlices(response.GetSnapshots(), []*px.Snapshot{&testSnapshot}) {
+		t.Error("response: expected", &testSnapshot, "received", response.GetSnapshots())
+	}
+}
+
+func TestBackEnd_CreateClone(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		in      *px.Lvol
+		out     *px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"missing snapshot": {
+			testCloneID,
+			&px.Lvol{},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"snapshot_id of Lvol has to be set",
+			false,
+		},
+		"unknown snapshot": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")}},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Clone: %v", testCloneID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_clone: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			&testClone,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"9d4e1f2a-7b3c-4e5d-8a6f-0c1b2d3e4f50"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			&testClone,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+		"already exists as clone of other snapshot": {
+			testCloneID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("other-snapshot")}},
+			nil,
+			[]string{},
+			codes.AlreadyExists,
+			fmt.Sprintf("Lvol %v already exists and is not a clone of Snapshot %v", testCloneName, server.ResourceIDToVolumeName("other-snapshot")),
+			true,
+		},
+		"clone named after its snapshot": {
+			testSnapshotID,
+			&px.Lvol{SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("Lvol %v cannot be a clone of itself", testSnapshotName),
+			false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.Snapshots[testSnapshotName] = server.ProtoClone(&testSnapshot)
+			if tt.exist {
+				testEnv.opiSpdkServer.Volumes.Lvols[testCloneName] = &testClone
+			}
+
+			request := &px.CreateCloneRequest{Lvol: tt.in, LvolId: tt.id}
+			response, err := testEnv.client.CreateClone(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_InflateClone(t *testing.T) {
+	inflated := server.ProtoClone(&testClone)
+	inflated.SnapshotId = nil
+	inflated.ThinProvision = false
+	tests := map[string]struct {
+		in      string
+		out     *px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"not a clone": {
+			testLvolID,
+			nil,
+			[]string{},
+			codes.FailedPrecondition,
+			fmt.Sprintf("Lvol %v is not a clone", testLvolName),
+		},
+		"valid request with invalid SPDK response": {
+			testCloneID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			"Could not inflate Clone: lvs0/vm1-disk",
+		},
+		"valid request with error code from SPDK response": {
+			testCloneID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_inflate: %v", "json response error: myopierr"),
+		},
+		"valid request with valid SPDK response": {
+			testCloneID,
+			inflated,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+			testEnv.opiSpdkServer.Volumes.Lvols[testCloneName] = server.ProtoClone(&testClone)
+
+			request := &px.InflateCloneRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.InflateClone(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if clones := testEnv.opiSpdkServer.clonesOf(testSnapshotName); (tt.out == nil) != (len(clones) == 1) {
+				t.Error("clones: unexpected", clones)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+
+	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/fieldmask"
+	"go.einride.tech/aip/resourceid"
+	"go.einride.tech/aip/resourcename"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// lvolStoreVolumeKinds are kinds of volumes logical volume stores can be
+// created on, logical volumes cannot be nested
+var lvolStoreVolumeKinds = []string{"AioController", "NullDebug", "MallocVolume",
+	"NvmeRemoteNamespace", "EncryptedVolume", "QosVolume", "RaidVolume"}
+
+// CreateLvolStore creates a logical volume store on a volume
+func (s *Server) CreateLvolStore(ctx context.Context, in *px.CreateLvolStoreRequest) (*px.LvolStore, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.LvolStoreId != "" {
+		err := resourceid.ValidateUserSettable(in.LvolStoreId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.LvolStoreId, "name", in.LvolStore.Name)
+		resourceID = in.LvolStoreId
+	}
+	in.LvolStore.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.LvolStore.Name)
+	defer unlock()
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	lvs, ok := s.Volumes.LvolStores[in.LvolStore.Name]
+	s.mu.RUnlock()
+	if ok {
+		slog.InfoContext(ctx, "Already existing LvolStore", "name", in.LvolStore.Name)
+		return lvs, nil
+	}
+	// not found, so create a new one
+	return s.createLvolStore(ctx, in.LvolStore)
+}
+
+// DeleteLvolStore deletes a logical volume store, which has to have no
+// logical volumes left. Cascading deletes of the volume the store is
+// built on stop here rather than deleting the logical volumes as well.
+func (s *Server) DeleteLvolStore(ctx context.Context, in *px.DeleteLvolStoreRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	lvs, ok := s.Volumes.LvolStores[in.Name]
+	lvols := s.lvolsOfStore(in.Name)
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			return &emptypb.Empty{}, nil
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if len(lvols) != 0 {
+		err := status.Errorf(codes.FailedPrecondition, "LvolStore %s still has volumes %v", in.Name, lvols)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevLvolDeleteLvstoreParams{
+		LvsName: path.Base(lvs.Name),
+	}
+	var result bdevLvolDeleteLvstoreResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_delete_lvstore", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_delete_lvstore", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Lvol Store: %s", params.LvsName)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	if err := store.Remove(s.store, lvs.Name, lvs); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.Volumes.LvolStores, lvs.Name)
+	s.mu.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateLvolStore updates a logical volume store. Volume and cluster size
+// of a store are fixed, so only unchanged stores are accepted.
+func (s *Server) UpdateLvolStore(ctx context.Context, in *px.UpdateLvolStoreRequest) (*px.LvolStore, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.LvolStore.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.LvolStore.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	lvs, ok := s.Volumes.LvolStores[in.LvolStore.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			slog.InfoContext(ctx, "Got AllowMissing, create a new resource, don't return error when resource not found")
+			return s.createLvolStore(ctx, in.LvolStore)
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.LvolStore.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// update_mask = 2
+	if err := fieldmask.Validate(in.UpdateMask, in.LvolStore); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	updated := server.ProtoClone(lvs)
+	fieldmask.Update(in.UpdateMask, updated, in.LvolStore)
+	// output only fields are ignored
+	updated.Uuid = lvs.Uuid
+	clearLvolStoreCapacity(updated)
+	if !proto.Equal(updated, lvs) {
+		err := status.Errorf(codes.InvalidArgument, "LvolStore %s cannot be changed", lvs.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	return server.ProtoClone(lvs), nil
+}
+
+// ListLvolStores lists logical volume stores
+func (s *Server) ListLvolStores(ctx context.Context, in *px.ListLvolStoresRequest) (*px.ListLvolStoresResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.LvolStore{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	result, err := s.getLvolStores(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	Blobarray := make([]*px.LvolStore, len(result))
+	for i := range result {
+		r := &result[i]
+		Blobarray[i] = &px.LvolStore{
+			Name:        server.ResourceIDToVolumeName(r.Name),
+			VolumeId:    &pc.ObjectKey{Value: r.BaseBdev},
+			ClusterSize: r.ClusterSize,
+		}
+		setLvolStoreCapacity(Blobarray[i], r)
+	}
+	Blobarray, token := server.Paginate(opts, Blobarray, (*px.LvolStore).GetName)
+	return &px.ListLvolStoresResponse{LvolStores: Blobarray, NextPageToken: token}, nil
+}
+
+// GetLvolStore gets a logical volume store with its capacity
+func (s *Server) GetLvolStore(ctx context.Context, in *px.GetLvolStoreRequest) (*px.LvolStore, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	lvs, ok := s.Volumes.LvolStores[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	result, err := s.getLvolStores(ctx, path.Base(lvs.Name))
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(lvs)
+	setLvolStoreCapacity(response, &result[0])
+	return response, nil
+}
+
+// verifyLvolStore checks lvs before it is created
+func verifyLvolStore(lvs *px.LvolStore) error {
+	switch {
+	case lvs.GetVolumeId().GetValue() == "":
+		return errors.New("volume_id of LvolStore has to be set")
+	case lvs.ClusterSize < 0:
+		return fmt.Errorf("cluster_size cannot be negative, got %d", lvs.ClusterSize)
+	}
+	return nil
+}
+
+// createLvolStore creates logical volume store lvs in SPDK and keeps lvs
+func (s *Server) createLvolStore(ctx context.Context, lvs *px.LvolStore) (*px.LvolStore, error) {
+	if err := verifyLvolStore(lvs); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.VolumeIndex.ResolveKey(lvs.VolumeId, lvolStoreVolumeKinds...); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(lvs.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
+	params := bdevLvolCreateLvstoreParams{
+		BdevName:  lvs.VolumeId.Value,
+		LvsName:   path.Base(lvs.Name),
+		ClusterSz: lvs.ClusterSize,
+	}
+	var result bdevLvolCreateLvstoreResult
+	err = server.Call(ctx, s.rpc, "bdev_lvol_create_lvstore", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_create_lvstore", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Lvol Store: %s", params.LvsName)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(lvs)
+	clearLvolStoreCapacity(response)
+	response.Uuid = &pc.Uuid{Value: string(result)}
+	if err := store.Save(s.store, lvs.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.LvolStores[lvs.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// getLvolStores gets logical volume store lvsName from SPDK, all stores if
+// lvsName is empty
+func (s *Server) getLvolStores(ctx context.Context, lvsName string) ([]bdevLvolGetLvstoresResult, error) {
+	params := bdevLvolGetLvstoresParams{
+		LvsName: lvsName,
+	}
+	var result []bdevLvolGetLvstoresResult
+	err := server.Call(ctx, s.rpc, "bdev_lvol_get_lvstores", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_lvol_get_lvstores", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	return result, nil
+}
+
+// lvolsOfStore returns names of logical volumes and snapshots in store
+// name, caller holds s.mu
+func (s *Server) lvolsOfStore(name string) []string {
+	var lvols []string
+	for _, lvol := range s.Volumes.Lvols {
+		if lvol.LvolStoreId.GetValue() == name {
+			lvols = append(lvols, lvol.Name)
+		}
+	}
+	for _, snapshot := range s.Volumes.Snapshots {
+		if snapshot.LvolStoreId.GetValue() == name {
+			lvols = append(lvols, snapshot.Name)
+		}
+	}
+	sort.Strings(lvols)
+	return lvols
+}
+
+// setLvolStoreCapacity sets UUID and capacity of lvs reported by SPDK
+func setLvolStoreCapacity(lvs *px.LvolStore, result *bdevLvolGetLvstoresResult) {
+	lvs.Uuid = &pc.Uuid{Value: result.UUID}
+	lvs.TotalBytes = result.TotalDataClusters * result.ClusterSize
+	lvs.FreeBytes = result.FreeClusters * result.ClusterSize
+}
+
+// clearLvolStoreCapacity clears capacity of lvs, which is only reported
+// by Get and List and never kept
+func clearLvolStoreCapacity(lvs *px.LvolStore) {
+	lvs.TotalBytes = 0
+	lvs.FreeBytes = 0
+}
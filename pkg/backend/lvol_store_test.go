@@ -0,0 +1,502 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testLvolStoreID   = "lvs0"
+	testLvolStoreName = server.ResourceIDToVolumeName(testLvolStoreID)
+	testLvolStoreUUID = "a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10"
+	testLvolStore     = px.LvolStore{
+		VolumeId:    &pc.ObjectKey{Value: "Malloc0"},
+		ClusterSize: 4194304,
+	}
+	testLvolStoresResponse = `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"uuid":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10","name":"lvs0","base_bdev":"Malloc0","total_data_clusters":15,"free_clusters":10,"block_size":512,"cluster_size":4194304}]}`
+)
+
+func TestBackEnd_CreateLvolStore(t *testing.T) {
+	created := server.ProtoClone(&testLvolStore)
+	created.Name = testLvolStoreName
+	created.Uuid = &pc.Uuid{Value: testLvolStoreUUID}
+	tests := map[string]struct {
+		id      string
+		in      *px.LvolStore
+		out     *px.LvolStore
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"illegal resource_id": {
+			"CapitalLettersNotAllowed",
+			&testLvolStore,
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("user-settable ID must only contain lowercase, numbers and hyphens (%v)", "got: 'C' in position 0"),
+			false,
+		},
+		"missing volume": {
+			testLvolStoreID,
+			&px.LvolStore{},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"volume_id of LvolStore has to be set",
+			false,
+		},
+		"negative cluster size": {
+			testLvolStoreID,
+			&px.LvolStore{VolumeId: &pc.ObjectKey{Value: "Malloc0"}, ClusterSize: -1},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"cluster_size cannot be negative, got -1",
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testLvolStoreID,
+			&testLvolStore,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Lvol Store: %v", testLvolStoreID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testLvolStoreID,
+			&testLvolStore,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_create_lvstore: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testLvolStoreID,
+			&testLvolStore,
+			created,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testLvolStoreID,
+			&testLvolStore,
+			created,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+		"volume name": {
+			testLvolStoreID,
+			&px.LvolStore{VolumeId: &pc.ObjectKey{Value: testMallocVolumeName}, ClusterSize: 4194304},
+			&px.LvolStore{Name: testLvolStoreName, VolumeId: &pc.ObjectKey{Value: testMallocVolumeID},
+				ClusterSize: 4194304, Uuid: &pc.Uuid{Value: testLvolStoreUUID}},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"unknown volume name": {
+			testLvolStoreID,
+			&px.LvolStore{VolumeId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")}},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"lvol volume name": {
+			testLvolStoreID,
+			&px.LvolStore{VolumeId: &pc.ObjectKey{Value: testLvolName}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("volume %v is Lvol, expected one of %v", testLvolName, lvolStoreVolumeKinds),
+			false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			if tt.exist {
+				testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = created
+			}
+			testEnv.opiSpdkServer.VolumeIndex = &server.VolumeIndex{}
+			testEnv.opiSpdkServer.VolumeIndex.Register(testEnv.opiSpdkServer.VolumeProviders()...)
+			testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = server.ProtoClone(&testMallocVolume)
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+
+			request := &px.CreateLvolStoreRequest{LvolStore: tt.in, LvolStoreId: tt.id}
+			response, err := testEnv.client.CreateLvolStore(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateLvolStore(t *testing.T) {
+	existing := server.ProtoClone(&testLvolStore)
+	existing.Name = testLvolStoreName
+	existing.Uuid = &pc.Uuid{Value: testLvolStoreUUID}
+	tests := map[string]struct {
+		mask    *fieldmaskpb.FieldMask
+		in      *px.LvolStore
+		out     *px.LvolStore
+		errCode codes.Code
+		errMsg  string
+	}{
+		"invalid fieldmask": {
+			&fieldmaskpb.FieldMask{Paths: []string{"*", "author"}},
+			&px.LvolStore{Name: testLvolStoreName},
+			nil,
+			codes.Unknown,
+			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
+		},
+		"unchanged": {
+			nil,
+			&px.LvolStore{Name: testLvolStoreName, VolumeId: &pc.ObjectKey{Value: "Malloc0"}, ClusterSize: 4194304, FreeBytes: 1},
+			existing,
+			codes.OK,
+			"",
+		},
+		"changed cluster size": {
+			&fieldmaskpb.FieldMask{Paths: []string{"cluster_size"}},
+			&px.LvolStore{Name: testLvolStoreName, ClusterSize: 1048576},
+			nil,
+			codes.InvalidArgument,
+			fmt.Sprintf("LvolStore %v cannot be changed", testLvolStoreName),
+		},
+		"valid request with unknown key": {
+			nil,
+			&px.LvolStore{Name: server.ResourceIDToVolumeName("unknown-id")},
+			nil,
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			nil,
+			&px.LvolStore{Name: "-ABC-DEF"},
+			nil,
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment([]string{})
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = existing
+
+			request := &px.UpdateLvolStoreRequest{LvolStore: tt.in, UpdateMask: tt.mask}
+			response, err := testEnv.client.UpdateLvolStore(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_ListLvolStores(t *testing.T) {
+	tests := map[string]struct {
+		out     []*px.LvolStore
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with empty result SPDK response": {
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.OK,
+			"",
+		},
+		"valid request with error code from SPDK response": {
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_get_lvstores: %v", "json response error: myopierr"),
+		},
+		"valid request with valid SPDK response": {
+			[]*px.LvolStore{
+				{
+					Name:        testLvolStoreName,
+					VolumeId:    &pc.ObjectKey{Value: "Malloc0"},
+					ClusterSize: 4194304,
+					Uuid:        &pc.Uuid{Value: testLvolStoreUUID},
+					TotalBytes:  15 * 4194304,
+					FreeBytes:   10 * 4194304,
+				},
+			},
+			[]string{testLvolStoresResponse},
+			codes.OK,
+			"",
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			request := &px.ListLvolStoresRequest{Parent: "todo"}
+			response, err := testEnv.client.ListLvolStores(testEnv.ctx, request)
+
+			if !server.EqualProtoSlices(response.GetLvolStores(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetLvolStores())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_GetLvolStore(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *px.LvolStore
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			testLvolStoreID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_get_lvstores: %v", "json response error: myopierr"),
+		},
+		"valid request with empty result SPDK response": {
+			testLvolStoreID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testLvolStoreID,
+			&px.LvolStore{
+				Name:        testLvolStoreName,
+				VolumeId:    &pc.ObjectKey{Value: "Malloc0"},
+				ClusterSize: 4194304,
+				Uuid:        &pc.Uuid{Value: testLvolStoreUUID},
+				TotalBytes:  15 * 4194304,
+				FreeBytes:   10 * 4194304,
+			},
+			[]string{testLvolStoresResponse},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvs := server.ProtoClone(&testLvolStore)
+			lvs.Name = testLvolStoreName
+			testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = lvs
+
+			request := &px.GetLvolStoreRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.GetLvolStore(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_DeleteLvolStore(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *emptypb.Empty
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+		lvols   bool
+	}{
+		"store with lvols": {
+			testLvolStoreID,
+			nil,
+			[]string{},
+			codes.FailedPrecondition,
+			fmt.Sprintf("LvolStore %v still has volumes [%v]", testLvolStoreName, testLvolName),
+			false,
+			true,
+		},
+		"valid request with invalid SPDK response": {
+			testLvolStoreID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Lvol Store: %s", testLvolStoreID),
+			false,
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testLvolStoreID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_delete_lvstore: %v", "json response error: myopierr"),
+			false,
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testLvolStoreID,
+			&emptypb.Empty{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+			false,
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+			false,
+		},
+		"unknown key with missing allowed": {
+			"unknown-id",
+			&emptypb.Empty{},
+			[]string{},
+			codes.OK,
+			"",
+			true,
+			false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvs := server.ProtoClone(&testLvolStore)
+			lvs.Name = testLvolStoreName
+			testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = lvs
+			if tt.lvols {
+				lvol := server.ProtoClone(&testLvol)
+				lvol.Name = testLvolName
+				testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+			}
+
+			request := &px.DeleteLvolStoreRequest{Name: server.ResourceIDToVolumeName(tt.in), AllowMissing: tt.missing}
+			response, err := testEnv.client.DeleteLvolStore(testEnv.ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if reflect.TypeOf(response) != reflect.TypeOf(tt.out) {
+				t.Error("response: expected", reflect.TypeOf(tt.out), "received", reflect.TypeOf(response))
+			}
+		})
+	}
+}
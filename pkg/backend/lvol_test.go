@@ -0,0 +1,602 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testLvolID   = "lvol0"
+	testLvolName = server.ResourceIDToVolumeName(testLvolID)
+	testLvolUUID = "6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11"
+	testLvol     = px.Lvol{
+		LvolStoreId:   &pc.ObjectKey{Value: testLvolStoreName},
+		SizeMib:       16,
+		ThinProvision: true,
+	}
+	testLvolBdevResponse = `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11","aliases":["lvs0/lvol0"],"product_name":"Logical Volume","block_size":512,"num_blocks":32768,` +
+		`"uuid":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11","driver_specific":{"lvol":{"lvol_store_uuid":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10","thin_provision":true,"num_allocated_clusters":2}}}]}`
+)
+
+func TestBackEnd_CreateLvol(t *testing.T) {
+	created := server.ProtoClone(&testLvol)
+	created.Name = testLvolName
+	created.Uuid = &pc.Uuid{Value: testLvolUUID}
+	tests := map[string]struct {
+		id      string
+		in      *px.Lvol
+		out     *px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"illegal resource_id": {
+			"CapitalLettersNotAllowed",
+			&testLvol,
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("user-settable ID must only contain lowercase, numbers and hyphens (%v)", "got: 'C' in position 0"),
+			false,
+		},
+		"missing lvol store": {
+			testLvolID,
+			&px.Lvol{SizeMib: 16},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"lvol_store_id of Lvol has to be set",
+			false,
+		},
+		"zero size": {
+			testLvolID,
+			&px.Lvol{LvolStoreId: &pc.ObjectKey{Value: testLvolStoreName}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"size_mib has to be positive, got 0",
+			false,
+		},
+		"snapshot id set": {
+			testLvolID,
+			&px.Lvol{LvolStoreId: &pc.ObjectKey{Value: testLvolStoreName}, SizeMib: 16, SnapshotId: &pc.ObjectKey{Value: testSnapshotName}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("Lvol %v of a Snapshot has to be created by CreateClone", testLvolName),
+			false,
+		},
+		"unknown lvol store": {
+			testLvolID,
+			&px.Lvol{LvolStoreId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")}, SizeMib: 16},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testLvolID,
+			&testLvol,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Lvol: %v", testLvolID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testLvolID,
+			&testLvol,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_create: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testLvolID,
+			&testLvol,
+			created,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testLvolID,
+			&testLvol,
+			created,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+		"already exists in other lvol store": {
+			testLvolID,
+			&px.Lvol{LvolStoreId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName("lvs1")}, SizeMib: 16},
+			nil,
+			[]string{},
+			codes.AlreadyExists,
+			fmt.Sprintf("Lvol %v already exists in LvolStore %v", testLvolName, testLvolStoreName),
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvs := server.ProtoClone(&testLvolStore)
+			lvs.Name = testLvolStoreName
+			testEnv.opiSpdkServer.Volumes.LvolStores[testLvolStoreName] = lvs
+			if tt.exist {
+				testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = created
+			}
+
+			request := &px.CreateLvolRequest{Lvol: tt.in, LvolId: tt.id}
+			response, err := testEnv.client.CreateLvol(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateLvol(t *testing.T) {
+	grown := server.ProtoClone(&testLvol)
+	grown.Name = testLvolName
+	grown.SizeMib = 32
+	sizeMask := &fieldmaskpb.FieldMask{Paths: []string{"size_mib"}}
+	tests := map[string]struct {
+		mask    *fieldmaskpb.FieldMask
+		in      *px.Lvol
+		out     *px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		size    int64
+	}{
+		"invalid fieldmask": {
+			&fieldmaskpb.FieldMask{Paths: []string{"*", "author"}},
+			&px.Lvol{Name: testLvolName},
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
+			16,
+		},
+		"unchanged": {
+			sizeMask,
+			&px.Lvol{Name: testLvolName, SizeMib: 16},
+			&px.Lvol{Name: testLvolName, LvolStoreId: &pc.ObjectKey{Value: testLvolStoreName}, SizeMib: 16, ThinProvision: true},
+			[]string{},
+			codes.OK,
+			"",
+			16,
+		},
+		"changed provisioning": {
+			&fieldmaskpb.FieldMask{Paths: []string{"thin_provision"}},
+			&px.Lvol{Name: testLvolName},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("only size_mib of Lvol %v can be updated", testLvolName),
+			16,
+		},
+		"zero size": {
+			sizeMask,
+			&px.Lvol{Name: testLvolName},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"size_mib has to be positive, got 0",
+			16,
+		},
+		"valid request with invalid SPDK response": {
+			sizeMask,
+			grown,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			"Could not resize Lvol: lvs0/lvol0",
+			16,
+		},
+		"valid request with valid SPDK response": {
+			sizeMask,
+			grown,
+			grown,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			32,
+		},
+		"valid request with unknown key": {
+			nil,
+			&px.Lvol{Name: server.ResourceIDToVolumeName("unknown-id")},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			16,
+		},
+		"malformed name": {
+			nil,
+			&px.Lvol{Name: "-ABC-DEF"},
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+			16,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+
+			request := &px.UpdateLvolRequest{Lvol: tt.in, UpdateMask: tt.mask}
+			response, err := testEnv.client.UpdateLvol(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if size := testEnv.opiSpdkServer.Volumes.Lvols[testLvolName].SizeMib; size != tt.size {
+				t.Error("size: expected", tt.size, "received", size)
+			}
+		})
+	}
+}
+
+func TestBackEnd_ListLvols(t *testing.T) {
+	tests := map[string]struct {
+		out     []*px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_bdevs: %v", "json response error: myopierr"),
+		},
+		"valid request with empty result SPDK response": {
+			nil,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"Malloc0","product_name":"Malloc disk","block_size":512,"num_blocks":131072},` +
+					`{"name":"3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93","aliases":["lvs0/golden"],"product_name":"Logical Volume","block_size":512,"num_blocks":32768,"driver_specific":{"lvol":{"snapshot":true}}}]}`,
+				testLvolStoresResponse,
+			},
+			codes.OK,
+			"",
+		},
+		"valid request with valid SPDK response": {
+			[]*px.Lvol{
+				{
+					Name:           testLvolName,
+					LvolStoreId:    &pc.ObjectKey{Value: testLvolStoreName},
+					SizeMib:        16,
+					ThinProvision:  true,
+					Uuid:           &pc.Uuid{Value: testLvolUUID},
+					BlockSize:      512,
+					BlocksCount:    32768,
+					AllocatedBytes: 2 * 4194304,
+				},
+			},
+			[]string{testLvolBdevResponse, testLvolStoresResponse},
+			codes.OK,
+			"",
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			request := &px.ListLvolsRequest{Parent: "todo"}
+			response, err := testEnv.client.ListLvols(testEnv.ctx, request)
+
+			if !server.EqualProtoSlices(response.GetLvols(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetLvols())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_GetLvol(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *px.Lvol
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			testLvolID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_bdevs: %v", "json response error: myopierr"),
+		},
+		"missing lvol store": {
+			testLvolID,
+			nil,
+			[]string{testLvolBdevResponse, `{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testLvolID,
+			&px.Lvol{
+				Name:           testLvolName,
+				LvolStoreId:    &pc.ObjectKey{Value: testLvolStoreName},
+				SizeMib:        16,
+				ThinProvision:  true,
+				Uuid:           &pc.Uuid{Value: testLvolUUID},
+				BlockSize:      512,
+				BlocksCount:    32768,
+				AllocatedBytes: 2 * 4194304,
+			},
+			[]string{testLvolBdevResponse, testLvolStoresResponse},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+
+			request := &px.GetLvolRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.GetLvol(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_LvolStats(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *pb.VolumeStats
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with empty result SPDK response": {
+			testLvolID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":{"tick_rate":0,"ticks":0,"bdevs":[]}}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testLvolID,
+			&pb.VolumeStats{
+				ReadBytesCount:  1,
+				ReadOpsCount:    2,
+				WriteBytesCount: 3,
+				WriteOpsCount:   4,
+			},
+			[]string{`{"jsonrpc":"2.0","id":%d,"result":{"tick_rate":2490000000,"ticks":18787040917434338,"bdevs":[{"name":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11","bytes_read":1,"num_read_ops":2,"bytes_written":3,"num_write_ops":4}]}}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+
+			request := &px.LvolStatsRequest{Handle: &pc.ObjectKey{Value: server.ResourceIDToVolumeName(tt.in)}}
+			response, err := testEnv.client.LvolStats(testEnv.ctx, request)
+
+			if !proto.Equal(response.GetStats(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetStats())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_DeleteLvol(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *emptypb.Empty
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+	}{
+		"valid request with invalid SPDK response": {
+			testLvolID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			"Could not delete Lvol: lvs0/lvol0",
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testLvolID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_lvol_delete: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testLvolID,
+			&emptypb.Empty{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"unknown key with missing allowed": {
+			"unknown-id",
+			&emptypb.Empty{},
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			lvol := server.ProtoClone(&testLvol)
+			lvol.Name = testLvolName
+			testEnv.opiSpdkServer.Volumes.Lvols[testLvolName] = lvol
+
+			request := &px.DeleteLvolRequest{Name: server.ResourceIDToVolumeName(tt.in), AllowMissing: tt.missing}
+			response, err := testEnv.client.DeleteLvol(testEnv.ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if reflect.TypeOf(response) != reflect.TypeOf(tt.out) {
+				t.Error("response: expected", reflect.TypeOf(tt.out), "received", reflect.TypeOf(response))
+			}
+		})
+	}
+}
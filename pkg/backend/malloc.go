@@ -0,0 +1,373 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+
+	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/fieldmask"
+	"go.einride.tech/aip/resourceid"
+	"go.einride.tech/aip/resourcename"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// mallocBlockAlignment is what SPDK requires block size of Malloc Block
+// Devices to be a multiple of
+const mallocBlockAlignment = 512
+
+// CreateMallocVolume creates a Malloc volume
+func (s *Server) CreateMallocVolume(ctx context.Context, in *px.CreateMallocVolumeRequest) (*px.MallocVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.MallocVolumeId != "" {
+		err := resourceid.ValidateUserSettable(in.MallocVolumeId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.MallocVolumeId, "name", in.MallocVolume.Name)
+		resourceID = in.MallocVolumeId
+	}
+	in.MallocVolume.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.MallocVolume.Name)
+	defer unlock()
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	volume, ok := s.Volumes.MallocVolumes[in.MallocVolume.Name]
+	s.mu.RUnlock()
+	if ok {
+		slog.InfoContext(ctx, "Already existing MallocVolume", "name", in.MallocVolume.Name)
+		return volume, nil
+	}
+	// not found, so create a new one
+	return s.createMallocVolume(ctx, in.MallocVolume)
+}
+
+// DeleteMallocVolume deletes a Malloc volume
+func (s *Server) DeleteMallocVolume(ctx context.Context, in *px.DeleteMallocVolumeRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.MallocVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			return &emptypb.Empty{}, nil
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, path.Base(volume.Name))
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
+	if err := s.deleteMallocBdev(ctx, path.Base(volume.Name)); err != nil {
+		return nil, err
+	}
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.Volumes.MallocVolumes, volume.Name)
+	s.mu.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateMallocVolume updates a Malloc volume. SPDK cannot resize Malloc
+// Block Devices, so the bdev is recreated and its data is lost.
+func (s *Server) UpdateMallocVolume(ctx context.Context, in *px.UpdateMallocVolumeRequest) (*px.MallocVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.MallocVolume.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.MallocVolume.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.MallocVolumes[in.MallocVolume.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			slog.InfoContext(ctx, "Got AllowMissing, create a new resource, don't return error when resource not found")
+			return s.createMallocVolume(ctx, in.MallocVolume)
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.MallocVolume.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	resourceID := path.Base(volume.Name)
+	// update_mask = 2
+	if err := fieldmask.Validate(in.UpdateMask, in.MallocVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	updated := server.ProtoClone(volume)
+	fieldmask.Update(in.UpdateMask, updated, in.MallocVolume)
+	if proto.Equal(updated, volume) {
+		return server.ProtoClone(volume), nil
+	}
+	if err := validateMallocGeometry(updated); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// the bdev is deleted and created again
+	released, err := s.Consumers.ReleaseBdev(ctx, volume.Name, resourceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
+	bdev, err := s.getBdev(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if bdev.Claimed {
+		err := status.Errorf(codes.FailedPrecondition, "MallocVolume %s is in use and cannot be updated", volume.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := s.deleteMallocBdev(ctx, resourceID); err != nil {
+		return nil, err
+	}
+	response, err := s.createMallocVolume(ctx, updated)
+	if err != nil {
+		return nil, s.restoreMallocBdev(ctx, volume, err)
+	}
+	return response, nil
+}
+
+// restoreMallocBdev creates bdev of volume again after its update failed
+// with err. The resource is kept even if the bdev cannot be restored, the
+// returned error tells the client that its bdev is missing.
+func (s *Server) restoreMallocBdev(ctx context.Context, volume *px.MallocVolume, err error) error {
+	if _, rerr := s.createMallocVolume(ctx, volume); rerr != nil {
+		slog.ErrorContext(ctx, "Unable to restore Malloc Dev after failed update", "name", volume.Name, "err", rerr)
+		return status.Errorf(codes.Internal, "bdev of MallocVolume %s is missing after failed update: %v",
+			volume.Name, status.Convert(err).Message())
+	}
+	return err
+}
+
+// ListMallocVolumes lists Malloc volumes
+func (s *Server) ListMallocVolumes(ctx context.Context, in *px.ListMallocVolumesRequest) (*px.ListMallocVolumesResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.MallocVolume{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	var result []spdk.BdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	Blobarray := make([]*px.MallocVolume, len(result))
+	for i := range result {
+		r := &result[i]
+		Blobarray[i] = &px.MallocVolume{Name: r.Name, Uuid: &pc.Uuid{Value: r.UUID}, BlockSize: r.BlockSize, BlocksCount: r.NumBlocks}
+	}
+	Blobarray, token := server.Paginate(opts, Blobarray, (*px.MallocVolume).GetName)
+	return &px.ListMallocVolumesResponse{MallocVolumes: Blobarray, NextPageToken: token}, nil
+}
+
+// GetMallocVolume gets a Malloc volume
+func (s *Server) GetMallocVolume(ctx context.Context, in *px.GetMallocVolumeRequest) (*px.MallocVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.MallocVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	bdev, err := s.getBdev(ctx, path.Base(volume.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &px.MallocVolume{Name: bdev.Name, Uuid: &pc.Uuid{Value: bdev.UUID}, BlockSize: bdev.BlockSize, BlocksCount: bdev.NumBlocks}, nil
+}
+
+// MallocVolumeStats gets a Malloc volume stats
+func (s *Server) MallocVolumeStats(ctx context.Context, in *px.MallocVolumeStatsRequest) (*px.MallocVolumeStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Handle.Value); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.MallocVolumes[in.Handle.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Handle.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := spdk.BdevGetIostatParams{
+		Name: path.Base(volume.Name),
+	}
+	var result spdk.BdevGetIostatResult
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if len(result.Bdevs) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	return &px.MallocVolumeStatsResponse{Stats: &pb.VolumeStats{
+		ReadBytesCount:    int32(result.Bdevs[0].BytesRead),
+		ReadOpsCount:      int32(result.Bdevs[0].NumReadOps),
+		WriteBytesCount:   int32(result.Bdevs[0].BytesWritten),
+		WriteOpsCount:     int32(result.Bdevs[0].NumWriteOps),
+		UnmapBytesCount:   int32(result.Bdevs[0].BytesUnmapped),
+		UnmapOpsCount:     int32(result.Bdevs[0].NumUnmapOps),
+		ReadLatencyTicks:  int32(result.Bdevs[0].ReadLatencyTicks),
+		WriteLatencyTicks: int32(result.Bdevs[0].WriteLatencyTicks),
+		UnmapLatencyTicks: int32(result.Bdevs[0].UnmapLatencyTicks),
+	}}, nil
+}
+
+// validateMallocGeometry checks geometry of volume against rules SPDK
+// applies to Malloc Block Devices
+func validateMallocGeometry(volume *px.MallocVolume) error {
+	switch {
+	case volume.BlockSize <= 0 || volume.BlockSize%mallocBlockAlignment != 0:
+		return fmt.Errorf("block_size has to be a positive multiple of %d, got %d", mallocBlockAlignment, volume.BlockSize)
+	case volume.BlocksCount <= 0:
+		return fmt.Errorf("blocks_count has to be positive, got %d", volume.BlocksCount)
+	}
+	return nil
+}
+
+// createMallocVolume creates Malloc Block Device of volume and keeps volume
+func (s *Server) createMallocVolume(ctx context.Context, volume *px.MallocVolume) (*px.MallocVolume, error) {
+	if err := validateMallocGeometry(volume); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevMallocCreateParams{
+		Name:      path.Base(volume.Name),
+		BlockSize: volume.BlockSize,
+		NumBlocks: volume.BlocksCount,
+		UUID:      volume.GetUuid().GetValue(),
+	}
+	var result spdk.BdevAMalloCreateResult
+	err := server.Call(ctx, s.rpc, "bdev_malloc_create", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_malloc_create", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Malloc Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(volume)
+	if err := store.Save(s.store, volume.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.MallocVolumes[volume.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// deleteMallocBdev deletes Malloc Block Device name
+func (s *Server) deleteMallocBdev(ctx context.Context, name string) error {
+	params := spdk.BdevMallocDeleteParams{
+		Name: name,
+	}
+	var result spdk.BdevMallocDeleteResult
+	err := server.Call(ctx, s.rpc, "bdev_malloc_delete", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_malloc_delete", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Malloc Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
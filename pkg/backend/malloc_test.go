@@ -0,0 +1,708 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testMallocVolumeID   = "mytest"
+	testMallocVolumeName = server.ResourceIDToVolumeName(testMallocVolumeID)
+	testMallocVolume     = px.MallocVolume{
+		BlockSize:   512,
+		BlocksCount: 64,
+	}
+)
+
+func TestBackEnd_CreateMallocVolume(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		in      *px.MallocVolume
+		out     *px.MallocVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"illegal resource_id": {
+			"CapitalLettersNotAllowed",
+			&testMallocVolume,
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("user-settable ID must only contain lowercase, numbers and hyphens (%v)", "got: 'C' in position 0"),
+			false,
+		},
+		"block size not multiple of 512": {
+			testMallocVolumeID,
+			&px.MallocVolume{BlockSize: 520, BlocksCount: 64},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"block_size has to be a positive multiple of 512, got 520",
+			false,
+		},
+		"zero blocks count": {
+			testMallocVolumeID,
+			&px.MallocVolume{BlockSize: 512},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"blocks_count has to be positive, got 0",
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Malloc Dev: %v", testMallocVolumeID),
+			false,
+		},
+		"valid request with empty SPDK response": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			nil,
+			[]string{""},
+			codes.Unknown,
+			fmt.Sprintf("bdev_malloc_create: %v", "EOF"),
+			false,
+		},
+		"valid request with ID mismatch SPDK response": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			nil,
+			[]string{`{"id":0,"error":{"code":0,"message":""},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_malloc_create: %v", "json response ID mismatch"),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_malloc_create: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			&testMallocVolume,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testMallocVolumeID,
+			&testMallocVolume,
+			&testMallocVolume,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			if tt.exist {
+				testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = &testMallocVolume
+			}
+			if tt.out != nil {
+				tt.out.Name = testMallocVolumeName
+			}
+
+			request := &px.CreateMallocVolumeRequest{MallocVolume: tt.in, MallocVolumeId: tt.id}
+			response, err := testEnv.client.CreateMallocVolume(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateMallocVolume(t *testing.T) {
+	grown := &px.MallocVolume{Name: testMallocVolumeName, BlockSize: 512, BlocksCount: 128}
+	countMask := &fieldmaskpb.FieldMask{Paths: []string{"blocks_count"}}
+	tests := map[string]struct {
+		mask    *fieldmaskpb.FieldMask
+		in      *px.MallocVolume
+		out     *px.MallocVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+		kept    bool
+	}{
+		"invalid fieldmask": {
+			&fieldmaskpb.FieldMask{Paths: []string{"*", "author"}},
+			&px.MallocVolume{Name: testMallocVolumeName},
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
+			false,
+			true,
+		},
+		"unchanged": {
+			nil,
+			&px.MallocVolume{Name: testMallocVolumeName, BlockSize: 512, BlocksCount: 64},
+			&px.MallocVolume{Name: testMallocVolumeName, BlockSize: 512, BlocksCount: 64},
+			[]string{},
+			codes.OK,
+			"",
+			false,
+			true,
+		},
+		"invalid geometry": {
+			nil,
+			&px.MallocVolume{Name: testMallocVolumeName, BlockSize: 520, BlocksCount: 64},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"block_size has to be a positive multiple of 512, got 520",
+			false,
+			true,
+		},
+		"bdev in use": {
+			countMask,
+			grown,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":64,"claimed":true}]}`},
+			codes.FailedPrecondition,
+			fmt.Sprintf("MallocVolume %v is in use and cannot be updated", testMallocVolumeName),
+			false,
+			true,
+		},
+		"delete fails": {
+			countMask,
+			grown,
+			nil,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":64}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`,
+			},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Malloc Dev: %v", testMallocVolumeID),
+			false,
+			true,
+		},
+		"create fails and is rolled back": {
+			countMask,
+			grown,
+			nil,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":64}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":""}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`,
+			},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Malloc Dev: %v", testMallocVolumeID),
+			false,
+			true,
+		},
+		"create and rollback fail": {
+			countMask,
+			grown,
+			nil,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":64}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":""}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":""}`,
+			},
+			codes.Internal,
+			fmt.Sprintf("bdev of MallocVolume %v is missing after failed update: Could not create Malloc Dev: %v",
+				testMallocVolumeName, testMallocVolumeID),
+			false,
+			true,
+		},
+		"valid request with valid SPDK response": {
+			countMask,
+			grown,
+			grown,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":512,"num_blocks":64}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`,
+			},
+			codes.OK,
+			"",
+			false,
+			true,
+		},
+		"valid request with unknown key": {
+			nil,
+			&px.MallocVolume{Name: server.ResourceIDToVolumeName("unknown-id")},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+			true,
+		},
+		"unknown key with missing allowed": {
+			nil,
+			&px.MallocVolume{Name: server.ResourceIDToVolumeName("unknown-id"), BlockSize: 512, BlocksCount: 64},
+			&px.MallocVolume{Name: server.ResourceIDToVolumeName("unknown-id"), BlockSize: 512, BlocksCount: 64},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":"unknown-id"}`},
+			codes.OK,
+			"",
+			true,
+			true,
+		},
+		"malformed name": {
+			nil,
+			&px.MallocVolume{Name: "-ABC-DEF"},
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+			false,
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			volume := server.ProtoClone(&testMallocVolume)
+			volume.Name = testMallocVolumeName
+			testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = volume
+
+			request := &px.UpdateMallocVolumeRequest{MallocVolume: tt.in, UpdateMask: tt.mask, AllowMissing: tt.missing}
+			response, err := testEnv.client.UpdateMallocVolume(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if _, ok := testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName]; ok != tt.kept {
+				t.Error("volume kept: expected", tt.kept, "received", ok)
+			}
+		})
+	}
+}
+
+func TestBackEnd_ListMallocVolumes(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     []*px.MallocVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		size    int32
+		token   string
+	}{
+		"valid request with empty result SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.OK,
+			"",
+			0,
+			"",
+		},
+		"valid request with error code from SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_bdevs: %v", "json response error: myopierr"),
+			0,
+			"",
+		},
+		"valid request with valid SPDK response": {
+			testMallocVolumeID,
+			[]*px.MallocVolume{
+				{
+					Name:        "Malloc0",
+					Uuid:        &pc.Uuid{Value: "043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},
+					BlockSize:   512,
+					BlocksCount: 131072,
+				},
+				{
+					Name:        "Malloc1",
+					Uuid:        &pc.Uuid{Value: "11d3902e-d9bb-49a7-bb27-cd7261ef3217"},
+					BlockSize:   512,
+					BlocksCount: 131072,
+				},
+			},
+			[]string{`{"jsonrpc":"2.0","id":%d,"result":[` +
+				`{"name":"Malloc0","block_size":512,"num_blocks":131072,"uuid":"043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},` +
+				`{"name":"Malloc1","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217"}]}`},
+			codes.OK,
+			"",
+			0,
+			"",
+		},
+		"pagination negative": {
+			testMallocVolumeID,
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"negative PageSize is not allowed",
+			-10,
+			"",
+		},
+		"pagination": {
+			testMallocVolumeID,
+			[]*px.MallocVolume{
+				{
+					Name:        "Malloc0",
+					Uuid:        &pc.Uuid{Value: "043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},
+					BlockSize:   512,
+					BlocksCount: 131072,
+				},
+			},
+			[]string{`{"jsonrpc":"2.0","id":%d,"result":[` +
+				`{"name":"Malloc0","block_size":512,"num_blocks":131072,"uuid":"043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},` +
+				`{"name":"Malloc1","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217"}]}`},
+			codes.OK,
+			"",
+			1,
+			"",
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			request := &px.ListMallocVolumesRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			response, err := testEnv.client.ListMallocVolumes(testEnv.ctx, request)
+
+			if !server.EqualProtoSlices(response.GetMallocVolumes(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetMallocVolumes())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			// Empty NextPageToken indicates end of results list
+			if tt.size != 1 && response.GetNextPageToken() != "" {
+				t.Error("Expected end of results, receieved non-empty next page token", response.GetNextPageToken())
+			}
+		})
+	}
+}
+
+func TestBackEnd_GetMallocVolume(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *px.MallocVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with invalid marshal SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_bdevs: %v", "json: cannot unmarshal bool into Go value of type []backend.bdevGetBdevsResult"),
+		},
+		"valid request with error code from SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_bdevs: %v", "json response error: myopierr"),
+		},
+		"valid request with empty result SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testMallocVolumeID,
+			&px.MallocVolume{
+				Name:        "mytest",
+				Uuid:        &pc.Uuid{Value: "043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},
+				BlockSize:   512,
+				BlocksCount: 64,
+			},
+			[]string{`{"jsonrpc":"2.0","id":%d,"result":[{"name":"mytest","block_size":512,"num_blocks":64,"uuid":"043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"}]}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = &testMallocVolume
+
+			request := &px.GetMallocVolumeRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.GetMallocVolume(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_MallocVolumeStats(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *pb.VolumeStats
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":{"tick_rate":0,"ticks":0,"bdevs":null}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_iostat: %v", "json response error: myopierr"),
+		},
+		"valid request with empty result SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":{"tick_rate":0,"ticks":0,"bdevs":[]}}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testMallocVolumeID,
+			&pb.VolumeStats{
+				ReadBytesCount:    1,
+				ReadOpsCount:      2,
+				WriteBytesCount:   3,
+				WriteOpsCount:     4,
+				ReadLatencyTicks:  7,
+				WriteLatencyTicks: 8,
+			},
+			[]string{`{"jsonrpc":"2.0","id":%d,"result":{"tick_rate":2490000000,"ticks":18787040917434338,"bdevs":[{"name":"mytest","bytes_read":1,"num_read_ops":2,"bytes_written":3,"num_write_ops":4,"bytes_unmapped":0,"num_unmap_ops":0,"read_latency_ticks":7,"write_latency_ticks":8,"unmap_latency_ticks":0}]}}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = &testMallocVolume
+
+			request := &px.MallocVolumeStatsRequest{Handle: &pc.ObjectKey{Value: server.ResourceIDToVolumeName(tt.in)}}
+			response, err := testEnv.client.MallocVolumeStats(testEnv.ctx, request)
+
+			if !proto.Equal(response.GetStats(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetStats())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_DeleteMallocVolume(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *emptypb.Empty
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+	}{
+		"valid request with invalid SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Malloc Dev: %s", testMallocVolumeID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testMallocVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_malloc_delete: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testMallocVolumeID,
+			&emptypb.Empty{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"unknown key with missing allowed": {
+			"unknown-id",
+			&emptypb.Empty{},
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			&emptypb.Empty{},
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+			false,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			fname1 := server.ResourceIDToVolumeName(tt.in)
+			volume := server.ProtoClone(&testMallocVolume)
+			volume.Name = testMallocVolumeName
+			testEnv.opiSpdkServer.Volumes.MallocVolumes[testMallocVolumeName] = volume
+
+			request := &px.DeleteMallocVolumeRequest{Name: fname1, AllowMissing: tt.missing}
+			response, err := testEnv.client.DeleteMallocVolume(testEnv.ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if reflect.TypeOf(response) != reflect.TypeOf(tt.out) {
+				t.Error("response: expected", reflect.TypeOf(tt.out), "received", reflect.TypeOf(response))
+			}
+		})
+	}
+}
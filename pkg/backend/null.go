@@ -8,16 +8,16 @@ package backend
 import (
 	"context"
 	"fmt"
-	"log"
 	"path"
-	"sort"
 
 	"github.com/opiproject/gospdk/spdk"
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
@@ -27,286 +27,389 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortNullDebugs(nullDebugs []*pb.NullDebug) {
-	sort.Slice(nullDebugs, func(i int, j int) bool {
-		return nullDebugs[i].Name < nullDebugs[j].Name
-	})
+// CreateNullDebug creates a Null Debug instance without metadata
+func (s *Server) CreateNullDebug(ctx context.Context, in *pb.CreateNullDebugRequest) (*pb.NullDebug, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, _, err := s.createNullDebug(ctx, in, nullDebugFormat{})
+	return response, err
 }
 
-// CreateNullDebug creates a Null Debug instance
-func (s *Server) CreateNullDebug(_ context.Context, in *pb.CreateNullDebugRequest) (*pb.NullDebug, error) {
-	log.Printf("CreateNullDebug: Received from client: %v", in)
+// CreateExtendedNullDebug creates a Null Debug instance with metadata and
+// DIF format of its extension
+func (s *Server) CreateExtendedNullDebug(ctx context.Context, in *px.CreateExtendedNullDebugRequest) (*px.ExtendedNullDebug, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	format := nullFormatFromExtension(in.Extension)
+	volume, created, err := s.createNullDebug(ctx, &pb.CreateNullDebugRequest{NullDebug: in.NullDebug, NullDebugId: in.NullDebugId}, format)
+	if err != nil {
 		return nil, err
 	}
+	if !created {
+		// an existing instance keeps the format it has in SPDK
+		existing, err := s.getBdev(ctx, path.Base(volume.Name))
+		if err != nil {
+			return nil, err
+		}
+		format = nullFormatOfBdev(existing)
+	}
+	response := &px.ExtendedNullDebug{NullDebug: volume, Extension: format.extension()}
+	return response, nil
+}
+
+// createNullDebug creates a Null Debug instance with format, and reports if
+// it was created or already existed
+func (s *Server) createNullDebug(ctx context.Context, in *pb.CreateNullDebugRequest, format nullDebugFormat) (*pb.NullDebug, bool, error) {
 	// see https://google.aip.dev/133#user-specified-ids
 	resourceID := resourceid.NewSystemGenerated()
 	if in.NullDebugId != "" {
 		err := resourceid.ValidateUserSettable(in.NullDebugId)
 		if err != nil {
-			log.Printf("error: %v", err)
-			return nil, err
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, false, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.NullDebugId, in.NullDebug.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.NullDebugId, "name", in.NullDebug.Name)
 		resourceID = in.NullDebugId
 	}
 	in.NullDebug.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.NullDebug.Name)
+	defer unlock()
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	volume, ok := s.Volumes.NullVolumes[in.NullDebug.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing NullDebug with id %v", in.NullDebug.Name)
-		return volume, nil
+		slog.InfoContext(ctx, "Already existing NullDebug", "name", in.NullDebug.Name)
+		return volume, false, nil
 	}
 	// not found, so create a new one
-	params := spdk.BdevNullCreateParams{
-		Name:      resourceID,
-		BlockSize: 512,
-		NumBlocks: 64,
-	}
-	var result spdk.BdevNullCreateResult
-	err := s.rpc.Call("bdev_null_create", &params, &result)
-	if err != nil {
-		log.Printf("error: %v", err)
-		return nil, err
-	}
-	log.Printf("Received from SPDK: %v", result)
-	if result == "" {
-		msg := fmt.Sprintf("Could not create Null Dev: %s", params.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	if err := s.createNullBdev(ctx, in.NullDebug, format); err != nil {
+		return nil, false, err
 	}
 	response := server.ProtoClone(in.NullDebug)
+	if err := store.Save(s.store, in.NullDebug.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
+	}
+	s.mu.Lock()
 	s.Volumes.NullVolumes[in.NullDebug.Name] = response
-	log.Printf("CreateNullDebug: Sending to client: %v", response)
-	return response, nil
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, true, nil
 }
 
 // DeleteNullDebug deletes a Null Debug instance
-func (s *Server) DeleteNullDebug(_ context.Context, in *pb.DeleteNullDebugRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteNullDebug: Received from client: %v", in)
+func (s *Server) DeleteNullDebug(ctx context.Context, in *pb.DeleteNullDebugRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NullVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, resourceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
 	params := spdk.BdevNullDeleteParams{
 		Name: resourceID,
 	}
 	var result spdk.BdevNullDeleteResult
-	err := s.rpc.Call("bdev_null_delete", &params, &result)
+	err = server.Call(ctx, s.rpc, "bdev_null_delete", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_null_delete", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not delete Null Dev: %s", params.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Volumes.NullVolumes, volume.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateNullDebug updates a Null Debug instance
-func (s *Server) UpdateNullDebug(_ context.Context, in *pb.UpdateNullDebugRequest) (*pb.NullDebug, error) {
-	log.Printf("UpdateNullDebug: Received from client: %v", in)
+func (s *Server) UpdateNullDebug(ctx context.Context, in *pb.UpdateNullDebugRequest) (*pb.NullDebug, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.NullDebug.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.NullDebug.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NullVolumes[in.NullDebug.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
-			log.Printf("Got AllowMissing, create a new resource, don't return error when resource not found")
-			params := spdk.BdevNullCreateParams{
-				Name:      path.Base(in.NullDebug.Name),
-				BlockSize: 512,
-				NumBlocks: 64,
-			}
-			var result spdk.BdevNullCreateResult
-			err := s.rpc.Call("bdev_null_create", &params, &result)
-			if err != nil {
-				log.Printf("error: %v", err)
+			slog.InfoContext(ctx, "Got AllowMissing, create a new resource, don't return error when resource not found")
+			if err := s.createNullBdev(ctx, in.NullDebug, nullDebugFormat{}); err != nil {
 				return nil, err
 			}
-			log.Printf("Received from SPDK: %v", result)
-			if result == "" {
-				msg := fmt.Sprintf("Could not create Null Dev: %s", params.Name)
-				log.Print(msg)
-				return nil, status.Errorf(codes.InvalidArgument, msg)
-			}
 			response := server.ProtoClone(in.NullDebug)
+			if err := store.Save(s.store, in.NullDebug.Name, response); err != nil {
+				slog.ErrorContext(ctx, "Request failed", "err", err)
+				return nil, err
+			}
+			s.mu.Lock()
 			s.Volumes.NullVolumes[in.NullDebug.Name] = response
-			log.Printf("CreateNullDebug: Sending to client: %v", response)
+			s.mu.Unlock()
+			slog.DebugContext(ctx, "Sending to client", "response", response)
 			return response, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NullDebug.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
 	// update_mask = 2
 	if err := fieldmask.Validate(in.UpdateMask, in.NullDebug); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	params1 := spdk.BdevNullDeleteParams{
-		Name: resourceID,
+	updated := server.ProtoClone(volume)
+	fieldmask.Update(in.UpdateMask, updated, in.NullDebug)
+	if updated.BlockSize != volume.BlockSize {
+		err := status.Errorf(codes.InvalidArgument, "only blocks_count of NullDebug can be updated")
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
 	}
-	var result1 spdk.BdevNullDeleteResult
-	err1 := s.rpc.Call("bdev_null_delete", &params1, &result1)
-	if err1 != nil {
-		log.Printf("error: %v", err1)
-		return nil, err1
+	if updated.BlocksCount == volume.BlocksCount {
+		return server.ProtoClone(volume), nil
 	}
-	log.Printf("Received from SPDK: %v", result1)
-	if !result1 {
-		msg := fmt.Sprintf("Could not delete Null Dev: %s", params1.Name)
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	newSize, err := nullResizeSize(volume, updated)
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
 	}
-	params2 := spdk.BdevNullCreateParams{
-		Name:      resourceID,
-		BlockSize: 512,
-		NumBlocks: 64,
-	}
-	var result2 spdk.BdevNullCreateResult
-	err2 := s.rpc.Call("bdev_null_create", &params2, &result2)
-	if err2 != nil {
-		log.Printf("error: %v", err2)
-		return nil, err2
-	}
-	log.Printf("Received from SPDK: %v", result2)
-	if result2 == "" {
-		msg := fmt.Sprintf("Could not create Null Dev: %s", params2.Name)
-		log.Print(msg)
+	params := bdevNullResizeParams{
+		Name:    resourceID,
+		NewSize: newSize,
+	}
+	var result bdevNullResizeResult
+	err = server.Call(ctx, s.rpc, "bdev_null_resize", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_null_resize", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not resize Null Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
-	response := server.ProtoClone(in.NullDebug)
-	s.Volumes.NullVolumes[in.NullDebug.Name] = response
+	response := server.ProtoClone(updated)
+	if err := store.Save(s.store, updated.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.NullVolumes[updated.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // ListNullDebugs lists Null Debug instances
-func (s *Server) ListNullDebugs(_ context.Context, in *pb.ListNullDebugsRequest) (*pb.ListNullDebugsResponse, error) {
-	log.Printf("ListNullDebugs: Received from client: %v", in)
+func (s *Server) ListNullDebugs(ctx context.Context, in *pb.ListNullDebugsRequest) (*pb.ListNullDebugsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.NullDebug{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
-	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", nil, &result)
+	extended, err := s.nullDebugs(ctx)
 	if err != nil {
-		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-	result, hasMoreElements := server.LimitPagination(result, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
+	Blobarray := make([]*pb.NullDebug, len(extended))
+	for i := range extended {
+		Blobarray[i] = extended[i].NullDebug
 	}
-	Blobarray := make([]*pb.NullDebug, len(result))
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.NullDebug).GetName)
+	return &pb.ListNullDebugsResponse{NullDebugs: Blobarray, NextPageToken: token}, nil
+}
+
+// ListExtendedNullDebugs lists Null Debug instances with their metadata and
+// DIF format
+func (s *Server) ListExtendedNullDebugs(ctx context.Context, in *px.ListExtendedNullDebugsRequest) (*px.ListExtendedNullDebugsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.ExtendedNullDebug{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	Blobarray, err := s.nullDebugs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	Blobarray, token := server.Paginate(opts, Blobarray, func(n *px.ExtendedNullDebug) string { return n.NullDebug.Name })
+	return &px.ListExtendedNullDebugsResponse{ExtendedNullDebugs: Blobarray, NextPageToken: token}, nil
+}
+
+// nullDebugs returns bdevs of SPDK as Null Debug instances with their format
+func (s *Server) nullDebugs(ctx context.Context) ([]*px.ExtendedNullDebug, error) {
+	var result []bdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	volumes := make([]*px.ExtendedNullDebug, len(result))
 	for i := range result {
-		r := &result[i]
-		Blobarray[i] = &pb.NullDebug{Name: r.Name, Uuid: &pc.Uuid{Value: r.UUID}, BlockSize: r.BlockSize, BlocksCount: r.NumBlocks}
+		volumes[i] = extendNullDebug(&result[i])
 	}
-	sortNullDebugs(Blobarray)
-	return &pb.ListNullDebugsResponse{NullDebugs: Blobarray, NextPageToken: token}, nil
+	return volumes, nil
 }
 
 // GetNullDebug gets a a Null Debug instance
-func (s *Server) GetNullDebug(_ context.Context, in *pb.GetNullDebugRequest) (*pb.NullDebug, error) {
-	log.Printf("GetNullDebug: Received from client: %v", in)
+func (s *Server) GetNullDebug(ctx context.Context, in *pb.GetNullDebugRequest) (*pb.NullDebug, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NullVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	resourceID := path.Base(volume.Name)
-	params := spdk.BdevGetBdevsParams{
-		Name: resourceID,
+	result, err := s.getBdev(ctx, path.Base(volume.Name))
+	if err != nil {
+		return nil, err
+	}
+	return extendNullDebug(result).NullDebug, nil
+}
+
+// GetExtendedNullDebug gets a Null Debug instance with its metadata and DIF
+// format
+func (s *Server) GetExtendedNullDebug(ctx context.Context, in *px.GetExtendedNullDebugRequest) (*px.ExtendedNullDebug, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
 	}
-	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", &params, &result)
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.NullVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	result, err := s.getBdev(ctx, path.Base(volume.Name))
 	if err != nil {
-		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if len(result) != 1 {
-		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	response := extendNullDebug(result)
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// extendNullDebug returns bdev as Null Debug instance with its format
+func extendNullDebug(bdev *bdevGetBdevsResult) *px.ExtendedNullDebug {
+	format := nullFormatOfBdev(bdev)
+	return &px.ExtendedNullDebug{
+		NullDebug: &pb.NullDebug{Name: bdev.Name, Uuid: &pc.Uuid{Value: bdev.UUID}, BlockSize: bdev.BlockSize, BlocksCount: bdev.NumBlocks},
+		Extension: format.extension(),
 	}
-	return &pb.NullDebug{Name: result[0].Name, Uuid: &pc.Uuid{Value: result[0].UUID}, BlockSize: result[0].BlockSize, BlocksCount: result[0].NumBlocks}, nil
 }
 
 // NullDebugStats gets a Null Debug instance stats
-func (s *Server) NullDebugStats(_ context.Context, in *pb.NullDebugStatsRequest) (*pb.NullDebugStatsResponse, error) {
-	log.Printf("NullDebugStats: Received from client: %v", in)
+func (s *Server) NullDebugStats(ctx context.Context, in *pb.NullDebugStatsRequest) (*pb.NullDebugStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Handle.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NullVolumes[in.Handle.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Handle.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
@@ -315,15 +418,15 @@ func (s *Server) NullDebugStats(_ context.Context, in *pb.NullDebugStatsRequest)
 	}
 	// See https://mholt.github.io/json-to-go/
 	var result spdk.BdevGetIostatResult
-	err := s.rpc.Call("bdev_get_iostat", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result.Bdevs) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.NullDebugStatsResponse{Stats: &pb.VolumeStats{
@@ -338,3 +441,35 @@ func (s *Server) NullDebugStats(_ context.Context, in *pb.NullDebugStatsRequest)
 		UnmapLatencyTicks: int32(result.Bdevs[0].UnmapLatencyTicks),
 	}}, nil
 }
+
+// createNullBdev creates Null Block Device of volume geometry with format
+func (s *Server) createNullBdev(ctx context.Context, volume *pb.NullDebug, format nullDebugFormat) error {
+	if err := format.validate(volume); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return err
+	}
+	params := bdevNullCreateParams{
+		BdevNullCreateParams: spdk.BdevNullCreateParams{
+			Name:      path.Base(volume.Name),
+			BlockSize: int(volume.BlockSize),
+			NumBlocks: int(volume.BlocksCount),
+		},
+		MdSize:        format.MdSize,
+		DifType:       format.DifType,
+		DifIsHeadOfMd: format.DifIsHeadOfMd,
+	}
+	var result spdk.BdevNullCreateResult
+	err := server.Call(ctx, s.rpc, "bdev_null_create", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_null_create", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if result == "" {
+		msg := fmt.Sprintf("Could not create Null Dev: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
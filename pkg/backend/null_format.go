@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+)
+
+const (
+	nullDataBlockAlignment = 512
+	nullMaxDifType         = 3
+	nullResizeUnit         = 1024 * 1024
+)
+
+// nullDebugFormat is metadata and DIF format of a Null Debug instance.
+// Metadata is interleaved with data, so block_size includes md_size. DIF
+// insert and strip is not supported per Null Debug, bdev_null_create has no
+// such param as SPDK does it in the NVMe/TCP transport for all namespaces,
+// which the frontend enables with a setting of the bridge.
+type nullDebugFormat struct {
+	// MdSize is the size of metadata of each block in bytes
+	MdSize int64
+	// DifType is the type of T10 DIF protection, 0 means none
+	DifType int64
+	// DifIsHeadOfMd reports if DIF is at the beginning of metadata
+	// instead of its end
+	DifIsHeadOfMd bool
+}
+
+// nullFormatFromExtension returns format set in extension of a Null Debug
+// instance, no metadata if extension is nil
+func nullFormatFromExtension(extension *px.NullDebugExtension) nullDebugFormat {
+	return nullDebugFormat{
+		MdSize:        extension.GetMdSize(),
+		DifType:       int64(extension.GetDifType()),
+		DifIsHeadOfMd: extension.GetDifIsHeadOfMd(),
+	}
+}
+
+// nullFormatOfBdev returns format of a Null Block Device reported by SPDK
+func nullFormatOfBdev(bdev *bdevGetBdevsResult) nullDebugFormat {
+	return nullDebugFormat{MdSize: bdev.MdSize, DifType: bdev.DifType, DifIsHeadOfMd: bdev.DifIsHeadOfMd}
+}
+
+// extension returns format as extension of a Null Debug instance
+func (f *nullDebugFormat) extension() *px.NullDebugExtension {
+	return &px.NullDebugExtension{
+		MdSize:        f.MdSize,
+		DifType:       int32(f.DifType),
+		DifIsHeadOfMd: f.DifIsHeadOfMd,
+	}
+}
+
+// validate checks format and geometry of volume against rules SPDK
+// applies to Null Block Devices
+func (f *nullDebugFormat) validate(volume *pb.NullDebug) error {
+	switch f.MdSize {
+	case 0, 8, 16, 32, 64, 128:
+	default:
+		return fmt.Errorf("md_size has to be 0, 8, 16, 32, 64 or 128, got %d", f.MdSize)
+	}
+	switch {
+	case f.DifType < 0 || f.DifType > nullMaxDifType:
+		return fmt.Errorf("dif_type has to be 0 to %d, got %d", nullMaxDifType, f.DifType)
+	case f.DifType != 0 && f.MdSize == 0:
+		return errors.New("dif_type requires md_size")
+	case f.DifType == 0 && f.DifIsHeadOfMd:
+		return errors.New("dif_is_head_of_md requires dif_type")
+	case volume.BlocksCount <= 0:
+		return fmt.Errorf("blocks_count has to be positive, got %d", volume.BlocksCount)
+	case volume.BlockSize <= f.MdSize || (volume.BlockSize-f.MdSize)%nullDataBlockAlignment != 0:
+		return fmt.Errorf("block_size without md_size has to be a positive multiple of %d, got %d", nullDataBlockAlignment, volume.BlockSize)
+	}
+	return nil
+}
+
+// nullResizeSize returns the size in MiB bdev_null_resize takes to resize
+// volume to updated. SPDK only grows Null Block Devices by whole MiBs.
+func nullResizeSize(volume *pb.NullDebug, updated *pb.NullDebug) (int64, error) {
+	size := updated.BlocksCount * updated.BlockSize
+	switch {
+	case updated.BlocksCount < volume.BlocksCount:
+		return 0, fmt.Errorf("blocks_count cannot shrink from %d to %d", volume.BlocksCount, updated.BlocksCount)
+	case size%nullResizeUnit != 0:
+		return 0, fmt.Errorf("blocks_count has to make size a multiple of %d bytes, got %d bytes", nullResizeUnit, size)
+	}
+	return size / nullResizeUnit, nil
+}
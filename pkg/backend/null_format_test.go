@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+func TestNullDebugFormat_Validate(t *testing.T) {
+	tests := map[string]struct {
+		format nullDebugFormat
+		volume *pb.NullDebug
+		err    bool
+	}{
+		"no metadata": {
+			format: nullDebugFormat{},
+			volume: &pb.NullDebug{BlockSize: 4096, BlocksCount: 64},
+			err:    false,
+		},
+		"DIF type 1 at head of metadata": {
+			format: nullDebugFormat{MdSize: 8, DifType: 1, DifIsHeadOfMd: true},
+			volume: &pb.NullDebug{BlockSize: 520, BlocksCount: 64},
+			err:    false,
+		},
+		"metadata without DIF": {
+			format: nullDebugFormat{MdSize: 64},
+			volume: &pb.NullDebug{BlockSize: 4160, BlocksCount: 64},
+			err:    false,
+		},
+		"block size not multiple of 512": {
+			format: nullDebugFormat{},
+			volume: &pb.NullDebug{BlockSize: 520, BlocksCount: 64},
+			err:    true,
+		},
+		"block size without metadata": {
+			format: nullDebugFormat{MdSize: 8},
+			volume: &pb.NullDebug{BlockSize: 512, BlocksCount: 64},
+			err:    true,
+		},
+		"zero block size": {
+			format: nullDebugFormat{},
+			volume: &pb.NullDebug{BlocksCount: 64},
+			err:    true,
+		},
+		"zero blocks count": {
+			format: nullDebugFormat{},
+			volume: &pb.NullDebug{BlockSize: 512},
+			err:    true,
+		},
+		"unsupported metadata size": {
+			format: nullDebugFormat{MdSize: 12},
+			volume: &pb.NullDebug{BlockSize: 524, BlocksCount: 64},
+			err:    true,
+		},
+		"unknown DIF type": {
+			format: nullDebugFormat{MdSize: 8, DifType: 4},
+			volume: &pb.NullDebug{BlockSize: 520, BlocksCount: 64},
+			err:    true,
+		},
+		"DIF without metadata": {
+			format: nullDebugFormat{DifType: 1},
+			volume: &pb.NullDebug{BlockSize: 512, BlocksCount: 64},
+			err:    true,
+		},
+		"DIF placement without DIF": {
+			format: nullDebugFormat{MdSize: 8, DifIsHeadOfMd: true},
+			volume: &pb.NullDebug{BlockSize: 520, BlocksCount: 64},
+			err:    true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := tt.format.validate(tt.volume)
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestBackEnd_ExtendedNullDebugFormat(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		`{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":520,"num_blocks":64,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15","md_size":8,"dif_type":1,"dif_is_head_of_md":true}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":520,"num_blocks":64,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15","md_size":8,"dif_type":1,"dif_is_head_of_md":true}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"mytest","block_size":520,"num_blocks":64,"uuid":"9ef1f7d5-1ab2-4a4b-b3ba-7a7c1e5e2c15","md_size":8,"dif_type":1,"dif_is_head_of_md":true}]}`,
+	})
+	defer testEnv.Close()
+	extension := &px.NullDebugExtension{MdSize: 8, DifType: 1, DifIsHeadOfMd: true}
+
+	// an invalid format is rejected before SPDK is called
+	_, err := testEnv.client.CreateExtendedNullDebug(testEnv.ctx, &px.CreateExtendedNullDebugRequest{
+		NullDebugId: "invalid", NullDebug: &pb.NullDebug{BlockSize: 512, BlocksCount: 64}, Extension: extension})
+	expectedMsg := fmt.Sprintf("block_size without md_size has to be a positive multiple of %d, got %d", nullDataBlockAlignment, 512)
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != expectedMsg {
+		t.Errorf("Expected InvalidArgument for block size without metadata, received: %v", err)
+	}
+
+	created, err := testEnv.client.CreateExtendedNullDebug(testEnv.ctx, &px.CreateExtendedNullDebugRequest{
+		NullDebugId: testNullVolumeID, NullDebug: &pb.NullDebug{BlockSize: 520, BlocksCount: 64}, Extension: extension})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(created.Extension, extension) {
+		t.Error("extension: expected", extension, "received", created.Extension)
+	}
+
+	// an existing instance is returned with its format in SPDK
+	existing, err := testEnv.client.CreateExtendedNullDebug(testEnv.ctx, &px.CreateExtendedNullDebugRequest{
+		NullDebugId: testNullVolumeID, NullDebug: &pb.NullDebug{BlockSize: 520, BlocksCount: 64}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(existing.Extension, extension) {
+		t.Error("existing extension: expected", extension, "received", existing.Extension)
+	}
+
+	got, err := testEnv.client.GetExtendedNullDebug(testEnv.ctx, &px.GetExtendedNullDebugRequest{Name: created.NullDebug.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got.Extension, extension) {
+		t.Error("extension from SPDK: expected", extension, "received", got.Extension)
+	}
+
+	// instances are filtered by fields of the extension
+	ctx := metadata.AppendToOutgoingContext(testEnv.ctx, server.FilterMetadataKey, `extension.dif_type = 1`)
+	list, err := testEnv.client.ListExtendedNullDebugs(ctx, &px.ListExtendedNullDebugsRequest{Parent: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.ExtendedNullDebugs) != 1 || !proto.Equal(list.ExtendedNullDebugs[0].Extension, extension) {
+		t.Error("list: expected", extension, "received", list.ExtendedNullDebugs)
+	}
+}
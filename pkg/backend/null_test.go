@@ -159,83 +159,83 @@ func TestBackEnd_UpdateNullDebug(t *testing.T) {
 			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
 			false,
 		},
-		"delete fails": {
+		"unchanged": {
 			nil,
 			&testNullVolume,
-			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
-			codes.InvalidArgument,
-			fmt.Sprintf("Could not delete Null Dev: %s", testNullVolumeID),
+			&testNullVolume,
+			[]string{},
+			codes.OK,
+			"",
 			false,
 		},
-		"delete empty": {
+		"block size change": {
+			&fieldmaskpb.FieldMask{Paths: []string{"block_size"}},
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 4096, BlocksCount: 64},
 			nil,
-			&testNullVolume,
-			nil,
-			[]string{""},
-			codes.Unknown,
-			fmt.Sprintf("bdev_null_delete: %v", "EOF"),
+			[]string{},
+			codes.InvalidArgument,
+			"only blocks_count of NullDebug can be updated",
 			false,
 		},
-		"delete ID mismatch": {
+		"shrink": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlocksCount: 32},
 			nil,
-			[]string{`{"id":0,"error":{"code":0,"message":""},"result":false}`},
-			codes.Unknown,
-			fmt.Sprintf("bdev_null_delete: %v", "json response ID mismatch"),
+			[]string{},
+			codes.InvalidArgument,
+			"blocks_count cannot shrink from 64 to 32",
 			false,
 		},
-		"delete exception": {
+		"size not multiple of MiB": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlocksCount: 128},
 			nil,
-			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
-			codes.Unknown,
-			fmt.Sprintf("bdev_null_delete: %v", "json response error: myopierr"),
+			[]string{},
+			codes.InvalidArgument,
+			"blocks_count has to make size a multiple of 1048576 bytes, got 65536 bytes",
 			false,
 		},
-		"delete ok create fails": {
+		"resize fails": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.InvalidArgument,
-			fmt.Sprintf("Could not create Null Dev: %v", "mytest"),
+			fmt.Sprintf("Could not resize Null Dev: %s", testNullVolumeID),
 			false,
 		},
-		"delete ok create empty": {
+		"resize empty": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, ""},
+			[]string{""},
 			codes.Unknown,
-			fmt.Sprintf("bdev_null_create: %v", "EOF"),
+			fmt.Sprintf("bdev_null_resize: %v", "EOF"),
 			false,
 		},
-		"delete ok create ID mismatch": {
+		"resize ID mismatch": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":0,"error":{"code":0,"message":""},"result":""}`},
+			[]string{`{"id":0,"error":{"code":0,"message":""},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_null_create: %v", "json response ID mismatch"),
+			fmt.Sprintf("bdev_null_resize: %v", "json response ID mismatch"),
 			false,
 		},
-		"delete ok create exception": {
+		"resize exception": {
 			nil,
-			&testNullVolume,
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
 			nil,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_null_create: %v", "json response error: myopierr"),
+			fmt.Sprintf("bdev_null_resize: %v", "json response error: myopierr"),
 			false,
 		},
 		"valid request with valid SPDK response": {
-			nil,
-			&testNullVolume,
-			&testNullVolume,
-			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
+			&fieldmaskpb.FieldMask{Paths: []string{"blocks_count"}},
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
+			&pb.NullDebug{Name: testNullVolumeName, BlockSize: 512, BlocksCount: 2048},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
 			codes.OK,
 			"",
 			false,
@@ -335,7 +335,7 @@ func TestBackEnd_ListNullDebugs(t *testing.T) {
 			nil,
 			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_get_bdevs: %v", "json: cannot unmarshal bool into Go value of type []spdk.BdevGetBdevsResult"),
+			fmt.Sprintf("bdev_get_bdevs: %v", "json: cannot unmarshal bool into Go value of type []backend.bdevGetBdevsResult"),
 			0,
 			"",
 		},
@@ -471,9 +471,11 @@ func TestBackEnd_ListNullDebugs(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
-
 			request := &pb.ListNullDebugsRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&pb.NullDebug{Name: "Malloc0"}, "Malloc0")
+			}
 			response, err := testEnv.client.ListNullDebugs(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetNullDebugs(), tt.out) {
@@ -518,7 +520,7 @@ func TestBackEnd_GetNullDebug(t *testing.T) {
 			nil,
 			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.Unknown,
-			fmt.Sprintf("bdev_get_bdevs: %v", "json: cannot unmarshal bool into Go value of type []spdk.BdevGetBdevsResult"),
+			fmt.Sprintf("bdev_get_bdevs: %v", "json: cannot unmarshal bool into Go value of type []backend.bdevGetBdevsResult"),
 		},
 		"valid request with empty SPDK response": {
 			testNullVolumeID,
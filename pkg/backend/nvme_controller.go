@@ -7,161 +7,365 @@ package backend
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"path"
-	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
 	"go.einride.tech/aip/resourcename"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortNvmeRemoteControllers(controllers []*pb.NvmeRemoteController) {
-	sort.Slice(controllers, func(i int, j int) bool {
-		return controllers[i].Name < controllers[j].Name
-	})
+// CreateNvmeRemoteController creates an Nvme remote controller with SPDK
+// default multipath policy and reconnect settings
+func (s *Server) CreateNvmeRemoteController(ctx context.Context, in *pb.CreateNvmeRemoteControllerRequest) (*pb.NvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, err := s.createNvmeRemoteController(ctx, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.NvmeRemoteController, nil
 }
 
-// CreateNvmeRemoteController creates an Nvme remote controller
-func (s *Server) CreateNvmeRemoteController(_ context.Context, in *pb.CreateNvmeRemoteControllerRequest) (*pb.NvmeRemoteController, error) {
-	log.Printf("CreateNvmeRemoteController: Received from client: %v", in)
+// CreateExtendedNvmeRemoteController creates an Nvme remote controller with
+// multipath policy and reconnect settings of its extension
+func (s *Server) CreateExtendedNvmeRemoteController(ctx context.Context, in *px.CreateExtendedNvmeRemoteControllerRequest) (*px.ExtendedNvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	return s.createNvmeRemoteController(ctx, &pb.CreateNvmeRemoteControllerRequest{
+		NvmeRemoteController:   in.NvmeRemoteController,
+		NvmeRemoteControllerId: in.NvmeRemoteControllerId,
+	}, in.Extension)
+}
+
+// createNvmeRemoteController creates an Nvme remote controller with tuning
+// set in extension
+func (s *Server) createNvmeRemoteController(ctx context.Context, in *pb.CreateNvmeRemoteControllerRequest, extension *px.NvmeRemoteControllerExtension) (*px.ExtendedNvmeRemoteController, error) {
 	if in.NvmeRemoteController.Multipath == pb.NvmeMultipath_NVME_MULTIPATH_UNSPECIFIED {
 		msg := "Multipath type should be specified"
-		log.Printf("error: %v", msg)
+		slog.ErrorContext(ctx, "Request failed", "err", msg)
 		return nil, status.Error(codes.InvalidArgument, msg)
 	}
+	if len(in.NvmeRemoteController.Psk) > 0 {
+		if _, err := pskInterchange(in.NvmeRemoteController.Psk); err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	tuning, err := tuningFromExtension(extension)
+	if err == nil {
+		err = tuning.validate(in.NvmeRemoteController.Multipath)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	// see https://google.aip.dev/133#user-specified-ids
 	resourceID := resourceid.NewSystemGenerated()
 	if in.NvmeRemoteControllerId != "" {
 		err := resourceid.ValidateUserSettable(in.NvmeRemoteControllerId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.NvmeRemoteControllerId, in.NvmeRemoteController.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.NvmeRemoteControllerId, "name", in.NvmeRemoteController.Name)
 		resourceID = in.NvmeRemoteControllerId
 	}
 	in.NvmeRemoteController.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.NvmeRemoteController.Name)
+	defer unlock()
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	volume, ok := s.Volumes.NvmeControllers[in.NvmeRemoteController.Name]
+	existing := s.tunings[in.NvmeRemoteController.Name]
+	reserving := s.discoveryReserving(in.NvmeRemoteController.Name)
+	s.mu.RUnlock()
+	if reserving != "" {
+		err := status.Errorf(codes.InvalidArgument, "name %s is reserved for resources discovered through %s", in.NvmeRemoteController.Name, reserving)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
 	if ok {
-		log.Printf("Already existing NvmeRemoteController with id %v", in.NvmeRemoteController.Name)
-		return volume, nil
+		slog.InfoContext(ctx, "Already existing NvmeRemoteController", "name", in.NvmeRemoteController.Name)
+		return &px.ExtendedNvmeRemoteController{NvmeRemoteController: volume, Extension: existing.extension()}, nil
 	}
 	// not found, so create a new one
-	response := server.ProtoClone(in.NvmeRemoteController)
-	s.Volumes.NvmeControllers[in.NvmeRemoteController.Name] = response
-	log.Printf("CreateNvmeRemoteController: Sending to client: %v", response)
+	controller := server.ProtoClone(in.NvmeRemoteController)
+	if err := s.saveTuning(in.NvmeRemoteController.Name, tuning); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := store.Save(s.store, in.NvmeRemoteController.Name, controller); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.NvmeControllers[in.NvmeRemoteController.Name] = controller
+	s.mu.Unlock()
+	response := &px.ExtendedNvmeRemoteController{NvmeRemoteController: server.ProtoClone(controller), Extension: tuning.extension()}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // DeleteNvmeRemoteController deletes an Nvme remote controller
-func (s *Server) DeleteNvmeRemoteController(_ context.Context, in *pb.DeleteNvmeRemoteControllerRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteNvmeRemoteController: Received from client: %v", in)
+func (s *Server) DeleteNvmeRemoteController(ctx context.Context, in *pb.DeleteNvmeRemoteControllerRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NvmeControllers[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v -> %v", err, volume)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	if s.numberOfPathsForController(in.Name) > 0 {
+	s.mu.RLock()
+	numberOfPaths := s.numberOfPathsForController(in.Name)
+	s.mu.RUnlock()
+	if numberOfPaths > 0 {
 		return nil, status.Error(codes.FailedPrecondition, "NvmePaths exist for controller")
 	}
+	if err := s.removePskFile(volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := s.removeTuning(volume.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Volumes.NvmeControllers, volume.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
+// UpdateNvmeRemoteController updates an Nvme remote controller. Only its
+// multipath policy and reconnect settings can be updated, which OPI has no
+// fields for, so the update succeeds only if other fields are unchanged.
+func (s *Server) UpdateNvmeRemoteController(ctx context.Context, in *pb.UpdateNvmeRemoteControllerRequest) (*pb.NvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.NvmeRemoteController.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.NvmeRemoteController.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.NvmeControllers[in.NvmeRemoteController.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NvmeRemoteController.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := fieldmask.Validate(in.UpdateMask, in.NvmeRemoteController); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	updated := server.ProtoClone(volume)
+	fieldmask.Update(in.UpdateMask, updated, in.NvmeRemoteController)
+	if !proto.Equal(volume, updated) {
+		msg := "only multipath policy and reconnect settings of NvmeRemoteController can be updated"
+		slog.ErrorContext(ctx, "Request failed", "err", msg)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(volume)
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// UpdateExtendedNvmeRemoteController updates multipath policy and reconnect
+// settings of an Nvme remote controller. Other fields of the controller
+// cannot be updated.
+func (s *Server) UpdateExtendedNvmeRemoteController(ctx context.Context, in *px.UpdateExtendedNvmeRemoteControllerRequest) (*px.ExtendedNvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.NvmeRemoteController.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.NvmeRemoteController.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.NvmeControllers[in.NvmeRemoteController.Name]
+	current := s.tunings[in.NvmeRemoteController.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NvmeRemoteController.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	patch := &px.ExtendedNvmeRemoteController{NvmeRemoteController: in.NvmeRemoteController, Extension: in.Extension}
+	if err := fieldmask.Validate(in.UpdateMask, patch); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	updated := &px.ExtendedNvmeRemoteController{NvmeRemoteController: server.ProtoClone(volume), Extension: current.extension()}
+	fieldmask.Update(in.UpdateMask, updated, patch)
+	if !proto.Equal(volume, updated.NvmeRemoteController) {
+		msg := "only multipath policy and reconnect settings of NvmeRemoteController can be updated"
+		slog.ErrorContext(ctx, "Request failed", "err", msg)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	tuning, err := tuningFromExtension(updated.Extension)
+	if err == nil {
+		err = tuning.validate(volume.Multipath)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.applyTuning(ctx, volume, current, tuning); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response := &px.ExtendedNvmeRemoteController{NvmeRemoteController: server.ProtoClone(volume), Extension: tuning.extension()}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
 // NvmeRemoteControllerReset resets an Nvme remote controller
-func (s *Server) NvmeRemoteControllerReset(_ context.Context, in *pb.NvmeRemoteControllerResetRequest) (*emptypb.Empty, error) {
-	log.Printf("Received: %v", in.GetId())
+func (s *Server) NvmeRemoteControllerReset(ctx context.Context, in *pb.NvmeRemoteControllerResetRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "id", in.GetId())
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Id.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Id.Value)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.NvmeControllers[in.Id.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Id.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevNvmeResetControllerParams{
+		Name: path.Base(volume.Name),
+	}
+	var result bdevNvmeResetControllerResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_reset_controller", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_reset_controller", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not reset Nvme controller: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.Internal, msg)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 // ListNvmeRemoteControllers lists an Nvme remote controllers
-func (s *Server) ListNvmeRemoteControllers(_ context.Context, in *pb.ListNvmeRemoteControllersRequest) (*pb.ListNvmeRemoteControllersResponse, error) {
-	log.Printf("ListNvmeRemoteControllers: Received from client: %v", in)
+func (s *Server) ListNvmeRemoteControllers(ctx context.Context, in *pb.ListNvmeRemoteControllersRequest) (*pb.ListNvmeRemoteControllersResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.NvmeRemoteController{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
 
 	Blobarray := []*pb.NvmeRemoteController{}
+	s.mu.RLock()
 	for _, controller := range s.Volumes.NvmeControllers {
 		Blobarray = append(Blobarray, controller)
 	}
-	sortNvmeRemoteControllers(Blobarray)
+	s.mu.RUnlock()
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.NvmeRemoteController).GetName)
 
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(Blobarray), offset, size)
-	Blobarray, hasMoreElements := server.LimitPagination(Blobarray, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
-	}
 	return &pb.ListNvmeRemoteControllersResponse{NvmeRemoteControllers: Blobarray, NextPageToken: token}, nil
 }
 
 // GetNvmeRemoteController gets an Nvme remote controller
-func (s *Server) GetNvmeRemoteController(_ context.Context, in *pb.GetNvmeRemoteControllerRequest) (*pb.NvmeRemoteController, error) {
-	log.Printf("GetNvmeRemoteController: Received from client: %v", in)
+func (s *Server) GetNvmeRemoteController(ctx context.Context, in *pb.GetNvmeRemoteControllerRequest) (*pb.NvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NvmeControllers[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
@@ -169,27 +373,119 @@ func (s *Server) GetNvmeRemoteController(_ context.Context, in *pb.GetNvmeRemote
 	return response, nil
 }
 
+// ListExtendedNvmeRemoteControllers lists Nvme remote controllers with their
+// multipath policy and reconnect settings
+func (s *Server) ListExtendedNvmeRemoteControllers(ctx context.Context, in *px.ListExtendedNvmeRemoteControllersRequest) (*px.ListExtendedNvmeRemoteControllersResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.ExtendedNvmeRemoteController{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+
+	Blobarray := []*px.ExtendedNvmeRemoteController{}
+	s.mu.RLock()
+	for name, controller := range s.Volumes.NvmeControllers {
+		tuning := s.tunings[name]
+		Blobarray = append(Blobarray, &px.ExtendedNvmeRemoteController{NvmeRemoteController: controller, Extension: tuning.extension()})
+	}
+	s.mu.RUnlock()
+	Blobarray, token := server.Paginate(opts, Blobarray, func(c *px.ExtendedNvmeRemoteController) string { return c.NvmeRemoteController.Name })
+
+	return &px.ListExtendedNvmeRemoteControllersResponse{ExtendedNvmeRemoteControllers: Blobarray, NextPageToken: token}, nil
+}
+
+// GetExtendedNvmeRemoteController gets an Nvme remote controller with its
+// multipath policy and reconnect settings
+func (s *Server) GetExtendedNvmeRemoteController(ctx context.Context, in *px.GetExtendedNvmeRemoteControllerRequest) (*px.ExtendedNvmeRemoteController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.Volumes.NvmeControllers[in.Name]
+	tuning := s.tunings[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response := &px.ExtendedNvmeRemoteController{NvmeRemoteController: server.ProtoClone(volume), Extension: tuning.extension()}
+	return response, nil
+}
+
 // NvmeRemoteControllerStats gets Nvme remote controller stats
-func (s *Server) NvmeRemoteControllerStats(_ context.Context, in *pb.NvmeRemoteControllerStatsRequest) (*pb.NvmeRemoteControllerStatsResponse, error) {
-	log.Printf("Received: %v", in.GetId())
+func (s *Server) NvmeRemoteControllerStats(ctx context.Context, in *pb.NvmeRemoteControllerStatsRequest) (*pb.NvmeRemoteControllerStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "id", in.GetId())
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Id.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NvmeControllers[in.Id.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Id.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.RLock()
+	numberOfPaths := s.numberOfPathsForController(volume.Name)
+	s.mu.RUnlock()
+	stats := &pb.VolumeStats{}
+	if numberOfPaths == 0 {
+		// not attached in SPDK, so there are no namespaces to read statistics of
+		return &pb.NvmeRemoteControllerStatsResponse{Stats: stats}, nil
+	}
+	var result spdk.BdevGetIostatResult
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
 		return nil, err
 	}
-	name := path.Base(volume.Name)
-	log.Printf("TODO: send name to SPDK and get back stats: %v", name)
-	return &pb.NvmeRemoteControllerStatsResponse{Stats: &pb.VolumeStats{ReadOpsCount: -1, WriteOpsCount: -1}}, nil
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	// namespaces of controller X are exposed by SPDK as bdevs XnY,
+	// where Y is the namespace ID
+	prefix := path.Base(volume.Name) + "n"
+	for _, bdev := range result.Bdevs {
+		nsid := strings.TrimPrefix(bdev.Name, prefix)
+		if nsid == bdev.Name {
+			continue
+		}
+		if _, err := strconv.ParseUint(nsid, 10, 32); err != nil {
+			continue
+		}
+		stats.ReadBytesCount += int32(bdev.BytesRead)
+		stats.ReadOpsCount += int32(bdev.NumReadOps)
+		stats.WriteBytesCount += int32(bdev.BytesWritten)
+		stats.WriteOpsCount += int32(bdev.NumWriteOps)
+		stats.UnmapBytesCount += int32(bdev.BytesUnmapped)
+		stats.UnmapOpsCount += int32(bdev.NumUnmapOps)
+		stats.ReadLatencyTicks += int32(bdev.ReadLatencyTicks)
+		stats.WriteLatencyTicks += int32(bdev.WriteLatencyTicks)
+		stats.UnmapLatencyTicks += int32(bdev.UnmapLatencyTicks)
+	}
+	return &pb.NvmeRemoteControllerStatsResponse{Stats: stats}, nil
 }
@@ -109,13 +109,41 @@ func TestBackEnd_NvmeRemoteControllerReset(t *testing.T) {
 		errCode codes.Code
 		errMsg  string
 	}{
-		"valid request without SPDK": {
+		"valid request with valid SPDK response": {
 			testNvmeCtrlID,
 			&emptypb.Empty{},
-			[]string{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
 			codes.OK,
 			"",
 		},
+		"valid request with invalid SPDK response": {
+			testNvmeCtrlID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.Internal,
+			fmt.Sprintf("Could not reset Nvme controller: %v", testNvmeCtrlID),
+		},
+		"valid request with error code from SPDK response": {
+			testNvmeCtrlID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_reset_controller: %v", "json response error: myopierr"),
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", "unknown-id"),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
 	}
 
 	// run tests
@@ -124,6 +152,9 @@ func TestBackEnd_NvmeRemoteControllerReset(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlID] = server.ProtoClone(&testNvmeCtrl)
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlID].Name = testNvmeCtrlName
+
 			request := &pb.NvmeRemoteControllerResetRequest{Id: &pc.ObjectKey{Value: tt.in}}
 			response, err := testEnv.client.NvmeRemoteControllerReset(testEnv.ctx, request)
 
@@ -257,10 +288,13 @@ func TestBackEnd_ListNvmeRemoteControllers(t *testing.T) {
 			testEnv := createTestEnvironment([]string{})
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
 			testEnv.opiSpdkServer.Volumes.NvmeControllers = tt.existingControllers
 
 			request := &pb.ListNvmeRemoteControllersRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&pb.NvmeRemoteController{Name: server.ResourceIDToVolumeName("OpiNvme12")}, server.ResourceIDToVolumeName("OpiNvme12"))
+			}
 			response, err := testEnv.client.ListNvmeRemoteControllers(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetNvmeRemoteControllers(), tt.out) {
@@ -352,16 +386,43 @@ func TestBackEnd_NvmeRemoteControllerStats(t *testing.T) {
 		spdk    []string
 		errCode codes.Code
 		errMsg  string
+		paths   bool
 	}{
 		"valid request with valid SPDK response": {
 			testNvmeCtrlID,
 			&pb.VolumeStats{
-				ReadOpsCount:  -1,
-				WriteOpsCount: -1,
+				ReadBytesCount:    36864,
+				ReadOpsCount:      9,
+				WriteBytesCount:   4096,
+				WriteOpsCount:     1,
+				ReadLatencyTicks:  300,
+				WriteLatencyTicks: 50,
 			},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":{"tick_rate":2490000000,"ticks":18787040917434338,"bdevs":[` +
+				`{"name":"opi-nvme8n1","bytes_read":32768,"num_read_ops":8,"bytes_written":4096,"num_write_ops":1,"read_latency_ticks":200,"write_latency_ticks":50},` +
+				`{"name":"opi-nvme8n2","bytes_read":4096,"num_read_ops":1,"read_latency_ticks":100},` +
+				`{"name":"opi-nvme8n1p0","bytes_read":512,"num_read_ops":1},` +
+				`{"name":"opi-nvme80n1","bytes_read":512,"num_read_ops":1},` +
+				`{"name":"Malloc0","bytes_read":512,"num_read_ops":1}]}}`},
+			codes.OK,
+			"",
+			true,
+		},
+		"valid request without paths": {
+			testNvmeCtrlID,
+			&pb.VolumeStats{},
 			[]string{},
 			codes.OK,
 			"",
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testNvmeCtrlID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":{}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_get_iostat: %v", "json response error: myopierr"),
+			true,
 		},
 		"valid request with unknown key": {
 			"unknown-id",
@@ -369,6 +430,7 @@ func TestBackEnd_NvmeRemoteControllerStats(t *testing.T) {
 			[]string{},
 			codes.NotFound,
 			fmt.Sprintf("unable to find key %v", "unknown-id"),
+			false,
 		},
 		"malformed name": {
 			"-ABC-DEF",
@@ -376,6 +438,7 @@ func TestBackEnd_NvmeRemoteControllerStats(t *testing.T) {
 			[]string{},
 			codes.Unknown,
 			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+			false,
 		},
 	}
 
@@ -385,7 +448,11 @@ func TestBackEnd_NvmeRemoteControllerStats(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlID] = &testNvmeCtrl
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlID] = server.ProtoClone(&testNvmeCtrl)
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlID].Name = testNvmeCtrlName
+			if tt.paths {
+				testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = server.ProtoClone(&testNvmePath)
+			}
 
 			request := &pb.NvmeRemoteControllerStatsRequest{Id: &pc.ObjectKey{Value: tt.in}}
 			response, err := testEnv.client.NvmeRemoteControllerStats(testEnv.ctx, request)
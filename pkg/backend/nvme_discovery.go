@@ -0,0 +1,491 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DiscoveryNqn is the well-known NQN of NVMe-oF discovery subsystems. An
+// NvmePath to it makes its controller a discovery controller: subsystems
+// listed in the discovery log are attached and kept as managed
+// NvmeRemoteControllers and NvmePaths, following changes of the log.
+const DiscoveryNqn = "nqn.2014-08.org.nvmexpress.discovery"
+
+// DefaultDiscoveryInterval is the default of how often WatchDiscovery
+// follows changes of discovery logs
+const DefaultDiscoveryInterval = 10 * time.Second
+
+// pathStateDiscovery is the state of paths to discovery controllers
+const pathStateDiscovery = "discovery"
+
+// nvmeDiscoveredKind is the kind of records of resources discoveries
+// manage in the store
+const nvmeDiscoveredKind = "opi_spdk_bridge.NvmeDiscovered"
+
+// nvmeDiscovered are names of NvmeRemoteControllers and NvmePaths a
+// discovery manages for subsystems discovered through it. Only these are
+// changed by the discovery, resources of clients are never adopted.
+type nvmeDiscovered struct {
+	Controllers []string `json:"controllers,omitempty"`
+	Paths       []string `json:"paths,omitempty"`
+}
+
+// union returns names of both d and other
+func (d nvmeDiscovered) union(other nvmeDiscovered) nvmeDiscovered {
+	union := nvmeDiscovered{
+		Controllers: append([]string{}, d.Controllers...),
+		Paths:       append([]string{}, d.Paths...),
+	}
+	for _, name := range other.Controllers {
+		if !slices.Contains(union.Controllers, name) {
+			union.Controllers = append(union.Controllers, name)
+		}
+	}
+	for _, name := range other.Paths {
+		if !slices.Contains(union.Paths, name) {
+			union.Paths = append(union.Paths, name)
+		}
+	}
+	slices.Sort(union.Controllers)
+	slices.Sort(union.Paths)
+	return union
+}
+
+// ownsBdev reports if bdev is a namespace of a discovered controller
+func (d nvmeDiscovered) ownsBdev(bdev string) bool {
+	return slices.ContainsFunc(d.Controllers, func(controller string) bool {
+		return isControllerBdev(path.Base(controller), bdev)
+	})
+}
+
+// storedNvmePathState returns the state of stored Nvme path not found
+// among paths of NVMe controllers in SPDK
+func storedNvmePathState(nvmePath *pb.NvmePath) nvmePathState {
+	if isDiscoveryPath(nvmePath) {
+		// SPDK does not list discovery controllers with the others
+		return nvmePathState{State: pathStateDiscovery}
+	}
+	return nvmePathState{State: pathStateMissing}
+}
+
+// isDiscoveryPath reports if nvmePath goes to a discovery subsystem
+func isDiscoveryPath(nvmePath *pb.NvmePath) bool {
+	return nvmePath.Subnqn == DiscoveryNqn
+}
+
+// discoveryPrefix returns prefix of names of controllers SPDK attaches for
+// subsystems discovered through discovery controller, SPDK appends index
+// of the discovered subsystem to it
+func discoveryPrefix(controller string) string {
+	return path.Base(controller) + "-"
+}
+
+// isDiscoveredBy reports if SPDK controller was attached by discovery
+// with prefix
+func isDiscoveredBy(prefix string, controller string) bool {
+	index := strings.TrimPrefix(controller, prefix)
+	if index == controller || index == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(index, 10, 32)
+	return err == nil
+}
+
+// discoveryReserving returns the discovery controller whose discovered
+// resources may be named name, if any, must be called with s.mu held.
+// Clients cannot create resources of such names.
+func (s *Server) discoveryReserving(name string) string {
+	for _, nvmePath := range s.Volumes.NvmePaths {
+		if isDiscoveryPath(nvmePath) && strings.HasPrefix(path.Base(name), discoveryPrefix(nvmePath.ControllerId.Value)) {
+			return nvmePath.ControllerId.Value
+		}
+	}
+	return ""
+}
+
+// discoveryOwning returns the discovery controller managing controller,
+// if any, must be called with s.mu held
+func (s *Server) discoveryOwning(controller string) string {
+	for discovery, discovered := range s.discovered {
+		if slices.Contains(discovered.Controllers, controller) {
+			return discovery
+		}
+	}
+	return ""
+}
+
+// saveDiscovered stores names of resources managed by discovery, the
+// record is removed once there are none
+func (s *Server) saveDiscovered(discovery string, discovered nvmeDiscovered) error {
+	s.mu.RLock()
+	current := s.discovered[discovery]
+	s.mu.RUnlock()
+	if slices.Equal(current.Controllers, discovered.Controllers) && slices.Equal(current.Paths, discovered.Paths) {
+		return nil
+	}
+	if len(discovered.Controllers) == 0 && len(discovered.Paths) == 0 {
+		if err := s.store.Delete(nvmeDiscoveredKind, discovery); err != nil {
+			return status.Errorf(codes.Internal, "unable to remove discovered resources of %s from store: %v", discovery, err)
+		}
+		s.mu.Lock()
+		delete(s.discovered, discovery)
+		s.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(discovered)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal discovered resources of %s: %v", discovery, err)
+	}
+	if err := s.store.Put(nvmeDiscoveredKind, discovery, data); err != nil {
+		return status.Errorf(codes.Internal, "unable to store discovered resources of %s: %v", discovery, err)
+	}
+	s.mu.Lock()
+	s.discovered[discovery] = discovered
+	s.mu.Unlock()
+	return nil
+}
+
+// restoreDiscovered loads records of resources managed by discoveries from
+// the store
+func (s *Server) restoreDiscovered() error {
+	entries, err := s.store.List(nvmeDiscoveredKind)
+	if err != nil {
+		return err
+	}
+	for discovery, data := range entries {
+		var discovered nvmeDiscovered
+		if err := json.Unmarshal(data, &discovered); err != nil {
+			return err
+		}
+		s.discovered[discovery] = discovered
+	}
+	return nil
+}
+
+// startDiscovery starts discovery through discovery controller at nvmePath
+// and waits until discovered subsystems are attached
+func (s *Server) startDiscovery(ctx context.Context, controller *pb.NvmeRemoteController, nvmePath *pb.NvmePath) error {
+	s.mu.RLock()
+	tuning := s.tunings[controller.Name]
+	s.mu.RUnlock()
+	params := bdevNvmeStartDiscoveryParams{
+		Name:                 discoveryPrefix(controller.Name),
+		Trtype:               s.opiTransportToSpdk(nvmePath.Trtype),
+		Traddr:               nvmePath.Traddr,
+		Adrfam:               s.opiAdressFamilyToSpdk(nvmePath.Adrfam),
+		Trsvcid:              fmt.Sprint(nvmePath.Trsvcid),
+		Hostnqn:              nvmePath.Hostnqn,
+		WaitForAttach:        true,
+		CtrlrLossTimeoutSec:  tuning.CtrlrLossTimeoutSec,
+		ReconnectDelaySec:    tuning.ReconnectDelaySec,
+		FastIoFailTimeoutSec: tuning.FastIoFailTimeoutSec,
+	}
+	var result bdevNvmeStartDiscoveryResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_start_discovery", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_start_discovery", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not start discovery: %s", path.Base(controller.Name))
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// stopDiscovery stops discovery through discovery controller, SPDK
+// detaches controllers of discovered subsystems
+func (s *Server) stopDiscovery(ctx context.Context, controller *pb.NvmeRemoteController) error {
+	params := bdevNvmeStopDiscoveryParams{
+		Name: discoveryPrefix(controller.Name),
+	}
+	var result bdevNvmeStopDiscoveryResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_stop_discovery", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_stop_discovery", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not stop discovery: %s", path.Base(controller.Name))
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// RefreshDiscovery updates managed NvmeRemoteControllers and NvmePaths of
+// subsystems discovered through all discovery controllers according to
+// controllers attached by SPDK. Changes are logged.
+func (s *Server) RefreshDiscovery(ctx context.Context) error {
+	s.mu.RLock()
+	var discoveries []string
+	for _, nvmePath := range s.Volumes.NvmePaths {
+		if isDiscoveryPath(nvmePath) {
+			discoveries = append(discoveries, nvmePath.ControllerId.Value)
+		}
+	}
+	s.mu.RUnlock()
+	for _, discovery := range discoveries {
+		if err := s.refreshDiscoveryOf(ctx, discovery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshDiscoveryOf updates managed resources of subsystems discovered
+// through discovery controller. Resources of a discovery which is stopped
+// are removed. Must be called without any name locked, as discovery is
+// locked together with the discovered resources it changes.
+func (s *Server) refreshDiscoveryOf(ctx context.Context, discovery string) error {
+	s.mu.RLock()
+	tracked := s.discoveryPathForController(discovery) != nil
+	s.mu.RUnlock()
+	var spdkPaths []spdkNvmePath
+	if tracked {
+		var err error
+		spdkPaths, err = s.spdkNvmePaths(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return s.syncDiscovered(ctx, discovery, tracked, spdkPaths)
+}
+
+// WatchDiscovery runs RefreshDiscovery every interval until ctx is done
+func (s *Server) WatchDiscovery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RefreshDiscovery(ctx); err != nil {
+				slog.WarnContext(ctx, "Unable to refresh discovered Nvme subsystems", "err", err)
+			}
+		}
+	}
+}
+
+// discoveryChanges are managed resources of a discovery to be added for
+// subsystems in SPDK and removed for subsystems no longer there, owned are
+// the resources managed once the changes are applied
+type discoveryChanges struct {
+	addedControllers   []*pb.NvmeRemoteController
+	addedPaths         []*pb.NvmePath
+	removedControllers []*pb.NvmeRemoteController
+	removedPaths       []*pb.NvmePath
+	owned              nvmeDiscovered
+}
+
+// names returns names of resources changed, including controllers of
+// changed paths
+func (c *discoveryChanges) names() []string {
+	var names []string
+	for _, controller := range c.addedControllers {
+		names = append(names, controller.Name)
+	}
+	for _, controller := range c.removedControllers {
+		names = append(names, controller.Name)
+	}
+	for _, nvmePath := range append(c.addedPaths, c.removedPaths...) {
+		names = append(names, nvmePath.Name, nvmePath.ControllerId.Value)
+	}
+	return names
+}
+
+// syncDiscovered adds managed resources for paths in SPDK of subsystems
+// discovered through discovery controller and removes the ones no longer
+// there. Changes are planned again once their names are locked, as other
+// requests may take generated names meanwhile.
+func (s *Server) syncDiscovered(ctx context.Context, discovery string, tracked bool, spdkPaths []spdkNvmePath) error {
+	s.mu.RLock()
+	names := append(s.planDiscovered(discovery, spdkPaths).names(), discovery)
+	s.mu.RUnlock()
+	for {
+		unlock := s.names.Lock(names...)
+		s.mu.RLock()
+		stillTracked := s.discoveryPathForController(discovery) != nil
+		changes := s.planDiscovered(discovery, spdkPaths)
+		s.mu.RUnlock()
+		if stillTracked != tracked {
+			// discovery was started or stopped meanwhile, which refreshes it again
+			unlock()
+			return nil
+		}
+		if missing := missingNames(names, changes.names()); len(missing) != 0 {
+			unlock()
+			names = append(names, missing...)
+			continue
+		}
+		err := s.applyDiscovered(ctx, discovery, changes)
+		unlock()
+		return err
+	}
+}
+
+// missingNames returns names not among locked
+func missingNames(locked []string, names []string) []string {
+	var missing []string
+	for _, name := range names {
+		if !slices.Contains(locked, name) && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// planDiscovered returns changes of managed resources of discovery for
+// paths in SPDK, must be called with s.mu held. Resources of clients named
+// like discovered ones are left alone.
+func (s *Server) planDiscovered(discovery string, spdkPaths []spdkNvmePath) *discoveryChanges {
+	prefix := discoveryPrefix(discovery)
+	discovered := s.discovered[discovery]
+	changes := &discoveryChanges{}
+	taken := func(name string) bool {
+		if _, ok := s.Volumes.NvmePaths[name]; ok {
+			return true
+		}
+		return slices.ContainsFunc(changes.addedPaths, func(p *pb.NvmePath) bool { return p.Name == name })
+	}
+	for i := range spdkPaths {
+		p := &spdkPaths[i]
+		if !isDiscoveredBy(prefix, p.controller) {
+			continue
+		}
+		controllerName := server.ResourceIDToVolumeName(p.controller)
+		if _, ok := s.Volumes.NvmeControllers[controllerName]; !ok {
+			if !slices.Contains(changes.owned.Controllers, controllerName) {
+				changes.addedControllers = append(changes.addedControllers,
+					&pb.NvmeRemoteController{Name: controllerName, Multipath: pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH})
+			}
+		} else if !slices.Contains(discovered.Controllers, controllerName) {
+			continue
+		}
+		if !slices.Contains(changes.owned.Controllers, controllerName) {
+			changes.owned.Controllers = append(changes.owned.Controllers, controllerName)
+		}
+		if nvmePath := s.discoveredPath(discovered, controllerName, p.trid); nvmePath != nil {
+			changes.owned.Paths = append(changes.owned.Paths, nvmePath.Name)
+			continue
+		}
+		var name string
+		for index := 0; name == "" || taken(name); index++ {
+			name = server.ResourceIDToVolumeName(fmt.Sprintf("%s-path%d", path.Base(controllerName), index))
+		}
+		changes.addedPaths = append(changes.addedPaths,
+			spdkNvmePathToOpi(&pb.NvmePath{Name: name, ControllerId: &pc.ObjectKey{Value: controllerName}}, p))
+		changes.owned.Paths = append(changes.owned.Paths, name)
+	}
+	for _, name := range discovered.Paths {
+		if nvmePath, ok := s.Volumes.NvmePaths[name]; ok && !slices.Contains(changes.owned.Paths, name) {
+			changes.removedPaths = append(changes.removedPaths, nvmePath)
+		}
+	}
+	for _, name := range discovered.Controllers {
+		if controller, ok := s.Volumes.NvmeControllers[name]; ok && !slices.Contains(changes.owned.Controllers, name) {
+			changes.removedControllers = append(changes.removedControllers, controller)
+		}
+	}
+	slices.Sort(changes.owned.Controllers)
+	slices.Sort(changes.owned.Paths)
+	return changes
+}
+
+// discoveredPath returns path of discovered controller to trid managed by
+// discovery, if any, must be called with s.mu held
+func (s *Server) discoveredPath(discovered nvmeDiscovered, controllerName string, trid bdevNvmeTrid) *pb.NvmePath {
+	for _, name := range discovered.Paths {
+		nvmePath, ok := s.Volumes.NvmePaths[name]
+		if ok && nvmePath.ControllerId.Value == controllerName && matchesTrid(nvmePath, trid) {
+			return nvmePath
+		}
+	}
+	return nil
+}
+
+// applyDiscovered adds and removes managed resources of a discovery, must
+// be called with names of changes locked. Added resources are recorded as
+// managed before they are stored, so they are never taken for resources of
+// clients. SPDK detached controllers of removed subsystems already,
+// resources still using their namespaces are logged, so they can be deleted
+// or moved by the client.
+func (s *Server) applyDiscovered(ctx context.Context, discovery string, changes *discoveryChanges) error {
+	s.mu.RLock()
+	discovered := s.discovered[discovery]
+	s.mu.RUnlock()
+	if err := s.saveDiscovered(discovery, discovered.union(changes.owned)); err != nil {
+		return err
+	}
+	for _, controller := range changes.addedControllers {
+		if err := store.Save(s.store, controller.Name, controller); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.Volumes.NvmeControllers[controller.Name] = controller
+		s.mu.Unlock()
+		slog.InfoContext(ctx, "Discovered Nvme subsystem", "name", controller.Name)
+	}
+	for _, nvmePath := range changes.addedPaths {
+		if err := store.Save(s.store, nvmePath.Name, nvmePath); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.Volumes.NvmePaths[nvmePath.Name] = nvmePath
+		s.mu.Unlock()
+		slog.InfoContext(ctx, "Discovered Nvme path", "name", nvmePath.Name, "controller", nvmePath.ControllerId.Value,
+			"subnqn", nvmePath.Subnqn, "traddr", nvmePath.Traddr, "trsvcid", nvmePath.Trsvcid)
+	}
+	for _, nvmePath := range changes.removedPaths {
+		if err := store.Remove(s.store, nvmePath.Name, nvmePath); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		delete(s.Volumes.NvmePaths, nvmePath.Name)
+		s.mu.Unlock()
+		slog.WarnContext(ctx, "Nvme path is no longer discovered", "name", nvmePath.Name, "traddr", nvmePath.Traddr, "trsvcid", nvmePath.Trsvcid)
+	}
+	for _, controller := range changes.removedControllers {
+		if err := store.Remove(s.store, controller.Name, controller); err != nil {
+			return err
+		}
+		if err := s.removeTuning(controller.Name); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		delete(s.Volumes.NvmeControllers, controller.Name)
+		s.mu.Unlock()
+		dependents := s.Consumers.Dependents(func(bdev string) bool {
+			return isControllerBdev(path.Base(controller.Name), bdev)
+		})
+		if len(dependents) != 0 {
+			slog.ErrorContext(ctx, "Nvme subsystem is no longer discovered, its namespaces are still used",
+				"name", controller.Name, "dependents", fmt.Sprint(dependents))
+			continue
+		}
+		slog.WarnContext(ctx, "Nvme subsystem is no longer discovered", "name", controller.Name)
+	}
+	return s.saveDiscovered(discovery, changes.owned)
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testDiscoveryPath = pb.NvmePath{
+		Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Adrfam:       pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
+		Traddr:       "127.0.0.1",
+		Trsvcid:      8009,
+		Subnqn:       DiscoveryNqn,
+		Hostnqn:      "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c",
+		ControllerId: &pc.ObjectKey{Value: testNvmeCtrlName},
+	}
+	// subsystem discovered through testDiscoveryPath
+	testSpdkDiscoveredControllers = `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"opi-nvme8-0","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4420","subnqn":"nqn.2016-06.io.spdk:cnode1"},"cntlid":1,"host":{"nqn":"nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c"}}]},` +
+		`{"name":"unmanaged","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"},"cntlid":2,"host":{"nqn":"nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c"}}]}]}`
+	testSpdkNoNvmeControllers = `{"id":%d,"error":{"code":0,"message":""},"result":[]}`
+	testSpdkNoIoPaths         = `{"id":%d,"error":{"code":0,"message":""},"result":{"poll_groups":[]}}`
+	testDiscoveredName        = server.ResourceIDToVolumeName("opi-nvme8-0")
+	testDiscoveredPathName    = server.ResourceIDToVolumeName("opi-nvme8-0-path0")
+)
+
+func TestIsDiscoveredBy(t *testing.T) {
+	tests := map[string]struct {
+		controller string
+		out        bool
+	}{
+		"discovered subsystem":     {controller: "opi-nvme8-0", out: true},
+		"discovered subsystem 12":  {controller: "opi-nvme8-12", out: true},
+		"discovery controller":     {controller: "opi-nvme8", out: false},
+		"prefix only":              {controller: "opi-nvme8-", out: false},
+		"other controller":         {controller: "opi-nvme8-path", out: false},
+		"controller of other name": {controller: "opi-nvme9-0", out: false},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if out := isDiscoveredBy(discoveryPrefix(testNvmeCtrlName), tt.controller); out != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+		})
+	}
+}
+
+func TestNvmeDiscovered_OwnsBdev(t *testing.T) {
+	discovered := nvmeDiscovered{Controllers: []string{testDiscoveredName}}
+	tests := map[string]struct {
+		bdev string
+		out  bool
+	}{
+		"namespace of discovered subsystem": {bdev: "opi-nvme8-0n1", out: true},
+		"namespace 12 of subsystem":         {bdev: "opi-nvme8-0n12", out: true},
+		"namespace of other subsystem":      {bdev: "opi-nvme8-3n1", out: false},
+		"discovered subsystem":              {bdev: "opi-nvme8-0", out: false},
+		"namespace of discovery controller": {bdev: "opi-nvme8n1", out: false},
+		"no namespace id":                   {bdev: "opi-nvme8-0n", out: false},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if out := discovered.ownsBdev(tt.bdev); out != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+		})
+	}
+}
+
+func TestBackEnd_NvmeDiscovery(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		// CreateNvmePath
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+		// GetNvmePath
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+		// RefreshDiscovery after subsystem left discovery log
+		testSpdkNoNvmeControllers,
+		testSpdkNoIoPaths,
+		// RefreshDiscovery after subsystem came back
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+		// DeleteNvmePath
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+	})
+	defer testEnv.Close()
+	volumes := &testEnv.opiSpdkServer.Volumes
+	if _, err := testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: &testNvmeCtrl, NvmeRemoteControllerId: testNvmeCtrlID}); err != nil {
+		t.Fatal(err)
+	}
+
+	discovery, err := testEnv.client.CreateNvmePath(testEnv.ctx,
+		&pb.CreateNvmePathRequest{NvmePath: &testDiscoveryPath, NvmePathId: testNvmePathID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := volumes.NvmeControllers[testDiscoveredName]; !ok {
+		t.Errorf("Expected discovered controller %v, received: %v", testDiscoveredName, volumes.NvmeControllers)
+	}
+	discovered, ok := volumes.NvmePaths[testDiscoveredPathName]
+	if !ok {
+		t.Fatalf("Expected discovered path %v, received: %v", testDiscoveredPathName, volumes.NvmePaths)
+	}
+	if discovered.ControllerId.Value != testDiscoveredName || discovered.Trsvcid != 4420 ||
+		discovered.Subnqn != "nqn.2016-06.io.spdk:cnode1" {
+		t.Errorf("Expected path of discovered subsystem, received: %v", discovered)
+	}
+	if len(volumes.NvmeControllers) != 2 || len(volumes.NvmePaths) != 2 {
+		t.Errorf("Expected only discovered subsystem managed, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+
+	// discovery controller has a single path
+	other := server.ProtoClone(&testNvmePath)
+	_, err = testEnv.client.CreateNvmePath(testEnv.ctx, &pb.CreateNvmePathRequest{NvmePath: other, NvmePathId: "other"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for second path, received: %v", err)
+	}
+
+	extended, err := testEnv.client.GetExtendedNvmePath(testEnv.ctx, &px.GetExtendedNvmePathRequest{Name: discovery.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &px.NvmePathExtension{State: "discovery"}
+	if !proto.Equal(extended.Extension, state) {
+		t.Errorf("Expected state %v, received: %v", state, extended.Extension)
+	}
+
+	if err := testEnv.opiSpdkServer.RefreshDiscovery(testEnv.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(volumes.NvmeControllers) != 1 || len(volumes.NvmePaths) != 1 {
+		t.Errorf("Expected subsystem removed from discovery log not managed, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+	if err := testEnv.opiSpdkServer.RefreshDiscovery(testEnv.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := volumes.NvmePaths[testDiscoveredPathName]; !ok {
+		t.Errorf("Expected subsystem added to discovery log managed, received: %v", volumes.NvmePaths)
+	}
+
+	if _, err := testEnv.client.DeleteNvmePath(testEnv.ctx, &pb.DeleteNvmePathRequest{Name: discovery.Name}); err != nil {
+		t.Fatal(err)
+	}
+	if len(volumes.NvmeControllers) != 1 || len(volumes.NvmePaths) != 0 {
+		t.Errorf("Expected discovered subsystems not managed after discovery stopped, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+}
+
+func TestBackEnd_NvmeDiscoveryLocksGeneratedNames(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+	})
+	defer testEnv.Close()
+	volumes := &testEnv.opiSpdkServer.Volumes
+	controller := server.ProtoClone(&testNvmeCtrl)
+	controller.Name = testNvmeCtrlName
+	volumes.NvmeControllers[testNvmeCtrlName] = controller
+	discovery := server.ProtoClone(&testDiscoveryPath)
+	discovery.Name = testNvmePathName
+	volumes.NvmePaths[testNvmePathName] = discovery
+
+	// a client request creating a path of the generated name
+	unlock := testEnv.opiSpdkServer.names.Lock(testDiscoveredPathName)
+	refreshed := make(chan error)
+	go func() {
+		refreshed <- testEnv.opiSpdkServer.RefreshDiscovery(context.Background())
+	}()
+	select {
+	case err := <-refreshed:
+		t.Fatal("Expected refresh to wait for locked name, received:", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	testEnv.opiSpdkServer.mu.Lock()
+	volumes.NvmePaths[testDiscoveredPathName] = server.ProtoClone(&testNvmePathNamed)
+	testEnv.opiSpdkServer.mu.Unlock()
+	unlock()
+	if err := <-refreshed; err != nil {
+		t.Fatal(err)
+	}
+
+	if discovered := volumes.NvmePaths[testDiscoveredPathName]; discovered.ControllerId.Value != testNvmeCtrlName {
+		t.Errorf("Expected path of client kept, received: %v", discovered)
+	}
+	name := server.ResourceIDToVolumeName("opi-nvme8-0-path1")
+	if discovered, ok := volumes.NvmePaths[name]; !ok || discovered.ControllerId.Value != testDiscoveredName {
+		t.Errorf("Expected discovered path %v, received: %v", name, volumes.NvmePaths)
+	}
+}
+
+func TestBackEnd_DeleteNvmeDiscoveryPathInUse(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	volumes := &testEnv.opiSpdkServer.Volumes
+	controller := server.ProtoClone(&testNvmeCtrl)
+	controller.Name = testNvmeCtrlName
+	volumes.NvmeControllers[testNvmeCtrlName] = controller
+	discovery := server.ProtoClone(&testDiscoveryPath)
+	discovery.Name = testNvmePathName
+	volumes.NvmePaths[testNvmePathName] = discovery
+	volumes.NvmeControllers[testDiscoveredName] = &pb.NvmeRemoteController{Name: testDiscoveredName}
+	volumes.NvmePaths[testDiscoveredPathName] = &pb.NvmePath{
+		Name: testDiscoveredPathName, ControllerId: &pc.ObjectKey{Value: testDiscoveredName},
+	}
+	testEnv.opiSpdkServer.discovered[testNvmeCtrlName] = nvmeDiscovered{
+		Controllers: []string{testDiscoveredName}, Paths: []string{testDiscoveredPathName},
+	}
+	testEnv.opiSpdkServer.Consumers = &server.VolumeConsumers{}
+	testEnv.opiSpdkServer.Consumers.Register(server.VolumeConsumer{
+		Kind: "NvmeNamespace",
+		Uses: func() map[string][]string { return map[string][]string{"opi-nvme8-0n1": {"ns0"}} },
+	})
+
+	_, err := testEnv.client.DeleteNvmePath(testEnv.ctx, &pb.DeleteNvmePathRequest{Name: testNvmePathName})
+
+	expectedMsg := testNvmePathName + " is used by [NvmeNamespace ns0]"
+	if er, ok := status.FromError(err); ok {
+		if er.Code() != codes.FailedPrecondition {
+			t.Error("error code: expected", codes.FailedPrecondition, "received", er.Code())
+		}
+		if er.Message() != expectedMsg {
+			t.Error("error message: expected", expectedMsg, "received", er.Message())
+		}
+	} else {
+		t.Error("expected grpc error status")
+	}
+	if len(volumes.NvmeControllers) != 2 || len(volumes.NvmePaths) != 2 {
+		t.Errorf("Expected discovery kept, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+}
+
+func TestBackEnd_NvmeDiscoveryKeepsClientResources(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+		testSpdkNoNvmeControllers,
+		testSpdkNoIoPaths,
+	})
+	defer testEnv.Close()
+	volumes := &testEnv.opiSpdkServer.Volumes
+	controller := server.ProtoClone(&testNvmeCtrl)
+	controller.Name = testNvmeCtrlName
+	volumes.NvmeControllers[testNvmeCtrlName] = controller
+	discovery := server.ProtoClone(&testDiscoveryPath)
+	discovery.Name = testNvmePathName
+	volumes.NvmePaths[testNvmePathName] = discovery
+	// created by a client before discovery started
+	volumes.NvmeControllers[testDiscoveredName] = &pb.NvmeRemoteController{Name: testDiscoveredName}
+	clientPath := server.ResourceIDToVolumeName("client-path")
+	volumes.NvmePaths[clientPath] = &pb.NvmePath{
+		Name: clientPath, ControllerId: &pc.ObjectKey{Value: testDiscoveredName},
+		Traddr: "127.0.0.1", Trsvcid: 4420, Subnqn: "nqn.2016-06.io.spdk:cnode1",
+	}
+
+	if err := testEnv.opiSpdkServer.RefreshDiscovery(testEnv.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(volumes.NvmeControllers) != 2 || len(volumes.NvmePaths) != 2 {
+		t.Errorf("Expected resources of client not adopted, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+	if discovered, ok := testEnv.opiSpdkServer.discovered[testNvmeCtrlName]; ok {
+		t.Errorf("Expected no discovered resources, received: %v", discovered)
+	}
+
+	if err := testEnv.opiSpdkServer.RefreshDiscovery(testEnv.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := volumes.NvmeControllers[testDiscoveredName]; !ok {
+		t.Errorf("Expected controller of client kept, received: %v", volumes.NvmeControllers)
+	}
+	if _, ok := volumes.NvmePaths[clientPath]; !ok {
+		t.Errorf("Expected path of client kept, received: %v", volumes.NvmePaths)
+	}
+}
+
+func TestBackEnd_NvmeDiscoveryRecordRestored(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		// CreateNvmePath
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+		testSpdkDiscoveredControllers,
+		testSpdkNoIoPaths,
+	})
+	defer testEnv.Close()
+	if _, err := testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: &testNvmeCtrl, NvmeRemoteControllerId: testNvmeCtrlID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testEnv.client.CreateNvmePath(testEnv.ctx,
+		&pb.CreateNvmePathRequest{NvmePath: &testDiscoveryPath, NvmePathId: testNvmePathID}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewServer(testEnv.opiSpdkServer.rpc, testEnv.opiSpdkServer.store)
+
+	expected := nvmeDiscovered{Controllers: []string{testDiscoveredName}, Paths: []string{testDiscoveredPathName}}
+	if discovered := restored.discovered[testNvmeCtrlName]; !reflect.DeepEqual(discovered, expected) {
+		t.Errorf("Expected discovered resources %v, received: %v", expected, discovered)
+	}
+}
+
+func TestBackEnd_CreateNvmeDiscoveredNames(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	volumes := &testEnv.opiSpdkServer.Volumes
+	controller := server.ProtoClone(&testNvmeCtrl)
+	controller.Name = testNvmeCtrlName
+	volumes.NvmeControllers[testNvmeCtrlName] = controller
+	discovery := server.ProtoClone(&testDiscoveryPath)
+	discovery.Name = testNvmePathName
+	volumes.NvmePaths[testNvmePathName] = discovery
+	volumes.NvmeControllers[testDiscoveredName] = &pb.NvmeRemoteController{Name: testDiscoveredName}
+	testEnv.opiSpdkServer.discovered[testNvmeCtrlName] = nvmeDiscovered{Controllers: []string{testDiscoveredName}}
+
+	_, err := testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: &testNvmeCtrl, NvmeRemoteControllerId: "opi-nvme8-1"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for controller named like discovered, received: %v", err)
+	}
+
+	nvmePath := server.ProtoClone(&testNvmePath)
+	_, err = testEnv.client.CreateNvmePath(testEnv.ctx, &pb.CreateNvmePathRequest{NvmePath: nvmePath, NvmePathId: "opi-nvme8-0-path1"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for path named like discovered, received: %v", err)
+	}
+
+	nvmePath.ControllerId = &pc.ObjectKey{Value: testDiscoveredName}
+	_, err = testEnv.client.CreateNvmePath(testEnv.ctx, &pb.CreateNvmePathRequest{NvmePath: nvmePath, NvmePathId: "other"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for path of discovered controller, received: %v", err)
+	}
+	if len(volumes.NvmeControllers) != 2 || len(volumes.NvmePaths) != 1 {
+		t.Errorf("Expected nothing created, received: %v %v", volumes.NvmeControllers, volumes.NvmePaths)
+	}
+}
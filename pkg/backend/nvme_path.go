@@ -8,36 +8,31 @@ package backend
 import (
 	"context"
 	"fmt"
-	"log"
 	"path"
-	"sort"
 	"strings"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
 	"go.einride.tech/aip/resourcename"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortNvmePaths(paths []*pb.NvmePath) {
-	sort.Slice(paths, func(i int, j int) bool {
-		return paths[i].Subnqn < paths[j].Subnqn
-	})
-}
-
 // CreateNvmePath creates a new Nvme path
-func (s *Server) CreateNvmePath(_ context.Context, in *pb.CreateNvmePathRequest) (*pb.NvmePath, error) {
-	log.Printf("CreateNvmePath: Received from client: %v", in)
+func (s *Server) CreateNvmePath(ctx context.Context, in *pb.CreateNvmePathRequest) (*pb.NvmePath, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
@@ -45,268 +40,503 @@ func (s *Server) CreateNvmePath(_ context.Context, in *pb.CreateNvmePathRequest)
 	if in.NvmePathId != "" {
 		err := resourceid.ValidateUserSettable(in.NvmePathId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.NvmePathId, in.NvmePath.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.NvmePathId, "name", in.NvmePath.Name)
 		resourceID = in.NvmePathId
 	}
 	in.NvmePath.Name = server.ResourceIDToVolumeName(resourceID)
+	response, created, err := s.createNvmePath(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if created && isDiscoveryPath(response) {
+		// subsystems are attached already, track them right away
+		if err := s.refreshDiscoveryOf(ctx, response.ControllerId.Value); err != nil {
+			slog.WarnContext(ctx, "Unable to track discovered Nvme subsystems", "controller", response.ControllerId.Value, "err", err)
+		}
+	}
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
 
+// createNvmePath creates Nvme path named in request, created is false if
+// it exists already. Discovered subsystems are tracked by the caller, as
+// the path and its controller are locked meanwhile.
+func (s *Server) createNvmePath(ctx context.Context, in *pb.CreateNvmePathRequest) (*pb.NvmePath, bool, error) {
+	// paths of the same controller are serialized, multipath depends on their number
+	unlock := s.names.Lock(in.NvmePath.Name, in.NvmePath.ControllerId.Value)
+	defer unlock()
+
+	s.mu.RLock()
 	nvmePath, ok := s.Volumes.NvmePaths[in.NvmePath.Name]
+	reserving := s.discoveryReserving(in.NvmePath.Name)
+	s.mu.RUnlock()
+	if reserving != "" {
+		err := status.Errorf(codes.InvalidArgument, "name %s is reserved for resources discovered through %s", in.NvmePath.Name, reserving)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
+	}
 	if ok {
-		log.Printf("Already existing NvmePath with id %v", in.NvmePath.Name)
-		return nvmePath, nil
+		slog.InfoContext(ctx, "Already existing NvmePath", "name", in.NvmePath.Name)
+		return nvmePath, false, nil
 	}
 
+	s.mu.RLock()
 	controller, ok := s.Volumes.NvmeControllers[in.NvmePath.ControllerId.Value]
+	owning := s.discoveryOwning(in.NvmePath.ControllerId.Value)
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find NvmeRemoteController by key %s", in.NvmePath.ControllerId.Value)
-		log.Printf("error: %v", err)
-		return nil, err
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
+	}
+	if owning != "" {
+		err := status.Errorf(codes.FailedPrecondition, "NvmeRemoteController %s is managed by discovery through %s", controller.Name, owning)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
 	}
 
 	multipath := ""
-	if numberOfPaths := s.numberOfPathsForController(controller.Name); numberOfPaths > 0 {
+	s.mu.RLock()
+	numberOfPaths := s.numberOfPathsForController(controller.Name)
+	discovery := s.discoveryPathForController(controller.Name)
+	s.mu.RUnlock()
+	if numberOfPaths > 0 && (discovery != nil || isDiscoveryPath(in.NvmePath)) {
+		err := status.Errorf(codes.FailedPrecondition, "discovery NvmeRemoteController %s can have a single path", controller.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
+	}
+	if numberOfPaths > 0 {
 		// set multipath parameter only when at least one path already exists
 		multipath = s.opiMultipathToSpdk(controller.Multipath)
 	}
-	psk := ""
-	if len(controller.Psk) > 0 {
-		log.Printf("Notice, TLS is used to establish connection: to %v", in.NvmePath)
-		// TODO: write controller.Psk to file /tmp/opikey.txt
-		psk = "/tmp/opikey.txt"
-	}
-	params := spdk.BdevNvmeAttachControllerParams{
-		Name:      path.Base(controller.Name),
-		Trtype:    s.opiTransportToSpdk(in.NvmePath.Trtype),
-		Traddr:    in.NvmePath.Traddr,
-		Adrfam:    s.opiAdressFamilyToSpdk(in.NvmePath.Adrfam),
-		Trsvcid:   fmt.Sprint(in.NvmePath.Trsvcid),
-		Subnqn:    in.NvmePath.Subnqn,
-		Hostnqn:   in.NvmePath.Hostnqn,
-		Multipath: multipath,
-		Hdgst:     controller.Hdgst,
-		Ddgst:     controller.Ddgst,
-		Psk:       psk,
-	}
-	var result []spdk.BdevNvmeAttachControllerResult
-	err := s.rpc.Call("bdev_nvme_attach_controller", &params, &result)
-	if err != nil {
-		log.Printf("error: %v", err)
-		return nil, err
+	if isDiscoveryPath(in.NvmePath) {
+		if err := s.startDiscovery(ctx, controller, in.NvmePath); err != nil {
+			return nil, false, err
+		}
+	} else if err := s.attachNvmePath(ctx, controller, in.NvmePath, multipath); err != nil {
+		return nil, false, err
 	}
-	log.Printf("Received from SPDK: %v", result)
 
 	response := server.ProtoClone(in.NvmePath)
+	if err := store.Save(s.store, in.NvmePath.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, false, err
+	}
+	s.mu.Lock()
 	s.Volumes.NvmePaths[in.NvmePath.Name] = response
-	log.Printf("CreateNvmePath: Sending to client: %v", response)
-	return response, nil
+	s.mu.Unlock()
+	return response, true, nil
 }
 
 // DeleteNvmePath deletes a Nvme path
-func (s *Server) DeleteNvmePath(_ context.Context, in *pb.DeleteNvmePathRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteNvmePath: Received from client: %v", in)
+func (s *Server) DeleteNvmePath(ctx context.Context, in *pb.DeleteNvmePathRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	nvmePath, err := s.deleteNvmePath(ctx, in)
+	if err != nil {
 		return nil, err
 	}
+	if nvmePath != nil && isDiscoveryPath(nvmePath) {
+		// SPDK detached all discovered subsystems
+		if err := s.refreshDiscoveryOf(ctx, nvmePath.ControllerId.Value); err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+	}
 
-	nvmePath, ok := s.Volumes.NvmePaths[in.Name]
-	if !ok {
+	return &emptypb.Empty{}, nil
+}
+
+// deleteNvmePath deletes Nvme path named in request and returns it, nil
+// if it is missing. Discovered subsystems are removed by the caller, as
+// the path and its controller are locked meanwhile.
+func (s *Server) deleteNvmePath(ctx context.Context, in *pb.DeleteNvmePathRequest) (*pb.NvmePath, error) {
+	// paths of the same controller are serialized, multipath depends on their number
+	nvmePath, unlock := s.lockNvmePath(in.Name)
+	defer unlock()
+	if nvmePath == nil {
 		if in.AllowMissing {
-			return &emptypb.Empty{}, nil
+			return nil, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	s.mu.RLock()
 	controller, ok := s.Volumes.NvmeControllers[nvmePath.ControllerId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.Internal, "unable to find NvmeRemoteController by key %s", nvmePath.ControllerId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
-	params := spdk.BdevNvmeDetachControllerParams{
-		Name:    path.Base(controller.Name),
-		Trtype:  s.opiTransportToSpdk(nvmePath.Trtype),
-		Traddr:  nvmePath.Traddr,
-		Adrfam:  s.opiAdressFamilyToSpdk(nvmePath.Adrfam),
-		Trsvcid: fmt.Sprint(nvmePath.Trsvcid),
-		Subnqn:  nvmePath.Subnqn,
-	}
-
-	var result spdk.BdevNvmeDetachControllerResult
-	err := s.rpc.Call("bdev_nvme_detach_controller", &params, &result)
+	released, err := s.releaseNvmePath(ctx, in.Name, controller, nvmePath)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if !result {
-		msg := fmt.Sprintf("Could not delete Nvme Path: %s", path.Base(in.Name))
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	defer released()
+
+	if isDiscoveryPath(nvmePath) {
+		if err := s.stopDiscovery(ctx, controller); err != nil {
+			return nil, err
+		}
+	} else if err := s.detachNvmePath(ctx, controller, nvmePath); err != nil {
+		return nil, err
 	}
 
+	if err := store.Remove(s.store, in.Name, nvmePath); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Volumes.NvmePaths, in.Name)
-
-	return &emptypb.Empty{}, nil
+	s.mu.Unlock()
+	return nvmePath, nil
 }
 
-// UpdateNvmePath updates an Nvme path
-func (s *Server) UpdateNvmePath(_ context.Context, in *pb.UpdateNvmePathRequest) (*pb.NvmePath, error) {
-	log.Printf("UpdateNvmePath: Received from client: %v", in)
+// UpdateNvmePath updates an Nvme path. Only transport address of the path
+// can be updated, which attaches the new path to the controller before the
+// old one is detached, so I/O fails over to it without interruption.
+func (s *Server) UpdateNvmePath(ctx context.Context, in *pb.UpdateNvmePathRequest) (*pb.NvmePath, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.NvmePath.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	// fetch object from the database
-	volume, ok := s.Volumes.NvmePaths[in.NvmePath.Name]
-	if !ok {
+	// paths of the same controller are serialized, multipath depends on their number
+	volume, unlock := s.lockNvmePath(in.NvmePath.Name)
+	defer unlock()
+	if volume == nil {
 		if in.AllowMissing {
-			log.Printf("TODO: in case of AllowMissing, create a new resource, don;t return error")
+			slog.DebugContext(ctx, "TODO: in case of AllowMissing, create a new resource, don;t return error")
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NvmePath.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	resourceID := path.Base(volume.Name)
 	// update_mask = 2
 	if err := fieldmask.Validate(in.UpdateMask, in.NvmePath); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("TODO: use resourceID=%v", resourceID)
-	response := server.ProtoClone(in.NvmePath)
-	// s.Volumes.NvmePaths[in.NvmePath.Name] = response
+	updated := server.ProtoClone(volume)
+	fieldmask.Update(in.UpdateMask, updated, in.NvmePath)
+	if err := validateNvmePathUpdate(volume, updated); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if proto.Equal(volume, updated) {
+		return server.ProtoClone(volume), nil
+	}
+	if isDiscoveryPath(volume) {
+		err := status.Errorf(codes.FailedPrecondition, "discovery NvmePath %s cannot be moved", volume.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.RLock()
+	controller, ok := s.Volumes.NvmeControllers[volume.ControllerId.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.Internal, "unable to find NvmeRemoteController by key %s", volume.ControllerId.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+
+	if err := s.failoverNvmePath(ctx, controller, volume, updated); err != nil {
+		return nil, err
+	}
+
+	response := server.ProtoClone(updated)
+	if err := store.Save(s.store, updated.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.Volumes.NvmePaths[updated.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
+// lockNvmePath locks Nvme path name together with its controller in a
+// single Lock call and returns the path, nil if there is no such path
+func (s *Server) lockNvmePath(name string) (*pb.NvmePath, func()) {
+	for {
+		s.mu.RLock()
+		volume, ok := s.Volumes.NvmePaths[name]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, s.names.Lock(name)
+		}
+		unlock := s.names.Lock(name, volume.ControllerId.Value)
+		// the path may have been deleted or recreated meanwhile
+		s.mu.RLock()
+		locked := s.Volumes.NvmePaths[name]
+		s.mu.RUnlock()
+		if locked == nil || locked.ControllerId.Value == volume.ControllerId.Value {
+			return locked, unlock
+		}
+		unlock()
+	}
+}
+
 // ListNvmePaths lists Nvme path
-func (s *Server) ListNvmePaths(_ context.Context, in *pb.ListNvmePathsRequest) (*pb.ListNvmePathsResponse, error) {
-	log.Printf("ListNvmePaths: Received from client: %v", in)
+func (s *Server) ListNvmePaths(ctx context.Context, in *pb.ListNvmePathsRequest) (*pb.ListNvmePathsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.NvmePath{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
-	var result []spdk.BdevNvmeGetControllerResult
-	err := s.rpc.Call("bdev_nvme_get_controllers", nil, &result)
+	paths, err := s.extendedNvmePaths(ctx)
 	if err != nil {
-		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-	result, hasMoreElements := server.LimitPagination(result, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
+	Blobarray := make([]*pb.NvmePath, len(paths))
+	for i, p := range paths {
+		Blobarray[i] = p.NvmePath
 	}
-	Blobarray := make([]*pb.NvmePath, len(result))
-	for i := range result {
-		r := &result[i]
-		Blobarray[i] = &pb.NvmePath{Name: r.Name /* TODO: fill this */}
-	}
-	sortNvmePaths(Blobarray)
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.NvmePath).GetName)
 	return &pb.ListNvmePathsResponse{NvmePaths: Blobarray, NextPageToken: token}, nil
 }
 
 // GetNvmePath gets Nvme path
-func (s *Server) GetNvmePath(_ context.Context, in *pb.GetNvmePathRequest) (*pb.NvmePath, error) {
-	log.Printf("GetNvmePath: Received from client: %v", in)
+func (s *Server) GetNvmePath(ctx context.Context, in *pb.GetNvmePathRequest) (*pb.NvmePath, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, err := s.extendedNvmePath(ctx, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Sending to client", "response", response.NvmePath)
+	return response.NvmePath, nil
+}
+
+// ListExtendedNvmePaths lists Nvme paths with their state in SPDK
+func (s *Server) ListExtendedNvmePaths(ctx context.Context, in *px.ListExtendedNvmePathsRequest) (*px.ListExtendedNvmePathsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	path, ok := s.Volumes.NvmePaths[in.Name]
-	if !ok {
-		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.ExtendedNvmePath{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	Blobarray, err := s.extendedNvmePaths(ctx)
+	if err != nil {
 		return nil, err
 	}
+	Blobarray, token := server.Paginate(opts, Blobarray, func(p *px.ExtendedNvmePath) string { return p.NvmePath.Name })
+	return &px.ListExtendedNvmePathsResponse{ExtendedNvmePaths: Blobarray, NextPageToken: token}, nil
+}
 
-	var result []spdk.BdevNvmeGetControllerResult
-	err := s.rpc.Call("bdev_nvme_get_controllers", nil, &result)
+// GetExtendedNvmePath gets Nvme path with its state in SPDK
+func (s *Server) GetExtendedNvmePath(ctx context.Context, in *px.GetExtendedNvmePathRequest) (*px.ExtendedNvmePath, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	response, err := s.extendedNvmePath(ctx, in.Name)
 	if err != nil {
-		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
 
-	for i := range result {
-		r := &result[i]
-		if r.Name != "" {
-			return &pb.NvmePath{ /* TODO: fill this */ }, nil
-		}
+// extendedNvmePaths returns all Nvme paths with transport fields and state
+// taken from their paths in SPDK
+func (s *Server) extendedNvmePaths(ctx context.Context) ([]*px.ExtendedNvmePath, error) {
+	spdkPaths, err := s.spdkNvmePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	paths := make([]*px.ExtendedNvmePath, 0, len(s.Volumes.NvmePaths))
+	for _, stored := range s.Volumes.NvmePaths {
+		paths = append(paths, extendNvmePath(spdkPaths, stored))
 	}
-	msg := fmt.Sprintf("Could not find NQN: %s", path.Subnqn)
-	log.Print(msg)
-	return nil, status.Errorf(codes.InvalidArgument, msg)
+	return paths, nil
+}
+
+// extendedNvmePath returns Nvme path name with transport fields and state
+// taken from its path in SPDK
+func (s *Server) extendedNvmePath(ctx context.Context, name string) (*px.ExtendedNvmePath, error) {
+	// fetch object from the database
+	s.mu.RLock()
+	path, ok := s.Volumes.NvmePaths[name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	spdkPaths, err := s.spdkNvmePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return extendNvmePath(spdkPaths, path), nil
 }
 
 // NvmePathStats gets Nvme path stats
-func (s *Server) NvmePathStats(_ context.Context, in *pb.NvmePathStatsRequest) (*pb.NvmePathStatsResponse, error) {
-	log.Printf("NvmePathStats: Received from client: %v", in)
+func (s *Server) NvmePathStats(ctx context.Context, in *pb.NvmePathStatsRequest) (*pb.NvmePathStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Id.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Volumes.NvmePaths[in.Id.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Id.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
-	log.Printf("TODO: send name to SPDK and get back stats: %v", resourceID)
+	slog.DebugContext(ctx, "TODO: send name to SPDK and get back stats", "name", resourceID)
 	var result spdk.NvmfGetSubsystemStatsResult
-	err := s.rpc.Call("nvmf_get_stats", nil, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_get_stats", nil, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_get_stats", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	return &pb.NvmePathStatsResponse{Stats: &pb.VolumeStats{ReadOpsCount: -1, WriteOpsCount: -1}}, nil
 }
 
+// attachNvmePath attaches nvmePath to controller in SPDK, multipath is
+// the SPDK mode of adding the path to already attached paths of controller
+func (s *Server) attachNvmePath(ctx context.Context, controller *pb.NvmeRemoteController, nvmePath *pb.NvmePath, multipath string) error {
+	psk := ""
+	if len(controller.Psk) > 0 {
+		slog.InfoContext(ctx, "TLS is used to establish connection", "controller", controller.Name, "traddr", nvmePath.Traddr)
+		var err error
+		if psk, err = s.writePskFile(controller); err != nil {
+			slog.ErrorContext(ctx, "Unable to write PSK file", "err", err)
+			return err
+		}
+	}
+	s.mu.RLock()
+	tuning := s.tunings[controller.Name]
+	s.mu.RUnlock()
+	params := bdevNvmeAttachControllerParams{
+		BdevNvmeAttachControllerParams: spdk.BdevNvmeAttachControllerParams{
+			Name:      path.Base(controller.Name),
+			Trtype:    s.opiTransportToSpdk(nvmePath.Trtype),
+			Traddr:    nvmePath.Traddr,
+			Adrfam:    s.opiAdressFamilyToSpdk(nvmePath.Adrfam),
+			Trsvcid:   fmt.Sprint(nvmePath.Trsvcid),
+			Subnqn:    nvmePath.Subnqn,
+			Hostnqn:   nvmePath.Hostnqn,
+			Multipath: multipath,
+			Hdgst:     controller.Hdgst,
+			Ddgst:     controller.Ddgst,
+			Psk:       psk,
+		},
+		CtrlrLossTimeoutSec:  tuning.CtrlrLossTimeoutSec,
+		ReconnectDelaySec:    tuning.ReconnectDelaySec,
+		FastIoFailTimeoutSec: tuning.FastIoFailTimeoutSec,
+	}
+	var result []spdk.BdevNvmeAttachControllerResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_attach_controller", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_attach_controller", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	// namespaces appear with the first path, policy is set on every attach
+	// since SPDK keeps it per namespace
+	bdevs := make([]string, len(result))
+	for i, bdev := range result {
+		bdevs[i] = string(bdev)
+	}
+	return s.setMultipathPolicy(ctx, tuning, bdevs)
+}
+
+// detachNvmePath detaches nvmePath from controller in SPDK, other paths
+// of the controller stay attached
+func (s *Server) detachNvmePath(ctx context.Context, controller *pb.NvmeRemoteController, nvmePath *pb.NvmePath) error {
+	params := spdk.BdevNvmeDetachControllerParams{
+		Name:    path.Base(controller.Name),
+		Trtype:  s.opiTransportToSpdk(nvmePath.Trtype),
+		Traddr:  nvmePath.Traddr,
+		Adrfam:  s.opiAdressFamilyToSpdk(nvmePath.Adrfam),
+		Trsvcid: fmt.Sprint(nvmePath.Trsvcid),
+		Subnqn:  nvmePath.Subnqn,
+	}
+
+	var result spdk.BdevNvmeDetachControllerResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_detach_controller", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_detach_controller", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Nvme Path: %s", path.Base(nvmePath.Name))
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
 func (s *Server) opiTransportToSpdk(transport pb.NvmeTransportType) string {
 	return strings.ReplaceAll(transport.String(), "NVME_TRANSPORT_", "")
 }
@@ -321,6 +551,42 @@ func (s *Server) opiMultipathToSpdk(multipath pb.NvmeMultipath) string {
 	)
 }
 
+// discoveryPathForController returns the discovery path of controller,
+// if any, must be called with s.mu held
+func (s *Server) discoveryPathForController(controllerName string) *pb.NvmePath {
+	for _, path := range s.Volumes.NvmePaths {
+		if path.ControllerId.Value == controllerName && isDiscoveryPath(path) {
+			return path
+		}
+	}
+	return nil
+}
+
+// releaseNvmePath releases namespace bdevs of controller from resources
+// using them, if nvmePath is the last path of controller. Namespaces of
+// subsystems discovered through a discovery path are released with it.
+func (s *Server) releaseNvmePath(ctx context.Context, name string, controller *pb.NvmeRemoteController, nvmePath *pb.NvmePath) (done func(), err error) {
+	if isDiscoveryPath(nvmePath) {
+		s.mu.RLock()
+		discovered := s.discovered[controller.Name]
+		s.mu.RUnlock()
+		return s.Consumers.Release(ctx, name, discovered.ownsBdev)
+	}
+	s.mu.RLock()
+	numberOfPaths := s.numberOfPathsForController(controller.Name)
+	if s.discoveryPathForController(controller.Name) != nil {
+		numberOfPaths--
+	}
+	s.mu.RUnlock()
+	if numberOfPaths > 1 {
+		return func() {}, nil
+	}
+	return s.Consumers.Release(ctx, name, func(bdev string) bool {
+		return isControllerBdev(path.Base(controller.Name), bdev)
+	})
+}
+
+// numberOfPathsForController must be called with s.mu held
 func (s *Server) numberOfPathsForController(controllerName string) int {
 	numberOfPaths := 0
 	for _, path := range s.Volumes.NvmePaths {
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPathConnectTimeout is how long UpdateNvmePath waits for the new
+// path to connect before the old one is detached
+const DefaultPathConnectTimeout = 30 * time.Second
+
+// pathConnectPollInterval is how often SPDK is asked if a new path connected
+const pathConnectPollInterval = 100 * time.Millisecond
+
+// validateNvmePathUpdate checks that only transport address fields
+// differ between stored Nvme path and its updated version
+func validateNvmePathUpdate(stored *pb.NvmePath, updated *pb.NvmePath) error {
+	check := server.ProtoClone(updated)
+	check.Adrfam = stored.Adrfam
+	check.Traddr = stored.Traddr
+	check.Trsvcid = stored.Trsvcid
+	if !proto.Equal(stored, check) {
+		return status.Error(codes.InvalidArgument, "only adrfam, traddr and trsvcid of NvmePath can be updated")
+	}
+	return nil
+}
+
+// failoverNvmePath replaces old path of controller with updated path in
+// SPDK. The updated path is attached first and the old one detached only
+// when it is connected, so I/O is not interrupted. Controllers not in
+// multipath mode get the updated path as a failover path, which SPDK
+// connects only when the old one is detached, so it is waited for after
+// the detach and the old path is attached again if it does not connect.
+// On failure the updated path is detached again and the old one is kept.
+func (s *Server) failoverNvmePath(ctx context.Context, controller *pb.NvmeRemoteController, old *pb.NvmePath, updated *pb.NvmePath) error {
+	multipathMode := controller.Multipath == pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH
+	multipath := s.opiMultipathToSpdk(pb.NvmeMultipath_NVME_MULTIPATH_FAILOVER)
+	if multipathMode {
+		multipath = s.opiMultipathToSpdk(controller.Multipath)
+	}
+	if err := s.attachNvmePath(ctx, controller, updated, multipath); err != nil {
+		return err
+	}
+	if multipathMode {
+		if err := s.waitNvmePathConnected(ctx, updated); err != nil {
+			s.detachUpdatedNvmePath(ctx, controller, updated)
+			return err
+		}
+	}
+	if err := s.detachNvmePath(ctx, controller, old); err != nil {
+		s.detachUpdatedNvmePath(ctx, controller, updated)
+		return err
+	}
+	if multipathMode {
+		return nil
+	}
+	if err := s.waitNvmePathConnected(ctx, updated); err != nil {
+		// the old path becomes the failover path before the updated one
+		// is detached, so SPDK fails back to it
+		if rerr := s.attachNvmePath(ctx, controller, old, multipath); rerr != nil {
+			slog.ErrorContext(ctx, "Unable to attach old Nvme path again", "name", old.Name, "traddr", old.Traddr, "err", rerr)
+			return err
+		}
+		s.detachUpdatedNvmePath(ctx, controller, updated)
+		return err
+	}
+	return nil
+}
+
+// detachUpdatedNvmePath detaches updated path of a failed move, errors are
+// only logged as the move has failed already
+func (s *Server) detachUpdatedNvmePath(ctx context.Context, controller *pb.NvmeRemoteController, updated *pb.NvmePath) {
+	if err := s.detachNvmePath(ctx, controller, updated); err != nil {
+		slog.ErrorContext(ctx, "Unable to detach updated Nvme path", "name", updated.Name, "traddr", updated.Traddr, "err", err)
+	}
+}
+
+// waitNvmePathConnected waits until nvmePath is connected in SPDK
+func (s *Server) waitNvmePathConnected(ctx context.Context, nvmePath *pb.NvmePath) error {
+	ctx, cancel := context.WithTimeout(ctx, s.PathConnectTimeout)
+	defer cancel()
+	ticker := time.NewTicker(pathConnectPollInterval)
+	defer ticker.Stop()
+	for {
+		paths, err := s.spdkNvmePaths(ctx)
+		if err != nil {
+			return err
+		}
+		if p := findSpdkNvmePath(paths, nvmePath); p != nil && p.state.Connected {
+			slog.InfoContext(ctx, "Nvme path connected", "name", nvmePath.Name, "traddr", nvmePath.Traddr, "trsvcid", nvmePath.Trsvcid)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			msg := fmt.Sprintf("Nvme path %s:%d did not connect in %v", nvmePath.Traddr, nvmePath.Trsvcid, s.PathConnectTimeout)
+			slog.ErrorContext(ctx, msg)
+			return status.Errorf(codes.DeadlineExceeded, msg)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opiproject/gospdk/spdk"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+)
+
+// pathStateMissing is the state of paths not found in SPDK
+const pathStateMissing = "missing"
+
+// nvmePathState is the state of an Nvme path in SPDK
+type nvmePathState struct {
+	// State of the controller path, e.g. enabled, resetting or failed
+	State string
+	// Connected reports if the path is connected to the remote controller
+	Connected bool
+	// Current reports if the path is used for I/O by multipath
+	Current bool
+	// Accessible reports if namespaces are accessible through the path,
+	// i.e. ANA state of the path is optimized or non-optimized
+	Accessible bool
+}
+
+// spdkNvmePath is a path of an NVMe controller in SPDK
+type spdkNvmePath struct {
+	controller string
+	trid       bdevNvmeTrid
+	hostnqn    string
+	hostAddr   string
+	hostSvcid  string
+	state      nvmePathState
+}
+
+// spdkNvmePaths gets all paths of NVMe controllers and their state from SPDK
+func (s *Server) spdkNvmePaths(ctx context.Context) ([]spdkNvmePath, error) {
+	var controllers []spdk.BdevNvmeGetControllerResult
+	err := server.Call(ctx, s.rpc, "bdev_nvme_get_controllers", nil, &controllers)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_get_controllers", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", controllers)
+	var ioPaths bdevNvmeGetIoPathsResult
+	err = server.Call(ctx, s.rpc, "bdev_nvme_get_io_paths", nil, &ioPaths)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_get_io_paths", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", ioPaths)
+
+	var paths []spdkNvmePath
+	for i := range controllers {
+		for j := range controllers[i].Ctrlrs {
+			ctrlr := &controllers[i].Ctrlrs[j]
+			p := spdkNvmePath{
+				controller: controllers[i].Name,
+				trid:       bdevNvmeTrid(ctrlr.Trid),
+				hostnqn:    ctrlr.Host.Nqn,
+				hostAddr:   ctrlr.Host.Addr,
+				hostSvcid:  ctrlr.Host.Svcid,
+				// controllers without namespaces have no I/O paths
+				state: nvmePathState{State: ctrlr.State, Connected: ctrlr.State == "enabled"},
+			}
+			p.state.fromIoPaths(&ioPaths, controllers[i].Name, p.trid)
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// fromIoPaths updates the state from I/O paths of namespaces of controller
+// going through the path with trid
+func (st *nvmePathState) fromIoPaths(ioPaths *bdevNvmeGetIoPathsResult, controller string, trid bdevNvmeTrid) {
+	found := false
+	for i := range ioPaths.PollGroups {
+		for j := range ioPaths.PollGroups[i].IoPaths {
+			ioPath := &ioPaths.PollGroups[i].IoPaths[j]
+			if !isControllerBdev(controller, ioPath.BdevName) || !equalTrids(ioPath.Transport, trid) {
+				continue
+			}
+			if !found {
+				st.Connected, st.Current, st.Accessible = false, false, false
+				found = true
+			}
+			st.Connected = st.Connected || ioPath.Connected
+			st.Current = st.Current || ioPath.Current
+			st.Accessible = st.Accessible || ioPath.Accessible
+		}
+	}
+}
+
+// findSpdkNvmePath returns the path in SPDK of stored Nvme path, if any
+func findSpdkNvmePath(paths []spdkNvmePath, stored *pb.NvmePath) *spdkNvmePath {
+	controller := path.Base(stored.GetControllerId().GetValue())
+	for i := range paths {
+		p := &paths[i]
+		if p.controller == controller && matchesTrid(stored, p.trid) {
+			return p
+		}
+	}
+	return nil
+}
+
+// matchesTrid reports if Nvme path goes to the same remote controller as trid
+func matchesTrid(nvmePath *pb.NvmePath, trid bdevNvmeTrid) bool {
+	return strings.EqualFold(trid.Trtype, strings.TrimPrefix(nvmePath.Trtype.String(), "NVME_TRANSPORT_")) &&
+		strings.EqualFold(trid.Adrfam, strings.TrimPrefix(nvmePath.Adrfam.String(), "NVME_ADRFAM_")) &&
+		trid.Traddr == nvmePath.Traddr &&
+		trid.Trsvcid == strconv.FormatInt(nvmePath.Trsvcid, 10) &&
+		trid.Subnqn == nvmePath.Subnqn
+}
+
+func equalTrids(a, b bdevNvmeTrid) bool {
+	return strings.EqualFold(a.Trtype, b.Trtype) && strings.EqualFold(a.Adrfam, b.Adrfam) &&
+		a.Traddr == b.Traddr && a.Trsvcid == b.Trsvcid && a.Subnqn == b.Subnqn
+}
+
+// isControllerBdev reports if bdev is a namespace <controller>n<nsid> of controller
+func isControllerBdev(controller, bdev string) bool {
+	nsid := strings.TrimPrefix(bdev, controller+"n")
+	if nsid == bdev || nsid == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(nsid, 10, 32)
+	return err == nil
+}
+
+// spdkNvmePathToOpi returns stored Nvme path with transport fields taken
+// from its path in SPDK
+func spdkNvmePathToOpi(stored *pb.NvmePath, p *spdkNvmePath) *pb.NvmePath {
+	nvmePath := server.ProtoClone(stored)
+	if v, ok := pb.NvmeTransportType_value["NVME_TRANSPORT_"+strings.ToUpper(p.trid.Trtype)]; ok {
+		nvmePath.Trtype = pb.NvmeTransportType(v)
+	}
+	if v, ok := pb.NvmeAddressFamily_value["NVME_ADRFAM_"+strings.ToUpper(p.trid.Adrfam)]; ok {
+		nvmePath.Adrfam = pb.NvmeAddressFamily(v)
+	}
+	nvmePath.Traddr = p.trid.Traddr
+	if trsvcid, err := strconv.ParseInt(p.trid.Trsvcid, 10, 64); err == nil {
+		nvmePath.Trsvcid = trsvcid
+	}
+	nvmePath.Subnqn = p.trid.Subnqn
+	if p.hostnqn != "" {
+		nvmePath.Hostnqn = p.hostnqn
+	}
+	if p.hostAddr != "" {
+		nvmePath.SourceTraddr = p.hostAddr
+	}
+	if trsvcid, err := strconv.ParseInt(p.hostSvcid, 10, 64); err == nil {
+		nvmePath.SourceTrsvcid = trsvcid
+	}
+	return nvmePath
+}
+
+// extension returns the state as extension of an Nvme path
+func (st *nvmePathState) extension() *px.NvmePathExtension {
+	return &px.NvmePathExtension{
+		State:      st.State,
+		Connected:  st.Connected,
+		Current:    st.Current,
+		Accessible: st.Accessible,
+	}
+}
+
+// extendNvmePath returns stored Nvme path with transport fields and state
+// taken from its path among paths in SPDK
+func extendNvmePath(paths []spdkNvmePath, stored *pb.NvmePath) *px.ExtendedNvmePath {
+	p := findSpdkNvmePath(paths, stored)
+	if p == nil {
+		state := storedNvmePathState(stored)
+		return &px.ExtendedNvmePath{NvmePath: server.ProtoClone(stored), Extension: state.extension()}
+	}
+	return &px.ExtendedNvmePath{NvmePath: spdkNvmePathToOpi(stored, p), Extension: p.state.extension()}
+}
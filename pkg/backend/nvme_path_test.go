@@ -9,16 +9,19 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
 )
 
@@ -34,6 +37,37 @@ var (
 		Hostnqn:      "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c",
 		ControllerId: &pc.ObjectKey{Value: testNvmeCtrlName},
 	}
+	testNvmePathNamed = pb.NvmePath{
+		Name:         testNvmePathName,
+		Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Adrfam:       pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
+		Traddr:       "127.0.0.1",
+		Trsvcid:      4444,
+		Subnqn:       "nqn.2016-06.io.spdk:cnode1",
+		Hostnqn:      "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c",
+		ControllerId: &pc.ObjectKey{Value: testNvmeCtrlName},
+	}
+	testNvmePathFromSpdk = pb.NvmePath{
+		Name:          testNvmePathName,
+		Trtype:        pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Adrfam:        pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
+		Traddr:        "127.0.0.1",
+		Trsvcid:       4444,
+		Subnqn:        "nqn.2016-06.io.spdk:cnode1",
+		Hostnqn:       "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c",
+		SourceTraddr:  "127.0.0.2",
+		SourceTrsvcid: 5555,
+		ControllerId:  &pc.ObjectKey{Value: testNvmeCtrlName},
+	}
+	// controller of testNvmePath with a path and a controller not managed by the bridge
+	testSpdkNvmeControllers = `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"opi-nvme8","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"},"cntlid":1,"host":{"nqn":"nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c","addr":"127.0.0.2","svcid":"5555"}}]},` +
+		`{"name":"unmanaged","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"},"cntlid":2,"host":{"nqn":"nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c"}}]}]}`
+	testSpdkNvmeIoPaths = `{"id":%d,"error":{"code":0,"message":""},"result":{"poll_groups":[{"io_paths":[` +
+		`{"bdev_name":"opi-nvme8n1","cntlid":1,"current":true,"connected":true,"accessible":true,"transport":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}},` +
+		`{"bdev_name":"unmanagedn1","cntlid":2,"current":false,"connected":false,"accessible":false,"transport":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}}]}]}}`
+	testNvmePathStateOptimized = px.NvmePathExtension{State: "enabled", Connected: true, Current: true, Accessible: true}
+	testNvmePathStateMissing   = px.NvmePathExtension{State: "missing"}
 )
 
 func TestBackEnd_CreateNvmePath(t *testing.T) {
@@ -261,6 +295,12 @@ func TestBackEnd_DeleteNvmePath(t *testing.T) {
 }
 
 func TestBackEnd_UpdateNvmePath(t *testing.T) {
+	movedPath := server.ProtoClone(&testNvmePathNamed)
+	movedPath.Traddr = "127.0.0.5"
+	// the old path and the new one connected
+	testSpdkNvmeControllersMoved := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"opi-nvme8","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}},` +
+		`{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.5","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}}]}]}`
 	tests := map[string]struct {
 		mask    *fieldmaskpb.FieldMask
 		in      *pb.NvmePath
@@ -279,87 +319,67 @@ func TestBackEnd_UpdateNvmePath(t *testing.T) {
 			fmt.Sprintf("invalid field path: %s", "'*' must not be used with other paths"),
 			false,
 		},
-		// "delete fails": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
-		// 	codes.InvalidArgument,
-		// 	fmt.Sprintf("Could not delete Null Dev: %s", testNvmePathID),
-		//	false,
-		// },
-		// "delete empty": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{""},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_detach_controller: %v", "EOF"),
-		//	false,
-		// },
-		// "delete ID mismatch": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":0,"error":{"code":0,"message":""},"result":false}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_detach_controller: %v", "json response ID mismatch"),
-		//	false,
-		// },
-		// "delete exception": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_detach_controller: %v", "json response error: myopierr"),
-		//	false,
-		// },
-		// "delete ok create fails": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":""}`},
-		// 	codes.InvalidArgument,
-		// 	fmt.Sprintf("Could not create Null Dev: %v", "mytest"),
-		//	false,
-		// },
-		// "delete ok create empty": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, ""},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_attach_controller: %v", "EOF"),
-		//	false,
-		// },
-		// "delete ok create ID mismatch": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":0,"error":{"code":0,"message":""},"result":""}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_attach_controller: %v", "json response ID mismatch"),
-		//	false,
-		// },
-		// "delete ok create exception": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":1,"message":"myopierr"},"result":""}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_attach_controller: %v", "json response error: myopierr"),
-		//	false,
-		// },
-		// "valid request with valid SPDK response": {
-		// 	nil,
-		// 	&testNvmePath,
-		// 	&testNvmePath,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":"mytest"}`},
-		// 	codes.OK,
-		// 	"",
-		//	false,
-		// },
+		"update of immutable field": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr", "subnqn"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5", Subnqn: "nqn.2016-06.io.spdk:cnode2"},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"only adrfam, traddr and trsvcid of NvmePath can be updated",
+			false,
+		},
+		"update without change": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.1"},
+			&testNvmePathNamed,
+			[]string{},
+			codes.OK,
+			"",
+			false,
+		},
+		"attach of new path fails": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_attach_controller: %v", "json response error: myopierr"),
+			false,
+		},
+		"new path does not connect": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				testSpdkNvmeControllers, testSpdkNvmeIoPaths,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.DeadlineExceeded,
+			fmt.Sprintf("Nvme path %v did not connect in %v", "127.0.0.5:4444", time.Millisecond),
+			false,
+		},
+		"detach of old path fails": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				testSpdkNvmeControllersMoved, testSpdkNvmeIoPaths,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Nvme Path: %s", testNvmePathID),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			&fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			&pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+			movedPath,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				testSpdkNvmeControllersMoved, testSpdkNvmeIoPaths,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+		},
 		"valid request with unknown key": {
 			nil,
 			&pb.NvmePath{
@@ -409,6 +429,8 @@ func TestBackEnd_UpdateNvmePath(t *testing.T) {
 
 			testNvmePath.Name = testNvmePathName
 			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = &testNvmePath
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = &testNvmeCtrl
+			testEnv.opiSpdkServer.PathConnectTimeout = time.Millisecond
 
 			request := &pb.UpdateNvmePathRequest{NvmePath: tt.in, UpdateMask: tt.mask, AllowMissing: tt.missing}
 			response, err := testEnv.client.UpdateNvmePath(testEnv.ctx, request)
@@ -416,6 +438,14 @@ func TestBackEnd_UpdateNvmePath(t *testing.T) {
 			if !proto.Equal(response, tt.out) {
 				t.Error("response: expected", tt.out, "received", response)
 			}
+			// the stored path changes only on success
+			stored := testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName]
+			if tt.out != nil && !proto.Equal(stored, tt.out) {
+				t.Error("stored: expected", tt.out, "received", stored)
+			}
+			if tt.out == nil && stored != &testNvmePath {
+				t.Error("stored: expected", &testNvmePath, "received", stored)
+			}
 
 			if er, ok := status.FromError(err); ok {
 				if er.Code() != tt.errCode {
@@ -432,6 +462,9 @@ func TestBackEnd_UpdateNvmePath(t *testing.T) {
 }
 
 func TestBackEnd_ListNvmePaths(t *testing.T) {
+	missingPath := server.ProtoClone(&testNvmePath)
+	missingPath.Name = server.ResourceIDToVolumeName("missing-path")
+	missingPath.Traddr = "127.0.0.3"
 	tests := map[string]struct {
 		in      string
 		out     []*pb.NvmePath
@@ -441,154 +474,96 @@ func TestBackEnd_ListNvmePaths(t *testing.T) {
 		size    int32
 		token   string
 	}{
-		// "valid request with invalid SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
-		// 	codes.InvalidArgument,
-		// 	fmt.Sprintf("Could not find any namespaces for NQN: %v", "nqn.2022-09.io.spdk:opi3"),
-		// 	0,
-		// 	"",
-		// },
-		// "valid request with invalid marshal SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_get_controllers: %v", "json: cannot unmarshal bool into Go value of type []spdk.BdevGetBdevsResult"),
-		// 	0,
-		// 	"",
-		// },
-		// "valid request with empty SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{""},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_get_controllers: %v", "EOF"),
-		// 	0,
-		// 	"",
-		// },
-		// "valid request with ID mismatch SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{`{"id":0,"error":{"code":0,"message":""},"result":[]}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response ID mismatch"),
-		// 	0,
-		// 	"",
-		// },
-		// "valid request with error code from SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
-		// 	codes.Unknown,
-		// 	fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response error: myopierr"),
-		// 	0,
-		// 	"",
-		// },
-		// "valid request with valid SPDK response": {
-		// 	testNvmePathID,
-		// 	[]*pb.NvmePath{
-		// 		{
-		// 			Name:    "Malloc0",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 		{
-		// 			Name:    "Malloc1",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 	},
-		// 	[]string{`{"jsonrpc":"2.0","id":%d,"result":[` +
-		// 		`{"name":"Malloc1","aliases":["88112c76-8c49-4395-955a-0d695b1d2099"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"88112c76-8c49-4395-955a-0d695b1d2099","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}},` +
-		// 		`{"name":"Malloc0","aliases":["11d3902e-d9bb-49a7-bb27-cd7261ef3217"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}}` +
-		// 		`]}`},
-		// 	codes.OK,
-		// 	"",
-		// 	0,
-		// 	"",
-		// },
-		// "pagination overflow": {
-		// 	testNvmePathID,
-		// 	[]*pb.NvmePath{
-		// 		{
-		// 			Name:    "Malloc0",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 		{
-		// 			Name:    "Malloc1",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 	},
-		// 	[]string{`{"jsonrpc":"2.0","id":%d,"result":[{"name":"Malloc0","aliases":["11d3902e-d9bb-49a7-bb27-cd7261ef3217"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}},{"name":"Malloc1","aliases":["88112c76-8c49-4395-955a-0d695b1d2099"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"88112c76-8c49-4395-955a-0d695b1d2099","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}}]}`},
-		// 	codes.OK,
-		// 	"",
-		// 	1000,
-		// 	"",
-		// },
-		// "pagination negative": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{},
-		// 	codes.InvalidArgument,
-		// 	"negative PageSize is not allowed",
-		// 	-10,
-		// 	"",
-		// },
-		// "pagination error": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{},
-		// 	codes.NotFound,
-		// 	fmt.Sprintf("unable to find pagination token %s", "unknown-pagination-token"),
-		// 	0,
-		// 	"unknown-pagination-token",
-		// },
-		// "pagination": {
-		// 	testNvmePathID,
-		// 	[]*pb.NvmePath{
-		// 		{
-		// 			Name:    "Malloc0",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 	},
-		// 	[]string{`{"jsonrpc":"2.0","id":%d,"result":[{"name":"Malloc0","aliases":["11d3902e-d9bb-49a7-bb27-cd7261ef3217"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}},{"name":"Malloc1","aliases":["88112c76-8c49-4395-955a-0d695b1d2099"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"88112c76-8c49-4395-955a-0d695b1d2099","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}}]}`},
-		// 	codes.OK,
-		// 	"",
-		// 	1,
-		// 	"",
-		// },
-		// "pagination offset": {
-		// 	testNvmePathID,
-		// 	[]*pb.NvmePath{
-		// 		{
-		// 			Name:    "Malloc1",
-		// 			Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 			Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 			Traddr:  "127.0.0.1",
-		// 			Trsvcid: 4444,
-		// 		},
-		// 	},
-		// 	[]string{`{"jsonrpc":"2.0","id":%d,"result":[{"name":"Malloc0","aliases":["11d3902e-d9bb-49a7-bb27-cd7261ef3217"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"11d3902e-d9bb-49a7-bb27-cd7261ef3217","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}},{"name":"Malloc1","aliases":["88112c76-8c49-4395-955a-0d695b1d2099"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"88112c76-8c49-4395-955a-0d695b1d2099","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}}]}`},
-		// 	codes.OK,
-		// 	"",
-		// 	1,
-		// 	"existing-pagination-token",
-		// },
+		"valid request with invalid marshal SPDK response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_controllers: %v", "json: cannot unmarshal bool into Go value of type []spdk.BdevNvmeGetControllerResult"),
+			0,
+			"",
+		},
+		"valid request with empty SPDK response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{""},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_controllers: %v", "EOF"),
+			0,
+			"",
+		},
+		"valid request with ID mismatch SPDK response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{`{"id":0,"error":{"code":0,"message":""},"result":[]}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response ID mismatch"),
+			0,
+			"",
+		},
+		"valid request with error code from SPDK response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response error: myopierr"),
+			0,
+			"",
+		},
+		"valid request with error code from SPDK io paths response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{testSpdkNvmeControllers, `{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_io_paths: %v", "json response error: myopierr"),
+			0,
+			"",
+		},
+		"valid request with valid SPDK response": {
+			testNvmeCtrlName,
+			[]*pb.NvmePath{missingPath, &testNvmePathFromSpdk},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+			0,
+			"",
+		},
+		"pagination negative": {
+			testNvmeCtrlName,
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"negative PageSize is not allowed",
+			-10,
+			"",
+		},
+		"pagination error": {
+			testNvmeCtrlName,
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find pagination token %s", "unknown-pagination-token"),
+			0,
+			"unknown-pagination-token",
+		},
+		"pagination": {
+			testNvmeCtrlName,
+			[]*pb.NvmePath{missingPath},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+			1,
+			"",
+		},
+		"pagination offset": {
+			testNvmeCtrlName,
+			[]*pb.NvmePath{&testNvmePathFromSpdk},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+			1,
+			"existing-pagination-token",
+		},
 	}
 
 	// run tests
@@ -597,9 +572,16 @@ func TestBackEnd_ListNvmePaths(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
+			path := server.ProtoClone(&testNvmePath)
+			path.Name = testNvmePathName
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = path
+			testEnv.opiSpdkServer.Volumes.NvmePaths[missingPath.Name] = missingPath
 
 			request := &pb.ListNvmePathsRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					missingPath, missingPath.Name)
+			}
 			response, err := testEnv.client.ListNvmePaths(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetNvmePaths(), tt.out) {
@@ -633,13 +615,6 @@ func TestBackEnd_GetNvmePath(t *testing.T) {
 		errCode codes.Code
 		errMsg  string
 	}{
-		// "valid request with invalid SPDK response": {
-		// 	testNvmePathID,
-		// 	nil,
-		// 	[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
-		// 	codes.InvalidArgument,
-		// 	fmt.Sprintf("expecting exactly 1 result, got %v", "0"),
-		// },
 		"valid request with invalid marshal SPDK response": {
 			testNvmePathID,
 			nil,
@@ -668,19 +643,27 @@ func TestBackEnd_GetNvmePath(t *testing.T) {
 			codes.Unknown,
 			fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response error: myopierr"),
 		},
-		// "valid request with valid SPDK response": {
-		// 	testNvmePathID,
-		// 	&pb.NvmePath{
-		// 		Name:    "Malloc1",
-		// 		Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_TCP,
-		// 		Adrfam:  pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
-		// 		Traddr:  "127.0.0.1",
-		// 		Trsvcid: 4444,
-		// 	},
-		// 	[]string{`{"jsonrpc":"2.0","id":%d,"result":[{"name":"Malloc1","aliases":["88112c76-8c49-4395-955a-0d695b1d2099"],"product_name":"Malloc disk","block_size":512,"num_blocks":131072,"uuid":"88112c76-8c49-4395-955a-0d695b1d2099","assigned_rate_limits":{"rw_ios_per_sec":0,"rw_mbytes_per_sec":0,"r_mbytes_per_sec":0,"w_mbytes_per_sec":0},"claimed":false,"zoned":false,"supported_io_types":{"read":true,"write":true,"unmap":true,"write_zeroes":true,"flush":true,"reset":true,"compare":false,"compare_and_write":false,"abort":true,"nvme_admin":false,"nvme_io":false},"driver_specific":{}}]}`},
-		// 	codes.OK,
-		// 	"",
-		// },
+		"valid request with error code from SPDK io paths response": {
+			testNvmePathID,
+			nil,
+			[]string{testSpdkNvmeControllers, `{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_io_paths: %v", "json response error: myopierr"),
+		},
+		"valid request with valid SPDK response": {
+			testNvmePathID,
+			&testNvmePathFromSpdk,
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+		},
+		"valid request with path missing in SPDK": {
+			testNvmePathID,
+			&testNvmePathNamed,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`, `{"id":%d,"error":{"code":0,"message":""},"result":{"poll_groups":[]}}`},
+			codes.OK,
+			"",
+		},
 		"valid request with unknown key": {
 			"unknown-id",
 			nil,
@@ -703,7 +686,7 @@ func TestBackEnd_GetNvmePath(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathID] = &testNvmePath
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathID] = &testNvmePathNamed
 
 			request := &pb.GetNvmePathRequest{Name: tt.in}
 			response, err := testEnv.client.GetNvmePath(testEnv.ctx, request)
@@ -726,6 +709,164 @@ func TestBackEnd_GetNvmePath(t *testing.T) {
 	}
 }
 
+func TestBackEnd_ListExtendedNvmePaths(t *testing.T) {
+	missingPath := server.ProtoClone(&testNvmePath)
+	missingPath.Name = server.ResourceIDToVolumeName("missing-path")
+	missingPath.Traddr = "127.0.0.3"
+	extendedMissingPath := &px.ExtendedNvmePath{NvmePath: missingPath, Extension: &testNvmePathStateMissing}
+	extendedPath := &px.ExtendedNvmePath{NvmePath: &testNvmePathFromSpdk, Extension: &testNvmePathStateOptimized}
+	tests := map[string]struct {
+		in      string
+		out     []*px.ExtendedNvmePath
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		filter  string
+	}{
+		"valid request with error code from SPDK io paths response": {
+			testNvmeCtrlName,
+			nil,
+			[]string{testSpdkNvmeControllers, `{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_io_paths: %v", "json response error: myopierr"),
+			"",
+		},
+		"valid request with valid SPDK response": {
+			testNvmeCtrlName,
+			[]*px.ExtendedNvmePath{extendedMissingPath, extendedPath},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+			"",
+		},
+		"filter by state": {
+			testNvmeCtrlName,
+			[]*px.ExtendedNvmePath{extendedPath},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+			`extension.connected = true AND nvme_path.traddr = "127.0.0.1"`,
+		},
+		"filter by unknown field": {
+			testNvmeCtrlName,
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"invalid filter \"extension.ana_state = optimized\": unknown field extension.ana_state",
+			"extension.ana_state = optimized",
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			path := server.ProtoClone(&testNvmePath)
+			path.Name = testNvmePathName
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = path
+			testEnv.opiSpdkServer.Volumes.NvmePaths[missingPath.Name] = missingPath
+
+			ctx := testEnv.ctx
+			if tt.filter != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, server.FilterMetadataKey, tt.filter)
+			}
+			request := &px.ListExtendedNvmePathsRequest{Parent: tt.in}
+			response, err := testEnv.client.ListExtendedNvmePaths(ctx, request)
+
+			if !server.EqualProtoSlices(response.GetExtendedNvmePaths(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetExtendedNvmePaths())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_GetExtendedNvmePath(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *px.ExtendedNvmePath
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			testNvmePathID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"}}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_nvme_get_controllers: %v", "json response error: myopierr"),
+		},
+		"valid request with valid SPDK response": {
+			testNvmePathID,
+			&px.ExtendedNvmePath{NvmePath: &testNvmePathFromSpdk, Extension: &testNvmePathStateOptimized},
+			[]string{testSpdkNvmeControllers, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+		},
+		"valid request with path missing in SPDK": {
+			testNvmePathID,
+			&px.ExtendedNvmePath{NvmePath: &testNvmePathNamed, Extension: &testNvmePathStateMissing},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`, `{"id":%d,"error":{"code":0,"message":""},"result":{"poll_groups":[]}}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", "unknown-id"),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathID] = &testNvmePathNamed
+
+			request := &px.GetExtendedNvmePathRequest{Name: tt.in}
+			response, err := testEnv.client.GetExtendedNvmePath(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
 func TestBackEnd_NvmePathStats(t *testing.T) {
 	tests := map[string]struct {
 		in      string
@@ -827,3 +968,113 @@ func TestBackEnd_NvmePathStats(t *testing.T) {
 		})
 	}
 }
+
+func TestBackEnd_UpdateNvmePathFailover(t *testing.T) {
+	movedPath := server.ProtoClone(&testNvmePathNamed)
+	movedPath.Traddr = "127.0.0.5"
+	// only the new path is left after the old one is detached
+	testSpdkNvmeControllersFailedOver := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"opi-nvme8","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.5","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"}}]}]}`
+	tests := map[string]struct {
+		out     *pb.NvmePath
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"new path connects after detach of old one": {
+			movedPath,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				testSpdkNvmeControllersFailedOver, testSpdkNvmeIoPaths},
+			codes.OK,
+			"",
+		},
+		"new path does not connect after detach of old one": {
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				testSpdkNvmeControllers, testSpdkNvmeIoPaths,
+				`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.DeadlineExceeded,
+			fmt.Sprintf("Nvme path %v did not connect in %v", "127.0.0.5:4444", time.Millisecond),
+		},
+		"detach of old path fails": {
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Nvme Path: %s", testNvmePathID),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			stored := server.ProtoClone(&testNvmePathNamed)
+			testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = stored
+			controller := server.ProtoClone(&testNvmeCtrl)
+			controller.Name = testNvmeCtrlName
+			controller.Multipath = pb.NvmeMultipath_NVME_MULTIPATH_FAILOVER
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = controller
+			testEnv.opiSpdkServer.PathConnectTimeout = time.Millisecond
+
+			request := &pb.UpdateNvmePathRequest{
+				NvmePath:   &pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			}
+			response, err := testEnv.client.UpdateNvmePath(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+			if tt.out == nil && testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] != stored {
+				t.Error("stored: expected", stored, "received", testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName])
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateNvmePathLocksControllerOnce(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+
+	// controller_id equal to the name of the path itself must not make
+	// the request wait for its own lock
+	nvmePath := server.ProtoClone(&testNvmePathNamed)
+	nvmePath.ControllerId = &pc.ObjectKey{Value: testNvmePathName}
+	testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = nvmePath
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := testEnv.client.UpdateNvmePath(testEnv.ctx, &pb.UpdateNvmePathRequest{
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"traddr"}},
+			NvmePath:   &pb.NvmePath{Name: testNvmePathName, Traddr: "127.0.0.5"},
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.Internal {
+			t.Error("error code: expected", codes.Internal, "received", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("UpdateNvmePath deadlocked on its own lock")
+	}
+}
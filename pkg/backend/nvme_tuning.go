@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nvmeControllerTuningKind is the kind of controller tunings in the store
+const nvmeControllerTuningKind = "opi_spdk_bridge.NvmeControllerTuning"
+
+const (
+	multipathPolicyActivePassive = "active_passive"
+	multipathPolicyActiveActive  = "active_active"
+	multipathSelectorRoundRobin  = "round_robin"
+	multipathSelectorQueueDepth  = "queue_depth"
+	infiniteCtrlrLossTimeoutSec  = -1
+)
+
+// multipathPolicies maps multipath policies of the API to those of SPDK
+var multipathPolicies = map[px.NvmeMultipathPolicy]string{
+	px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_UNSPECIFIED:    "",
+	px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE: multipathPolicyActivePassive,
+	px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE:  multipathPolicyActiveActive,
+}
+
+// multipathSelectors maps path selectors of the API to those of SPDK
+var multipathSelectors = map[px.NvmeMultipathSelector]string{
+	px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_UNSPECIFIED: "",
+	px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_ROUND_ROBIN: multipathSelectorRoundRobin,
+	px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH: multipathSelectorQueueDepth,
+}
+
+// nvmeControllerTuning is multipath policy and reconnect settings of an
+// Nvme remote controller
+type nvmeControllerTuning struct {
+	// Policy is the multipath policy of namespaces of the controller,
+	// active_passive or active_active, SPDK default if empty
+	Policy string `json:"policy,omitempty"`
+	// Selector is the path selector of active_active policy,
+	// round_robin or queue_depth
+	Selector string `json:"selector,omitempty"`
+	// CtrlrLossTimeoutSec is how long SPDK tries to reconnect a lost path
+	// before deleting it, -1 means forever and 0 no reconnect
+	CtrlrLossTimeoutSec int64 `json:"ctrlr_loss_timeout_sec,omitempty"`
+	// ReconnectDelaySec is the delay between reconnect attempts
+	ReconnectDelaySec int64 `json:"reconnect_delay_sec,omitempty"`
+	// FastIoFailTimeoutSec is how long I/O waits for a lost path to
+	// reconnect before it fails, 0 means until the path is deleted
+	FastIoFailTimeoutSec int64 `json:"fast_io_fail_timeout_sec,omitempty"`
+}
+
+// tuningFromExtension returns tuning set in extension of a controller,
+// SPDK defaults if extension is nil
+func tuningFromExtension(extension *px.NvmeRemoteControllerExtension) (nvmeControllerTuning, error) {
+	policy, ok := multipathPolicies[extension.GetMultipathPolicy()]
+	if !ok {
+		return nvmeControllerTuning{}, fmt.Errorf("unknown multipath_policy %v", extension.GetMultipathPolicy())
+	}
+	selector, ok := multipathSelectors[extension.GetMultipathSelector()]
+	if !ok {
+		return nvmeControllerTuning{}, fmt.Errorf("unknown multipath_selector %v", extension.GetMultipathSelector())
+	}
+	return nvmeControllerTuning{
+		Policy:               policy,
+		Selector:             selector,
+		CtrlrLossTimeoutSec:  extension.GetCtrlrLossTimeoutSec(),
+		ReconnectDelaySec:    extension.GetReconnectDelaySec(),
+		FastIoFailTimeoutSec: extension.GetFastIoFailTimeoutSec(),
+	}, nil
+}
+
+// extension returns tuning as extension of a controller
+func (t *nvmeControllerTuning) extension() *px.NvmeRemoteControllerExtension {
+	extension := &px.NvmeRemoteControllerExtension{
+		CtrlrLossTimeoutSec:  t.CtrlrLossTimeoutSec,
+		ReconnectDelaySec:    t.ReconnectDelaySec,
+		FastIoFailTimeoutSec: t.FastIoFailTimeoutSec,
+	}
+	for policy, name := range multipathPolicies {
+		if name == t.Policy {
+			extension.MultipathPolicy = policy
+		}
+	}
+	for selector, name := range multipathSelectors {
+		if name == t.Selector {
+			extension.MultipathSelector = selector
+		}
+	}
+	return extension
+}
+
+// validate checks tuning against rules SPDK applies to the settings
+func (t *nvmeControllerTuning) validate(multipath pb.NvmeMultipath) error {
+	switch t.Policy {
+	case "":
+		if t.Selector != "" {
+			return errors.New("multipath_selector requires active_active multipath_policy")
+		}
+	case multipathPolicyActivePassive, multipathPolicyActiveActive:
+		if multipath != pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH {
+			return fmt.Errorf("multipath_policy requires %v", pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH)
+		}
+	default:
+		return fmt.Errorf("unknown multipath_policy %q", t.Policy)
+	}
+	switch t.Selector {
+	case "":
+	case multipathSelectorRoundRobin, multipathSelectorQueueDepth:
+		if t.Policy != multipathPolicyActiveActive {
+			return errors.New("multipath_selector requires active_active multipath_policy")
+		}
+	default:
+		return fmt.Errorf("unknown multipath_selector %q", t.Selector)
+	}
+	switch {
+	case t.CtrlrLossTimeoutSec < infiniteCtrlrLossTimeoutSec:
+		return errors.New("ctrlr_loss_timeout_sec has to be -1 or more")
+	case t.CtrlrLossTimeoutSec == 0:
+		if t.ReconnectDelaySec != 0 || t.FastIoFailTimeoutSec != 0 {
+			return errors.New("reconnect_delay_sec and fast_io_fail_timeout_sec require ctrlr_loss_timeout_sec")
+		}
+		return nil
+	case t.ReconnectDelaySec <= 0:
+		return errors.New("reconnect_delay_sec has to be positive when ctrlr_loss_timeout_sec is set")
+	case t.CtrlrLossTimeoutSec != infiniteCtrlrLossTimeoutSec && t.ReconnectDelaySec > t.CtrlrLossTimeoutSec:
+		return errors.New("reconnect_delay_sec has to be at most ctrlr_loss_timeout_sec")
+	case t.FastIoFailTimeoutSec < 0:
+		return errors.New("fast_io_fail_timeout_sec has to be 0 or more")
+	case t.FastIoFailTimeoutSec == 0:
+		return nil
+	case t.FastIoFailTimeoutSec < t.ReconnectDelaySec:
+		return errors.New("fast_io_fail_timeout_sec has to be at least reconnect_delay_sec")
+	case t.CtrlrLossTimeoutSec != infiniteCtrlrLossTimeoutSec && t.FastIoFailTimeoutSec > t.CtrlrLossTimeoutSec:
+		return errors.New("fast_io_fail_timeout_sec has to be at most ctrlr_loss_timeout_sec")
+	}
+	return nil
+}
+
+// sameReconnect reports if reconnect settings of tuning and other are equal
+func (t *nvmeControllerTuning) sameReconnect(other nvmeControllerTuning) bool {
+	return t.CtrlrLossTimeoutSec == other.CtrlrLossTimeoutSec &&
+		t.ReconnectDelaySec == other.ReconnectDelaySec &&
+		t.FastIoFailTimeoutSec == other.FastIoFailTimeoutSec
+}
+
+// applyTuning changes tuning of controller volume from current. Multipath
+// policy is set on namespaces right away. SPDK applies reconnect settings
+// only when paths are attached, so they cannot be changed while the
+// controller has paths.
+func (s *Server) applyTuning(ctx context.Context, volume *pb.NvmeRemoteController, current, tuning nvmeControllerTuning) error {
+	if !tuning.sameReconnect(current) {
+		// paths of the controller are serialized by its name
+		s.mu.RLock()
+		numberOfPaths := s.numberOfPathsForController(volume.Name)
+		s.mu.RUnlock()
+		if numberOfPaths > 0 {
+			return status.Errorf(codes.FailedPrecondition, "reconnect settings of NvmeRemoteController %s cannot be updated while it has paths", volume.Name)
+		}
+	}
+	if tuning.Policy != current.Policy || tuning.Selector != current.Selector {
+		bdevs, err := s.controllerBdevs(ctx, path.Base(volume.Name))
+		if err != nil {
+			return err
+		}
+		if err := s.setMultipathPolicy(ctx, tuning, bdevs); err != nil {
+			return err
+		}
+	}
+	if tuning == current {
+		return nil
+	}
+	return s.saveTuning(volume.Name, tuning)
+}
+
+// saveTuning stores tuning of controller name
+func (s *Server) saveTuning(name string, tuning nvmeControllerTuning) error {
+	data, err := json.Marshal(tuning)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal tuning of %s: %v", name, err)
+	}
+	if err := s.store.Put(nvmeControllerTuningKind, name, data); err != nil {
+		return status.Errorf(codes.Internal, "unable to store tuning of %s: %v", name, err)
+	}
+	s.mu.Lock()
+	s.tunings[name] = tuning
+	s.mu.Unlock()
+	return nil
+}
+
+// removeTuning removes tuning of controller name from the store
+func (s *Server) removeTuning(name string) error {
+	if err := s.store.Delete(nvmeControllerTuningKind, name); err != nil {
+		return status.Errorf(codes.Internal, "unable to remove tuning of %s from store: %v", name, err)
+	}
+	s.mu.Lock()
+	delete(s.tunings, name)
+	s.mu.Unlock()
+	return nil
+}
+
+// restoreTunings loads tunings of controllers from the store
+func (s *Server) restoreTunings() error {
+	entries, err := s.store.List(nvmeControllerTuningKind)
+	if err != nil {
+		return err
+	}
+	for name, data := range entries {
+		var tuning nvmeControllerTuning
+		if err := json.Unmarshal(data, &tuning); err != nil {
+			return err
+		}
+		s.tunings[name] = tuning
+	}
+	return nil
+}
+
+// setMultipathPolicy sets multipath policy of tuning to NVMe bdevs
+func (s *Server) setMultipathPolicy(ctx context.Context, tuning nvmeControllerTuning, bdevs []string) error {
+	if tuning.Policy == "" {
+		return nil
+	}
+	for _, bdev := range bdevs {
+		params := bdevNvmeSetMultipathPolicyParams{
+			Name:     bdev,
+			Policy:   tuning.Policy,
+			Selector: tuning.Selector,
+		}
+		var result bdevNvmeSetMultipathPolicyResult
+		err := server.Call(ctx, s.rpc, "bdev_nvme_set_multipath_policy", &params, &result)
+		if err != nil {
+			slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_nvme_set_multipath_policy", "err", err)
+			return err
+		}
+		slog.DebugContext(ctx, "Received from SPDK", "result", result)
+		if !result {
+			msg := fmt.Sprintf("Could not set multipath policy of %s", bdev)
+			slog.ErrorContext(ctx, msg)
+			return status.Errorf(codes.Internal, msg)
+		}
+	}
+	return nil
+}
+
+// controllerBdevs returns names of NVMe bdevs of namespaces of controller in SPDK
+func (s *Server) controllerBdevs(ctx context.Context, controller string) ([]string, error) {
+	var result []server.Bdev
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	var bdevs []string
+	for i := range result {
+		if isControllerBdev(controller, result[i].Name) {
+			bdevs = append(bdevs, result[i].Name)
+		}
+	}
+	return bdevs, nil
+}
@@ -0,0 +1,497 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+func TestNvmeControllerTuning_Validate(t *testing.T) {
+	tests := map[string]struct {
+		tuning    nvmeControllerTuning
+		multipath pb.NvmeMultipath
+		errMsg    string
+	}{
+		"defaults": {
+			nvmeControllerTuning{},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"",
+		},
+		"active_active with round_robin": {
+			nvmeControllerTuning{Policy: "active_active", Selector: "round_robin"},
+			pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+			"",
+		},
+		"policy without multipath": {
+			nvmeControllerTuning{Policy: "active_passive"},
+			pb.NvmeMultipath_NVME_MULTIPATH_FAILOVER,
+			"multipath_policy requires NVME_MULTIPATH_MULTIPATH",
+		},
+		"unknown policy": {
+			nvmeControllerTuning{Policy: "random"},
+			pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+			`unknown multipath_policy "random"`,
+		},
+		"selector with active_passive": {
+			nvmeControllerTuning{Policy: "active_passive", Selector: "queue_depth"},
+			pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+			"multipath_selector requires active_active multipath_policy",
+		},
+		"unknown selector": {
+			nvmeControllerTuning{Policy: "active_active", Selector: "random"},
+			pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+			`unknown multipath_selector "random"`,
+		},
+		"reconnect forever": {
+			nvmeControllerTuning{CtrlrLossTimeoutSec: -1, ReconnectDelaySec: 5, FastIoFailTimeoutSec: 10},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"",
+		},
+		"reconnect without ctrlr loss timeout": {
+			nvmeControllerTuning{ReconnectDelaySec: 5},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"reconnect_delay_sec and fast_io_fail_timeout_sec require ctrlr_loss_timeout_sec",
+		},
+		"ctrlr loss timeout without reconnect delay": {
+			nvmeControllerTuning{CtrlrLossTimeoutSec: 60},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"reconnect_delay_sec has to be positive when ctrlr_loss_timeout_sec is set",
+		},
+		"reconnect delay over ctrlr loss timeout": {
+			nvmeControllerTuning{CtrlrLossTimeoutSec: 5, ReconnectDelaySec: 10},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"reconnect_delay_sec has to be at most ctrlr_loss_timeout_sec",
+		},
+		"fast io fail timeout under reconnect delay": {
+			nvmeControllerTuning{CtrlrLossTimeoutSec: 60, ReconnectDelaySec: 10, FastIoFailTimeoutSec: 5},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"fast_io_fail_timeout_sec has to be at least reconnect_delay_sec",
+		},
+		"fast io fail timeout over ctrlr loss timeout": {
+			nvmeControllerTuning{CtrlrLossTimeoutSec: 60, ReconnectDelaySec: 10, FastIoFailTimeoutSec: 90},
+			pb.NvmeMultipath_NVME_MULTIPATH_DISABLE,
+			"fast_io_fail_timeout_sec has to be at most ctrlr_loss_timeout_sec",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := tt.tuning.validate(tt.multipath)
+
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("Expected no error, received: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || err.Error() != tt.errMsg) {
+				t.Errorf("Expected error %v, received: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestNvmeControllerTuning_FromExtension(t *testing.T) {
+	tests := map[string]struct {
+		extension *px.NvmeRemoteControllerExtension
+		tuning    nvmeControllerTuning
+		errMsg    string
+	}{
+		"no extension": {
+			nil,
+			nvmeControllerTuning{},
+			"",
+		},
+		"all settings": {
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:      px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+				MultipathSelector:    px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH,
+				CtrlrLossTimeoutSec:  -1,
+				ReconnectDelaySec:    5,
+				FastIoFailTimeoutSec: 10,
+			},
+			nvmeControllerTuning{Policy: "active_active", Selector: "queue_depth", CtrlrLossTimeoutSec: -1, ReconnectDelaySec: 5, FastIoFailTimeoutSec: 10},
+			"",
+		},
+		"unknown policy": {
+			&px.NvmeRemoteControllerExtension{MultipathPolicy: 7},
+			nvmeControllerTuning{},
+			"unknown multipath_policy 7",
+		},
+		"unknown selector": {
+			&px.NvmeRemoteControllerExtension{MultipathSelector: 7},
+			nvmeControllerTuning{},
+			"unknown multipath_selector 7",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tuning, err := tuningFromExtension(tt.extension)
+
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("Expected no error, received: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || err.Error() != tt.errMsg) {
+				t.Errorf("Expected error %v, received: %v", tt.errMsg, err)
+			}
+			if tuning != tt.tuning {
+				t.Error("tuning: expected", tt.tuning, "received", tuning)
+			}
+			// known settings convert back to the same extension
+			if err == nil && tt.extension != nil && !proto.Equal(tuning.extension(), tt.extension) {
+				t.Error("extension: expected", tt.extension, "received", tuning.extension())
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateNvmeRemoteController(t *testing.T) {
+	tests := map[string]struct {
+		in      *pb.NvmeRemoteController
+		mask    *fieldmaskpb.FieldMask
+		errCode codes.Code
+		errMsg  string
+	}{
+		"update without changes": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			nil,
+			codes.OK,
+			"",
+		},
+		"update of controller field": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName, Hdgst: true},
+			&fieldmaskpb.FieldMask{Paths: []string{"hdgst"}},
+			codes.InvalidArgument,
+			"only multipath policy and reconnect settings of NvmeRemoteController can be updated",
+		},
+		"valid request with unknown key": {
+			&pb.NvmeRemoteController{Name: server.ResourceIDToVolumeName("unknown-id")},
+			nil,
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			&pb.NvmeRemoteController{Name: "-ABC-DEF"},
+			nil,
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment([]string{})
+			defer testEnv.Close()
+
+			controller := server.ProtoClone(&testNvmeCtrl)
+			controller.Name = testNvmeCtrlName
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = controller
+			current := nvmeControllerTuning{Policy: "active_passive"}
+			testEnv.opiSpdkServer.tunings[testNvmeCtrlName] = current
+
+			request := &pb.UpdateNvmeRemoteControllerRequest{NvmeRemoteController: tt.in, UpdateMask: tt.mask}
+			response, err := testEnv.client.UpdateNvmeRemoteController(testEnv.ctx, request)
+
+			if tt.errCode == codes.OK && !proto.Equal(response, controller) {
+				t.Error("response: expected", controller, "received", response)
+			}
+			// settings are kept
+			if testEnv.opiSpdkServer.tunings[testNvmeCtrlName] != current {
+				t.Error("stored tuning: expected", current, "received", testEnv.opiSpdkServer.tunings[testNvmeCtrlName])
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_UpdateExtendedNvmeRemoteController(t *testing.T) {
+	tests := map[string]struct {
+		in        *pb.NvmeRemoteController
+		extension *px.NvmeRemoteControllerExtension
+		mask      *fieldmaskpb.FieldMask
+		out       *px.NvmeRemoteControllerExtension
+		spdk      []string
+		errCode   codes.Code
+		errMsg    string
+		path      bool
+	}{
+		"update of reconnect settings": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{CtrlrLossTimeoutSec: 60, ReconnectDelaySec: 5},
+			nil,
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:     px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE,
+				CtrlrLossTimeoutSec: 60,
+				ReconnectDelaySec:   5,
+			},
+			[]string{},
+			codes.OK,
+			"",
+			false,
+		},
+		"update of reconnect settings with path": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{CtrlrLossTimeoutSec: 60, ReconnectDelaySec: 5},
+			nil,
+			nil,
+			[]string{},
+			codes.FailedPrecondition,
+			fmt.Sprintf("reconnect settings of NvmeRemoteController %v cannot be updated while it has paths", testNvmeCtrlName),
+			true,
+		},
+		"update of multipath policy with path": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:   px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+				MultipathSelector: px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_ROUND_ROBIN,
+			},
+			nil,
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:   px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+				MultipathSelector: px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_ROUND_ROBIN,
+			},
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"opi-nvme8n1"}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+			},
+			codes.OK,
+			"",
+			true,
+		},
+		"update of multipath policy": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:   px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+				MultipathSelector: px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH,
+			},
+			nil,
+			&px.NvmeRemoteControllerExtension{
+				MultipathPolicy:   px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+				MultipathSelector: px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_QUEUE_DEPTH,
+			},
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"opi-nvme8n1"},{"name":"opi-nvme8n2"},{"name":"opi-nvme80n1"},{"name":"Malloc0"}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+			},
+			codes.OK,
+			"",
+			false,
+		},
+		"update of masked setting only": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{CtrlrLossTimeoutSec: -1, ReconnectDelaySec: 5},
+			&fieldmaskpb.FieldMask{Paths: []string{"extension.multipath_policy", "extension.ctrlr_loss_timeout_sec", "extension.reconnect_delay_sec"}},
+			&px.NvmeRemoteControllerExtension{CtrlrLossTimeoutSec: -1, ReconnectDelaySec: 5},
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"opi-nvme8n1"}]}`,
+			},
+			codes.OK,
+			"",
+			false,
+		},
+		"multipath policy not set by SPDK": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{MultipathPolicy: px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE},
+			nil,
+			nil,
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"opi-nvme8n1"}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`,
+			},
+			codes.Internal,
+			fmt.Sprintf("Could not set multipath policy of %s", "opi-nvme8n1"),
+			false,
+		},
+		"invalid settings": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{ReconnectDelaySec: 5},
+			nil,
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"reconnect_delay_sec and fast_io_fail_timeout_sec require ctrlr_loss_timeout_sec",
+			false,
+		},
+		"unknown multipath policy": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{MultipathPolicy: 7},
+			nil,
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"unknown multipath_policy 7",
+			false,
+		},
+		"unknown setting": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName},
+			&px.NvmeRemoteControllerExtension{},
+			&fieldmaskpb.FieldMask{Paths: []string{"extension.retries"}},
+			nil,
+			[]string{},
+			codes.Unknown,
+			"invalid field path: extension.retries",
+			false,
+		},
+		"update of controller field": {
+			&pb.NvmeRemoteController{Name: testNvmeCtrlName, Hdgst: true},
+			nil,
+			&fieldmaskpb.FieldMask{Paths: []string{"nvme_remote_controller.hdgst"}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"only multipath policy and reconnect settings of NvmeRemoteController can be updated",
+			false,
+		},
+		"valid request with unknown key": {
+			&pb.NvmeRemoteController{Name: server.ResourceIDToVolumeName("unknown-id")},
+			nil,
+			nil,
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"malformed name": {
+			&pb.NvmeRemoteController{Name: "-ABC-DEF"},
+			nil,
+			nil,
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+			false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			controller := server.ProtoClone(&testNvmeCtrl)
+			controller.Name = testNvmeCtrlName
+			testEnv.opiSpdkServer.Volumes.NvmeControllers[testNvmeCtrlName] = controller
+			current := nvmeControllerTuning{Policy: "active_passive"}
+			testEnv.opiSpdkServer.tunings[testNvmeCtrlName] = current
+			if tt.path {
+				testEnv.opiSpdkServer.Volumes.NvmePaths[testNvmePathName] = server.ProtoClone(&testNvmePathNamed)
+			}
+
+			request := &px.UpdateExtendedNvmeRemoteControllerRequest{NvmeRemoteController: tt.in, Extension: tt.extension, UpdateMask: tt.mask}
+			response, err := testEnv.client.UpdateExtendedNvmeRemoteController(testEnv.ctx, request)
+
+			if tt.errCode == codes.OK {
+				expected := &px.ExtendedNvmeRemoteController{NvmeRemoteController: controller, Extension: tt.out}
+				if !proto.Equal(response, expected) {
+					t.Error("response: expected", expected, "received", response)
+				}
+			}
+			// settings change only on success
+			if tt.errCode != codes.OK && testEnv.opiSpdkServer.tunings[testNvmeCtrlName] != current {
+				t.Error("stored tuning: expected", current, "received", testEnv.opiSpdkServer.tunings[testNvmeCtrlName])
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestBackEnd_ExtendedNvmeRemoteControllerTuning(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		`{"id":%d,"error":{"code":0,"message":""},"result":["opi-nvme8n1"]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+	})
+	defer testEnv.Close()
+	extension := &px.NvmeRemoteControllerExtension{
+		MultipathPolicy:      px.NvmeMultipathPolicy_NVME_MULTIPATH_POLICY_ACTIVE_ACTIVE,
+		MultipathSelector:    px.NvmeMultipathSelector_NVME_MULTIPATH_SELECTOR_ROUND_ROBIN,
+		CtrlrLossTimeoutSec:  -1,
+		ReconnectDelaySec:    5,
+		FastIoFailTimeoutSec: 10,
+	}
+
+	created, err := testEnv.client.CreateExtendedNvmeRemoteController(testEnv.ctx, &px.CreateExtendedNvmeRemoteControllerRequest{
+		NvmeRemoteController: server.ProtoClone(&testNvmeCtrl), NvmeRemoteControllerId: testNvmeCtrlID, Extension: extension})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(created.Extension, extension) {
+		t.Error("extension: expected", extension, "received", created.Extension)
+	}
+	name := created.NvmeRemoteController.Name
+	path := server.ProtoClone(&testNvmePath)
+	path.ControllerId.Value = name
+	// multipath policy is set on namespaces of the attached path
+	if _, err := testEnv.client.CreateNvmePath(testEnv.ctx, &pb.CreateNvmePathRequest{NvmePath: path, NvmePathId: testNvmePathID}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := testEnv.client.GetExtendedNvmeRemoteController(testEnv.ctx, &px.GetExtendedNvmeRemoteControllerRequest{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, created) {
+		t.Error("get: expected", created, "received", got)
+	}
+	list, err := testEnv.client.ListExtendedNvmeRemoteControllers(testEnv.ctx, &px.ListExtendedNvmeRemoteControllersRequest{Parent: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.ExtendedNvmeRemoteControllers) != 1 || !proto.Equal(list.ExtendedNvmeRemoteControllers[0], created) {
+		t.Error("list: expected", created, "received", list.ExtendedNvmeRemoteControllers)
+	}
+	// settings are filtered as fields of the extension
+	ctx := metadata.AppendToOutgoingContext(testEnv.ctx, server.FilterMetadataKey, `extension.multipath_policy = NVME_MULTIPATH_POLICY_ACTIVE_PASSIVE`)
+	list, err = testEnv.client.ListExtendedNvmeRemoteControllers(ctx, &px.ListExtendedNvmeRemoteControllersRequest{Parent: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.ExtendedNvmeRemoteControllers) != 0 {
+		t.Error("filtered list: expected none, received", list.ExtendedNvmeRemoteControllers)
+	}
+
+	// tuning is kept in the store with the controller
+	restored := NewServer(testEnv.opiSpdkServer.rpc, testEnv.opiSpdkServer.store)
+	if restoredTuning := restored.tunings[name]; !proto.Equal(restoredTuning.extension(), extension) {
+		t.Error("restored extension: expected", extension, "received", restoredTuning.extension())
+	}
+
+	testEnv.opiSpdkServer.Volumes.NvmePaths = map[string]*pb.NvmePath{}
+	if _, err := testEnv.client.DeleteNvmeRemoteController(testEnv.ctx,
+		&pb.DeleteNvmeRemoteControllerRequest{Name: name}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := testEnv.opiSpdkServer.tunings[name]; ok {
+		t.Error("Expected tuning removed with the controller")
+	}
+}
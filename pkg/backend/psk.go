@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+)
+
+// DefaultKeyDir is the directory PSKs of remote controllers are written to,
+// it has to be readable by SPDK
+const DefaultKeyDir = "/var/tmp/opi-keys"
+
+const (
+	pskInterchangePrefix = "NVMeTLSkey-1"
+	// hash identifiers of PSK interchange format, SHA-256 is used for
+	// 32 bytes long and SHA-384 for 48 bytes long configured PSKs
+	pskHashSha256 = "01"
+	pskHashSha384 = "02"
+)
+
+// pskInterchange returns psk in NVMe TLS PSK interchange format
+// NVMeTLSkey-1:<hash>:<base64 of key and its CRC-32>: used by SPDK. PSKs
+// already in the format are validated and returned as they are, others are
+// expected to be configured PSKs of 32 or 48 bytes. Errors never contain
+// the key.
+func pskInterchange(psk []byte) (string, error) {
+	if bytes.HasPrefix(psk, []byte(pskInterchangePrefix+":")) {
+		return string(psk), validatePskInterchange(string(psk))
+	}
+	var hash string
+	switch len(psk) {
+	case 32:
+		hash = pskHashSha256
+	case 48:
+		hash = pskHashSha384
+	default:
+		return "", fmt.Errorf("PSK has to be 32 or 48 bytes long or in NVMe TLS PSK interchange format, received %d bytes", len(psk))
+	}
+	crc := make([]byte, 4)
+	binary.LittleEndian.PutUint32(crc, crc32.ChecksumIEEE(psk))
+	encoded := base64.StdEncoding.EncodeToString(append(append([]byte{}, psk...), crc...))
+	return fmt.Sprintf("%s:%s:%s:", pskInterchangePrefix, hash, encoded), nil
+}
+
+func validatePskInterchange(psk string) error {
+	fields := strings.Split(psk, ":")
+	if len(fields) != 4 || fields[3] != "" {
+		return errors.New("malformed PSK in NVMe TLS PSK interchange format")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(fields[2])
+	if err != nil {
+		return errors.New("PSK in NVMe TLS PSK interchange format is not base64 encoded")
+	}
+	expectedLen := 0
+	switch fields[1] {
+	case "00":
+		expectedLen = len(decoded)
+	case pskHashSha256:
+		expectedLen = 32 + 4
+	case pskHashSha384:
+		expectedLen = 48 + 4
+	default:
+		return fmt.Errorf("unsupported hash %v of PSK in NVMe TLS PSK interchange format", fields[1])
+	}
+	if len(decoded) != expectedLen || (len(decoded) != 32+4 && len(decoded) != 48+4) {
+		return errors.New("PSK in NVMe TLS PSK interchange format has invalid length")
+	}
+	key, crc := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	if binary.LittleEndian.Uint32(crc) != crc32.ChecksumIEEE(key) {
+		return errors.New("PSK in NVMe TLS PSK interchange format has invalid CRC")
+	}
+	return nil
+}
+
+// pskFile returns path of the file with PSK of controller
+func (s *Server) pskFile(controller *pb.NvmeRemoteController) string {
+	return filepath.Join(s.KeyDir, path.Base(controller.Name)+".psk")
+}
+
+// writePskFile writes PSK of controller to a file accessible by the owner
+// only and returns its path. The file is replaced atomically, so SPDK never
+// reads a partially written key.
+func (s *Server) writePskFile(controller *pb.NvmeRemoteController) (string, error) {
+	psk, err := pskInterchange(controller.Psk)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(s.KeyDir, 0o700); err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(s.KeyDir, ".psk-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.WriteString(psk); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	name := s.pskFile(controller)
+	if err := os.Rename(file.Name(), name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// removePskFile removes the file with PSK of controller, if any
+func (s *Server) removePskFile(controller *pb.NvmeRemoteController) error {
+	if err := os.Remove(s.pskFile(controller)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
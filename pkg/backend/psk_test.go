@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+const testPskInterchange = "NVMeTLSkey-1:01:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmZwJEiQ:"
+
+func TestPskInterchange(t *testing.T) {
+	tests := map[string]struct {
+		psk []byte
+		out string
+		err bool
+	}{
+		"configured 32 bytes PSK": {
+			psk: []byte("00112233445566778899aabbccddeeff"),
+			out: testPskInterchange,
+			err: false,
+		},
+		"configured 48 bytes PSK": {
+			psk: []byte("00112233445566778899aabbccddeeff0011223344556677"),
+			out: "NVMeTLSkey-1:02:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmYwMDExMjIzMzQ0NTU2Njc3wWXNJw==:",
+			err: false,
+		},
+		"interchange format": {
+			psk: []byte("NVMeTLSkey-1:01:VRLbtnN9AQb2WXW3c9+wEf/DRLz0QuLdbYvEhwtdWwNf9LrZ:"),
+			out: "NVMeTLSkey-1:01:VRLbtnN9AQb2WXW3c9+wEf/DRLz0QuLdbYvEhwtdWwNf9LrZ:",
+			err: false,
+		},
+		"interchange format with invalid CRC": {
+			psk: []byte("NVMeTLSkey-1:01:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmZwJEiR:"),
+			err: true,
+		},
+		"interchange format with unsupported hash": {
+			psk: []byte("NVMeTLSkey-1:03:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmZwJEiQ:"),
+			err: true,
+		},
+		"interchange format without trailing colon": {
+			psk: []byte("NVMeTLSkey-1:01:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmZwJEiQ"),
+			err: true,
+		},
+		"invalid length": {
+			psk: []byte("0011223344"),
+			err: true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			out, err := pskInterchange(tt.psk)
+
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			if err != nil && strings.Contains(err.Error(), string(tt.psk)) {
+				t.Errorf("Expected error without PSK, received: %v", err)
+			}
+			if !tt.err && out != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+		})
+	}
+}
+
+func TestBackEnd_NvmeRemoteControllerPsk(t *testing.T) {
+	testEnv := createTestEnvironment([]string{`{"id":%d,"error":{"code":0,"message":""},"result":["mytest"]}`})
+	defer testEnv.Close()
+	controller := server.ProtoClone(&testNvmeCtrl)
+	controller.Psk = []byte("00112233445566778899aabbccddeeff")
+	other := server.ProtoClone(&testNvmeCtrl)
+	other.Psk = []byte(testPskInterchange)
+
+	// a controller with an invalid key is rejected
+	invalid := server.ProtoClone(&testNvmeCtrl)
+	invalid.Psk = []byte("0011223344")
+	_, err := testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: invalid, NvmeRemoteControllerId: "invalid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for invalid PSK, received: %v", err)
+	}
+
+	controller, err = testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: controller, NvmeRemoteControllerId: testNvmeCtrlID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = testEnv.client.CreateNvmeRemoteController(testEnv.ctx,
+		&pb.CreateNvmeRemoteControllerRequest{NvmeRemoteController: other, NvmeRemoteControllerId: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	path := server.ProtoClone(&testNvmePath)
+	path.ControllerId.Value = controller.Name
+	if _, err := testEnv.client.CreateNvmePath(testEnv.ctx,
+		&pb.CreateNvmePathRequest{NvmePath: path, NvmePathId: testNvmePathID}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(testEnv.opiSpdkServer.KeyDir, testNvmeCtrlID+".psk")
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatalf("Expected PSK file %v, received: %v", file, err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected PSK file accessible by owner only, received: %v", info.Mode())
+	}
+	if data, _ := os.ReadFile(file); string(data) != testPskInterchange {
+		t.Errorf("Expected PSK in interchange format, received: %s", data)
+	}
+	if entries, _ := os.ReadDir(testEnv.opiSpdkServer.KeyDir); len(entries) != 1 {
+		t.Errorf("Expected only PSK file of the controller with a path, received: %v", entries)
+	}
+
+	testEnv.opiSpdkServer.Volumes.NvmePaths = map[string]*pb.NvmePath{}
+	if _, err := testEnv.client.DeleteNvmeRemoteController(testEnv.ctx,
+		&pb.DeleteNvmeRemoteControllerRequest{Name: controller.Name}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Expected PSK file removed, received: %v", err)
+	}
+}
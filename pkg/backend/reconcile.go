@@ -0,0 +1,515 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implements the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+)
+
+// product names reported by SPDK for bdevs managed by backend
+const (
+	aioProductName    = "AIO disk"
+	uringProductName  = "URING bdev"
+	nullProductName   = "Null disk"
+	mallocProductName = "Malloc disk"
+	lvolProductName   = "Logical Volume"
+)
+
+// Reconcile compares backend resources with bdevs and Nvme controllers
+// configured in SPDK and resolves found differences according to mode
+func (s *Server) Reconcile(mode server.ReconcileMode) (*server.ReconcileResult, error) {
+	slog.Info("Reconcile: backend", "mode", mode)
+	// resources are compared and adopted as a whole, so no request may interleave
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bdevs []server.Bdev
+	err := s.rpc.Call("bdev_get_bdevs", nil, &bdevs)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", bdevs)
+	var controllers []spdk.BdevNvmeGetControllerResult
+	err = s.rpc.Call("bdev_nvme_get_controllers", nil, &controllers)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "bdev_nvme_get_controllers", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", controllers)
+	var lvstores []bdevLvolGetLvstoresResult
+	err = s.rpc.Call("bdev_lvol_get_lvstores", nil, &lvstores)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "bdev_lvol_get_lvstores", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", lvstores)
+
+	result := &server.ReconcileResult{}
+	// logical volumes go before the bdevs they are stored on,
+	// clones before their snapshots
+	if err := s.reconcileLvols(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileSnapshots(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileLvolStores(mode, lvstores, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileAioControllers(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileNullDebugs(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileMallocVolumes(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileNvmeRemoteControllers(mode, controllers, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Server) reconcileAioControllers(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		engine, filename := aioEngineLibaio, bdev.DriverSpecific.Aio.Filename
+		switch bdev.ProductName {
+		case aioProductName:
+		case uringProductName:
+			engine, filename = aioEngineUring, bdev.DriverSpecific.Uring.Filename
+		default:
+			continue
+		}
+		present[bdev.Name] = true
+		if _, ok := s.Volumes.AioVolumes[server.ResourceIDToVolumeName(bdev.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("aio bdev", bdev.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(bdev.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			volume := &pb.AioController{
+				Name:        name,
+				BlockSize:   bdev.BlockSize,
+				BlocksCount: bdev.NumBlocks,
+				Filename:    filename,
+			}
+			if err := server.Adopt(s.store, s.Volumes.AioVolumes, name, volume); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+			if engine != aioEngineLibaio {
+				if err := s.store.Put(aioEngineKind, name, []byte(engine)); err != nil {
+					slog.Error("Request failed", "err", err)
+					return err
+				}
+				s.aioVolumeEngines[name] = engine
+			}
+		case server.ReconcileCleanup:
+			methods, _ := aioEngines(engine)
+			params := spdk.BdevAioDeleteParams{
+				Name: bdev.Name,
+			}
+			var res spdk.BdevAioDeleteResult
+			if err := s.rpc.Call(methods.delete, &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", methods.delete, "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Aio Dev: %s", params.Name)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.AioVolumes,
+		func(volume *pb.AioController) bool { return present[path.Base(volume.Name)] }, result)
+}
+
+func (s *Server) reconcileNullDebugs(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		if bdev.ProductName != nullProductName {
+			continue
+		}
+		present[bdev.Name] = true
+		if _, ok := s.Volumes.NullVolumes[server.ResourceIDToVolumeName(bdev.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("null bdev", bdev.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(bdev.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			volume := &pb.NullDebug{
+				Name:        name,
+				BlockSize:   bdev.BlockSize,
+				BlocksCount: bdev.NumBlocks,
+			}
+			if err := server.Adopt(s.store, s.Volumes.NullVolumes, name, volume); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := spdk.BdevNullDeleteParams{
+				Name: bdev.Name,
+			}
+			var res spdk.BdevNullDeleteResult
+			if err := s.rpc.Call("bdev_null_delete", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "bdev_null_delete", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Null Dev: %s", params.Name)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.NullVolumes,
+		func(volume *pb.NullDebug) bool { return present[path.Base(volume.Name)] }, result)
+}
+
+func (s *Server) reconcileMallocVolumes(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		if bdev.ProductName != mallocProductName {
+			continue
+		}
+		present[bdev.Name] = true
+		if _, ok := s.Volumes.MallocVolumes[server.ResourceIDToVolumeName(bdev.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("malloc bdev", bdev.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(bdev.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			volume := &px.MallocVolume{
+				Name:        name,
+				BlockSize:   bdev.BlockSize,
+				BlocksCount: bdev.NumBlocks,
+				Uuid:        &pc.Uuid{Value: bdev.UUID},
+			}
+			if err := server.Adopt(s.store, s.Volumes.MallocVolumes, name, volume); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := spdk.BdevMallocDeleteParams{
+				Name: bdev.Name,
+			}
+			var res spdk.BdevMallocDeleteResult
+			if err := s.rpc.Call("bdev_malloc_delete", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "bdev_malloc_delete", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Malloc Dev: %s", params.Name)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.MallocVolumes,
+		func(volume *px.MallocVolume) bool { return present[path.Base(volume.Name)] }, result)
+}
+
+func (s *Server) reconcileLvolStores(mode server.ReconcileMode, lvstores []bdevLvolGetLvstoresResult, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range lvstores {
+		lvs := &lvstores[i]
+		present[lvs.Name] = true
+		if _, ok := s.Volumes.LvolStores[server.ResourceIDToVolumeName(lvs.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("lvol store", lvs.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(lvs.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			lvolStore := &px.LvolStore{
+				Name:        name,
+				VolumeId:    &pc.ObjectKey{Value: lvs.BaseBdev},
+				ClusterSize: lvs.ClusterSize,
+				Uuid:        &pc.Uuid{Value: lvs.UUID},
+			}
+			if err := server.Adopt(s.store, s.Volumes.LvolStores, name, lvolStore); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := bdevLvolDeleteLvstoreParams{
+				LvsName: lvs.Name,
+			}
+			var res bdevLvolDeleteLvstoreResult
+			if err := s.rpc.Call("bdev_lvol_delete_lvstore", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "bdev_lvol_delete_lvstore", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Lvol Store: %s", params.LvsName)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.LvolStores,
+		func(lvs *px.LvolStore) bool { return present[path.Base(lvs.Name)] }, result)
+}
+
+// reconcileLvols matches logical volumes by their
+// "<lvol store name>/<lvol name>" alias
+func (s *Server) reconcileLvols(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	managed := make(map[string]bool, len(s.Volumes.Lvols))
+	for _, lvol := range s.Volumes.Lvols {
+		managed[lvolBdevName(lvol)] = true
+	}
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		lvsName, lvolName, ok := splitLvolAlias(bdev.ProductName, bdev.Aliases)
+		if !ok || bdev.DriverSpecific.Lvol.Snapshot {
+			continue
+		}
+		alias := lvsName + "/" + lvolName
+		present[alias] = true
+		if managed[alias] {
+			continue
+		}
+		result.AddOrphaned("lvol", alias)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(lvolName)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			if _, ok := s.Volumes.Lvols[name]; ok {
+				slog.Error("Request failed", "err", fmt.Errorf("cannot adopt %v: name is used by another Lvol", alias))
+				continue
+			}
+			lvol := &px.Lvol{
+				Name:          name,
+				LvolStoreId:   &pc.ObjectKey{Value: server.ResourceIDToVolumeName(lvsName)},
+				SizeMib:       bdev.BlockSize * bdev.NumBlocks / mib,
+				ThinProvision: bdev.DriverSpecific.Lvol.ThinProvision,
+				Uuid:          &pc.Uuid{Value: bdev.UUID},
+				SnapshotId:    s.cloneSnapshotID(name, bdev.DriverSpecific.Lvol.BaseSnapshot),
+			}
+			if err := server.Adopt(s.store, s.Volumes.Lvols, name, lvol); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := bdevLvolDeleteParams{
+				Name: alias,
+			}
+			var res bdevLvolDeleteResult
+			if err := s.rpc.Call("bdev_lvol_delete", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "bdev_lvol_delete", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Lvol: %s", params.Name)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.Lvols,
+		func(lvol *px.Lvol) bool { return present[lvolBdevName(lvol)] }, result)
+}
+
+// reconcileSnapshots matches snapshots by their
+// "<lvol store name>/<snapshot name>" alias. SPDK does not know which
+// logical volume a snapshot was taken of, so adopted snapshots have none.
+func (s *Server) reconcileSnapshots(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		lvsName, snapshotName, ok := splitLvolAlias(bdev.ProductName, bdev.Aliases)
+		if !ok || !bdev.DriverSpecific.Lvol.Snapshot {
+			continue
+		}
+		alias := lvsName + "/" + snapshotName
+		present[alias] = true
+		if snapshot, ok := s.Volumes.Snapshots[server.ResourceIDToVolumeName(snapshotName)]; ok && snapshotBdevName(snapshot) == alias {
+			continue
+		}
+		result.AddOrphaned("snapshot", alias)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(snapshotName)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			if _, ok := s.Volumes.Snapshots[name]; ok {
+				slog.Error("Request failed", "err", fmt.Errorf("cannot adopt %v: name is used by another Snapshot", alias))
+				continue
+			}
+			snapshot := &px.Snapshot{
+				Name:        name,
+				LvolStoreId: &pc.ObjectKey{Value: server.ResourceIDToVolumeName(lvsName)},
+				SizeMib:     bdev.BlockSize * bdev.NumBlocks / mib,
+				Uuid:        &pc.Uuid{Value: bdev.UUID},
+			}
+			if err := server.Adopt(s.store, s.Volumes.Snapshots, name, snapshot); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := bdevLvolDeleteParams{
+				Name: alias,
+			}
+			var res bdevLvolDeleteResult
+			if err := s.rpc.Call("bdev_lvol_delete", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "bdev_lvol_delete", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete Snapshot: %s", params.Name)
+			}
+		}
+	}
+	return server.ReconcileMissing(mode, s.store, s.Volumes.Snapshots,
+		func(snapshot *px.Snapshot) bool { return present[snapshotBdevName(snapshot)] }, result)
+}
+
+// reconcileNvmeRemoteControllers matches Nvme paths against transport IDs of
+// Nvme controllers in SPDK. NvmeRemoteController without paths exists in the
+// bridge only, so it is never reported as missing.
+func (s *Server) reconcileNvmeRemoteControllers(mode server.ReconcileMode,
+	controllers []spdk.BdevNvmeGetControllerResult, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range controllers {
+		controller := &controllers[i]
+		name := server.ResourceIDToVolumeName(controller.Name)
+		_, managed := s.Volumes.NvmeControllers[name]
+		for j := range controller.Ctrlrs {
+			ctrlr := &controller.Ctrlrs[j]
+			nvmePath := &pb.NvmePath{
+				ControllerId: &pc.ObjectKey{Value: name},
+				Trtype:       spdkTransportToOpi(ctrlr.Trid.Trtype),
+				Adrfam:       spdkAdressFamilyToOpi(ctrlr.Trid.Adrfam),
+				Traddr:       ctrlr.Trid.Traddr,
+				Subnqn:       ctrlr.Trid.Subnqn,
+				Hostnqn:      ctrlr.Host.Nqn,
+			}
+			nvmePath.Trsvcid, _ = strconv.ParseInt(ctrlr.Trid.Trsvcid, 10, 64)
+			if existing := s.findNvmePath(nvmePath); existing != nil {
+				present[existing.Name] = true
+				continue
+			}
+			result.AddOrphaned("nvme controller path", fmt.Sprintf("%s %s:%s", controller.Name, ctrlr.Trid.Traddr, ctrlr.Trid.Trsvcid))
+			switch mode {
+			case server.ReconcileAdopt:
+				if err := s.adoptNvmePath(controller.Name, managed, j, nvmePath); err != nil {
+					return err
+				}
+				present[nvmePath.Name] = true
+				managed = true
+			case server.ReconcileCleanup:
+				params := spdk.BdevNvmeDetachControllerParams{
+					Name:    controller.Name,
+					Trtype:  ctrlr.Trid.Trtype,
+					Traddr:  ctrlr.Trid.Traddr,
+					Adrfam:  ctrlr.Trid.Adrfam,
+					Trsvcid: ctrlr.Trid.Trsvcid,
+					Subnqn:  ctrlr.Trid.Subnqn,
+				}
+				var res spdk.BdevNvmeDetachControllerResult
+				if err := s.rpc.Call("bdev_nvme_detach_controller", &params, &res); err != nil {
+					slog.Error("SPDK call failed", "method", "bdev_nvme_detach_controller", "err", err)
+					return err
+				}
+				slog.Debug("Received from SPDK", "result", res)
+				if !res {
+					return fmt.Errorf("could not detach Nvme controller: %s", params.Name)
+				}
+			}
+		}
+	}
+	// SPDK does not list discovery controllers with the others
+	return server.ReconcileMissing(mode, s.store, s.Volumes.NvmePaths,
+		func(nvmePath *pb.NvmePath) bool { return present[nvmePath.Name] || isDiscoveryPath(nvmePath) }, result)
+}
+
+func (s *Server) adoptNvmePath(controllerName string, managed bool, index int, nvmePath *pb.NvmePath) error {
+	if !managed {
+		name, err := server.AdoptableName(controllerName)
+		if err != nil {
+			slog.Error("Request failed", "err", err)
+			return nil
+		}
+		controller := &pb.NvmeRemoteController{
+			Name:      name,
+			Multipath: pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+		}
+		if err := server.Adopt(s.store, s.Volumes.NvmeControllers, name, controller); err != nil {
+			slog.Error("Request failed", "err", err)
+			return err
+		}
+	}
+	name, err := server.AdoptableName(fmt.Sprintf("%s-path%d", controllerName, index))
+	if err != nil {
+		slog.Error("Request failed", "err", err)
+		return nil
+	}
+	nvmePath.Name = name
+	if err := server.Adopt(s.store, s.Volumes.NvmePaths, name, nvmePath); err != nil {
+		slog.Error("Request failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+// findNvmePath returns stored Nvme path of the same controller
+// connected to the same transport ID as nvmePath, s.mu must be held
+func (s *Server) findNvmePath(nvmePath *pb.NvmePath) *pb.NvmePath {
+	for _, existing := range s.Volumes.NvmePaths {
+		if existing.ControllerId.GetValue() == nvmePath.ControllerId.GetValue() &&
+			existing.Trtype == nvmePath.Trtype &&
+			existing.Traddr == nvmePath.Traddr &&
+			existing.Trsvcid == nvmePath.Trsvcid &&
+			existing.Subnqn == nvmePath.Subnqn {
+			return existing
+		}
+	}
+	return nil
+}
+
+func spdkTransportToOpi(transport string) pb.NvmeTransportType {
+	return pb.NvmeTransportType(pb.NvmeTransportType_value["NVME_TRANSPORT_"+strings.ToUpper(transport)])
+}
+
+func spdkAdressFamilyToOpi(adrfam string) pb.NvmeAddressFamily {
+	return pb.NvmeAddressFamily(pb.NvmeAddressFamily_value["NVME_ADRFAM_"+strings.ToUpper(adrfam)])
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implements the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+func TestBackEnd_Reconcile(t *testing.T) {
+	staleAioVolumeName := server.ResourceIDToVolumeName("stale")
+	bdevsResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"mytest","product_name":"AIO disk","block_size":512,"num_blocks":12,"driver_specific":{"aio":{"filename":"/tmp/aio_bdev_file"}}},` +
+		`{"name":"orphan","product_name":"AIO disk","block_size":4096,"num_blocks":64,"driver_specific":{"aio":{"filename":"/tmp/orphan_file"}}},` +
+		`{"name":"scratch","product_name":"Malloc disk","block_size":512,"num_blocks":64,"uuid":"043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},` +
+		`{"name":"Passthru0","product_name":"passthru","block_size":512,"num_blocks":64},` +
+		`{"name":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11","aliases":["lvs0/lvol0"],"product_name":"Logical Volume","block_size":512,"num_blocks":2048,"uuid":"6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11","driver_specific":{"lvol":{"lvol_store_uuid":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10","thin_provision":true,"base_snapshot":"golden"}}},` +
+		`{"name":"3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93","aliases":["lvs0/golden"],"product_name":"Logical Volume","block_size":512,"num_blocks":2048,"uuid":"3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93","driver_specific":{"lvol":{"lvol_store_uuid":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10","snapshot":true}}}]}`
+	controllersResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"nvme0","ctrlrs":[{"state":"enabled","trid":{"trtype":"TCP","adrfam":"IPv4","traddr":"127.0.0.1","trsvcid":"4444","subnqn":"nqn.2016-06.io.spdk:cnode1"},"cntlid":1,"host":{"nqn":"nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c"}}]}]}`
+	lvstoresResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"uuid":"a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10","name":"lvs0","base_bdev":"scratch","total_data_clusters":7,"free_clusters":6,"block_size":512,"cluster_size":4194304}]}`
+	orphanAio := &pb.AioController{
+		Name:        server.ResourceIDToVolumeName("orphan"),
+		BlockSize:   4096,
+		BlocksCount: 64,
+		Filename:    "/tmp/orphan_file",
+	}
+	orphanMalloc := &px.MallocVolume{
+		Name:        server.ResourceIDToVolumeName("scratch"),
+		BlockSize:   512,
+		BlocksCount: 64,
+		Uuid:        &pc.Uuid{Value: "043c1df5-fa2f-4f58-8a4c-cfe1e57fa16c"},
+	}
+	orphanLvolStore := &px.LvolStore{
+		Name:        server.ResourceIDToVolumeName("lvs0"),
+		VolumeId:    &pc.ObjectKey{Value: "scratch"},
+		ClusterSize: 4194304,
+		Uuid:        &pc.Uuid{Value: "a6f2f1a4-2d5c-4b4e-8c0b-3b1c2d9e7f10"},
+	}
+	orphanLvol := &px.Lvol{
+		Name:          server.ResourceIDToVolumeName("lvol0"),
+		LvolStoreId:   &pc.ObjectKey{Value: orphanLvolStore.Name},
+		SizeMib:       1,
+		ThinProvision: true,
+		Uuid:          &pc.Uuid{Value: "6f3a5b8e-6b0c-4b2a-9a8b-1f1f7d5e2c11"},
+		SnapshotId:    &pc.ObjectKey{Value: server.ResourceIDToVolumeName("golden")},
+	}
+	orphanSnapshot := &px.Snapshot{
+		Name:        server.ResourceIDToVolumeName("golden"),
+		LvolStoreId: &pc.ObjectKey{Value: orphanLvolStore.Name},
+		SizeMib:     1,
+		Uuid:        &pc.Uuid{Value: "3c0f2a7d-5e1b-4d8c-9f6a-2b7e4c1d0a93"},
+	}
+	orphanController := &pb.NvmeRemoteController{
+		Name:      server.ResourceIDToVolumeName("nvme0"),
+		Multipath: pb.NvmeMultipath_NVME_MULTIPATH_MULTIPATH,
+	}
+	orphanPath := &pb.NvmePath{
+		Name:         server.ResourceIDToVolumeName("nvme0-path0"),
+		ControllerId: &pc.ObjectKey{Value: orphanController.Name},
+		Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Adrfam:       pb.NvmeAddressFamily_NVME_ADRFAM_IPV4,
+		Traddr:       "127.0.0.1",
+		Trsvcid:      4444,
+		Subnqn:       "nqn.2016-06.io.spdk:cnode1",
+		Hostnqn:      "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c",
+	}
+
+	tests := map[string]struct {
+		mode        server.ReconcileMode
+		spdk        []string
+		out         *server.ReconcileResult
+		errMsg      string
+		aios        []*pb.AioController
+		mallocs     []*px.MallocVolume
+		lvstores    []*px.LvolStore
+		lvols       []*px.Lvol
+		snapshots   []*px.Snapshot
+		controllers []*pb.NvmeRemoteController
+		paths       []*pb.NvmePath
+	}{
+		"report only": {
+			mode: server.ReconcileReport,
+			spdk: []string{bdevsResponse, controllersResponse, lvstoresResponse},
+			out: &server.ReconcileResult{
+				Missing:  []string{staleAioVolumeName},
+				Orphaned: []string{"lvol lvs0/lvol0", "snapshot lvs0/golden", "lvol store lvs0", "aio bdev orphan", "malloc bdev scratch", "nvme controller path nvme0 127.0.0.1:4444"},
+			},
+			aios: []*pb.AioController{&testAioVolume, {Name: staleAioVolumeName}},
+		},
+		"adopt": {
+			mode: server.ReconcileAdopt,
+			spdk: []string{bdevsResponse, controllersResponse, lvstoresResponse},
+			out: &server.ReconcileResult{
+				Missing:  []string{staleAioVolumeName},
+				Orphaned: []string{"lvol lvs0/lvol0", "snapshot lvs0/golden", "lvol store lvs0", "aio bdev orphan", "malloc bdev scratch", "nvme controller path nvme0 127.0.0.1:4444"},
+			},
+			aios:        []*pb.AioController{&testAioVolume, orphanAio},
+			mallocs:     []*px.MallocVolume{orphanMalloc},
+			lvstores:    []*px.LvolStore{orphanLvolStore},
+			lvols:       []*px.Lvol{orphanLvol},
+			snapshots:   []*px.Snapshot{orphanSnapshot},
+			controllers: []*pb.NvmeRemoteController{orphanController},
+			paths:       []*pb.NvmePath{orphanPath},
+		},
+		"cleanup": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{bdevsResponse, controllersResponse, lvstoresResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			out: &server.ReconcileResult{
+				Missing:  []string{staleAioVolumeName},
+				Orphaned: []string{"lvol lvs0/lvol0", "snapshot lvs0/golden", "lvol store lvs0", "aio bdev orphan", "malloc bdev scratch", "nvme controller path nvme0 127.0.0.1:4444"},
+			},
+			aios: []*pb.AioController{&testAioVolume},
+		},
+		"cleanup with invalid SPDK response": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{bdevsResponse, controllersResponse, lvstoresResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			errMsg: "could not delete Lvol: lvs0/lvol0",
+		},
+		"valid request with error code from SPDK": {
+			mode:   server.ReconcileReport,
+			spdk:   []string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			errMsg: "bdev_get_bdevs: json response error: myopierr",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testAioVolume.Name = testAioVolumeName
+			testEnv.opiSpdkServer.Volumes.AioVolumes[testAioVolumeName] = &testAioVolume
+			testEnv.opiSpdkServer.Volumes.AioVolumes[staleAioVolumeName] = &pb.AioController{Name: staleAioVolumeName}
+
+			result, err := testEnv.opiSpdkServer.Reconcile(tt.mode)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("expected error %v, received: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, received: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.out) {
+				t.Errorf("expected %v, received: %v", tt.out, result)
+			}
+
+			checkResources(t, testEnv.opiSpdkServer.Volumes.AioVolumes, tt.aios)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.MallocVolumes, tt.mallocs)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.LvolStores, tt.lvstores)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.Lvols, tt.lvols)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.Snapshots, tt.snapshots)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.NvmeControllers, tt.controllers)
+			checkResources(t, testEnv.opiSpdkServer.Volumes.NvmePaths, tt.paths)
+		})
+	}
+}
+
+func checkResources[T proto.Message](t *testing.T, actual map[string]T, expected []T) {
+	if len(actual) != len(expected) {
+		t.Errorf("expected %v, received: %v", expected, actual)
+		return
+	}
+	for _, e := range expected {
+		name := e.ProtoReflect().Descriptor().Fields().ByName("name")
+		a, ok := actual[e.ProtoReflect().Get(name).String()]
+		if !ok || !proto.Equal(a, e) {
+			t.Errorf("expected %v, received: %v", expected, actual)
+		}
+	}
+}
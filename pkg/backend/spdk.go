@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package backend implememnts the BackEnd APIs (network facing) of the storage Server
+package backend
+
+import "github.com/opiproject/gospdk/spdk"
+
+// SPDK JSON-RPC models used by the backend, which are not provided by gospdk
+
+// bdevNvmeResetControllerParams is the parameters required to reset an NVMe controller
+type bdevNvmeResetControllerParams struct {
+	Name string `json:"name"`
+}
+
+// bdevNvmeResetControllerResult is the result of resetting an NVMe controller
+type bdevNvmeResetControllerResult bool
+
+// bdevNvmeTrid is the transport ID of a path to an NVMe controller, the same
+// as trid of spdk.BdevNvmeGetControllerResult controllers
+type bdevNvmeTrid struct {
+	Trtype  string `json:"trtype"`
+	Adrfam  string `json:"adrfam"`
+	Traddr  string `json:"traddr"`
+	Trsvcid string `json:"trsvcid"`
+	Subnqn  string `json:"subnqn"`
+}
+
+// bdevNvmeIoPath is an I/O path of an NVMe bdev through one of the controller paths
+type bdevNvmeIoPath struct {
+	BdevName   string       `json:"bdev_name"`
+	Cntlid     int          `json:"cntlid"`
+	Current    bool         `json:"current"`
+	Connected  bool         `json:"connected"`
+	Accessible bool         `json:"accessible"`
+	Transport  bdevNvmeTrid `json:"transport"`
+}
+
+// bdevNvmeGetIoPathsResult is the result of getting I/O paths of NVMe bdevs
+type bdevNvmeGetIoPathsResult struct {
+	PollGroups []struct {
+		IoPaths []bdevNvmeIoPath `json:"io_paths"`
+	} `json:"poll_groups"`
+}
+
+// bdevNvmeAttachControllerParams is spdk.BdevNvmeAttachControllerParams
+// with reconnect settings of the attached path
+type bdevNvmeAttachControllerParams struct {
+	spdk.BdevNvmeAttachControllerParams
+	CtrlrLossTimeoutSec  int64 `json:"ctrlr_loss_timeout_sec,omitempty"`
+	ReconnectDelaySec    int64 `json:"reconnect_delay_sec,omitempty"`
+	FastIoFailTimeoutSec int64 `json:"fast_io_fail_timeout_sec,omitempty"`
+}
+
+// bdevNvmeSetMultipathPolicyParams is the parameters required to set
+// multipath policy of an NVMe bdev
+type bdevNvmeSetMultipathPolicyParams struct {
+	Name     string `json:"name"`
+	Policy   string `json:"policy"`
+	Selector string `json:"selector,omitempty"`
+}
+
+// bdevNvmeSetMultipathPolicyResult is the result of setting multipath policy of an NVMe bdev
+type bdevNvmeSetMultipathPolicyResult bool
+
+// bdevNvmeStartDiscoveryParams is the parameters required to start discovery
+// of NVMe-oF subsystems through a discovery controller
+type bdevNvmeStartDiscoveryParams struct {
+	Name                 string `json:"name"`
+	Trtype               string `json:"trtype"`
+	Traddr               string `json:"traddr"`
+	Adrfam               string `json:"adrfam,omitempty"`
+	Trsvcid              string `json:"trsvcid,omitempty"`
+	Hostnqn              string `json:"hostnqn,omitempty"`
+	WaitForAttach        bool   `json:"wait_for_attach,omitempty"`
+	CtrlrLossTimeoutSec  int64  `json:"ctrlr_loss_timeout_sec,omitempty"`
+	ReconnectDelaySec    int64  `json:"reconnect_delay_sec,omitempty"`
+	FastIoFailTimeoutSec int64  `json:"fast_io_fail_timeout_sec,omitempty"`
+}
+
+// bdevNvmeStartDiscoveryResult is the result of starting discovery
+type bdevNvmeStartDiscoveryResult bool
+
+// bdevNvmeStopDiscoveryParams is the parameters required to stop discovery,
+// which detaches controllers of discovered subsystems
+type bdevNvmeStopDiscoveryParams struct {
+	Name string `json:"name"`
+}
+
+// bdevNvmeStopDiscoveryResult is the result of stopping discovery
+type bdevNvmeStopDiscoveryResult bool
+
+// bdevNullCreateParams is spdk.BdevNullCreateParams with metadata and DIF
+// format of the Null Block Device
+type bdevNullCreateParams struct {
+	spdk.BdevNullCreateParams
+	MdSize        int64 `json:"md_size,omitempty"`
+	DifType       int64 `json:"dif_type,omitempty"`
+	DifIsHeadOfMd bool  `json:"dif_is_head_of_md,omitempty"`
+}
+
+// bdevNullResizeParams is the parameters required to resize a Null Block Device
+type bdevNullResizeParams struct {
+	Name string `json:"name"`
+	// NewSize is the new size in MiB
+	NewSize int64 `json:"new_size"`
+}
+
+// bdevNullResizeResult is the result of resizing a Null Block Device
+type bdevNullResizeResult bool
+
+// bdevGetBdevsResult is spdk.BdevGetBdevsResult with metadata and DIF format,
+// claim, product, aliases and driver specific information of the bdev
+type bdevGetBdevsResult struct {
+	spdk.BdevGetBdevsResult
+	MdSize        int64 `json:"md_size"`
+	DifType       int64 `json:"dif_type"`
+	DifIsHeadOfMd bool  `json:"dif_is_head_of_md"`
+	// Claimed reports if a module, e.g. NVMe-oF target or crypto, uses the bdev
+	Claimed        bool               `json:"claimed"`
+	ProductName    string             `json:"product_name"`
+	Aliases        []string           `json:"aliases"`
+	DriverSpecific bdevDriverSpecific `json:"driver_specific"`
+}
+
+// bdevDriverSpecific is driver specific information of bdevs used by the backend
+type bdevDriverSpecific struct {
+	Lvol struct {
+		LvolStoreUUID        string `json:"lvol_store_uuid"`
+		ThinProvision        bool   `json:"thin_provision"`
+		NumAllocatedClusters int64  `json:"num_allocated_clusters"`
+		Snapshot             bool   `json:"snapshot"`
+		BaseSnapshot         string `json:"base_snapshot"`
+	} `json:"lvol"`
+}
+
+// bdevAioRescanParams is the parameters required to rescan size of an AIO Block Device
+type bdevAioRescanParams struct {
+	Name string `json:"name"`
+}
+
+// bdevAioRescanResult is the result of rescanning size of an AIO Block Device
+type bdevAioRescanResult bool
+
+// bdevMallocCreateParams is spdk.BdevMalloCreateParams which lets SPDK
+// generate UUID of the bdev when none is given
+type bdevMallocCreateParams struct {
+	Name      string `json:"name"`
+	NumBlocks int64  `json:"num_blocks"`
+	BlockSize int64  `json:"block_size"`
+	UUID      string `json:"uuid,omitempty"`
+}
+
+// bdevLvolCreateLvstoreParams is the parameters required to create a logical volume store
+type bdevLvolCreateLvstoreParams struct {
+	BdevName  string `json:"bdev_name"`
+	LvsName   string `json:"lvs_name"`
+	ClusterSz int64  `json:"cluster_sz,omitempty"`
+}
+
+// bdevLvolCreateLvstoreResult is UUID of the created logical volume store
+type bdevLvolCreateLvstoreResult string
+
+// bdevLvolDeleteLvstoreParams is the parameters required to delete a logical volume store
+type bdevLvolDeleteLvstoreParams struct {
+	LvsName string `json:"lvs_name"`
+}
+
+// bdevLvolDeleteLvstoreResult is the result of deleting a logical volume store
+type bdevLvolDeleteLvstoreResult bool
+
+// bdevLvolGetLvstoresParams is the parameters required to get logical volume
+// stores, all of them if LvsName is not set
+type bdevLvolGetLvstoresParams struct {
+	LvsName string `json:"lvs_name,omitempty"`
+}
+
+// bdevLvolGetLvstoresResult is a logical volume store of the result of
+// getting logical volume stores
+type bdevLvolGetLvstoresResult struct {
+	UUID              string `json:"uuid"`
+	Name              string `json:"name"`
+	BaseBdev          string `json:"base_bdev"`
+	TotalDataClusters int64  `json:"total_data_clusters"`
+	FreeClusters      int64  `json:"free_clusters"`
+	BlockSize         int64  `json:"block_size"`
+	ClusterSize       int64  `json:"cluster_size"`
+}
+
+// bdevLvolCreateParams is the parameters required to create a logical volume
+type bdevLvolCreateParams struct {
+	LvolName      string `json:"lvol_name"`
+	SizeInMib     int64  `json:"size_in_mib"`
+	ThinProvision bool   `json:"thin_provision"`
+	LvsName       string `json:"lvs_name"`
+}
+
+// bdevLvolCreateResult is UUID of the created logical volume bdev
+type bdevLvolCreateResult string
+
+// bdevLvolResizeParams is the parameters required to resize a logical volume
+type bdevLvolResizeParams struct {
+	Name      string `json:"name"`
+	SizeInMib int64  `json:"size_in_mib"`
+}
+
+// bdevLvolResizeResult is the result of resizing a logical volume
+type bdevLvolResizeResult bool
+
+// bdevLvolDeleteParams is the parameters required to delete a logical volume
+type bdevLvolDeleteParams struct {
+	Name string `json:"name"`
+}
+
+// bdevLvolDeleteResult is the result of deleting a logical volume
+type bdevLvolDeleteResult bool
+
+// bdevLvolSnapshotParams is the parameters required to snapshot a logical volume
+type bdevLvolSnapshotParams struct {
+	LvolName     string `json:"lvol_name"`
+	SnapshotName string `json:"snapshot_name"`
+}
+
+// bdevLvolSnapshotResult is UUID of the created snapshot bdev
+type bdevLvolSnapshotResult string
+
+// bdevLvolCloneParams is the parameters required to clone a snapshot
+type bdevLvolCloneParams struct {
+	SnapshotName string `json:"snapshot_name"`
+	CloneName    string `json:"clone_name"`
+}
+
+// bdevLvolCloneResult is UUID of the created clone bdev
+type bdevLvolCloneResult string
+
+// bdevLvolInflateParams is the parameters required to inflate a clone
+type bdevLvolInflateParams struct {
+	Name string `json:"name"`
+}
+
+// bdevLvolInflateResult is the result of inflating a clone
+type bdevLvolInflateResult bool
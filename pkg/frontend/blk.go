@@ -8,16 +8,15 @@ package frontend
 import (
 	"context"
 	"fmt"
-	"log"
 	"path"
-	"sort"
 
 	"github.com/opiproject/gospdk/spdk"
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
@@ -27,18 +26,12 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortVirtioBlks(virtioBlks []*pb.VirtioBlk) {
-	sort.Slice(virtioBlks, func(i int, j int) bool {
-		return virtioBlks[i].Name < virtioBlks[j].Name
-	})
-}
-
 // CreateVirtioBlk creates a Virtio block device
-func (s *Server) CreateVirtioBlk(_ context.Context, in *pb.CreateVirtioBlkRequest) (*pb.VirtioBlk, error) {
-	log.Printf("CreateVirtioBlk: Received from client: %v", in)
+func (s *Server) CreateVirtioBlk(ctx context.Context, in *pb.CreateVirtioBlkRequest) (*pb.VirtioBlk, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// see https://google.aip.dev/133#user-specified-ids
@@ -46,64 +39,88 @@ func (s *Server) CreateVirtioBlk(_ context.Context, in *pb.CreateVirtioBlkReques
 	if in.VirtioBlkId != "" {
 		err := resourceid.ValidateUserSettable(in.VirtioBlkId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.VirtioBlkId, in.VirtioBlk.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.VirtioBlkId, "name", in.VirtioBlk.Name)
 		resourceID = in.VirtioBlkId
 	}
 	in.VirtioBlk.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.VirtioBlk.Name)
+	defer unlock()
 
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	controller, ok := s.Virt.BlkCtrls[in.VirtioBlk.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing NvmeController with id %v", in.VirtioBlk.Name)
+		slog.InfoContext(ctx, "Already existing NvmeController", "name", in.VirtioBlk.Name)
 		return controller, nil
 	}
 	// not found, so create a new one
+	if err := s.VolumeIndex.ResolveKey(in.VirtioBlk.VolumeId); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.VirtioBlk.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
 	params := spdk.VhostCreateBlkControllerParams{
 		Ctrlr:   resourceID,
 		DevName: in.VirtioBlk.VolumeId.Value,
 	}
 	var result spdk.VhostCreateBlkControllerResult
-	err := s.rpc.Call("vhost_create_blk_controller", &params, &result)
+	err = server.Call(ctx, s.rpc, "vhost_create_blk_controller", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "vhost_create_blk_controller", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not create virtio-blk: %s", resourceID)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	response := server.ProtoClone(in.VirtioBlk)
 	// response.Status = &pb.NvmeControllerStatus{Active: true}
+	if err := store.Save(s.store, in.VirtioBlk.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.Virt.BlkCtrls[in.VirtioBlk.Name] = response
+	s.mu.Unlock()
 	return response, nil
 }
 
 // DeleteVirtioBlk deletes a Virtio block device
-func (s *Server) DeleteVirtioBlk(_ context.Context, in *pb.DeleteVirtioBlkRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteVirtioBlk: Received from client: %v", in)
+func (s *Server) DeleteVirtioBlk(ctx context.Context, in *pb.DeleteVirtioBlkRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	controller, ok := s.Virt.BlkCtrls[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(controller.Name)
@@ -111,82 +128,85 @@ func (s *Server) DeleteVirtioBlk(_ context.Context, in *pb.DeleteVirtioBlkReques
 		Ctrlr: resourceID,
 	}
 	var result spdk.VhostDeleteControllerResult
-	err := s.rpc.Call("vhost_delete_controller", &params, &result)
+	err := server.Call(ctx, s.rpc, "vhost_delete_controller", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "vhost_delete_controller", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not delete virtio-blk: %s", resourceID)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
+	if err := store.Remove(s.store, controller.Name, controller); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Virt.BlkCtrls, controller.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateVirtioBlk updates a Virtio block device
-func (s *Server) UpdateVirtioBlk(_ context.Context, in *pb.UpdateVirtioBlkRequest) (*pb.VirtioBlk, error) {
-	log.Printf("UpdateVirtioBlk: Received from client: %v", in)
+func (s *Server) UpdateVirtioBlk(ctx context.Context, in *pb.UpdateVirtioBlkRequest) (*pb.VirtioBlk, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.VirtioBlk.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.VirtioBlk.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Virt.BlkCtrls[in.VirtioBlk.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
-			log.Printf("TODO: in case of AllowMissing, create a new resource, don;t return error")
+			slog.DebugContext(ctx, "TODO: in case of AllowMissing, create a new resource, don;t return error")
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.VirtioBlk.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
 	// update_mask = 2
 	if err := fieldmask.Validate(in.UpdateMask, in.VirtioBlk); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("TODO: use resourceID=%v", resourceID)
+	slog.DebugContext(ctx, "TODO: use resourceID", "resource_id", resourceID)
 	return nil, status.Errorf(codes.Unimplemented, "UpdateVirtioBlk method is not implemented")
 }
 
 // ListVirtioBlks lists Virtio block devices
-func (s *Server) ListVirtioBlks(_ context.Context, in *pb.ListVirtioBlksRequest) (*pb.ListVirtioBlksResponse, error) {
-	log.Printf("ListVirtioBlks: Received from client: %v", in)
+func (s *Server) ListVirtioBlks(ctx context.Context, in *pb.ListVirtioBlksRequest) (*pb.ListVirtioBlksResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.VirtioBlk{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
 	var result []spdk.VhostGetControllersResult
-	err := s.rpc.Call("vhost_get_controllers", nil, &result)
+	err := server.Call(ctx, s.rpc, "vhost_get_controllers", nil, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "vhost_get_controllers", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-	result, hasMoreElements := server.LimitPagination(result, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
-	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	Blobarray := make([]*pb.VirtioBlk, len(result))
 	for i := range result {
 		r := &result[i]
@@ -195,29 +215,31 @@ func (s *Server) ListVirtioBlks(_ context.Context, in *pb.ListVirtioBlksRequest)
 			PcieId:   &pb.PciEndpoint{PhysicalFunction: 1},
 			VolumeId: &pc.ObjectKey{Value: "TBD"}}
 	}
-	sortVirtioBlks(Blobarray)
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.VirtioBlk).GetName)
 
 	return &pb.ListVirtioBlksResponse{VirtioBlks: Blobarray, NextPageToken: token}, nil
 }
 
 // GetVirtioBlk gets a Virtio block device
-func (s *Server) GetVirtioBlk(_ context.Context, in *pb.GetVirtioBlkRequest) (*pb.VirtioBlk, error) {
-	log.Printf("GetVirtioBlk: Received from client: %v", in)
+func (s *Server) GetVirtioBlk(ctx context.Context, in *pb.GetVirtioBlkRequest) (*pb.VirtioBlk, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Virt.BlkCtrls[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
@@ -225,15 +247,15 @@ func (s *Server) GetVirtioBlk(_ context.Context, in *pb.GetVirtioBlkRequest) (*p
 		Name: resourceID,
 	}
 	var result []spdk.VhostGetControllersResult
-	err := s.rpc.Call("vhost_get_controllers", &params, &result)
+	err := server.Call(ctx, s.rpc, "vhost_get_controllers", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "vhost_get_controllers", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.VirtioBlk{
@@ -243,26 +265,28 @@ func (s *Server) GetVirtioBlk(_ context.Context, in *pb.GetVirtioBlkRequest) (*p
 }
 
 // VirtioBlkStats gets a Virtio block device stats
-func (s *Server) VirtioBlkStats(_ context.Context, in *pb.VirtioBlkStatsRequest) (*pb.VirtioBlkStatsResponse, error) {
-	log.Printf("VirtioBlkStats: Received from client: %v", in)
+func (s *Server) VirtioBlkStats(ctx context.Context, in *pb.VirtioBlkStatsRequest) (*pb.VirtioBlkStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.ControllerId.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Virt.BlkCtrls[in.ControllerId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.ControllerId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
-	log.Printf("TODO: send name to SPDK and get back stats: %v", resourceID)
+	slog.DebugContext(ctx, "TODO: send name to SPDK and get back stats", "name", resourceID)
 	return nil, status.Errorf(codes.Unimplemented, "VirtioBlkStats method is not implemented")
 }
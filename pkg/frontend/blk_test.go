@@ -340,7 +340,7 @@ func TestFrontEnd_ListVirtioBlks(t *testing.T) {
 			"subsystem-test",
 			[]*pb.VirtioBlk{
 				{
-					Name:     testVirtioCtrlName,
+					Name:     server.ResourceIDToVolumeName("VblkEmu0pf2"),
 					PcieId:   &pb.PciEndpoint{PhysicalFunction: int32(1)},
 					VolumeId: &pc.ObjectKey{Value: "TBD"},
 				},
@@ -359,9 +359,11 @@ func TestFrontEnd_ListVirtioBlks(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
-
 			request := &pb.ListVirtioBlksRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&pb.VirtioBlk{Name: server.ResourceIDToVolumeName("VblkEmu0pf0")}, server.ResourceIDToVolumeName("VblkEmu0pf0"))
+			}
 			response, err := testEnv.client.ListVirtioBlks(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetVirtioBlks(), tt.out) {
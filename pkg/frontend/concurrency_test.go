@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package frontend implements the FrontEnd APIs (host facing) of the storage Server
+package frontend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+const (
+	concurrentWorkers    = 32
+	concurrentSubsystems = 4
+)
+
+func newConcurrentTestSpdkStub() *server.TestSpdkStub {
+	subsystems := ""
+	for i := 0; i < concurrentSubsystems; i++ {
+		if i > 0 {
+			subsystems += ","
+		}
+		subsystems += fmt.Sprintf(`{"nqn":"nqn.2022-09.io.spdk:opi%d","subtype":"NVMe","namespaces":[{"nsid":1,"name":"Malloc0"}]}`, i)
+	}
+	stub := server.NewTestSpdkStub(map[string]string{
+		"nvmf_create_subsystem":               `true`,
+		"nvmf_delete_subsystem":               `true`,
+		"nvmf_get_subsystems":                 "[" + subsystems + "]",
+		"nvmf_get_stats":                      `{"tick_rate":3300000000,"poll_groups":[]}`,
+		"nvmf_subsystem_add_listener":         `true`,
+		"nvmf_subsystem_remove_listener":      `true`,
+		"nvmf_subsystem_add_ns":               `1`,
+		"nvmf_subsystem_remove_ns":            `true`,
+		"spdk_get_version":                    `{"version":"SPDK v20.10"}`,
+		"vhost_create_blk_controller":         `true`,
+		"vhost_create_scsi_controller":        `true`,
+		"vhost_delete_controller":             `true`,
+		"vhost_get_controllers":               `[{"ctrlr":"mytest","iops_threshold":60000,"cpumask":"0x2","delay_base_us":100}]`,
+		"vhost_scsi_controller_add_target":    `0`,
+		"vhost_scsi_controller_remove_target": `true`,
+	})
+	stub.Delay = time.Millisecond
+	return stub
+}
+
+// checkConcurrentError fails on errors which cannot be explained
+// by other requests running in parallel on the same resources
+func checkConcurrentError(t *testing.T, method string, err error) {
+	switch status.Code(err) {
+	case codes.OK, codes.NotFound:
+	default:
+		t.Errorf("%v: unexpected error: %v", method, err)
+	}
+}
+
+// runConcurrently calls f from concurrentWorkers goroutines and waits for all of them
+func runConcurrently(f func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFrontEnd_ConcurrentRequests(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	testEnv.opiSpdkServer.rpc = newConcurrentTestSpdkStub()
+
+	// subsystems are created first, since children of a missing subsystem are rejected
+	runConcurrently(func(i int) {
+		n := i % concurrentSubsystems
+		_, err := testEnv.client.CreateNvmeSubsystem(testEnv.ctx, &pb.CreateNvmeSubsystemRequest{
+			NvmeSubsystemId: fmt.Sprintf("subsystem-%d", n),
+			NvmeSubsystem: &pb.NvmeSubsystem{
+				Spec: &pb.NvmeSubsystemSpec{Nqn: fmt.Sprintf("nqn.2022-09.io.spdk:opi%d", n)},
+			}})
+		checkConcurrentError(t, "CreateNvmeSubsystem", err)
+	})
+
+	runConcurrently(func(i int) {
+		n := i % concurrentSubsystems
+		subsysName := server.ResourceIDToVolumeName(fmt.Sprintf("subsystem-%d", n))
+		id := fmt.Sprintf("mytest-%d", i%8)
+		name := server.ResourceIDToVolumeName(id)
+
+		_, err := testEnv.client.GetNvmeSubsystem(testEnv.ctx, &pb.GetNvmeSubsystemRequest{Name: subsysName})
+		checkConcurrentError(t, "GetNvmeSubsystem", err)
+		_, err = testEnv.client.ListNvmeSubsystems(testEnv.ctx, &pb.ListNvmeSubsystemsRequest{Parent: "todo", PageSize: 1})
+		checkConcurrentError(t, "ListNvmeSubsystems", err)
+		_, err = testEnv.client.NvmeSubsystemStats(testEnv.ctx, &pb.NvmeSubsystemStatsRequest{SubsystemId: &pc.ObjectKey{Value: subsysName}})
+		checkConcurrentError(t, "NvmeSubsystemStats", err)
+
+		_, err = testEnv.client.CreateNvmeController(testEnv.ctx, &pb.CreateNvmeControllerRequest{
+			NvmeControllerId: id,
+			NvmeController: &pb.NvmeController{Spec: &pb.NvmeControllerSpec{
+				SubsystemId: &pc.ObjectKey{Value: subsysName},
+				PcieId:      &pb.PciEndpoint{PhysicalFunction: 1},
+			}}})
+		checkConcurrentError(t, "CreateNvmeController", err)
+		_, err = testEnv.client.GetNvmeController(testEnv.ctx, &pb.GetNvmeControllerRequest{Name: name})
+		checkConcurrentError(t, "GetNvmeController", err)
+		_, err = testEnv.client.ListNvmeControllers(testEnv.ctx, &pb.ListNvmeControllersRequest{Parent: subsysName})
+		checkConcurrentError(t, "ListNvmeControllers", err)
+		_, err = testEnv.client.NvmeControllerStats(testEnv.ctx, &pb.NvmeControllerStatsRequest{Id: &pc.ObjectKey{Value: name}})
+		checkConcurrentError(t, "NvmeControllerStats", err)
+		_, err = testEnv.client.DeleteNvmeController(testEnv.ctx, &pb.DeleteNvmeControllerRequest{Name: name, AllowMissing: true})
+		checkConcurrentError(t, "DeleteNvmeController", err)
+
+		_, err = testEnv.client.CreateNvmeNamespace(testEnv.ctx, &pb.CreateNvmeNamespaceRequest{
+			NvmeNamespaceId: id,
+			NvmeNamespace: &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{
+				SubsystemId: &pc.ObjectKey{Value: subsysName},
+				VolumeId:    &pc.ObjectKey{Value: "Malloc0"},
+				HostNsid:    1,
+			}}})
+		checkConcurrentError(t, "CreateNvmeNamespace", err)
+		_, err = testEnv.client.GetNvmeNamespace(testEnv.ctx, &pb.GetNvmeNamespaceRequest{Name: name})
+		checkConcurrentError(t, "GetNvmeNamespace", err)
+		_, err = testEnv.client.ListNvmeNamespaces(testEnv.ctx, &pb.ListNvmeNamespacesRequest{Parent: subsysName, PageSize: 1})
+		checkConcurrentError(t, "ListNvmeNamespaces", err)
+		_, err = testEnv.client.NvmeNamespaceStats(testEnv.ctx, &pb.NvmeNamespaceStatsRequest{NamespaceId: &pc.ObjectKey{Value: name}})
+		checkConcurrentError(t, "NvmeNamespaceStats", err)
+		_, err = testEnv.client.DeleteNvmeNamespace(testEnv.ctx, &pb.DeleteNvmeNamespaceRequest{Name: name, AllowMissing: true})
+		checkConcurrentError(t, "DeleteNvmeNamespace", err)
+
+		_, err = testEnv.client.CreateVirtioBlk(testEnv.ctx, &pb.CreateVirtioBlkRequest{
+			VirtioBlkId: id,
+			VirtioBlk: &pb.VirtioBlk{
+				PcieId:   &pb.PciEndpoint{PhysicalFunction: 42},
+				VolumeId: &pc.ObjectKey{Value: "Malloc42"},
+			}})
+		checkConcurrentError(t, "CreateVirtioBlk", err)
+		_, err = testEnv.client.GetVirtioBlk(testEnv.ctx, &pb.GetVirtioBlkRequest{Name: name})
+		checkConcurrentError(t, "GetVirtioBlk", err)
+		_, err = testEnv.client.ListVirtioBlks(testEnv.ctx, &pb.ListVirtioBlksRequest{Parent: "todo", PageSize: 1})
+		checkConcurrentError(t, "ListVirtioBlks", err)
+		_, err = testEnv.client.DeleteVirtioBlk(testEnv.ctx, &pb.DeleteVirtioBlkRequest{Name: name, AllowMissing: true})
+		checkConcurrentError(t, "DeleteVirtioBlk", err)
+
+		_, err = testEnv.client.CreateVirtioScsiController(testEnv.ctx, &pb.CreateVirtioScsiControllerRequest{
+			VirtioScsiControllerId: id,
+			VirtioScsiController:   &pb.VirtioScsiController{}})
+		checkConcurrentError(t, "CreateVirtioScsiController", err)
+		_, err = testEnv.client.CreateVirtioScsiLun(testEnv.ctx, &pb.CreateVirtioScsiLunRequest{
+			VirtioScsiLunId: id + "-lun",
+			VirtioScsiLun: &pb.VirtioScsiLun{
+				TargetId: &pc.ObjectKey{Value: name},
+				VolumeId: &pc.ObjectKey{Value: "Malloc1"},
+			}})
+		checkConcurrentError(t, "CreateVirtioScsiLun", err)
+		_, err = testEnv.client.GetVirtioScsiController(testEnv.ctx, &pb.GetVirtioScsiControllerRequest{Name: name})
+		checkConcurrentError(t, "GetVirtioScsiController", err)
+		_, err = testEnv.client.ListVirtioScsiControllers(testEnv.ctx, &pb.ListVirtioScsiControllersRequest{Parent: "todo", PageSize: 1})
+		checkConcurrentError(t, "ListVirtioScsiControllers", err)
+		_, err = testEnv.client.DeleteVirtioScsiLun(testEnv.ctx, &pb.DeleteVirtioScsiLunRequest{Name: name + "-lun", AllowMissing: true})
+		checkConcurrentError(t, "DeleteVirtioScsiLun", err)
+		_, err = testEnv.client.DeleteVirtioScsiController(testEnv.ctx, &pb.DeleteVirtioScsiControllerRequest{Name: name, AllowMissing: true})
+		checkConcurrentError(t, "DeleteVirtioScsiController", err)
+	})
+
+	runConcurrently(func(i int) {
+		subsysName := server.ResourceIDToVolumeName(fmt.Sprintf("subsystem-%d", i%concurrentSubsystems))
+		_, err := testEnv.client.DeleteNvmeSubsystem(testEnv.ctx, &pb.DeleteNvmeSubsystemRequest{Name: subsysName, AllowMissing: true})
+		checkConcurrentError(t, "DeleteNvmeSubsystem", err)
+	})
+
+	if _, err := testEnv.opiSpdkServer.Reconcile(server.ReconcileReport); err != nil {
+		t.Errorf("expected no error, received: %v", err)
+	}
+}
+
+func TestFrontEnd_ConcurrentCreateSameNqn(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	stub := newConcurrentTestSpdkStub()
+	testEnv.opiSpdkServer.rpc = stub
+
+	// different names with the same NQN, only one of them may reach SPDK
+	runConcurrently(func(i int) {
+		_, err := testEnv.client.CreateNvmeSubsystem(testEnv.ctx, &pb.CreateNvmeSubsystemRequest{
+			NvmeSubsystemId: fmt.Sprintf("subsystem-%d", i),
+			NvmeSubsystem: &pb.NvmeSubsystem{
+				Spec: &pb.NvmeSubsystemSpec{Nqn: "nqn.2022-09.io.spdk:opi0"},
+			}})
+		if code := status.Code(err); code != codes.OK && code != codes.AlreadyExists {
+			t.Errorf("CreateNvmeSubsystem: unexpected error: %v", err)
+		}
+	})
+
+	if calls := stub.Calls("nvmf_create_subsystem"); calls != 1 {
+		t.Errorf("expected nvmf_create_subsystem to reach SPDK once, received: %v", calls)
+	}
+	if len(testEnv.opiSpdkServer.Nvme.Subsystems) != 1 {
+		t.Errorf("expected exactly one subsystem, received: %v", testEnv.opiSpdkServer.Nvme.Subsystems)
+	}
+}
@@ -6,10 +6,14 @@
 package frontend
 
 import (
+	"context"
 	"log"
+	"sync"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 )
 
 // SubsystemListener interface is used to provide SPDK call params to create/delete
@@ -40,16 +44,33 @@ type Server struct {
 	pb.UnimplementedFrontendVirtioScsiServiceServer
 
 	rpc        spdk.JSONRPC
+	store      store.Store
 	Nvme       NvmeParameters
 	Virt       VirtioParameters
-	Pagination map[string]int
+	Pagination *server.PageTokens
+	// Consumers is the registry of resources using volumes, shared with
+	// other services. Volumes are claimed while resources are created.
+	Consumers *server.VolumeConsumers
+	// VolumeIndex resolves names of volume resources of any service in
+	// volume_id of requests to SPDK bdevs
+	VolumeIndex *server.VolumeIndex
+
+	// mu guards resource maps, names serializes
+	// requests working with the same resource
+	mu    sync.RWMutex
+	names server.NameLocker
 }
 
 // NewServer creates initialized instance of FrontEnd server communicating
-// with provided jsonRPC
-func NewServer(jsonRPC spdk.JSONRPC) *Server {
-	return &Server{
-		rpc: jsonRPC,
+// with provided jsonRPC. Resources kept in store are restored on creation
+// and every change is written through to it.
+func NewServer(jsonRPC spdk.JSONRPC, st store.Store) *Server {
+	if st == nil {
+		log.Panic("nil for Store is not allowed")
+	}
+	s := &Server{
+		rpc:   jsonRPC,
+		store: st,
 		Nvme: NvmeParameters{
 			Subsystems:     make(map[string]*pb.NvmeSubsystem),
 			Controllers:    make(map[string]*pb.NvmeController),
@@ -61,17 +82,115 @@ func NewServer(jsonRPC spdk.JSONRPC) *Server {
 			ScsiCtrls: make(map[string]*pb.VirtioScsiController),
 			ScsiLuns:  make(map[string]*pb.VirtioScsiLun),
 		},
-		Pagination: make(map[string]int),
+		Pagination: server.NewPageTokens(server.DefaultPageTokenTTL, server.DefaultPageTokenLimit),
+	}
+	if err := s.restore(); err != nil {
+		log.Panicf("unable to restore frontend resources from store: %v", err)
 	}
+	return s
 }
 
 // NewServerWithSubsystemListener creates initialized instance of FrontEnd server communicating
 // with provided jsonRPC and externally created SubsystemListener instead default one.
-func NewServerWithSubsystemListener(jsonRPC spdk.JSONRPC, sysListener SubsystemListener) *Server {
+func NewServerWithSubsystemListener(jsonRPC spdk.JSONRPC, st store.Store, sysListener SubsystemListener) *Server {
 	if sysListener == nil {
 		log.Panic("nil for SubsystemListener is not allowed")
 	}
-	server := NewServer(jsonRPC)
+	server := NewServer(jsonRPC, st)
 	server.Nvme.subsysListener = sysListener
 	return server
 }
+
+func (s *Server) restore() error {
+	if err := store.Load(s.store, s.Nvme.Subsystems); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Nvme.Controllers); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Nvme.Namespaces); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Virt.BlkCtrls); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.Virt.ScsiCtrls); err != nil {
+		return err
+	}
+	return store.Load(s.store, s.Virt.ScsiLuns)
+}
+
+// ResourceCounts returns number of resources of each kind kept by the server
+func (s *Server) ResourceCounts() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]int{
+		"nvme_subsystem":         len(s.Nvme.Subsystems),
+		"nvme_controller":        len(s.Nvme.Controllers),
+		"nvme_namespace":         len(s.Nvme.Namespaces),
+		"virtio_blk":             len(s.Virt.BlkCtrls),
+		"virtio_scsi_controller": len(s.Virt.ScsiCtrls),
+		"virtio_scsi_lun":        len(s.Virt.ScsiLuns),
+	}
+}
+
+// VirtioBlkConsumerKind is the kind VirtioBlks are registered with as
+// consumers of volumes
+const VirtioBlkConsumerKind = "VirtioBlk"
+
+// VolumeConsumers returns kinds of frontend resources using volumes
+func (s *Server) VolumeConsumers() []server.VolumeConsumer {
+	return []server.VolumeConsumer{
+		{
+			Kind: "NvmeNamespace",
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				uses := make(map[string][]string)
+				for _, namespace := range s.Nvme.Namespaces {
+					bdev := namespace.GetSpec().GetVolumeId().GetValue()
+					uses[bdev] = append(uses[bdev], namespace.Name)
+				}
+				return uses
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteNvmeNamespace(ctx, &pb.DeleteNvmeNamespaceRequest{Name: name})
+				return err
+			},
+		},
+		{
+			Kind: VirtioBlkConsumerKind,
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				uses := make(map[string][]string)
+				for _, blk := range s.Virt.BlkCtrls {
+					bdev := blk.GetVolumeId().GetValue()
+					uses[bdev] = append(uses[bdev], blk.Name)
+				}
+				return uses
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteVirtioBlk(ctx, &pb.DeleteVirtioBlkRequest{Name: name})
+				return err
+			},
+		},
+		{
+			Kind: "VirtioScsiLun",
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				uses := make(map[string][]string)
+				for _, lun := range s.Virt.ScsiLuns {
+					bdev := lun.GetVolumeId().GetValue()
+					uses[bdev] = append(uses[bdev], lun.Name)
+				}
+				return uses
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteVirtioScsiLun(ctx, &pb.DeleteVirtioScsiLunRequest{Name: name})
+				return err
+			},
+		},
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 )
 
 // TODO: move test infrastructure code to a separate (test/server) package to avoid duplication
@@ -50,7 +51,7 @@ func createTestEnvironment(spdkResponses []string) *testEnv {
 	env := &testEnv{}
 	env.testSocket = server.GenerateSocketName("frontend")
 	env.ln, env.jsonRPC = server.CreateTestSpdkServer(env.testSocket, spdkResponses)
-	env.opiSpdkServer = NewServer(env.jsonRPC)
+	env.opiSpdkServer = NewServer(env.jsonRPC, store.NewMemoryStore())
 
 	ctx := context.Background()
 	conn, err := grpc.DialContext(ctx,
@@ -11,13 +11,13 @@ import (
 	"log"
 	"net"
 	"path"
-	"sort"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
@@ -39,12 +39,6 @@ type tcpSubsystemListener struct {
 	protocol   string
 }
 
-func sortNvmeControllers(controllers []*pb.NvmeController) {
-	sort.Slice(controllers, func(i int, j int) bool {
-		return controllers[i].Name < controllers[j].Name
-	})
-}
-
 // NewTCPSubsystemListener creates a new instance of tcpSubsystemListener
 func NewTCPSubsystemListener(listenAddr string) SubsystemListener {
 	host, port, err := net.SplitHostPort(listenAddr)
@@ -87,11 +81,11 @@ func (c *tcpSubsystemListener) Params(_ *pb.NvmeController, nqn string) spdk.Nvm
 }
 
 // CreateNvmeController creates an Nvme controller
-func (s *Server) CreateNvmeController(_ context.Context, in *pb.CreateNvmeControllerRequest) (*pb.NvmeController, error) {
-	log.Printf("Received from client: %v", in.NvmeController)
+func (s *Server) CreateNvmeController(ctx context.Context, in *pb.CreateNvmeControllerRequest) (*pb.NvmeController, error) {
+	slog.DebugContext(ctx, "Received from client", "nvme_controller", in.NvmeController)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// check input parameters validity
@@ -103,194 +97,245 @@ func (s *Server) CreateNvmeController(_ context.Context, in *pb.CreateNvmeContro
 	if in.NvmeControllerId != "" {
 		err := resourceid.ValidateUserSettable(in.NvmeControllerId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.NvmeControllerId, in.NvmeController.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.NvmeControllerId, "name", in.NvmeController.Name)
 		resourceID = in.NvmeControllerId
 	}
 	in.NvmeController.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.NvmeController.Name, in.NvmeController.Spec.SubsystemId.Value)
+	defer unlock()
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	controller, ok := s.Nvme.Controllers[in.NvmeController.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing NvmeController with id %v", in.NvmeController.Name)
+		slog.InfoContext(ctx, "Already existing NvmeController", "name", in.NvmeController.Name)
 		return controller, nil
 	}
 	// not found, so create a new one
+	s.mu.RLock()
 	subsys, ok := s.Nvme.Subsystems[in.NvmeController.Spec.SubsystemId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", in.NvmeController.Spec.SubsystemId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	params := s.Nvme.subsysListener.Params(in.NvmeController, subsys.Spec.Nqn)
 	var result spdk.NvmfSubsystemAddListenerResult
-	err := s.rpc.Call("nvmf_subsystem_add_listener", &params, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_subsystem_add_listener", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_subsystem_add_listener", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not create CTRL: %s", in.NvmeController.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	response := server.ProtoClone(in.NvmeController)
 	response.Spec.NvmeControllerId = -1
 	response.Status = &pb.NvmeControllerStatus{Active: true}
+	if err := store.Save(s.store, in.NvmeController.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.Nvme.Controllers[in.NvmeController.Name] = response
+	s.mu.Unlock()
 
 	return response, nil
 }
 
 // DeleteNvmeController deletes an Nvme controller
-func (s *Server) DeleteNvmeController(_ context.Context, in *pb.DeleteNvmeControllerRequest) (*emptypb.Empty, error) {
-	log.Printf("Received from client: %v", in.Name)
+func (s *Server) DeleteNvmeController(ctx context.Context, in *pb.DeleteNvmeControllerRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "name", in.Name)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	controller, ok := s.Nvme.Controllers[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	s.mu.RLock()
 	subsys, ok := s.Nvme.Subsystems[controller.Spec.SubsystemId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", controller.Spec.SubsystemId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	params := s.Nvme.subsysListener.Params(controller, subsys.Spec.Nqn)
 	var result spdk.NvmfSubsystemAddListenerResult
-	err := s.rpc.Call("nvmf_subsystem_remove_listener", &params, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_subsystem_remove_listener", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_subsystem_remove_listener", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not delete NQN:ID %s:%d", subsys.Spec.Nqn, controller.Spec.NvmeControllerId)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
+	if err := store.Remove(s.store, controller.Name, controller); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Nvme.Controllers, controller.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateNvmeController updates an Nvme controller
-func (s *Server) UpdateNvmeController(_ context.Context, in *pb.UpdateNvmeControllerRequest) (*pb.NvmeController, error) {
-	log.Printf("UpdateNvmeController: Received from client: %v", in)
+func (s *Server) UpdateNvmeController(ctx context.Context, in *pb.UpdateNvmeControllerRequest) (*pb.NvmeController, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.NvmeController.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.NvmeController.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Nvme.Controllers[in.NvmeController.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
-			log.Printf("TODO: in case of AllowMissing, create a new resource, don;t return error")
+			slog.DebugContext(ctx, "TODO: in case of AllowMissing, create a new resource, don;t return error")
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NvmeController.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
 	// update_mask = 2
 	if err := fieldmask.Validate(in.UpdateMask, in.NvmeController); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("TODO: use resourceID=%v", resourceID)
+	slog.DebugContext(ctx, "TODO: use resourceID", "resource_id", resourceID)
 	response := server.ProtoClone(in.NvmeController)
 	response.Status = &pb.NvmeControllerStatus{Active: true}
+	if err := store.Save(s.store, in.NvmeController.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.Nvme.Controllers[in.NvmeController.Name] = response
+	s.mu.Unlock()
 	return response, nil
 }
 
 // ListNvmeControllers lists Nvme controllers
-func (s *Server) ListNvmeControllers(_ context.Context, in *pb.ListNvmeControllersRequest) (*pb.ListNvmeControllersResponse, error) {
-	log.Printf("Received from client: %v", in.Parent)
+func (s *Server) ListNvmeControllers(ctx context.Context, in *pb.ListNvmeControllersRequest) (*pb.ListNvmeControllersResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "parent", in.Parent)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.NvmeController{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
 	Blobarray := []*pb.NvmeController{}
+	s.mu.RLock()
 	for _, controller := range s.Nvme.Controllers {
 		Blobarray = append(Blobarray, controller)
 	}
-	sortNvmeControllers(Blobarray)
-	token := uuid.New().String()
-	s.Pagination[token] = int(in.PageSize)
+	s.mu.RUnlock()
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.NvmeController).GetName)
 	return &pb.ListNvmeControllersResponse{NvmeControllers: Blobarray, NextPageToken: token}, nil
 }
 
 // GetNvmeController gets an Nvme controller
-func (s *Server) GetNvmeController(_ context.Context, in *pb.GetNvmeControllerRequest) (*pb.NvmeController, error) {
-	log.Printf("Received from client: %v", in.Name)
+func (s *Server) GetNvmeController(ctx context.Context, in *pb.GetNvmeControllerRequest) (*pb.NvmeController, error) {
+	slog.DebugContext(ctx, "Received from client", "name", in.Name)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	controller, ok := s.Nvme.Controllers[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	return &pb.NvmeController{Name: in.Name, Spec: &pb.NvmeControllerSpec{NvmeControllerId: controller.Spec.NvmeControllerId}, Status: &pb.NvmeControllerStatus{Active: true}}, nil
 }
 
 // NvmeControllerStats gets an Nvme controller stats
-func (s *Server) NvmeControllerStats(_ context.Context, in *pb.NvmeControllerStatsRequest) (*pb.NvmeControllerStatsResponse, error) {
-	log.Printf("NvmeControllerStats: Received from client: %v", in)
+func (s *Server) NvmeControllerStats(ctx context.Context, in *pb.NvmeControllerStatsRequest) (*pb.NvmeControllerStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Id.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Nvme.Controllers[in.Id.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Id.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
-	log.Printf("TODO: send name to SPDK and get back stats: %v", resourceID)
+	slog.DebugContext(ctx, "TODO: send name to SPDK and get back stats", "name", resourceID)
 	return &pb.NvmeControllerStatsResponse{Stats: &pb.VolumeStats{ReadOpsCount: -1, WriteOpsCount: -1}}, nil
 }
+
+// FindNvmeController returns a stored Nvme controller, safe for concurrent use
+func (s *Server) FindNvmeController(name string) (*pb.NvmeController, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	controller, ok := s.Nvme.Controllers[name]
+	return controller, ok
+}
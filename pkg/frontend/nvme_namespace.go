@@ -8,15 +8,14 @@ package frontend
 import (
 	"context"
 	"fmt"
-	"log"
 	"path"
-	"sort"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
-	"github.com/google/uuid"
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/fieldmask"
 	"go.einride.tech/aip/resourceid"
@@ -26,18 +25,12 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortNvmeNamespaces(namespaces []*pb.NvmeNamespace) {
-	sort.Slice(namespaces, func(i int, j int) bool {
-		return namespaces[i].Spec.HostNsid < namespaces[j].Spec.HostNsid
-	})
-}
-
 // CreateNvmeNamespace creates an Nvme namespace
-func (s *Server) CreateNvmeNamespace(_ context.Context, in *pb.CreateNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
-	log.Printf("CreateNvmeNamespace: Received from client: %v", in)
+func (s *Server) CreateNvmeNamespace(ctx context.Context, in *pb.CreateNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
@@ -50,82 +43,109 @@ func (s *Server) CreateNvmeNamespace(_ context.Context, in *pb.CreateNvmeNamespa
 	if in.NvmeNamespaceId != "" {
 		err := resourceid.ValidateUserSettable(in.NvmeNamespaceId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.NvmeNamespaceId, in.NvmeNamespace.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.NvmeNamespaceId, "name", in.NvmeNamespace.Name)
 		resourceID = in.NvmeNamespaceId
 	}
 	in.NvmeNamespace.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.NvmeNamespace.Name, in.NvmeNamespace.Spec.SubsystemId.Value)
+	defer unlock()
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	namespace, ok := s.Nvme.Namespaces[in.NvmeNamespace.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing NvmeNamespace with id %v", in.NvmeNamespace.Name)
+		slog.InfoContext(ctx, "Already existing NvmeNamespace", "name", in.NvmeNamespace.Name)
 		return namespace, nil
 	}
 	// not found, so create a new one
+	s.mu.RLock()
 	subsys, ok := s.Nvme.Subsystems[in.NvmeNamespace.Spec.SubsystemId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", in.NvmeNamespace.Spec.SubsystemId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	if err := s.VolumeIndex.ResolveKey(in.NvmeNamespace.Spec.VolumeId); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.NvmeNamespace.Spec.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	defer unclaim()
 
 	params := spdk.NvmfSubsystemAddNsParams{
 		Nqn: subsys.Spec.Nqn,
 	}
 
-	// TODO: using bdev for volume id as a middle end handle for now
 	params.Namespace.Nsid = int(in.NvmeNamespace.Spec.HostNsid)
 	params.Namespace.BdevName = in.NvmeNamespace.Spec.VolumeId.Value
 
 	var result spdk.NvmfSubsystemAddNsResult
-	err := s.rpc.Call("nvmf_subsystem_add_ns", &params, &result)
+	err = server.Call(ctx, s.rpc, "nvmf_subsystem_add_ns", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_subsystem_add_ns", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if result < 0 {
 		msg := fmt.Sprintf("Could not create NS: %s", in.NvmeNamespace.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 
 	response := server.ProtoClone(in.NvmeNamespace)
 	response.Status = &pb.NvmeNamespaceStatus{PciState: 2, PciOperState: 1}
 	response.Spec.HostNsid = int32(result)
+	if err := store.Save(s.store, in.NvmeNamespace.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.Nvme.Namespaces[in.NvmeNamespace.Name] = response
+	s.mu.Unlock()
 	return response, nil
 }
 
 // DeleteNvmeNamespace deletes an Nvme namespace
-func (s *Server) DeleteNvmeNamespace(_ context.Context, in *pb.DeleteNvmeNamespaceRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteNvmeNamespace: Received from client: %v", in)
+func (s *Server) DeleteNvmeNamespace(ctx context.Context, in *pb.DeleteNvmeNamespaceRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	namespace, ok := s.Nvme.Namespaces[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	s.mu.RLock()
 	subsys, ok := s.Nvme.Subsystems[namespace.Spec.SubsystemId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
@@ -134,155 +154,175 @@ func (s *Server) DeleteNvmeNamespace(_ context.Context, in *pb.DeleteNvmeNamespa
 		Nsid: int(namespace.Spec.HostNsid),
 	}
 	var result spdk.NvmfSubsystemRemoveNsResult
-	err := s.rpc.Call("nvmf_subsystem_remove_ns", &params, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_subsystem_remove_ns", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_subsystem_remove_ns", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not delete NS: %s", in.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
+	if err := store.Remove(s.store, namespace.Name, namespace); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.Nvme.Namespaces, namespace.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateNvmeNamespace updates an Nvme namespace
-func (s *Server) UpdateNvmeNamespace(_ context.Context, in *pb.UpdateNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
-	log.Printf("UpdateNvmeNamespace: Received from client: %v", in)
+func (s *Server) UpdateNvmeNamespace(ctx context.Context, in *pb.UpdateNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.NvmeNamespace.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.NvmeNamespace.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Nvme.Namespaces[in.NvmeNamespace.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
-			log.Printf("TODO: in case of AllowMissing, create a new resource, don;t return error")
+			slog.DebugContext(ctx, "TODO: in case of AllowMissing, create a new resource, don;t return error")
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NvmeNamespace.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
 	// update_mask = 2
 	if err := fieldmask.Validate(in.UpdateMask, in.NvmeNamespace); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
-	log.Printf("TODO: use resourceID=%v", resourceID)
+	slog.DebugContext(ctx, "TODO: use resourceID", "resource_id", resourceID)
 	response := server.ProtoClone(in.NvmeNamespace)
 	response.Status = &pb.NvmeNamespaceStatus{PciState: 2, PciOperState: 1}
+	if err := store.Save(s.store, in.NvmeNamespace.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.Nvme.Namespaces[in.NvmeNamespace.Name] = response
+	s.mu.Unlock()
 
 	return response, nil
 }
 
 // ListNvmeNamespaces lists Nvme namespaces
-func (s *Server) ListNvmeNamespaces(_ context.Context, in *pb.ListNvmeNamespacesRequest) (*pb.ListNvmeNamespacesResponse, error) {
-	log.Printf("ListNvmeNamespaces: Received from client: %v", in)
+func (s *Server) ListNvmeNamespaces(ctx context.Context, in *pb.ListNvmeNamespacesRequest) (*pb.ListNvmeNamespacesResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.NvmeNamespace{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
 	nqn := ""
 	if in.Parent != "" {
+		s.mu.RLock()
 		subsys, ok := s.Nvme.Subsystems[in.Parent]
+		s.mu.RUnlock()
 		if !ok {
 			err := fmt.Errorf("unable to find subsystem %s", in.Parent)
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
 		nqn = subsys.Spec.Nqn
 	}
 	var result []spdk.NvmfGetSubsystemsResult
-	err := s.rpc.Call("nvmf_get_subsystems", nil, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_get_subsystems", nil, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_get_subsystems", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	Blobarray := []*pb.NvmeNamespace{}
+	// namespaces of all subsystems are listed when parent is empty, NSIDs repeat among them
+	keys := make(map[*pb.NvmeNamespace]string)
 	for i := range result {
 		rr := &result[i]
 		if rr.Nqn == nqn || nqn == "" {
-			log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-			hasMoreElements := false
-			rr.Namespaces, hasMoreElements = server.LimitPagination(rr.Namespaces, offset, size)
-			if hasMoreElements {
-				token = uuid.New().String()
-				s.Pagination[token] = offset + size
-			}
 			for j := range rr.Namespaces {
 				r := &rr.Namespaces[j]
-				Blobarray = append(Blobarray, &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{HostNsid: int32(r.Nsid)}})
+				namespace := &pb.NvmeNamespace{Spec: &pb.NvmeNamespaceSpec{HostNsid: int32(r.Nsid)}}
+				keys[namespace] = nvmfNamespaceKey{rr.Nqn, int32(r.Nsid)}.String()
+				Blobarray = append(Blobarray, namespace)
 			}
 		}
 	}
 	if len(Blobarray) > 0 {
-		sortNvmeNamespaces(Blobarray)
+		Blobarray, token := server.Paginate(opts, Blobarray, func(namespace *pb.NvmeNamespace) string {
+			return keys[namespace]
+		})
 		return &pb.ListNvmeNamespacesResponse{NvmeNamespaces: Blobarray, NextPageToken: token}, nil
 	}
 
 	msg := fmt.Sprintf("Could not find any namespaces for NQN: %s", nqn)
-	log.Print(msg)
+	slog.ErrorContext(ctx, msg)
 	return nil, status.Errorf(codes.InvalidArgument, msg)
 }
 
 // GetNvmeNamespace gets an Nvme namespace
-func (s *Server) GetNvmeNamespace(_ context.Context, in *pb.GetNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
-	log.Printf("GetNvmeNamespace: Received from client: %v", in)
+func (s *Server) GetNvmeNamespace(ctx context.Context, in *pb.GetNvmeNamespaceRequest) (*pb.NvmeNamespace, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	namespace, ok := s.Nvme.Namespaces[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// TODO: do we even query SPDK to confirm if namespace is present?
 	// return namespace, nil
 
 	// fetch subsystems -> namespaces from Server, match the nsid to find the corresponding namespace
+	s.mu.RLock()
 	subsys, ok := s.Nvme.Subsystems[namespace.Spec.SubsystemId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	var result []spdk.NvmfGetSubsystemsResult
-	err := s.rpc.Call("nvmf_get_subsystems", nil, &result)
+	err := server.Call(ctx, s.rpc, "nvmf_get_subsystems", nil, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "nvmf_get_subsystems", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	for i := range result {
 		rr := &result[i]
 		if rr.Nqn == subsys.Spec.Nqn {
@@ -297,36 +337,38 @@ func (s *Server) GetNvmeNamespace(_ context.Context, in *pb.GetNvmeNamespaceRequ
 				}
 			}
 			msg := fmt.Sprintf("Could not find NSID: %d", namespace.Spec.HostNsid)
-			log.Print(msg)
+			slog.ErrorContext(ctx, msg)
 			return nil, status.Errorf(codes.InvalidArgument, msg)
 		}
 	}
 	msg := fmt.Sprintf("Could not find NQN: %s", subsys.Spec.Nqn)
-	log.Print(msg)
+	slog.ErrorContext(ctx, msg)
 	return nil, status.Errorf(codes.InvalidArgument, msg)
 }
 
 // NvmeNamespaceStats gets an Nvme namespace stats
-func (s *Server) NvmeNamespaceStats(_ context.Context, in *pb.NvmeNamespaceStatsRequest) (*pb.NvmeNamespaceStatsResponse, error) {
-	log.Printf("NvmeNamespaceStats: Received from client: %v", in)
+func (s *Server) NvmeNamespaceStats(ctx context.Context, in *pb.NvmeNamespaceStatsRequest) (*pb.NvmeNamespaceStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.NamespaceId.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.Nvme.Namespaces[in.NamespaceId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.NamespaceId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
-	log.Printf("TODO: send name to SPDK and get back stats: %v", resourceID)
+	slog.DebugContext(ctx, "TODO: send name to SPDK and get back stats", "name", resourceID)
 	return &pb.NvmeNamespaceStatsResponse{Stats: &pb.VolumeStats{ReadOpsCount: -1, WriteOpsCount: -1}}, nil
 }
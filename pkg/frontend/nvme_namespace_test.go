@@ -54,6 +54,8 @@ func TestFrontEnd_CreateNvmeNamespace(t *testing.T) {
 		Nguid:       "1b4e28ba-2fa1-11d2-883f-b9a761bde3fb",
 		Eui64:       1967554867335598546,
 	}
+	volumeNameSpec := server.ProtoClone(namespaceSpec)
+	volumeNameSpec.VolumeId = &pc.ObjectKey{Value: server.ResourceIDToVolumeName("Malloc1")}
 	tests := map[string]struct {
 		id      string
 		in      *pb.NvmeNamespace
@@ -146,6 +148,37 @@ func TestFrontEnd_CreateNvmeNamespace(t *testing.T) {
 			"",
 			true,
 		},
+		"volume name": {
+			testNamespaceID,
+			&pb.NvmeNamespace{
+				Spec: volumeNameSpec,
+			},
+			&pb.NvmeNamespace{
+				Spec: namespaceSpec,
+				Status: &pb.NvmeNamespaceStatus{
+					PciState:     2,
+					PciOperState: 1,
+				},
+			},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":22}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"unknown volume name": {
+			testNamespaceID,
+			&pb.NvmeNamespace{
+				Spec: &pb.NvmeNamespaceSpec{
+					SubsystemId: &pc.ObjectKey{Value: testSubsystemName},
+					VolumeId:    &pc.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")},
+				},
+			},
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
 	}
 
 	// run tests
@@ -162,6 +195,13 @@ func TestFrontEnd_CreateNvmeNamespace(t *testing.T) {
 			if tt.out != nil {
 				tt.out.Name = testNamespaceName
 			}
+			testEnv.opiSpdkServer.VolumeIndex = &server.VolumeIndex{}
+			testEnv.opiSpdkServer.VolumeIndex.Register(server.VolumeProvider{
+				Kind: "MallocVolume",
+				Bdev: func(name string) (string, bool) {
+					return "Malloc1", name == server.ResourceIDToVolumeName("Malloc1")
+				},
+			})
 
 			request := &pb.CreateNvmeNamespaceRequest{NvmeNamespace: tt.in, NvmeNamespaceId: tt.id}
 			response, err := testEnv.client.CreateNvmeNamespace(testEnv.ctx, request)
@@ -567,9 +607,11 @@ func TestFrontEnd_ListNvmeNamespaces(t *testing.T) {
 			testEnv.opiSpdkServer.Nvme.Namespaces[server.ResourceIDToVolumeName("ns0")] = &testNamespaces[0]
 			testEnv.opiSpdkServer.Nvme.Namespaces[server.ResourceIDToVolumeName("ns1")] = &testNamespaces[1]
 			testEnv.opiSpdkServer.Nvme.Namespaces[server.ResourceIDToVolumeName("ns2")] = &testNamespaces[2]
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
-
 			request := &pb.ListNvmeNamespacesRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&testNamespaces[0], nvmfNamespaceKey{testSubsystem.Spec.Nqn, testNamespaces[0].Spec.HostNsid}.String())
+			}
 			response, err := testEnv.client.ListNvmeNamespaces(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetNvmeNamespaces(), tt.out) {
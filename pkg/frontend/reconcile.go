@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package frontend implements the FrontEnd APIs (host facing) of the storage Server
+package frontend
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+)
+
+// vhostController is a subset of vhost_get_controllers result used for reconciliation.
+// spdk.VhostGetControllersResult cannot tell virtio-blk and virtio-scsi apart.
+type vhostController struct {
+	Ctrlr           string `json:"ctrlr"`
+	BackendSpecific struct {
+		Block *struct {
+			Bdev string `json:"bdev"`
+		} `json:"block"`
+	} `json:"backend_specific"`
+}
+
+type nvmfNamespaceKey struct {
+	nqn  string
+	nsid int32
+}
+
+// String orders namespaces by subsystem NQN first and then by NSID
+func (k nvmfNamespaceKey) String() string {
+	return fmt.Sprintf("%s/%010d", k.nqn, k.nsid)
+}
+
+// Reconcile compares frontend resources with Nvme subsystems and vhost
+// controllers configured in SPDK and resolves found differences according to mode
+func (s *Server) Reconcile(mode server.ReconcileMode) (*server.ReconcileResult, error) {
+	slog.Info("Reconcile: frontend", "mode", mode)
+	// resources are compared and adopted as a whole, so no request may interleave
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subsystems []spdk.NvmfGetSubsystemsResult
+	err := s.rpc.Call("nvmf_get_subsystems", nil, &subsystems)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "nvmf_get_subsystems", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", subsystems)
+	var controllers []vhostController
+	err = s.rpc.Call("vhost_get_controllers", nil, &controllers)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "vhost_get_controllers", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", controllers)
+
+	result := &server.ReconcileResult{}
+	if err := s.reconcileNvmeSubsystems(mode, subsystems, result); err != nil {
+		return nil, err
+	}
+	if err := s.reconcileVhostControllers(mode, controllers, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Server) reconcileNvmeSubsystems(mode server.ReconcileMode,
+	subsystems []spdk.NvmfGetSubsystemsResult, result *server.ReconcileResult) error {
+	presentSubsystems := make(map[string]bool)
+	presentNamespaces := make(map[nvmfNamespaceKey]bool)
+	for i := range subsystems {
+		r := &subsystems[i]
+		if r.Subtype == "Discovery" {
+			continue
+		}
+		presentSubsystems[r.Nqn] = true
+		for _, ns := range r.Namespaces {
+			presentNamespaces[nvmfNamespaceKey{r.Nqn, int32(ns.Nsid)}] = true
+		}
+		subsys := s.findNvmeSubsystem(r.Nqn)
+		if subsys == nil {
+			result.AddOrphaned("nvmf subsystem", r.Nqn)
+			switch mode {
+			case server.ReconcileAdopt:
+				name, err := server.AdoptableName(nqnToResourceID(r.Nqn))
+				if err != nil {
+					slog.Error("Request failed", "err", err)
+					continue
+				}
+				subsys = &pb.NvmeSubsystem{
+					Name: name,
+					Spec: &pb.NvmeSubsystemSpec{
+						Nqn:           r.Nqn,
+						SerialNumber:  r.SerialNumber,
+						ModelNumber:   r.ModelNumber,
+						MaxNamespaces: int64(r.MaxNamespaces),
+					},
+				}
+				if err := server.Adopt(s.store, s.Nvme.Subsystems, name, subsys); err != nil {
+					slog.Error("Request failed", "err", err)
+					return err
+				}
+			case server.ReconcileCleanup:
+				if err := s.deleteOrphanedNvmeSubsystem(r.Nqn); err != nil {
+					return err
+				}
+				continue
+			default:
+				continue
+			}
+		}
+		if err := s.reconcileNvmeNamespaces(mode, subsys, r, result); err != nil {
+			return err
+		}
+	}
+
+	// subsystems are looked up by namespaces and controllers, so drop them last
+	err := server.ReconcileMissing(mode, s.store, s.Nvme.Namespaces, func(namespace *pb.NvmeNamespace) bool {
+		subsys, ok := s.Nvme.Subsystems[namespace.Spec.SubsystemId.GetValue()]
+		return ok && presentNamespaces[nvmfNamespaceKey{subsys.Spec.Nqn, namespace.Spec.HostNsid}]
+	}, result)
+	if err != nil {
+		return err
+	}
+	err = server.ReconcileMissing(mode, s.store, s.Nvme.Controllers, func(controller *pb.NvmeController) bool {
+		subsys, ok := s.Nvme.Subsystems[controller.Spec.SubsystemId.GetValue()]
+		return ok && presentSubsystems[subsys.Spec.Nqn]
+	}, result)
+	if err != nil {
+		return err
+	}
+	return server.ReconcileMissing(mode, s.store, s.Nvme.Subsystems,
+		func(subsys *pb.NvmeSubsystem) bool { return presentSubsystems[subsys.Spec.Nqn] }, result)
+}
+
+func (s *Server) reconcileNvmeNamespaces(mode server.ReconcileMode, subsys *pb.NvmeSubsystem,
+	r *spdk.NvmfGetSubsystemsResult, result *server.ReconcileResult) error {
+	for _, ns := range r.Namespaces {
+		if s.findNvmeNamespace(subsys.Name, int32(ns.Nsid)) != nil {
+			continue
+		}
+		result.AddOrphaned("nvmf namespace", fmt.Sprintf("%s/%d", r.Nqn, ns.Nsid))
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(fmt.Sprintf("%s-ns%d", path.Base(subsys.Name), ns.Nsid))
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			namespace := &pb.NvmeNamespace{
+				Name: name,
+				Spec: &pb.NvmeNamespaceSpec{
+					SubsystemId: &pc.ObjectKey{Value: subsys.Name},
+					HostNsid:    int32(ns.Nsid),
+					VolumeId:    &pc.ObjectKey{Value: ns.Name},
+				},
+			}
+			if err := server.Adopt(s.store, s.Nvme.Namespaces, name, namespace); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := spdk.NvmfSubsystemRemoveNsParams{
+				Nqn:  r.Nqn,
+				Nsid: ns.Nsid,
+			}
+			var res spdk.NvmfSubsystemRemoveNsResult
+			if err := s.rpc.Call("nvmf_subsystem_remove_ns", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "nvmf_subsystem_remove_ns", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete NS: %s/%d", params.Nqn, params.Nsid)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteOrphanedNvmeSubsystem(nqn string) error {
+	params := spdk.NvmfDeleteSubsystemParams{
+		Nqn: nqn,
+	}
+	var result spdk.NvmfDeleteSubsystemResult
+	err := s.rpc.Call("nvmf_delete_subsystem", &params, &result)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "nvmf_delete_subsystem", "err", err)
+		return err
+	}
+	slog.Debug("Received from SPDK", "result", result)
+	if !result {
+		return fmt.Errorf("could not delete NQN: %s", nqn)
+	}
+	return nil
+}
+
+func (s *Server) reconcileVhostControllers(mode server.ReconcileMode,
+	controllers []vhostController, result *server.ReconcileResult) error {
+	present := make(map[string]bool)
+	for i := range controllers {
+		r := &controllers[i]
+		present[r.Ctrlr] = true
+		name := server.ResourceIDToVolumeName(r.Ctrlr)
+		_, isBlk := s.Virt.BlkCtrls[name]
+		_, isScsi := s.Virt.ScsiCtrls[name]
+		if isBlk || isScsi {
+			continue
+		}
+		result.AddOrphaned("vhost controller", r.Ctrlr)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(r.Ctrlr)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			if r.BackendSpecific.Block != nil {
+				blk := &pb.VirtioBlk{Name: name, VolumeId: &pc.ObjectKey{Value: r.BackendSpecific.Block.Bdev}}
+				err = server.Adopt(s.store, s.Virt.BlkCtrls, name, blk)
+			} else {
+				scsi := &pb.VirtioScsiController{Name: name}
+				err = server.Adopt(s.store, s.Virt.ScsiCtrls, name, scsi)
+			}
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			params := spdk.VhostDeleteControllerParams{
+				Ctrlr: r.Ctrlr,
+			}
+			var res spdk.VhostDeleteControllerResult
+			if err := s.rpc.Call("vhost_delete_controller", &params, &res); err != nil {
+				slog.Error("SPDK call failed", "method", "vhost_delete_controller", "err", err)
+				return err
+			}
+			slog.Debug("Received from SPDK", "result", res)
+			if !res {
+				return fmt.Errorf("could not delete vhost controller: %s", params.Ctrlr)
+			}
+		}
+	}
+	err := server.ReconcileMissing(mode, s.store, s.Virt.BlkCtrls,
+		func(blk *pb.VirtioBlk) bool { return present[path.Base(blk.Name)] }, result)
+	if err != nil {
+		return err
+	}
+	return server.ReconcileMissing(mode, s.store, s.Virt.ScsiCtrls,
+		func(scsi *pb.VirtioScsiController) bool { return present[path.Base(scsi.Name)] }, result)
+}
+
+// findNvmeSubsystem returns stored subsystem with nqn, s.mu must be held
+func (s *Server) findNvmeSubsystem(nqn string) *pb.NvmeSubsystem {
+	for _, subsys := range s.Nvme.Subsystems {
+		if subsys.Spec.Nqn == nqn {
+			return subsys
+		}
+	}
+	return nil
+}
+
+// findNvmeNamespace returns stored namespace with nsid in subsystem, s.mu must be held
+func (s *Server) findNvmeNamespace(subsysName string, nsid int32) *pb.NvmeNamespace {
+	for _, namespace := range s.Nvme.Namespaces {
+		if namespace.Spec.SubsystemId.GetValue() == subsysName && namespace.Spec.HostNsid == nsid {
+			return namespace
+		}
+	}
+	return nil
+}
+
+// nqnToResourceID converts NQN e.g. nqn.2016-06.io.spdk:cnode1 into
+// resource ID e.g. nqn-2016-06-io-spdk-cnode1, since NQN is not a valid one
+func nqnToResourceID(nqn string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, nqn)
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package frontend implements the FrontEnd APIs (host facing) of the storage Server
+package frontend
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+func TestFrontEnd_Reconcile(t *testing.T) {
+	subsystemsResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"nqn":"nqn.2014-08.org.nvmexpress.discovery","subtype":"Discovery"},` +
+		`{"nqn":"nqn.2022-09.io.spdk:opi3","subtype":"NVMe","namespaces":[{"nsid":22,"name":"Malloc0"},{"nsid":5,"name":"Malloc1"}]},` +
+		`{"nqn":"nqn.2016-06.io.spdk:cnode1","subtype":"NVMe","serial_number":"SPDK00000000000001","model_number":"SPDK_Controller1","max_namespaces":32,"namespaces":[{"nsid":3,"name":"Malloc2"}]}]}`
+	controllersResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"ctrlr":"virtio-scsi-0","backend_specific":{"scsi":[]}}]}`
+	adoptedSubsystemName := server.ResourceIDToVolumeName("nqn-2016-06-io-spdk-cnode1")
+
+	tests := map[string]struct {
+		mode       server.ReconcileMode
+		spdk       []string
+		out        *server.ReconcileResult
+		errMsg     string
+		subsystems []string
+		namespaces []string
+		blks       []string
+		scsis      []string
+	}{
+		"report only": {
+			mode: server.ReconcileReport,
+			spdk: []string{subsystemsResponse, controllersResponse},
+			out: &server.ReconcileResult{
+				Missing: []string{testVirtioCtrlName},
+				Orphaned: []string{
+					"nvmf namespace nqn.2022-09.io.spdk:opi3/5",
+					"nvmf subsystem nqn.2016-06.io.spdk:cnode1",
+					"vhost controller virtio-scsi-0",
+				},
+			},
+			subsystems: []string{testSubsystemName},
+			namespaces: []string{testNamespaceName},
+			blks:       []string{testVirtioCtrlName},
+		},
+		"adopt": {
+			mode: server.ReconcileAdopt,
+			spdk: []string{subsystemsResponse, controllersResponse},
+			out: &server.ReconcileResult{
+				Missing: []string{testVirtioCtrlName},
+				Orphaned: []string{
+					"nvmf namespace nqn.2022-09.io.spdk:opi3/5",
+					"nvmf subsystem nqn.2016-06.io.spdk:cnode1",
+					"nvmf namespace nqn.2016-06.io.spdk:cnode1/3",
+					"vhost controller virtio-scsi-0",
+				},
+			},
+			subsystems: []string{adoptedSubsystemName, testSubsystemName},
+			namespaces: []string{
+				testNamespaceName,
+				server.ResourceIDToVolumeName("nqn-2016-06-io-spdk-cnode1-ns3"),
+				server.ResourceIDToVolumeName("subsystem-test-ns5"),
+			},
+			scsis: []string{server.ResourceIDToVolumeName("virtio-scsi-0")},
+		},
+		"cleanup": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{subsystemsResponse, controllersResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			out: &server.ReconcileResult{
+				Missing: []string{testVirtioCtrlName},
+				Orphaned: []string{
+					"nvmf namespace nqn.2022-09.io.spdk:opi3/5",
+					"nvmf subsystem nqn.2016-06.io.spdk:cnode1",
+					"vhost controller virtio-scsi-0",
+				},
+			},
+			subsystems: []string{testSubsystemName},
+			namespaces: []string{testNamespaceName},
+		},
+		"cleanup with invalid SPDK response": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{subsystemsResponse, controllersResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			errMsg: "could not delete NS: nqn.2022-09.io.spdk:opi3/5",
+		},
+		"valid request with error code from SPDK": {
+			mode:   server.ReconcileReport,
+			spdk:   []string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			errMsg: "nvmf_get_subsystems: json response error: myopierr",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testSubsystem.Name = testSubsystemName
+			testNamespace.Name = testNamespaceName
+			testVirtioCtrl.Name = testVirtioCtrlName
+			testEnv.opiSpdkServer.Nvme.Subsystems[testSubsystemName] = &testSubsystem
+			testEnv.opiSpdkServer.Nvme.Namespaces[testNamespaceName] = &testNamespace
+			testEnv.opiSpdkServer.Virt.BlkCtrls[testVirtioCtrlName] = &testVirtioCtrl
+
+			result, err := testEnv.opiSpdkServer.Reconcile(tt.mode)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("expected error %v, received: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, received: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.out) {
+				t.Errorf("expected %v, received: %v", tt.out, result)
+			}
+
+			checkResourceNames(t, testEnv.opiSpdkServer.Nvme.Subsystems, tt.subsystems)
+			checkResourceNames(t, testEnv.opiSpdkServer.Nvme.Namespaces, tt.namespaces)
+			checkResourceNames(t, testEnv.opiSpdkServer.Virt.BlkCtrls, tt.blks)
+			checkResourceNames(t, testEnv.opiSpdkServer.Virt.ScsiCtrls, tt.scsis)
+		})
+	}
+}
+
+func TestFrontEnd_ReconcileAdoptsSubsystemFields(t *testing.T) {
+	testEnv := createTestEnvironment([]string{
+		`{"id":%d,"error":{"code":0,"message":""},"result":[{"nqn":"nqn.2016-06.io.spdk:cnode1","subtype":"NVMe","serial_number":"SPDK00000000000001","model_number":"SPDK_Controller1","max_namespaces":32}]}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":[]}`,
+	})
+	defer testEnv.Close()
+
+	if _, err := testEnv.opiSpdkServer.Reconcile(server.ReconcileAdopt); err != nil {
+		t.Fatalf("expected no error, received: %v", err)
+	}
+	expected := &pb.NvmeSubsystemSpec{
+		Nqn:           "nqn.2016-06.io.spdk:cnode1",
+		SerialNumber:  "SPDK00000000000001",
+		ModelNumber:   "SPDK_Controller1",
+		MaxNamespaces: 32,
+	}
+	subsys, ok := testEnv.opiSpdkServer.Nvme.Subsystems[server.ResourceIDToVolumeName("nqn-2016-06-io-spdk-cnode1")]
+	if !ok || !proto.Equal(subsys.Spec, expected) {
+		t.Errorf("expected %v, received: %v", expected, testEnv.opiSpdkServer.Nvme.Subsystems)
+	}
+}
+
+func checkResourceNames[T any](t *testing.T, actual map[string]T, expected []string) {
+	names := []string{}
+	for name := range actual {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if expected == nil {
+		expected = []string{}
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, received: %v", expected, names)
+	}
+}
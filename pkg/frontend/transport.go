@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package frontend implements the FrontEnd APIs (host facing) of the storage Server
+package frontend
+
+import (
+	"errors"
+
+	"golang.org/x/exp/slog"
+)
+
+// tcpTransportType is trtype of the NVMe/TCP transport in SPDK
+const tcpTransportType = "TCP"
+
+// nvmfTransport is a subset of nvmf_get_transports result, which is not
+// provided by gospdk
+type nvmfTransport struct {
+	Trtype           string `json:"trtype"`
+	DifInsertOrStrip bool   `json:"dif_insert_or_strip"`
+}
+
+// nvmfCreateTransportParams is the parameters required to create an NVMe-oF
+// transport, which are not provided by gospdk
+type nvmfCreateTransportParams struct {
+	Trtype           string `json:"trtype"`
+	DifInsertOrStrip bool   `json:"dif_insert_or_strip,omitempty"`
+}
+
+// nvmfCreateTransportResult is the result of creating an NVMe-oF transport
+type nvmfCreateTransportResult bool
+
+// EnableTCPDifInsertOrStrip makes SPDK insert and strip DIF of all
+// namespaces exposed through the NVMe/TCP transport, creating the transport
+// if it does not exist yet. SPDK has no such setting per namespace or bdev
+// and cannot change it on an existing transport.
+func (s *Server) EnableTCPDifInsertOrStrip() error {
+	var transports []nvmfTransport
+	err := s.rpc.Call("nvmf_get_transports", nil, &transports)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "nvmf_get_transports", "err", err)
+		return err
+	}
+	slog.Debug("Received from SPDK", "result", transports)
+	for _, transport := range transports {
+		if transport.Trtype != tcpTransportType {
+			continue
+		}
+		if !transport.DifInsertOrStrip {
+			return errors.New("NVMe/TCP transport already exists without dif_insert_or_strip, SPDK cannot change it")
+		}
+		return nil
+	}
+
+	params := nvmfCreateTransportParams{
+		Trtype:           tcpTransportType,
+		DifInsertOrStrip: true,
+	}
+	var result nvmfCreateTransportResult
+	err = s.rpc.Call("nvmf_create_transport", &params, &result)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "nvmf_create_transport", "err", err)
+		return err
+	}
+	slog.Debug("Received from SPDK", "result", result)
+	if !result {
+		return errors.New("could not create NVMe/TCP transport with dif_insert_or_strip")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package frontend implements the FrontEnd APIs (host facing) of the storage Server
+package frontend
+
+import (
+	"testing"
+)
+
+func TestFrontEnd_EnableTCPDifInsertOrStrip(t *testing.T) {
+	tests := map[string]struct {
+		spdk   []string
+		errMsg string
+	}{
+		"create missing transport": {
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[{"trtype":"VFIOUSER"}]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+			},
+			"",
+		},
+		"transport with dif_insert_or_strip": {
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"trtype":"TCP","dif_insert_or_strip":true}]}`},
+			"",
+		},
+		"transport without dif_insert_or_strip": {
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[{"trtype":"TCP","dif_insert_or_strip":false}]}`},
+			"NVMe/TCP transport already exists without dif_insert_or_strip, SPDK cannot change it",
+		},
+		"valid request with invalid SPDK response": {
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[]}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`,
+			},
+			"could not create NVMe/TCP transport with dif_insert_or_strip",
+		},
+		"valid request with error code from SPDK": {
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":[]}`,
+				`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`,
+			},
+			"nvmf_create_transport: json response error: myopierr",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			err := testEnv.opiSpdkServer.EnableTCPDifInsertOrStrip()
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("expected error %v, received: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected no error, received: %v", err)
+			}
+		})
+	}
+}
@@ -6,35 +6,37 @@ package kvm
 
 import (
 	"context"
-	"log"
 	"path/filepath"
 
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/frontend"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // CreateVirtioBlk creates a virtio-blk device and attaches it to QEMU instance
 func (s *Server) CreateVirtioBlk(ctx context.Context, in *pb.CreateVirtioBlkRequest) (*pb.VirtioBlk, error) {
 	if in.VirtioBlk.PcieId == nil {
-		log.Println("Pci endpoint should be specified")
+		slog.ErrorContext(ctx, "Pci endpoint should be specified")
 		return nil, errNoPcieEndpoint
 	}
 
 	location, err := s.locator.Calculate(in.VirtioBlk.PcieId)
 	if err != nil {
-		log.Println("Failed to calculate device location:", err)
+		slog.ErrorContext(ctx, "Failed to calculate device location", "err", err)
 		return nil, errDeviceEndpoint
 	}
 
 	out, err := s.Server.CreateVirtioBlk(ctx, in)
 	if err != nil {
-		log.Println("Error running cmd on opi-spdk bridge:", err)
+		slog.ErrorContext(ctx, "Error running cmd on opi-spdk bridge", "err", err)
 		return out, err
 	}
 
-	mon, err := newMonitor(s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep)
+	mon, err := newMonitor(ctx, s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep, s.QmpObserver)
 	if err != nil {
-		log.Println("Couldn't create QEMU monitor")
+		slog.ErrorContext(ctx, "Couldn't create QEMU monitor")
 		_, _ = s.Server.DeleteVirtioBlk(context.Background(), &pb.DeleteVirtioBlkRequest{Name: out.Name})
 		return nil, errMonitorCreation
 	}
@@ -43,14 +45,14 @@ func (s *Server) CreateVirtioBlk(ctx context.Context, in *pb.CreateVirtioBlkRequ
 	ctrlr := filepath.Join(s.ctrlrDir, filepath.Base(out.Name))
 	qemuChardevID := toQemuID(out.Name)
 	if err := mon.AddChardev(qemuChardevID, ctrlr); err != nil {
-		log.Println("Couldn't add chardev:", err)
+		slog.ErrorContext(ctx, "Couldn't add chardev", "err", err)
 		_, _ = s.Server.DeleteVirtioBlk(context.Background(), &pb.DeleteVirtioBlkRequest{Name: out.Name})
 		return nil, errAddChardevFailed
 	}
 
 	qemuDevID := toQemuID(out.Name)
 	if err = mon.AddVirtioBlkDevice(qemuDevID, qemuChardevID, location); err != nil {
-		log.Println("Couldn't add device:", err)
+		slog.ErrorContext(ctx, "Couldn't add device", "err", err)
 		_ = mon.DeleteChardev(qemuDevID)
 		_, _ = s.Server.DeleteVirtioBlk(context.Background(), &pb.DeleteVirtioBlkRequest{Name: out.Name})
 		return nil, errAddDeviceFailed
@@ -61,9 +63,9 @@ func (s *Server) CreateVirtioBlk(ctx context.Context, in *pb.CreateVirtioBlkRequ
 
 // DeleteVirtioBlk deletes a virtio-blk device and detaches it from QEMU instance
 func (s *Server) DeleteVirtioBlk(ctx context.Context, in *pb.DeleteVirtioBlkRequest) (*emptypb.Empty, error) {
-	mon, monErr := newMonitor(s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep)
+	mon, monErr := newMonitor(ctx, s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep, s.QmpObserver)
 	if monErr != nil {
-		log.Println("Couldn't create QEMU monitor")
+		slog.ErrorContext(ctx, "Couldn't create QEMU monitor")
 		return nil, errMonitorCreation
 	}
 	defer mon.Disconnect()
@@ -71,18 +73,18 @@ func (s *Server) DeleteVirtioBlk(ctx context.Context, in *pb.DeleteVirtioBlkRequ
 	qemuDeviceID := toQemuID(in.Name)
 	delDevErr := mon.DeleteVirtioBlkDevice(qemuDeviceID)
 	if delDevErr != nil {
-		log.Printf("Couldn't delete virtio-blk: %v", delDevErr)
+		slog.ErrorContext(ctx, "Couldn't delete virtio-blk", "err", delDevErr)
 	}
 
 	qemuChardevID := toQemuID(in.Name)
 	delChardevErr := mon.DeleteChardev(qemuChardevID)
 	if delChardevErr != nil {
-		log.Printf("Couldn't delete chardev for virtio-blk: %v. Device is partially deleted", delChardevErr)
+		slog.ErrorContext(ctx, "Couldn't delete chardev for virtio-blk. Device is partially deleted", "err", delChardevErr)
 	}
 
 	response, spdkErr := s.Server.DeleteVirtioBlk(ctx, in)
 	if spdkErr != nil {
-		log.Println("Error running underlying cmd on opi-spdk bridge:", spdkErr)
+		slog.ErrorContext(ctx, "Error running underlying cmd on opi-spdk bridge", "err", spdkErr)
 	}
 
 	var err error
@@ -94,3 +96,18 @@ func (s *Server) DeleteVirtioBlk(ctx context.Context, in *pb.DeleteVirtioBlkRequ
 
 	return response, err
 }
+
+// VolumeConsumers returns kinds of frontend resources using volumes,
+// VirtioBlks deleted by cascading are detached from QEMU instance as well
+func (s *Server) VolumeConsumers() []server.VolumeConsumer {
+	consumers := s.Server.VolumeConsumers()
+	for i := range consumers {
+		if consumers[i].Kind == frontend.VirtioBlkConsumerKind {
+			consumers[i].Delete = func(ctx context.Context, name string) error {
+				_, err := s.DeleteVirtioBlk(ctx, &pb.DeleteVirtioBlkRequest{Name: name})
+				return err
+			}
+		}
+	}
+	return consumers
+}
@@ -13,6 +13,7 @@ import (
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/frontend"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -150,7 +151,7 @@ func TestCreateVirtioBlk(t *testing.T) {
 
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
-			opiSpdkServer := frontend.NewServer(tt.jsonRPC)
+			opiSpdkServer := frontend.NewServer(tt.jsonRPC, store.NewMemoryStore())
 			qmpServer := startMockQmpServer(t, tt.mockQmpCalls)
 			defer qmpServer.Stop()
 			qmpAddress := qmpServer.socketPath
@@ -250,7 +251,7 @@ func TestDeleteVirtioBlk(t *testing.T) {
 
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
-			opiSpdkServer := frontend.NewServer(tt.jsonRPC)
+			opiSpdkServer := frontend.NewServer(tt.jsonRPC, store.NewMemoryStore())
 			opiSpdkServer.Virt.BlkCtrls[testVirtioBlkName] =
 				server.ProtoClone(testCreateVirtioBlkRequest.VirtioBlk)
 			opiSpdkServer.Virt.BlkCtrls[testVirtioBlkName].Name = testVirtioBlkName
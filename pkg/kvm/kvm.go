@@ -48,6 +48,9 @@ type Server struct {
 	pollDevicePresenceStep time.Duration
 
 	locator deviceLocator
+
+	// QmpObserver is notified about QMP commands if set, e.g. to export metrics
+	QmpObserver QmpObserver
 }
 
 // NewServer creates instance of KvmServer
@@ -77,7 +80,8 @@ func NewServer(s *frontend.Server, qmpAddress string, ctrlrDir string, buses []s
 		qmpProtocol,
 		timeout,
 		pollDevicePresenceStep,
-		newDeviceLocator(buses)}
+		newDeviceLocator(buses),
+		nil}
 }
 
 func getProtocol(qmpAddress string) (string, error) {
@@ -9,6 +9,7 @@ import (
 	"log"
 
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"golang.org/x/exp/slog"
 )
 
 type deviceLocation struct {
@@ -22,7 +23,7 @@ type deviceLocator interface {
 
 func newDeviceLocator(buses []string) deviceLocator {
 	if len(buses) == 0 {
-		log.Println("Device location for virtio-blk and Nvme devices will be assigned by QEMU")
+		slog.Info("Device location for virtio-blk and Nvme devices will be assigned by QEMU")
 		return defaultDeviceLocator{}
 	}
 	elementSet := make(map[string]struct{})
@@ -35,7 +36,7 @@ func newDeviceLocator(buses []string) deviceLocator {
 		}
 		elementSet[bus] = struct{}{}
 	}
-	log.Println("Device location will be calculated based on requested PcieEndpoint on", buses)
+	slog.Info("Device location will be calculated based on requested PcieEndpoint", "buses", buses)
 	return busDeviceLocator{buses}
 }
 
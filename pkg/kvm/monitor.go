@@ -15,6 +15,9 @@ import (
 
 	"github.com/digitalocean/go-qemu/qmp"
 	qmpraw "github.com/digitalocean/go-qemu/qmp/raw"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/exp/slog"
 )
 
 // TODO: check for device existence to provide idempotence in all methods
@@ -22,29 +25,62 @@ import (
 type monitor struct {
 	rmon *qmpraw.Monitor
 	mon  qmp.Monitor
+	// ctx is the context of the request the monitor serves,
+	// operations are traced as part of that request
+	ctx context.Context
 
 	waitEventTimeout          time.Duration
 	pollDevicePresenceTimeout time.Duration
 	pollDevicePresenceStep    time.Duration
 }
 
-func newMonitor(qmpAddress string, protocol string,
-	timeout time.Duration, pollDevicePresenceStep time.Duration) (*monitor, error) {
-	mon, err := qmp.NewSocketMonitor(protocol, qmpAddress, timeout)
+// QmpObserver is notified about every QMP command sent to QEMU
+// with the time it took to complete and its error
+type QmpObserver func(command string, duration time.Duration, err error)
+
+// observedMonitor reports commands run on the wrapped monitor to observe
+type observedMonitor struct {
+	qmp.Monitor
+	observe QmpObserver
+}
+
+func (m *observedMonitor) Run(command []byte) ([]byte, error) {
+	var cmd qmp.Command
+	if err := json.Unmarshal(command, &cmd); err != nil || cmd.Execute == "" {
+		cmd.Execute = "unknown"
+	}
+	start := time.Now()
+	out, err := m.Monitor.Run(command)
+	m.observe(cmd.Execute, time.Since(start), err)
+	return out, err
+}
+
+func newMonitor(ctx context.Context, qmpAddress string, protocol string,
+	timeout time.Duration, pollDevicePresenceStep time.Duration, observe QmpObserver) (mon *monitor, err error) {
+	_, span := server.StartSpan(ctx, "qmp/Connect", attribute.String("qmp.address", qmpAddress))
+	defer func() { server.EndSpan(span, err) }()
+
+	socketMon, err := qmp.NewSocketMonitor(protocol, qmpAddress, timeout)
 	if err != nil {
-		log.Printf("couldn't create QEMU monitor: %v", err)
+		slog.ErrorContext(ctx, "Couldn't create QEMU monitor", "err", err)
 		return nil, err
 	}
 
-	if err := mon.Connect(); err != nil {
-		log.Printf("Failed to connect to QEMU: %v", err)
+	var qmpMon qmp.Monitor = socketMon
+	if observe != nil {
+		qmpMon = &observedMonitor{Monitor: socketMon, observe: observe}
+	}
+
+	if err := qmpMon.Connect(); err != nil {
+		slog.ErrorContext(ctx, "Failed to connect to QEMU", "err", err)
 		return nil, err
 	}
 
-	rawMon := qmpraw.NewMonitor(mon)
+	rawMon := qmpraw.NewMonitor(qmpMon)
 	return &monitor{
 		rmon:                      rawMon,
-		mon:                       mon,
+		mon:                       qmpMon,
+		ctx:                       ctx,
 		waitEventTimeout:          timeout,
 		pollDevicePresenceTimeout: timeout,
 		pollDevicePresenceStep:    pollDevicePresenceStep}, nil
@@ -57,18 +93,31 @@ func (m *monitor) Disconnect() {
 	}
 }
 
-func (m *monitor) AddChardev(id string, sockPath string) error {
-	server := false
-	socketBackend := qmpraw.ChardevBackendSocket{
-		Addr: qmpraw.SocketAddressLegacyUnix{
-			Path: sockPath},
-		Server: &server}
-	_, err := m.rmon.ChardevAdd(id, socketBackend)
+// traced runs operation op on QEMU object id in a span, which is a child
+// of the span of the request
+func (m *monitor) traced(op string, id string, run func(ctx context.Context) error) error {
+	ctx, span := server.StartSpan(m.ctx, "qmp/"+op, attribute.String("qemu.id", id))
+	err := run(ctx)
+	server.EndSpan(span, err)
 	return err
 }
 
+func (m *monitor) AddChardev(id string, sockPath string) error {
+	return m.traced("AddChardev", id, func(context.Context) error {
+		server := false
+		socketBackend := qmpraw.ChardevBackendSocket{
+			Addr: qmpraw.SocketAddressLegacyUnix{
+				Path: sockPath},
+			Server: &server}
+		_, err := m.rmon.ChardevAdd(id, socketBackend)
+		return err
+	})
+}
+
 func (m *monitor) DeleteChardev(id string) error {
-	return m.rmon.ChardevRemove(id)
+	return m.traced("DeleteChardev", id, func(context.Context) error {
+		return m.rmon.ChardevRemove(id)
+	})
 }
 
 func (m *monitor) AddVirtioBlkDevice(id string, chardevID string, location deviceLocation) error {
@@ -85,10 +134,12 @@ func (m *monitor) AddVirtioBlkDevice(id string, chardevID string, location devic
 		Addr:    location.Addr,
 		Chardev: &chardevID,
 	}
-	if err := m.addDevice(qmpCmd); err != nil {
-		return err
-	}
-	return m.waitForDeviceExist(id)
+	return m.traced("AddVirtioBlkDevice", id, func(ctx context.Context) error {
+		if err := m.addDevice(ctx, qmpCmd); err != nil {
+			return err
+		}
+		return m.waitForDeviceExist(ctx, id)
+	})
 }
 
 func (m *monitor) AddNvmeControllerDevice(id string, ctrlrDir string, location deviceLocation) error {
@@ -106,46 +157,52 @@ func (m *monitor) AddNvmeControllerDevice(id string, ctrlrDir string, location d
 		Addr:   location.Addr,
 		Socket: &socket,
 	}
-	if err := m.addDevice(qmpCmd); err != nil {
-		return err
-	}
-	return m.waitForDeviceExist(id)
+	return m.traced("AddNvmeControllerDevice", id, func(ctx context.Context) error {
+		if err := m.addDevice(ctx, qmpCmd); err != nil {
+			return err
+		}
+		return m.waitForDeviceExist(ctx, id)
+	})
 }
 
 func (m *monitor) DeleteVirtioBlkDevice(id string) error {
-	err := m.rmon.DeviceDel(id)
-	if err != nil {
-		return fmt.Errorf("couldn't delete device: %w", err)
-	}
-	return m.waitForEvent("DEVICE_DELETED", "device", id)
+	return m.traced("DeleteVirtioBlkDevice", id, func(ctx context.Context) error {
+		err := m.rmon.DeviceDel(id)
+		if err != nil {
+			return fmt.Errorf("couldn't delete device: %w", err)
+		}
+		return m.waitForEvent(ctx, "DEVICE_DELETED", "device", id)
+	})
 }
 
 func (m *monitor) DeleteNvmeControllerDevice(id string) error {
-	if err := m.rmon.DeviceDel(id); err != nil {
-		return err
-	}
-	return m.waitForDeviceNotExist(id)
+	return m.traced("DeleteNvmeControllerDevice", id, func(ctx context.Context) error {
+		if err := m.rmon.DeviceDel(id); err != nil {
+			return err
+		}
+		return m.waitForDeviceNotExist(ctx, id)
+	})
 }
 
-func (m *monitor) addDevice(qmpCmd interface{}) error {
+func (m *monitor) addDevice(ctx context.Context, qmpCmd interface{}) error {
 	bs, err := json.Marshal(map[string]interface{}{
 		"execute":   "device_add",
 		"arguments": qmpCmd,
 	})
 	if err != nil {
-		log.Println("json marshalling error:", err)
+		slog.ErrorContext(ctx, "JSON marshalling error", "err", err)
 		return fmt.Errorf("couldn't create QMP command: %w", err)
 	}
 
-	log.Println("QMP command to send: ", string(bs))
+	slog.DebugContext(ctx, "QMP command to send", "command", string(bs))
 	raw, err := m.mon.Run(bs)
 	if err != nil {
-		log.Println("QMP error:", err)
+		slog.ErrorContext(ctx, "QMP error", "err", err)
 		return fmt.Errorf("couldn't run QMP command: %w", err)
 	}
 
 	response := string(raw)
-	log.Println("QMP response:", response)
+	slog.DebugContext(ctx, "QMP response", "response", response)
 	if strings.Contains(response, "error") {
 		return fmt.Errorf("qemu cmd run error: %v", string(bs))
 	}
@@ -153,7 +210,10 @@ func (m *monitor) addDevice(qmpCmd interface{}) error {
 	return nil
 }
 
-func (m *monitor) waitForEvent(event string, key string, value string) error {
+func (m *monitor) waitForEvent(ctx context.Context, event string, key string, value string) (err error) {
+	_, span := server.StartSpan(ctx, "qmp/waitForEvent", attribute.String("qmp.event", event))
+	defer func() { server.EndSpan(span, err) }()
+
 	stream, err := m.mon.Events(context.Background())
 	if err != nil {
 		return fmt.Errorf("couldn't get event channel: %v", err)
@@ -163,7 +223,7 @@ func (m *monitor) waitForEvent(event string, key string, value string) error {
 	for {
 		select {
 		case e := <-stream:
-			log.Println("qemu event:", e)
+			slog.DebugContext(ctx, "QEMU event", "event", e)
 			if e.Event != event {
 				continue
 			}
@@ -178,24 +238,31 @@ func (m *monitor) waitForEvent(event string, key string, value string) error {
 			if val != value {
 				continue
 			}
-			log.Println("Event:", event, "found")
+			slog.DebugContext(ctx, "Event found", "event", event)
 			return nil
 		case <-timeoutTimer.C:
-			log.Println("Event timeout:", event, ", key:", key, "value:", value)
+			slog.ErrorContext(ctx, "Event timeout", "event", event, "key", key, "value", value)
 			return fmt.Errorf("qemu event not found: %v", event)
 		}
 	}
 }
 
-func (m *monitor) waitForDeviceExist(id string) error {
-	return m.waitForDevicePresence(id, true)
+func (m *monitor) waitForDeviceExist(ctx context.Context, id string) error {
+	return m.waitForDevicePresence(ctx, id, true)
 }
 
-func (m *monitor) waitForDeviceNotExist(id string) error {
-	return m.waitForDevicePresence(id, false)
+func (m *monitor) waitForDeviceNotExist(ctx context.Context, id string) error {
+	return m.waitForDevicePresence(ctx, id, false)
 }
 
-func (m *monitor) waitForDevicePresence(id string, shouldExist bool) error {
+func (m *monitor) waitForDevicePresence(ctx context.Context, id string, shouldExist bool) (err error) {
+	_, span := server.StartSpan(ctx, "qmp/waitForDevicePresence", attribute.Bool("qemu.device.present", shouldExist))
+	polls := 0
+	defer func() {
+		span.SetAttributes(attribute.Int("qemu.pci.polls", polls))
+		server.EndSpan(span, err)
+	}()
+
 	timeoutTimer := time.NewTimer(m.pollDevicePresenceTimeout)
 	devicePresenceTicker := time.NewTicker(m.pollDevicePresenceStep)
 	defer devicePresenceTicker.Stop()
@@ -204,9 +271,10 @@ func (m *monitor) waitForDevicePresence(id string, shouldExist bool) error {
 		case <-timeoutTimer.C:
 			return fmt.Errorf("timeout waiting for PCI device %v presence %v", id, shouldExist)
 		case <-devicePresenceTicker.C:
+			polls++
 			exist, err := m.pciDeviceExist(id)
 			if err != nil {
-				log.Println("failed to check pci device existence:", err)
+				slog.ErrorContext(ctx, "Failed to check PCI device existence", "err", err)
 				continue
 			}
 			if exist != shouldExist {
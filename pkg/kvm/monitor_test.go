@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package kvm automates plugging of SPDK devices to a QEMU instance
+package kvm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/go-qemu/qmp"
+)
+
+type stubQmpMonitor struct {
+	qmp.Monitor
+	err error
+}
+
+func (m stubQmpMonitor) Run([]byte) ([]byte, error) {
+	return []byte(`{"return":{}}`), m.err
+}
+
+func TestObservedMonitor(t *testing.T) {
+	tests := map[string]struct {
+		command  string
+		err      error
+		observed string
+	}{
+		"command": {
+			command:  `{"execute":"device_add","arguments":{"id":"virtio-blk-42"}}`,
+			err:      nil,
+			observed: "device_add",
+		},
+		"failed command": {
+			command:  `{"execute":"device_del"}`,
+			err:      errors.New("DeviceNotFound"),
+			observed: "device_del",
+		},
+		"invalid command": {
+			command:  `device_add`,
+			err:      nil,
+			observed: "unknown",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			observed := ""
+			var observedErr error
+			mon := &observedMonitor{
+				Monitor: stubQmpMonitor{err: tt.err},
+				observe: func(command string, _ time.Duration, err error) {
+					observed = command
+					observedErr = err
+				},
+			}
+
+			_, err := mon.Run([]byte(tt.command))
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			if observed != tt.observed {
+				t.Errorf("Expected observed command %v, received: %v", tt.observed, observed)
+			}
+			if !errors.Is(observedErr, tt.err) {
+				t.Errorf("Expected observed error %v, received: %v", tt.err, observedErr)
+			}
+		})
+	}
+}
@@ -11,11 +11,13 @@ import (
 	"os"
 	"path/filepath"
 
+	"golang.org/x/exp/slog"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/frontend"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
 )
 
 type vfiouserSubsystemListener struct {
@@ -57,35 +59,37 @@ func (s *Server) CreateNvmeController(ctx context.Context, in *pb.CreateNvmeCont
 		return nil, errInvalidSubsystem
 	}
 	if in.NvmeController.Spec.PcieId == nil {
-		log.Println("Pci endpoint should be specified")
+		slog.ErrorContext(ctx, "Pci endpoint should be specified")
 		return nil, errNoPcieEndpoint
 	}
 	location, err := s.locator.Calculate(in.NvmeController.Spec.PcieId)
 	if err != nil {
-		log.Println("Failed to calculate device location: ", err)
+		slog.ErrorContext(ctx, "Failed to calculate device location", "err", err)
 		return nil, errDeviceEndpoint
 	}
 
 	// Create request can miss Name field which is generated in spdk bridge.
 	// Use subsystem instead, since it is required to exist
 	dirName := filepath.Base(in.NvmeController.Spec.SubsystemId.Value)
+	_, span := server.StartSpan(ctx, "kvm/createControllerDir")
 	err = createControllerDir(s.ctrlrDir, dirName)
+	server.EndSpan(span, err)
 	if err != nil {
-		log.Print(err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, errFailedToCreateNvmeDir
 	}
 
 	out, err := s.Server.CreateNvmeController(ctx, in)
 	if err != nil {
-		log.Println("Error running cmd on opi-spdk bridge:", err)
+		slog.ErrorContext(ctx, "Error running cmd on opi-spdk bridge", "err", err)
 		_ = deleteControllerDir(s.ctrlrDir, dirName)
 		return out, err
 	}
 	name := out.Name
 
-	mon, monErr := newMonitor(s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep)
+	mon, monErr := newMonitor(ctx, s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep, s.QmpObserver)
 	if monErr != nil {
-		log.Println("Couldn't create QEMU monitor")
+		slog.ErrorContext(ctx, "Couldn't create QEMU monitor")
 		_, _ = s.Server.DeleteNvmeController(context.Background(), &pb.DeleteNvmeControllerRequest{Name: name})
 		_ = deleteControllerDir(s.ctrlrDir, dirName)
 		return nil, errMonitorCreation
@@ -94,7 +98,7 @@ func (s *Server) CreateNvmeController(ctx context.Context, in *pb.CreateNvmeCont
 
 	qemuDeviceID := toQemuID(name)
 	if err := mon.AddNvmeControllerDevice(qemuDeviceID, controllerDirPath(s.ctrlrDir, dirName), location); err != nil {
-		log.Println("Couldn't add Nvme controller:", err)
+		slog.ErrorContext(ctx, "Couldn't add Nvme controller", "err", err)
 		_, _ = s.Server.DeleteNvmeController(context.Background(), &pb.DeleteNvmeControllerRequest{Name: name})
 		_ = deleteControllerDir(s.ctrlrDir, dirName)
 		return nil, errAddDeviceFailed
@@ -104,33 +108,33 @@ func (s *Server) CreateNvmeController(ctx context.Context, in *pb.CreateNvmeCont
 
 // DeleteNvmeController deletes an Nvme controller device and detaches it from QEMU instance
 func (s *Server) DeleteNvmeController(ctx context.Context, in *pb.DeleteNvmeControllerRequest) (*emptypb.Empty, error) {
-	mon, monErr := newMonitor(s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep)
+	mon, monErr := newMonitor(ctx, s.qmpAddress, s.protocol, s.timeout, s.pollDevicePresenceStep, s.QmpObserver)
 	if monErr != nil {
-		log.Println("Couldn't create QEMU monitor")
+		slog.ErrorContext(ctx, "Couldn't create QEMU monitor")
 		return nil, errMonitorCreation
 	}
 	defer mon.Disconnect()
 
 	dirName, findDirNameErr := s.findDirName(in.Name)
 	if findDirNameErr != nil {
-		log.Println("Failed to detect controller directory name:", findDirNameErr)
+		slog.ErrorContext(ctx, "Failed to detect controller directory name", "err", findDirNameErr)
 		return nil, findDirNameErr
 	}
 
 	qemuDeviceID := toQemuID(in.Name)
 	delNvmeErr := mon.DeleteNvmeControllerDevice(qemuDeviceID)
 	if delNvmeErr != nil {
-		log.Printf("Couldn't delete Nvme controller: %v", delNvmeErr)
+		slog.ErrorContext(ctx, "Couldn't delete Nvme controller", "err", delNvmeErr)
 	}
 
 	response, spdkErr := s.Server.DeleteNvmeController(ctx, in)
 	if spdkErr != nil {
-		log.Println("Error running underlying cmd on opi-spdk bridge:", spdkErr)
+		slog.ErrorContext(ctx, "Error running underlying cmd on opi-spdk bridge", "err", spdkErr)
 	}
 
 	delDirErr := deleteControllerDir(s.ctrlrDir, dirName)
 	if delDirErr != nil {
-		log.Println("Failed to delete Nvme controller directory:", delDirErr)
+		slog.ErrorContext(ctx, "Failed to delete Nvme controller directory", "err", delDirErr)
 	}
 
 	var err error
@@ -143,7 +147,7 @@ func (s *Server) DeleteNvmeController(ctx context.Context, in *pb.DeleteNvmeCont
 }
 
 func (s *Server) findDirName(name string) (string, error) {
-	ctrlr, ok := s.Server.Nvme.Controllers[name]
+	ctrlr, ok := s.Server.FindNvmeController(name)
 	if !ok {
 		return "", errNoController
 	}
@@ -152,7 +156,7 @@ func (s *Server) findDirName(name string) (string, error) {
 
 func createControllerDir(ctrlrDir string, dirName string) error {
 	ctrlrDirPath := controllerDirPath(ctrlrDir, dirName)
-	log.Printf("Creating dir for Nvme controller: %v", ctrlrDirPath)
+	slog.Debug("Creating dir for Nvme controller", "path", ctrlrDirPath)
 	if os.Mkdir(ctrlrDirPath, 0600) != nil {
 		return fmt.Errorf("cannot create controller directory %v", ctrlrDirPath)
 	}
@@ -161,9 +165,9 @@ func createControllerDir(ctrlrDir string, dirName string) error {
 
 func deleteControllerDir(ctrlrDir string, dirName string) error {
 	ctrlrDirPath := controllerDirPath(ctrlrDir, dirName)
-	log.Printf("Deleting dir for Nvme controller: %v", ctrlrDirPath)
+	slog.Debug("Deleting dir for Nvme controller", "path", ctrlrDirPath)
 	if _, err := os.Stat(ctrlrDirPath); os.IsNotExist(err) {
-		log.Printf("%v directory does not exist.", ctrlrDirPath)
+		slog.Debug("Directory does not exist", "path", ctrlrDirPath)
 		return nil
 	}
 
@@ -17,6 +17,7 @@ import (
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/frontend"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -287,7 +288,7 @@ func TestCreateNvmeController(t *testing.T) {
 
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
-			opiSpdkServer := frontend.NewServer(tt.jsonRPC)
+			opiSpdkServer := frontend.NewServer(tt.jsonRPC, store.NewMemoryStore())
 			opiSpdkServer.Nvme.Subsystems[testSubsystemName] = &testSubsystem
 			qmpServer := startMockQmpServer(t, tt.mockQmpCalls)
 			defer qmpServer.Stop()
@@ -432,7 +433,7 @@ func TestDeleteNvmeController(t *testing.T) {
 
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
-			opiSpdkServer := frontend.NewServer(tt.jsonRPC)
+			opiSpdkServer := frontend.NewServer(tt.jsonRPC, store.NewMemoryStore())
 			opiSpdkServer.Nvme.Subsystems[testSubsystemName] = &testSubsystem
 			if !tt.noController {
 				opiSpdkServer.Nvme.Controllers[testNvmeControllerName] =
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FormatText writes records as key=value pairs
+	FormatText = "text"
+	// FormatJSON writes records as JSON objects
+	FormatJSON = "json"
+
+	// RequestIDMetadataKey is gRPC metadata key of request ID. IDs sent by
+	// clients are used to correlate logs, otherwise a new ID is generated.
+	// The ID is sent back to clients in response header.
+	RequestIDMetadataKey = "x-request-id"
+)
+
+// Options configure how records are written
+type Options struct {
+	// Level is the minimum level of records written
+	Level slog.Level
+	// Format is FormatText or FormatJSON
+	Format string
+	// Writer receives the records
+	Writer io.Writer
+}
+
+// New creates a logger writing records as configured by opts. Records are
+// annotated with ID and method of the request of the context they are
+// logged with and values of sensitive fields of protobuf messages logged
+// are redacted.
+func New(opts Options) (*slog.Logger, error) {
+	handlerOpts := slog.HandlerOptions{Level: opts.Level}
+	var h slog.Handler
+	switch opts.Format {
+	case FormatText:
+		h = slog.NewTextHandler(opts.Writer, &handlerOpts)
+	case FormatJSON:
+		h = slog.NewJSONHandler(opts.Writer, &handlerOpts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q, expected %v or %v", opts.Format, FormatText, FormatJSON)
+	}
+	return slog.New(&handler{Handler: h}), nil
+}
+
+// ParseLevel parses level name like debug, info, warn or error
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
+
+// handler redacts protobuf messages and adds request
+// attributes of the context to records
+type handler struct {
+	slog.Handler
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		out.AddAttrs(slog.String("request_id", req.id), slog.String("method", req.method))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		out.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &handler{Handler: h.Handler.WithAttrs(redacted)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redactAttr replaces protobuf messages in a by their redacted text
+func redactAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		redacted := make([]slog.Attr, len(attrs))
+		for i, ga := range attrs {
+			redacted[i] = redactAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		if m, ok := a.Value.Any().(proto.Message); ok {
+			return slog.String(a.Key, prototext.MarshalOptions{}.Format(Redact(m)))
+		}
+	}
+	return a
+}
+
+type requestKey struct{}
+
+type request struct {
+	id     string
+	method string
+}
+
+// RequestID returns ID of the request ctx belongs to, empty if none
+func RequestID(ctx context.Context) string {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		return req.id
+	}
+	return ""
+}
+
+// WithRequest returns ctx of request with id of gRPC method
+func WithRequest(ctx context.Context, id string, method string) context.Context {
+	return context.WithValue(ctx, requestKey{}, &request{id: id, method: method})
+}
+
+// UnaryServerInterceptor returns gRPC interceptor assigning ID to each
+// request, so records logged with its context can be correlated, and
+// logging outcome of the request
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (interface{}, error) {
+		var id string
+		if values := metadata.ValueFromIncomingContext(ctx, RequestIDMetadataKey); len(values) > 0 && values[0] != "" {
+			id = values[0]
+		} else {
+			id = uuid.New().String()
+		}
+		ctx = WithRequest(ctx, id, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id)); err != nil {
+			slog.WarnContext(ctx, "Unable to send request ID", "err", err)
+		}
+
+		start := time.Now()
+		resp, err := h(ctx, req)
+		attrs := []any{"code", status.Code(err).String(), "duration", time.Since(start)}
+		if err != nil {
+			slog.WarnContext(ctx, "Request failed", append(attrs, "err", err)...)
+		} else {
+			slog.InfoContext(ctx, "Request handled", attrs...)
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		errMsg string
+	}{
+		"text": {
+			format: FormatText,
+			errMsg: "",
+		},
+		"json": {
+			format: FormatJSON,
+			errMsg: "",
+		},
+		"unknown format": {
+			format: "xml",
+			errMsg: `unknown log format "xml", expected text or json`,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			logger, err := New(Options{Format: tt.format, Writer: &bytes.Buffer{}})
+
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("Expected error %v, received: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil || logger == nil {
+				t.Errorf("Expected logger, received error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]struct {
+		name  string
+		level slog.Level
+		err   bool
+	}{
+		"debug": {"debug", slog.LevelDebug, false},
+		"info":  {"info", slog.LevelInfo, false},
+		"warn":  {"WARN", slog.LevelWarn, false},
+		"error": {"error", slog.LevelError, false},
+		"bad":   {"verbose", slog.LevelInfo, true},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			level, err := ParseLevel(tt.name)
+
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			if !tt.err && level != tt.level {
+				t.Errorf("Expected level %v, received: %v", tt.level, level)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	key := "0123456789abcdef0123456789abcdef"
+	var out bytes.Buffer
+	logger, err := New(Options{Level: slog.LevelDebug, Format: FormatJSON, Writer: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithRequest(context.Background(), "42", "/opi_api.storage.v1.MiddleendEncryptionService/CreateEncryptedVolume")
+	in := &pb.CreateEncryptedVolumeRequest{
+		EncryptedVolume: &pb.EncryptedVolume{Name: "crypto-test", Key: []byte(key)},
+	}
+
+	logger.With("volume", in.EncryptedVolume).DebugContext(ctx, "Received from client", "request", in)
+
+	if strings.Contains(out.String(), key) || strings.Contains(out.String(), "MDEyMzQ1Njc4OWFi") {
+		t.Errorf("Expected key redacted, received: %v", out.String())
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("Expected JSON record, received %v: %v", out.String(), err)
+	}
+	if record["request_id"] != "42" {
+		t.Errorf("Expected request ID 42, received: %v", record["request_id"])
+	}
+	for _, attr := range []string{"request", "volume"} {
+		if s, _ := record[attr].(string); !strings.Contains(s, Redacted) || !strings.Contains(s, "crypto-test") {
+			t.Errorf("Expected redacted %v, received: %v", attr, record[attr])
+		}
+	}
+}
+
+func TestHandler_Level(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New(Options{Level: slog.LevelInfo, Format: FormatText, Writer: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Debug("not written")
+	logger.Info("written")
+
+	if strings.Contains(out.String(), "not written") || !strings.Contains(out.String(), "written") {
+		t.Errorf("Expected only info record, received: %v", out.String())
+	}
+}
+
+type testServerTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *testServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		incoming string
+		err      error
+	}{
+		"ID generated": {
+			incoming: "",
+			err:      nil,
+		},
+		"ID sent by client": {
+			incoming: "client-request-7",
+			err:      nil,
+		},
+		"failed request": {
+			incoming: "client-request-8",
+			err:      errors.New("failure"),
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			stream := &testServerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			if tt.incoming != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDMetadataKey, tt.incoming))
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.Test/Get"}
+			handlerID := ""
+
+			_, err := UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				handlerID = RequestID(ctx)
+				return nil, tt.err
+			})
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			if handlerID == "" || (tt.incoming != "" && handlerID != tt.incoming) {
+				t.Errorf("Expected request ID %q, received: %q", tt.incoming, handlerID)
+			}
+			if sent := stream.header.Get(RequestIDMetadataKey); len(sent) != 1 || sent[0] != handlerID {
+				t.Errorf("Expected request ID %v sent to client, received: %v", handlerID, sent)
+			}
+		})
+	}
+}
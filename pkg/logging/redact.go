@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Redacted replaces values of sensitive fields in logs
+const Redacted = "[REDACTED]"
+
+// IsSensitive reports whether field fd holds a secret which must never be
+// logged. Fields with debug_redact option set are sensitive as well as
+// known secret fields of OPI API, which do not set it yet.
+func IsSensitive(fd protoreflect.FieldDescriptor) bool {
+	switch fd.FullName() {
+	case "opi_api.storage.v1.EncryptedVolume.key",
+		"opi_api.storage.v1.NvmeRemoteController.psk":
+		return true
+	}
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
+
+// Redact returns m with values of sensitive fields replaced by Redacted,
+// m itself is returned when it holds no secrets and cloned otherwise
+func Redact(m proto.Message) proto.Message {
+	if m == nil || !m.ProtoReflect().IsValid() || !hasSecrets(m.ProtoReflect()) {
+		return m
+	}
+	redacted := proto.Clone(m)
+	redact(redacted.ProtoReflect())
+	return redacted
+}
+
+// walk calls visit for every populated field of m and its nested messages
+// until visit returns false
+func walk(m protoreflect.Message, visit func(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool) bool {
+	next := true
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !visit(m, fd) {
+			next = false
+			return false
+		}
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len() && next; i++ {
+				next = walk(list.Get(i).Message(), visit)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				next = walk(mv.Message(), visit)
+				return next
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			next = walk(v.Message(), visit)
+		}
+		return next
+	})
+	return next
+}
+
+func hasSecrets(m protoreflect.Message) bool {
+	return !walk(m, func(_ protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+		return !IsSensitive(fd)
+	})
+}
+
+func redact(m protoreflect.Message) {
+	type field struct {
+		m  protoreflect.Message
+		fd protoreflect.FieldDescriptor
+	}
+	// fields are changed after walking, since messages must not be
+	// modified while ranging over them
+	var sensitive []field
+	walk(m, func(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+		if IsSensitive(fd) {
+			sensitive = append(sensitive, field{m, fd})
+		}
+		return true
+	})
+	for _, f := range sensitive {
+		switch {
+		case f.fd.IsList() || f.fd.IsMap() || f.fd.Message() != nil:
+			f.m.Clear(f.fd)
+		case f.fd.Kind() == protoreflect.BytesKind:
+			f.m.Set(f.fd, protoreflect.ValueOfBytes([]byte(Redacted)))
+		case f.fd.Kind() == protoreflect.StringKind:
+			f.m.Set(f.fd, protoreflect.ValueOfString(Redacted))
+		default:
+			f.m.Clear(f.fd)
+		}
+	}
+}
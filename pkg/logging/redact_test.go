@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedact(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	tests := map[string]struct {
+		in       proto.Message
+		expected proto.Message
+	}{
+		"encrypted volume": {
+			in: &pb.EncryptedVolume{
+				Name:     "crypto-test",
+				VolumeId: &pc.ObjectKey{Value: "volume-test"},
+				Key:      key,
+			},
+			expected: &pb.EncryptedVolume{
+				Name:     "crypto-test",
+				VolumeId: &pc.ObjectKey{Value: "volume-test"},
+				Key:      []byte(Redacted),
+			},
+		},
+		"create encrypted volume request": {
+			in: &pb.CreateEncryptedVolumeRequest{
+				EncryptedVolumeId: "crypto-test",
+				EncryptedVolume:   &pb.EncryptedVolume{Key: key},
+			},
+			expected: &pb.CreateEncryptedVolumeRequest{
+				EncryptedVolumeId: "crypto-test",
+				EncryptedVolume:   &pb.EncryptedVolume{Key: []byte(Redacted)},
+			},
+		},
+		"nvme remote controller": {
+			in: &pb.NvmeRemoteController{
+				Name: "OpiNvme8",
+				Psk:  []byte("NVMeTLSkey-1:01:MDAxMTIyMzM0NDU1NjY3Nzg4OTlhYWJiY2NkZGVlZmZwJEiQ:"),
+			},
+			expected: &pb.NvmeRemoteController{
+				Name: "OpiNvme8",
+				Psk:  []byte(Redacted),
+			},
+		},
+		"no secrets": {
+			in:       &pb.NullDebug{Name: "OpiNull9", BlockSize: 512},
+			expected: &pb.NullDebug{Name: "OpiNull9", BlockSize: 512},
+		},
+		"unset secret": {
+			in:       &pb.EncryptedVolume{Name: "crypto-test"},
+			expected: &pb.EncryptedVolume{Name: "crypto-test"},
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			original := proto.Clone(tt.in)
+
+			redacted := Redact(tt.in)
+
+			if !proto.Equal(redacted, tt.expected) {
+				t.Errorf("Expected %v, received: %v", tt.expected, redacted)
+			}
+			if !proto.Equal(tt.in, original) {
+				t.Errorf("Expected %v not modified, received: %v", original, tt.in)
+			}
+		})
+	}
+}
+
+func TestRedact_NoSecretsNotCloned(t *testing.T) {
+	in := &pb.NullDebug{Name: "OpiNull9"}
+	if redacted := Redact(in); redacted != proto.Message(in) {
+		t.Errorf("Expected message without secrets returned as is, received a copy")
+	}
+	if redacted := Redact(nil); redacted != nil {
+		t.Errorf("Expected nil, received: %v", redacted)
+	}
+}
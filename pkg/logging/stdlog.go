@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slog"
+)
+
+// spdkMessagePrefixes start lines of JSON-RPC messages gospdk logs with
+// the standard log package
+var spdkMessagePrefixes = []string{"Sending to SPDK: ", "Received from SPDK: "}
+
+// spdkSecretParams are params of SPDK methods holding secrets, e.g. keys
+// of accel_crypto_key_create
+var spdkSecretParams = map[string]bool{
+	"key":              true,
+	"key2":             true,
+	"psk":              true,
+	"dhchap_key":       true,
+	"dhchap_ctrlr_key": true,
+}
+
+// StdLogWriter returns writer for the standard log package forwarding its
+// lines to logger. gospdk logs every JSON-RPC message with it, so these
+// are written at debug level with secret params redacted, other lines are
+// written at info level like slog.SetDefault does.
+func StdLogWriter(logger *slog.Logger) io.Writer {
+	return &stdLogWriter{logger: logger}
+}
+
+type stdLogWriter struct {
+	logger *slog.Logger
+}
+
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	level := slog.LevelInfo
+	for _, prefix := range spdkMessagePrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			level = slog.LevelDebug
+			break
+		}
+	}
+	ctx := context.Background()
+	if !w.logger.Enabled(ctx, level) {
+		return len(p), nil
+	}
+	if level == slog.LevelDebug {
+		msg = RedactSpdkMessage(msg)
+	}
+	w.logger.Log(ctx, level, msg)
+	return len(p), nil
+}
+
+// RedactSpdkMessage replaces values of secret params in JSON of an SPDK
+// JSON-RPC message logged as msg by Redacted. JSON which cannot be parsed
+// is replaced as a whole, as it may hold secrets as well.
+func RedactSpdkMessage(msg string) string {
+	start := strings.IndexAny(msg, "{[")
+	if start < 0 {
+		return msg
+	}
+	var message any
+	if err := json.Unmarshal([]byte(msg[start:]), &message); err != nil {
+		return msg[:start] + Redacted
+	}
+	if !redactSpdkParams(message) {
+		return msg
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return msg[:start] + Redacted
+	}
+	return msg[:start] + string(data)
+}
+
+// redactSpdkParams replaces values of secret params in v decoded from
+// JSON, reporting if any is found
+func redactSpdkParams(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			if spdkSecretParams[name] {
+				v[name] = Redacted
+				found = true
+				continue
+			}
+			found = redactSpdkParams(value) || found
+		}
+	case []any:
+		for _, value := range v {
+			found = redactSpdkParams(value) || found
+		}
+	}
+	return found
+}
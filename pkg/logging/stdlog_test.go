@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package logging implements structured, leveled logging of the bridge
+package logging
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slog"
+)
+
+func TestStdLogWriter(t *testing.T) {
+	tests := map[string]struct {
+		level   slog.Level
+		in      string
+		out     []string
+		missing []string
+	}{
+		"spdk request at debug level": {
+			slog.LevelDebug,
+			`Sending to SPDK: {"jsonrpc":"2.0","method":"accel_crypto_key_create","id":1,"params":{"cipher":"AES_XTS","key":"3031323334353637","key2":"3839616263646566","name":"crypto-test"}}`,
+			[]string{"level=DEBUG", "accel_crypto_key_create", "crypto-test", Redacted},
+			[]string{"3031323334353637", "3839616263646566"},
+		},
+		"spdk response without secrets": {
+			slog.LevelDebug,
+			`Received from SPDK: {"jsonrpc":"2.0","id":1,"result":true}`,
+			[]string{"level=DEBUG", `\"result\":true`},
+			[]string{Redacted},
+		},
+		"malformed spdk request": {
+			slog.LevelDebug,
+			`Sending to SPDK: {"params":{"key":"3031323334353637"`,
+			[]string{"Sending to SPDK: " + Redacted},
+			[]string{"3031323334353637"},
+		},
+		"spdk request at info level": {
+			slog.LevelInfo,
+			`Sending to SPDK: {"jsonrpc":"2.0","method":"bdev_get_bdevs","id":1}`,
+			nil,
+			[]string{"bdev_get_bdevs"},
+		},
+		"other line at info level": {
+			slog.LevelInfo,
+			"Connection to SPDK will be via: unix",
+			[]string{"level=INFO", "Connection to SPDK will be via: unix"},
+			nil,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var out bytes.Buffer
+			logger, err := New(Options{Level: tt.level, Format: FormatText, Writer: &out})
+			if err != nil {
+				t.Fatal(err)
+			}
+			l := log.New(StdLogWriter(logger), "", 0)
+
+			l.Print(tt.in)
+
+			for _, s := range tt.out {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("Expected %v written, received: %v", s, out.String())
+				}
+			}
+			for _, s := range tt.missing {
+				if strings.Contains(out.String(), s) {
+					t.Errorf("Expected %v not written, received: %v", s, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package metrics exports Prometheus metrics of the bridge
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/opiproject/gospdk/spdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slog"
+)
+
+// VolumeSource is implemented by servers creating SPDK bdevs
+type VolumeSource interface {
+	ManagedVolumes() []string
+}
+
+// volumeStats holds the last IO statistics of a bdev read from SPDK
+type volumeStats struct {
+	bytesRead, readOps        float64
+	bytesWritten, writeOps    float64
+	bytesUnmapped, unmapOps   float64
+	readLatency, writeLatency float64
+}
+
+// iostatCollector reports IO statistics of managed volumes from the last
+// scrape, volumes not found during the last scrape are not reported
+type iostatCollector struct {
+	descs [8]*prometheus.Desc
+
+	mu    sync.Mutex
+	stats map[string]volumeStats
+}
+
+func newIostatCollector() *iostatCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "volume", name),
+			help, []string{"volume"}, nil)
+	}
+	return &iostatCollector{
+		descs: [8]*prometheus.Desc{
+			desc("read_bytes_total", "Bytes read from the volume."),
+			desc("read_ops_total", "Read operations completed by the volume."),
+			desc("written_bytes_total", "Bytes written to the volume."),
+			desc("write_ops_total", "Write operations completed by the volume."),
+			desc("unmapped_bytes_total", "Bytes unmapped on the volume."),
+			desc("unmap_ops_total", "Unmap operations completed by the volume."),
+			desc("read_latency_seconds_total", "Time spent by read operations of the volume."),
+			desc("write_latency_seconds_total", "Time spent by write operations of the volume."),
+		},
+		stats: make(map[string]volumeStats),
+	}
+}
+
+func (c *iostatCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func (c *iostatCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for volume, s := range c.stats {
+		values := [8]float64{
+			s.bytesRead, s.readOps, s.bytesWritten, s.writeOps,
+			s.bytesUnmapped, s.unmapOps, s.readLatency, s.writeLatency,
+		}
+		for i, desc := range c.descs {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, values[i], volume)
+		}
+	}
+}
+
+// ScrapeIostat reads IO statistics of volumes managed by sources from SPDK
+// every interval until ctx is done
+func (m *Metrics) ScrapeIostat(ctx context.Context, rpc spdk.JSONRPC, interval time.Duration, sources ...VolumeSource) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.scrapeIostat(rpc, sources)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Metrics) scrapeIostat(rpc spdk.JSONRPC, sources []VolumeSource) {
+	volumes := make(map[string]bool)
+	for _, source := range sources {
+		for _, volume := range source.ManagedVolumes() {
+			volumes[volume] = true
+		}
+	}
+	names := make([]string, 0, len(volumes))
+	for volume := range volumes {
+		names = append(names, volume)
+	}
+	sort.Strings(names)
+
+	stats := make(map[string]volumeStats, len(names))
+	for _, name := range names {
+		var result spdk.BdevGetIostatResult
+		err := rpc.Call("bdev_get_iostat", &spdk.BdevGetIostatParams{Name: name}, &result)
+		if err != nil {
+			slog.Error("Unable to scrape IO statistics", "volume", name, "err", err)
+			continue
+		}
+		if len(result.Bdevs) != 1 {
+			slog.Error("Expected IO statistics of one bdev", "volume", name, "bdevs", len(result.Bdevs))
+			continue
+		}
+		bdev := result.Bdevs[0]
+		ticks := func(t int) float64 {
+			if result.TickRate == 0 {
+				return 0
+			}
+			return float64(t) / float64(result.TickRate)
+		}
+		stats[name] = volumeStats{
+			bytesRead:     float64(bdev.BytesRead),
+			readOps:       float64(bdev.NumReadOps),
+			bytesWritten:  float64(bdev.BytesWritten),
+			writeOps:      float64(bdev.NumWriteOps),
+			bytesUnmapped: float64(bdev.BytesUnmapped),
+			unmapOps:      float64(bdev.NumUnmapOps),
+			readLatency:   ticks(bdev.ReadLatencyTicks),
+			writeLatency:  ticks(bdev.WriteLatencyTicks),
+		}
+	}
+
+	m.iostat.mu.Lock()
+	defer m.iostat.mu.Unlock()
+	m.iostat.stats = stats
+}
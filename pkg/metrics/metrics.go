@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package metrics exports Prometheus metrics of the bridge
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/opiproject/gospdk/spdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "opi_bridge"
+
+// Metrics keeps metrics of gRPC requests served by the bridge, SPDK and
+// QMP calls made by it and resources it manages in its own registry
+type Metrics struct {
+	registry *prometheus.Registry
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	spdkDuration *prometheus.HistogramVec
+	spdkFailures *prometheus.CounterVec
+	qmpDuration  *prometheus.HistogramVec
+	qmpFailures  *prometheus.CounterVec
+	resources    *resourceCollector
+	iostat       *iostatCollector
+}
+
+// New creates Metrics with all bridge metrics registered
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Time taken to handle gRPC requests, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		spdkDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "spdk_call_duration_seconds",
+			Help:      "Time taken by SPDK JSON-RPC calls, by SPDK method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		spdkFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spdk_call_failures_total",
+			Help:      "Number of failed SPDK JSON-RPC calls, by SPDK method.",
+		}, []string{"method"}),
+		qmpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "qmp_command_duration_seconds",
+			Help:      "Time taken by QMP commands sent to QEMU, by command.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command"}),
+		qmpFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "qmp_command_failures_total",
+			Help:      "Number of failed QMP commands sent to QEMU, by command.",
+		}, []string{"command"}),
+		resources: newResourceCollector(),
+		iostat:    newIostatCollector(),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcRequests,
+		m.grpcDuration,
+		m.spdkDuration,
+		m.spdkFailures,
+		m.qmpDuration,
+		m.qmpFailures,
+		m.resources,
+		m.iostat,
+	)
+	return m
+}
+
+// Handler returns HTTP handler serving metrics in Prometheus format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// UnaryServerInterceptor returns gRPC interceptor counting requests
+// by method and status code and observing their latency
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.grpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		m.grpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
+
+// InstrumentSpdk wraps rpc, so latency and failures of its calls are observed
+func (m *Metrics) InstrumentSpdk(rpc spdk.JSONRPC) spdk.JSONRPC {
+	return &instrumentedJSONRPC{JSONRPC: rpc, metrics: m}
+}
+
+type instrumentedJSONRPC struct {
+	spdk.JSONRPC
+	metrics *Metrics
+}
+
+func (r *instrumentedJSONRPC) Call(method string, args, result interface{}) error {
+	start := time.Now()
+	err := r.JSONRPC.Call(method, args, result)
+	r.metrics.spdkDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		r.metrics.spdkFailures.WithLabelValues(method).Inc()
+	}
+	return err
+}
+
+// ObserveQmpCommand records latency and failure of a QMP command
+func (m *Metrics) ObserveQmpCommand(command string, duration time.Duration, err error) {
+	m.qmpDuration.WithLabelValues(command).Observe(duration.Seconds())
+	if err != nil {
+		m.qmpFailures.WithLabelValues(command).Inc()
+	}
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package metrics exports Prometheus metrics of the bridge
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+type testResources map[string]int
+
+func (r testResources) ResourceCounts() map[string]int {
+	return r
+}
+
+type testVolumes []string
+
+func (v testVolumes) ManagedVolumes() []string {
+	return v
+}
+
+func TestMetrics_UnaryServerInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code string
+	}{
+		"ok": {
+			err:  nil,
+			code: codes.OK.String(),
+		},
+		"not found": {
+			err:  status.Error(codes.NotFound, "unable to find key"),
+			code: codes.NotFound.String(),
+		},
+		"not a status": {
+			err:  errors.New("failure"),
+			code: codes.Unknown.String(),
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			m := New()
+			interceptor := m.UnaryServerInterceptor()
+			info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.Test/Get"}
+
+			_, err := interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			count := testutil.ToFloat64(m.grpcRequests.WithLabelValues(info.FullMethod, tt.code))
+			if count != 1 {
+				t.Errorf("Expected 1 request with code %v, received: %v", tt.code, count)
+			}
+			if n := testutil.CollectAndCount(m.grpcDuration); n != 1 {
+				t.Errorf("Expected latency of 1 method, received: %v", n)
+			}
+		})
+	}
+}
+
+func TestMetrics_InstrumentSpdk(t *testing.T) {
+	m := New()
+	rpc := m.InstrumentSpdk(server.NewTestSpdkStub(map[string]string{
+		"bdev_get_bdevs": `[]`,
+	}))
+
+	var result interface{}
+	for i := 0; i < 2; i++ {
+		if err := rpc.Call("bdev_get_bdevs", nil, &result); err != nil {
+			t.Errorf("Expected no error, received: %v", err)
+		}
+	}
+	if err := rpc.Call("bdev_aio_create", nil, &result); err == nil {
+		t.Error("Expected error of method without response")
+	}
+
+	if n := testutil.ToFloat64(m.spdkFailures.WithLabelValues("bdev_get_bdevs")); n != 0 {
+		t.Errorf("Expected no bdev_get_bdevs failures, received: %v", n)
+	}
+	if n := testutil.ToFloat64(m.spdkFailures.WithLabelValues("bdev_aio_create")); n != 1 {
+		t.Errorf("Expected 1 bdev_aio_create failure, received: %v", n)
+	}
+	if n := testutil.CollectAndCount(m.spdkDuration); n != 2 {
+		t.Errorf("Expected latency of 2 methods, received: %v", n)
+	}
+}
+
+func TestMetrics_ObserveQmpCommand(t *testing.T) {
+	m := New()
+	m.ObserveQmpCommand("device_add", time.Millisecond, nil)
+	m.ObserveQmpCommand("device_del", time.Millisecond, errors.New("no device"))
+
+	if n := testutil.ToFloat64(m.qmpFailures.WithLabelValues("device_add")); n != 0 {
+		t.Errorf("Expected no device_add failures, received: %v", n)
+	}
+	if n := testutil.ToFloat64(m.qmpFailures.WithLabelValues("device_del")); n != 1 {
+		t.Errorf("Expected 1 device_del failure, received: %v", n)
+	}
+	if n := testutil.CollectAndCount(m.qmpDuration); n != 2 {
+		t.Errorf("Expected latency of 2 commands, received: %v", n)
+	}
+}
+
+func TestMetrics_Resources(t *testing.T) {
+	m := New()
+	m.AddResourceCounters(testResources{"aio_controller": 2, "null_debug": 0}, testResources{"qos_volume": 1})
+
+	expected := `
+# HELP opi_bridge_resources Number of resources kept by the bridge, by kind.
+# TYPE opi_bridge_resources gauge
+opi_bridge_resources{kind="aio_controller"} 2
+opi_bridge_resources{kind="null_debug"} 0
+opi_bridge_resources{kind="qos_volume"} 1
+`
+	if err := testutil.CollectAndCompare(m.resources, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMetrics_ScrapeIostat(t *testing.T) {
+	tests := map[string]struct {
+		response string
+		volumes  []string
+		expected int
+	}{
+		"scraped": {
+			response: `{"tick_rate":1000,"ticks":1,"bdevs":[{"name":"Malloc0","bytes_read":4096,"num_read_ops":1,"read_latency_ticks":500}]}`,
+			volumes:  []string{"Malloc0", "Malloc0"},
+			expected: 1,
+		},
+		"no volumes": {
+			response: `{"tick_rate":1000,"ticks":1,"bdevs":[]}`,
+			volumes:  []string{},
+			expected: 0,
+		},
+		"volume missing in SPDK": {
+			response: `{"tick_rate":1000,"ticks":1,"bdevs":[]}`,
+			volumes:  []string{"Malloc0"},
+			expected: 0,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			m := New()
+			m.iostat.stats["stale"] = volumeStats{}
+			stub := server.NewTestSpdkStub(map[string]string{"bdev_get_iostat": tt.response})
+
+			m.scrapeIostat(stub, []VolumeSource{testVolumes(tt.volumes)})
+
+			if n := testutil.CollectAndCount(m.iostat, "opi_bridge_volume_read_bytes_total"); n != tt.expected {
+				t.Errorf("Expected statistics of %v volumes, received: %v", tt.expected, n)
+			}
+			if tt.expected == 0 {
+				return
+			}
+			if stats := m.iostat.stats["Malloc0"]; stats.bytesRead != 4096 || stats.readLatency != 0.5 {
+				t.Errorf("Expected 4096 bytes read in 0.5s, received: %+v", stats)
+			}
+			if calls := stub.Calls("bdev_get_iostat"); calls != 1 {
+				t.Errorf("Expected volume scraped once, received: %v", calls)
+			}
+		})
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.ObserveQmpCommand("device_add", time.Millisecond, nil)
+	recorder := httptest.NewRecorder()
+
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !strings.Contains(recorder.Body.String(), "opi_bridge_qmp_command_duration_seconds_count") {
+		t.Errorf("Expected QMP latency exported, received: %v", recorder.Body.String())
+	}
+}
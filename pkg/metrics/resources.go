@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package metrics exports Prometheus metrics of the bridge
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ResourceCounter is implemented by servers keeping OPI resources
+type ResourceCounter interface {
+	ResourceCounts() map[string]int
+}
+
+// resourceCollector reports number of resources of each kind
+// kept by servers at the time of collection
+type resourceCollector struct {
+	desc *prometheus.Desc
+
+	mu       sync.Mutex
+	counters []ResourceCounter
+}
+
+func newResourceCollector() *resourceCollector {
+	return &resourceCollector{
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "resources"),
+			"Number of resources kept by the bridge, by kind.", []string{"kind"}, nil),
+	}
+}
+
+// AddResourceCounters makes resources of counters reported
+func (m *Metrics) AddResourceCounters(counters ...ResourceCounter) {
+	m.resources.mu.Lock()
+	defer m.resources.mu.Unlock()
+	m.resources.counters = append(m.resources.counters, counters...)
+}
+
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, counter := range c.counters {
+		for kind, count := range counter.ResourceCounts() {
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), kind)
+		}
+	}
+}
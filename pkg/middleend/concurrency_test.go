@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+const concurrentWorkers = 32
+
+func newConcurrentTestSpdkStub() *server.TestSpdkStub {
+	stub := server.NewTestSpdkStub(map[string]string{
+		"accel_crypto_key_create":  `true`,
+		"accel_crypto_key_destroy": `true`,
+		"bdev_crypto_create":       `"mytest"`,
+		"bdev_crypto_delete":       `true`,
+		"bdev_get_bdevs":           `[{"name":"mytest","block_size":512,"num_blocks":64}]`,
+		"bdev_get_iostat":          `{"tick_rate":3300000000,"ticks":1,"bdevs":[{"name":"mytest","bytes_read":1,"num_read_ops":1}]}`,
+		"bdev_set_qos_limit":       `true`,
+	})
+	stub.Delay = time.Millisecond
+	return stub
+}
+
+// checkConcurrentError fails on errors which cannot be explained
+// by other requests running in parallel on the same resources
+func checkConcurrentError(t *testing.T, method string, err error) {
+	switch status.Code(err) {
+	case codes.OK, codes.NotFound:
+	default:
+		t.Errorf("%v: unexpected error: %v", method, err)
+	}
+}
+
+func TestMiddleEnd_ConcurrentRequests(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	testEnv.opiSpdkServer.rpc = newConcurrentTestSpdkStub()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// few IDs only, so workers contend on the same resources
+			id := fmt.Sprintf("volume-%d", i%4)
+			name := server.ResourceIDToVolumeName(id)
+			volume := &pb.EncryptedVolume{
+				VolumeId: &pc.ObjectKey{Value: "Malloc1"},
+				Cipher:   pb.EncryptionType_ENCRYPTION_TYPE_AES_XTS_128,
+				Key:      []byte("0123456789abcdef0123456789abcdef"),
+			}
+
+			_, err := testEnv.client.CreateEncryptedVolume(testEnv.ctx, &pb.CreateEncryptedVolumeRequest{
+				EncryptedVolumeId: id, EncryptedVolume: volume})
+			checkConcurrentError(t, "CreateEncryptedVolume", err)
+			_, err = testEnv.client.GetEncryptedVolume(testEnv.ctx, &pb.GetEncryptedVolumeRequest{Name: name})
+			checkConcurrentError(t, "GetEncryptedVolume", err)
+			_, err = testEnv.client.EncryptedVolumeStats(testEnv.ctx, &pb.EncryptedVolumeStatsRequest{EncryptedVolumeId: &pc.ObjectKey{Value: name}})
+			checkConcurrentError(t, "EncryptedVolumeStats", err)
+			volume.Name = name
+			_, err = testEnv.client.UpdateEncryptedVolume(testEnv.ctx, &pb.UpdateEncryptedVolumeRequest{EncryptedVolume: volume})
+			checkConcurrentError(t, "UpdateEncryptedVolume", err)
+			list, err := testEnv.client.ListEncryptedVolumes(testEnv.ctx, &pb.ListEncryptedVolumesRequest{Parent: "todo", PageSize: 1})
+			checkConcurrentError(t, "ListEncryptedVolumes", err)
+			_, err = testEnv.client.ListEncryptedVolumes(testEnv.ctx, &pb.ListEncryptedVolumesRequest{Parent: "todo", PageToken: list.GetNextPageToken()})
+			checkConcurrentError(t, "ListEncryptedVolumes", err)
+			_, err = testEnv.client.DeleteEncryptedVolume(testEnv.ctx, &pb.DeleteEncryptedVolumeRequest{Name: name, AllowMissing: true})
+			checkConcurrentError(t, "DeleteEncryptedVolume", err)
+
+			qosID := fmt.Sprintf("qos-volume-%d", i%4)
+			qosName := server.ResourceIDToVolumeName(qosID)
+			_, err = testEnv.client.CreateQosVolume(testEnv.ctx, &pb.CreateQosVolumeRequest{
+				QosVolumeId: qosID,
+				QosVolume: &pb.QosVolume{
+					VolumeId: &pc.ObjectKey{Value: "volume-42"},
+					MaxLimit: &pb.QosLimit{RwBandwidthMbs: 1},
+				}})
+			checkConcurrentError(t, "CreateQosVolume", err)
+			_, err = testEnv.client.GetQosVolume(testEnv.ctx, &pb.GetQosVolumeRequest{Name: qosName})
+			checkConcurrentError(t, "GetQosVolume", err)
+			_, err = testEnv.client.QosVolumeStats(testEnv.ctx, &pb.QosVolumeStatsRequest{VolumeId: &pc.ObjectKey{Value: qosName}})
+			checkConcurrentError(t, "QosVolumeStats", err)
+			_, err = testEnv.client.UpdateQosVolume(testEnv.ctx, &pb.UpdateQosVolumeRequest{
+				QosVolume: &pb.QosVolume{
+					Name:     qosName,
+					VolumeId: &pc.ObjectKey{Value: "volume-42"},
+					MaxLimit: &pb.QosLimit{RwBandwidthMbs: 2},
+				}})
+			checkConcurrentError(t, "UpdateQosVolume", err)
+			_, err = testEnv.client.ListQosVolumes(testEnv.ctx, &pb.ListQosVolumesRequest{Parent: "todo", PageSize: 1})
+			checkConcurrentError(t, "ListQosVolumes", err)
+			_, err = testEnv.client.DeleteQosVolume(testEnv.ctx, &pb.DeleteQosVolumeRequest{Name: qosName, AllowMissing: true})
+			checkConcurrentError(t, "DeleteQosVolume", err)
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := testEnv.opiSpdkServer.Reconcile(server.ReconcileReport); err != nil {
+		t.Errorf("expected no error, received: %v", err)
+	}
+}
+
+func TestMiddleEnd_ConcurrentCreateSameID(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+	stub := newConcurrentTestSpdkStub()
+	testEnv.opiSpdkServer.rpc = stub
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := testEnv.client.CreateEncryptedVolume(testEnv.ctx, &pb.CreateEncryptedVolumeRequest{
+				EncryptedVolumeId: "mytest",
+				EncryptedVolume: &pb.EncryptedVolume{
+					VolumeId: &pc.ObjectKey{Value: "Malloc1"},
+					Cipher:   pb.EncryptionType_ENCRYPTION_TYPE_AES_XTS_128,
+					Key:      []byte("0123456789abcdef0123456789abcdef"),
+				}})
+			if err != nil {
+				t.Errorf("CreateEncryptedVolume: expected no error, received: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, method := range []string{"accel_crypto_key_create", "bdev_crypto_create"} {
+		if calls := stub.Calls(method); calls != 1 {
+			t.Errorf("expected %v to reach SPDK once, received: %v", method, calls)
+		}
+	}
+}
@@ -9,14 +9,13 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"log"
 	"path"
-	"sort"
 
-	"github.com/google/uuid"
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/resourceid"
@@ -26,18 +25,12 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortEncryptedVolumes(volumes []*pb.EncryptedVolume) {
-	sort.Slice(volumes, func(i int, j int) bool {
-		return volumes[i].Name < volumes[j].Name
-	})
-}
-
 // CreateEncryptedVolume creates an encrypted volume
-func (s *Server) CreateEncryptedVolume(_ context.Context, in *pb.CreateEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
-	log.Printf("CreateEncryptedVolume: Received from client: %v", in)
+func (s *Server) CreateEncryptedVolume(ctx context.Context, in *pb.CreateEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// see https://google.aip.dev/133#user-specified-ids
@@ -45,38 +38,52 @@ func (s *Server) CreateEncryptedVolume(_ context.Context, in *pb.CreateEncrypted
 	if in.EncryptedVolumeId != "" {
 		err := resourceid.ValidateUserSettable(in.EncryptedVolumeId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.EncryptedVolumeId, in.EncryptedVolume.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.EncryptedVolumeId, "name", in.EncryptedVolume.Name)
 		resourceID = in.EncryptedVolumeId
 	}
 	in.EncryptedVolume.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.EncryptedVolume.Name)
+	defer unlock()
 
 	if err := s.verifyEncryptedVolume(in.EncryptedVolume); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
 	volume, ok := s.volumes.encVolumes[in.EncryptedVolume.Name]
+	s.mu.RUnlock()
 	if ok {
-		log.Printf("Already existing EncryptedVolume with id %v", in.EncryptedVolume.Name)
+		slog.InfoContext(ctx, "Already existing EncryptedVolume", "name", in.EncryptedVolume.Name)
 		return volume, nil
 	}
+	if err := s.VolumeIndex.ResolveKey(in.EncryptedVolume.VolumeId); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.EncryptedVolume.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
 
 	// first create a key
 	params1 := s.getAccelCryptoKeyCreateParams(in.EncryptedVolume)
 	var result1 spdk.AccelCryptoKeyCreateResult
-	err1 := s.rpc.Call("accel_crypto_key_create", &params1, &result1)
+	err1 := server.Call(ctx, s.rpc, "accel_crypto_key_create", &params1, &result1)
 	if err1 != nil {
-		log.Printf("error: %v", err1)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "accel_crypto_key_create", "err", err1)
 		return nil, err1
 	}
-	log.Printf("Received from SPDK: %v", result1)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result1)
 	if !result1 {
-		msg := fmt.Sprintf("Could not create Crypto Key: %s", string(in.EncryptedVolume.Key))
-		log.Print(msg)
+		msg := fmt.Sprintf("Could not create Crypto Key: %v", params1.Name)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	// create bdev now
@@ -86,60 +93,76 @@ func (s *Server) CreateEncryptedVolume(_ context.Context, in *pb.CreateEncrypted
 		KeyName:      resourceID,
 	}
 	var result spdk.BdevCryptoCreateResult
-	err := s.rpc.Call("bdev_crypto_create", &params, &result)
+	err = server.Call(ctx, s.rpc, "bdev_crypto_create", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_crypto_create", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if result == "" {
 		msg := fmt.Sprintf("Could not create Crypto Dev: %s", params.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	response := server.ProtoClone(in.EncryptedVolume)
+	if err := store.Save(s.store, in.EncryptedVolume.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.volumes.encVolumes[in.EncryptedVolume.Name] = response
-	log.Printf("CreateEncryptedVolume: Sending to client: %v", response)
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // DeleteEncryptedVolume deletes an encrypted volume
-func (s *Server) DeleteEncryptedVolume(_ context.Context, in *pb.DeleteEncryptedVolumeRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteEncryptedVolume: Received from client: %v", in)
+func (s *Server) DeleteEncryptedVolume(ctx context.Context, in *pb.DeleteEncryptedVolumeRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.volumes.encVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, resourceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
 	bdevCryptoDeleteParams := spdk.BdevCryptoDeleteParams{
 		Name: resourceID,
 	}
 	var bdevCryptoDeleteResult spdk.BdevCryptoDeleteResult
-	err := s.rpc.Call("bdev_crypto_delete", &bdevCryptoDeleteParams, &bdevCryptoDeleteResult)
+	err = server.Call(ctx, s.rpc, "bdev_crypto_delete", &bdevCryptoDeleteParams, &bdevCryptoDeleteResult)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_crypto_delete", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", bdevCryptoDeleteResult)
+	slog.DebugContext(ctx, "Received from SPDK", "result", bdevCryptoDeleteResult)
 	if !bdevCryptoDeleteResult {
 		msg := fmt.Sprintf("Could not delete Crypto: %s", bdevCryptoDeleteParams.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 
@@ -147,55 +170,79 @@ func (s *Server) DeleteEncryptedVolume(_ context.Context, in *pb.DeleteEncrypted
 		KeyName: resourceID,
 	}
 	var keyDestroyResult spdk.AccelCryptoKeyDestroyResult
-	err = s.rpc.Call("accel_crypto_key_destroy", &keyDestroyParams, &keyDestroyResult)
+	err = server.Call(ctx, s.rpc, "accel_crypto_key_destroy", &keyDestroyParams, &keyDestroyResult)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "accel_crypto_key_destroy", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", keyDestroyResult)
+	slog.DebugContext(ctx, "Received from SPDK", "result", keyDestroyResult)
 	if !keyDestroyResult {
 		msg := fmt.Sprintf("Could not destroy Crypto Key: %v", keyDestroyParams.KeyName)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.volumes.encVolumes, volume.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateEncryptedVolume updates an encrypted volume
-func (s *Server) UpdateEncryptedVolume(_ context.Context, in *pb.UpdateEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
-	log.Printf("UpdateEncryptedVolume: Received from client: %v", in)
+func (s *Server) UpdateEncryptedVolume(ctx context.Context, in *pb.UpdateEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.EncryptedVolume.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.EncryptedVolume.Name)
+	defer unlock()
 	// fetch object from the database
 	if err := s.verifyEncryptedVolume(in.EncryptedVolume); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err := s.VolumeIndex.ResolveKey(in.EncryptedVolume.VolumeId); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.EncryptedVolume.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
 	resourceID := path.Base(in.EncryptedVolume.Name)
+	released, err := s.Consumers.ReleaseBdev(ctx, in.EncryptedVolume.Name, resourceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
 	// first delete old bdev
 	params1 := spdk.BdevCryptoDeleteParams{
 		Name: resourceID,
 	}
 	var result1 spdk.BdevCryptoDeleteResult
-	err1 := s.rpc.Call("bdev_crypto_delete", &params1, &result1)
+	err1 := server.Call(ctx, s.rpc, "bdev_crypto_delete", &params1, &result1)
 	if err1 != nil {
-		log.Printf("error: %v", err1)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_crypto_delete", "err", err1)
 		return nil, err1
 	}
-	log.Printf("Received from SPDK: %v", result1)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result1)
 	if !result1 {
 		msg := fmt.Sprintf("Could not delete Crypto: %s", params1.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	// now delete a key
@@ -203,28 +250,28 @@ func (s *Server) UpdateEncryptedVolume(_ context.Context, in *pb.UpdateEncrypted
 		KeyName: resourceID,
 	}
 	var result0 spdk.AccelCryptoKeyDestroyResult
-	err0 := s.rpc.Call("accel_crypto_key_destroy", &params0, &result0)
+	err0 := server.Call(ctx, s.rpc, "accel_crypto_key_destroy", &params0, &result0)
 	if err0 != nil {
-		log.Printf("error: %v", err0)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "accel_crypto_key_destroy", "err", err0)
 		return nil, err0
 	}
-	log.Printf("Received from SPDK: %v", result0)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result0)
 	if !result0 {
 		msg := fmt.Sprintf("Could not destroy Crypto Key: %v", params0.KeyName)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	params2 := s.getAccelCryptoKeyCreateParams(in.EncryptedVolume)
 	var result2 spdk.AccelCryptoKeyCreateResult
-	err2 := s.rpc.Call("accel_crypto_key_create", &params2, &result2)
+	err2 := server.Call(ctx, s.rpc, "accel_crypto_key_create", &params2, &result2)
 	if err2 != nil {
-		log.Printf("error: %v", err2)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "accel_crypto_key_create", "err", err2)
 		return nil, err2
 	}
-	log.Printf("Received from SPDK: %v", result2)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result2)
 	if !result2 {
-		msg := fmt.Sprintf("Could not create Crypto Key: %s", string(in.EncryptedVolume.Key))
-		log.Print(msg)
+		msg := fmt.Sprintf("Could not create Crypto Key: %v", params2.Name)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	// create bdev now
@@ -234,78 +281,80 @@ func (s *Server) UpdateEncryptedVolume(_ context.Context, in *pb.UpdateEncrypted
 		KeyName:      resourceID,
 	}
 	var result3 spdk.BdevCryptoCreateResult
-	err3 := s.rpc.Call("bdev_crypto_create", &params3, &result3)
+	err3 := server.Call(ctx, s.rpc, "bdev_crypto_create", &params3, &result3)
 	if err3 != nil {
-		log.Printf("error: %v", err3)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_crypto_create", "err", err3)
 		return nil, err3
 	}
-	log.Printf("Received from SPDK: %v", result3)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result3)
 	if result3 == "" {
 		msg := fmt.Sprintf("Could not create Crypto Dev: %s", params3.Name)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	// return result
 	response := server.ProtoClone(in.EncryptedVolume)
+	if err := store.Save(s.store, in.EncryptedVolume.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.volumes.encVolumes[in.EncryptedVolume.Name] = response
+	s.mu.Unlock()
 	return response, nil
 }
 
 // ListEncryptedVolumes lists encrypted volumes
-func (s *Server) ListEncryptedVolumes(_ context.Context, in *pb.ListEncryptedVolumesRequest) (*pb.ListEncryptedVolumesResponse, error) {
-	log.Printf("ListEncryptedVolumes: Received from client: %v", in)
+func (s *Server) ListEncryptedVolumes(ctx context.Context, in *pb.ListEncryptedVolumesRequest) (*pb.ListEncryptedVolumesResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, perr := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &pb.EncryptedVolume{})
 	if perr != nil {
-		log.Printf("error: %v", perr)
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
 		return nil, perr
 	}
 	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", nil, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(result), offset, size)
-	result, hasMoreElements := server.LimitPagination(result, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
-	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	Blobarray := make([]*pb.EncryptedVolume, len(result))
 	for i := range result {
 		r := &result[i]
 		Blobarray[i] = &pb.EncryptedVolume{Name: r.Name}
 	}
-	sortEncryptedVolumes(Blobarray)
+	Blobarray, token := server.Paginate(opts, Blobarray, (*pb.EncryptedVolume).GetName)
 
 	return &pb.ListEncryptedVolumesResponse{EncryptedVolumes: Blobarray, NextPageToken: token}, nil
 }
 
 // GetEncryptedVolume gets an encrypted volume
-func (s *Server) GetEncryptedVolume(_ context.Context, in *pb.GetEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
-	log.Printf("GetEncryptedVolume: Received from client: %v", in)
+func (s *Server) GetEncryptedVolume(ctx context.Context, in *pb.GetEncryptedVolumeRequest) (*pb.EncryptedVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.volumes.encVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
@@ -313,38 +362,40 @@ func (s *Server) GetEncryptedVolume(_ context.Context, in *pb.GetEncryptedVolume
 		Name: resourceID,
 	}
 	var result []spdk.BdevGetBdevsResult
-	err := s.rpc.Call("bdev_get_bdevs", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.EncryptedVolume{Name: result[0].Name}, nil
 }
 
 // EncryptedVolumeStats gets an encrypted volume stats
-func (s *Server) EncryptedVolumeStats(_ context.Context, in *pb.EncryptedVolumeStatsRequest) (*pb.EncryptedVolumeStatsResponse, error) {
-	log.Printf("EncryptedVolumeStats: Received from client: %v", in)
+func (s *Server) EncryptedVolumeStats(ctx context.Context, in *pb.EncryptedVolumeStatsRequest) (*pb.EncryptedVolumeStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.EncryptedVolumeId.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.volumes.encVolumes[in.EncryptedVolumeId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.EncryptedVolumeId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	resourceID := path.Base(volume.Name)
@@ -353,15 +404,15 @@ func (s *Server) EncryptedVolumeStats(_ context.Context, in *pb.EncryptedVolumeS
 	}
 	// See https://mholt.github.io/json-to-go/
 	var result spdk.BdevGetIostatResult
-	err := s.rpc.Call("bdev_get_iostat", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result.Bdevs) != 1 {
 		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
 	return &pb.EncryptedVolumeStatsResponse{Stats: &pb.VolumeStats{
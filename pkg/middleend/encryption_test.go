@@ -6,14 +6,22 @@
 package middleend
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/logging"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -36,7 +44,7 @@ func TestMiddleEnd_CreateEncryptedVolume(t *testing.T) {
 			nil,
 			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.InvalidArgument,
-			fmt.Sprintf("Could not create Crypto Key: %v", "0123456789abcdef0123456789abcdef"),
+			fmt.Sprintf("Could not create Crypto Key: %v", encryptedVolumeID),
 			false,
 		},
 		"valid request with invalid marshal SPDK response": {
@@ -370,7 +378,7 @@ func TestMiddleEnd_UpdateEncryptedVolume(t *testing.T) {
 			nil,
 			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":true}`, `{"id":%d,"error":{"code":0,"message":""},"result":false}`},
 			codes.InvalidArgument,
-			fmt.Sprintf("Could not create Crypto Key: %v", "0123456789abcdef0123456789abcdef"),
+			fmt.Sprintf("Could not create Crypto Key: %v", encryptedVolumeID),
 			false,
 		},
 		"bdev delete ok ; key delete ok ; key create empty": {
@@ -741,9 +749,11 @@ func TestMiddleEnd_ListEncryptedVolumes(t *testing.T) {
 			testEnv := createTestEnvironment(tt.spdk)
 			defer testEnv.Close()
 
-			testEnv.opiSpdkServer.Pagination["existing-pagination-token"] = 1
-
 			request := &pb.ListEncryptedVolumesRequest{Parent: tt.in, PageSize: tt.size, PageToken: tt.token}
+			if tt.token == "existing-pagination-token" {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					&pb.EncryptedVolume{Name: "Malloc0"}, "Malloc0")
+			}
 			response, err := testEnv.client.ListEncryptedVolumes(testEnv.ctx, request)
 
 			if !server.EqualProtoSlices(response.GetEncryptedVolumes(), tt.out) {
@@ -1098,3 +1108,167 @@ func TestMiddleEnd_DeleteEncryptedVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestMiddleEnd_DeleteUsedEncryptedVolume(t *testing.T) {
+	qosVolume := server.ProtoClone(testQosVolume)
+	qosVolume.Name = testQosVolumeName
+	qosVolume.VolumeId = &pc.ObjectKey{Value: encryptedVolumeID}
+	tests := map[string]struct {
+		cascade string
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"used by qos volume": {
+			"",
+			[]string{},
+			codes.FailedPrecondition,
+			fmt.Sprintf("%v is used by [QosVolume %v]", encryptedVolumeName, testQosVolumeName),
+			true,
+		},
+		"cascade": {
+			"true",
+			[]string{
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+			},
+			codes.OK,
+			"",
+			false,
+		},
+		"invalid cascade": {
+			"yes please",
+			[]string{},
+			codes.InvalidArgument,
+			fmt.Sprintf("invalid %v: strconv.ParseBool: parsing %q: invalid syntax", server.CascadeMetadataKey, "yes please"),
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.Consumers = &server.VolumeConsumers{}
+			testEnv.opiSpdkServer.Consumers.Register(testEnv.opiSpdkServer.VolumeConsumers()...)
+			volume := server.ProtoClone(&encryptedVolume)
+			volume.Name = encryptedVolumeName
+			testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName] = volume
+			testEnv.opiSpdkServer.volumes.qosVolumes[testQosVolumeName] = server.ProtoClone(qosVolume)
+
+			ctx := testEnv.ctx
+			if tt.cascade != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, server.CascadeMetadataKey, tt.cascade)
+			}
+			request := &pb.DeleteEncryptedVolumeRequest{Name: encryptedVolumeName}
+			_, err := testEnv.client.DeleteEncryptedVolume(ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if _, ok := testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName]; ok != tt.exist {
+				t.Error("encrypted volume exists: expected", tt.exist, "received", ok)
+			}
+			if _, ok := testEnv.opiSpdkServer.volumes.qosVolumes[testQosVolumeName]; ok != tt.exist {
+				t.Error("qos volume exists: expected", tt.exist, "received", ok)
+			}
+		})
+	}
+}
+
+func TestMiddleEnd_CreateEncryptedVolumeOnReleasedVolume(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+
+	testEnv.opiSpdkServer.Consumers = &server.VolumeConsumers{}
+	testEnv.opiSpdkServer.Consumers.Register(testEnv.opiSpdkServer.VolumeConsumers()...)
+	done, err := testEnv.opiSpdkServer.Consumers.ReleaseBdev(testEnv.ctx, "aio0", encryptedVolume.VolumeId.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	request := &pb.CreateEncryptedVolumeRequest{EncryptedVolume: &encryptedVolume, EncryptedVolumeId: encryptedVolumeID}
+	_, err = testEnv.client.CreateEncryptedVolume(testEnv.ctx, request)
+
+	expectedMsg := fmt.Sprintf("volume %v of aio0 is being deleted", encryptedVolume.VolumeId.Value)
+	if er, ok := status.FromError(err); ok {
+		if er.Code() != codes.FailedPrecondition {
+			t.Error("error code: expected", codes.FailedPrecondition, "received", er.Code())
+		}
+		if er.Message() != expectedMsg {
+			t.Error("error message: expected", expectedMsg, "received", er.Message())
+		}
+	} else {
+		t.Error("expected grpc error status")
+	}
+	if _, ok := testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName]; ok {
+		t.Error("expected no encrypted volume to be created")
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes of loggers
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestMiddleEnd_CreateEncryptedVolumeKeyNotLogged(t *testing.T) {
+	var out syncBuffer
+	logger, err := logging.New(logging.Options{Level: slog.LevelDebug, Format: logging.FormatText, Writer: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultLogger, writer, flags := slog.Default(), log.Writer(), log.Flags()
+	slog.SetDefault(logger)
+	log.SetOutput(logging.StdLogWriter(logger))
+	defer func() {
+		slog.SetDefault(defaultLogger)
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+	}()
+
+	testEnv := createTestEnvironment([]string{
+		`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+		`{"id":%d,"error":{"code":0,"message":""},"result":"crypto-test"}`,
+	})
+	defer testEnv.Close()
+	request := &pb.CreateEncryptedVolumeRequest{EncryptedVolume: server.ProtoClone(&encryptedVolume), EncryptedVolumeId: encryptedVolumeID}
+	if _, err := testEnv.client.CreateEncryptedVolume(testEnv.ctx, request); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "accel_crypto_key_create") {
+		t.Errorf("Expected SPDK request logged, received: %v", out.String())
+	}
+	key := encryptedVolume.Key
+	for _, secret := range []string{string(key), hex.EncodeToString(key[:len(key)/2]), hex.EncodeToString(key[len(key)/2:])} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("Expected key %v never logged, received: %v", secret, out.String())
+		}
+	}
+}
@@ -6,35 +6,200 @@
 package middleend
 
 import (
+	"context"
+	"log"
+	"path"
+	"sync"
+
 	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 )
 
 // VolumeParameters contains MiddleEnd volume related structures
 type VolumeParameters struct {
-	qosVolumes map[string]*pb.QosVolume
-	encVolumes map[string]*pb.EncryptedVolume
+	qosVolumes  map[string]*pb.QosVolume
+	encVolumes  map[string]*pb.EncryptedVolume
+	raidVolumes map[string]*px.RaidVolume
 }
 
 // Server contains middleend related OPI services
 type Server struct {
 	pb.UnimplementedMiddleendEncryptionServiceServer
 	pb.UnimplementedMiddleendQosVolumeServiceServer
+	px.UnimplementedMiddleendRaidVolumeServiceServer
 
 	rpc        spdk.JSONRPC
+	store      store.Store
 	volumes    VolumeParameters
-	Pagination map[string]int
+	Pagination *server.PageTokens
+	// Consumers is the registry of resources using volumes, shared with
+	// other services. Volumes in use cannot be deleted.
+	Consumers *server.VolumeConsumers
+	// VolumeIndex resolves names of volume resources of any service in
+	// volume_id of requests to SPDK bdevs
+	VolumeIndex *server.VolumeIndex
+
+	// mu guards resource maps, names serializes
+	// requests working with the same resource
+	mu    sync.RWMutex
+	names server.NameLocker
 }
 
 // NewServer creates initialized instance of MiddleEnd server communicating
-// with provided jsonRPC
-func NewServer(jsonRPC spdk.JSONRPC) *Server {
-	return &Server{
-		rpc: jsonRPC,
+// with provided jsonRPC. Resources kept in store are restored on creation
+// and every change is written through to it.
+func NewServer(jsonRPC spdk.JSONRPC, st store.Store) *Server {
+	if st == nil {
+		log.Panic("nil for Store is not allowed")
+	}
+	s := &Server{
+		rpc:   jsonRPC,
+		store: st,
 		volumes: VolumeParameters{
-			qosVolumes: make(map[string]*pb.QosVolume),
-			encVolumes: make(map[string]*pb.EncryptedVolume),
+			qosVolumes:  make(map[string]*pb.QosVolume),
+			encVolumes:  make(map[string]*pb.EncryptedVolume),
+			raidVolumes: make(map[string]*px.RaidVolume),
 		},
-		Pagination: make(map[string]int),
+		Pagination: server.NewPageTokens(server.DefaultPageTokenTTL, server.DefaultPageTokenLimit),
+	}
+	if err := s.restore(); err != nil {
+		log.Panicf("unable to restore middleend resources from store: %v", err)
+	}
+	return s
+}
+
+func (s *Server) restore() error {
+	if err := store.Load(s.store, s.volumes.qosVolumes); err != nil {
+		return err
+	}
+	if err := store.Load(s.store, s.volumes.encVolumes); err != nil {
+		return err
+	}
+	return store.Load(s.store, s.volumes.raidVolumes)
+}
+
+// ResourceCounts returns number of resources of each kind kept by the server
+func (s *Server) ResourceCounts() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]int{
+		"encrypted_volume": len(s.volumes.encVolumes),
+		"qos_volume":       len(s.volumes.qosVolumes),
+		"raid_volume":      len(s.volumes.raidVolumes),
+	}
+}
+
+// ManagedVolumes returns names of SPDK bdevs created by the server
+// or having limits set by it
+func (s *Server) ManagedVolumes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.volumes.encVolumes)+len(s.volumes.qosVolumes)+len(s.volumes.raidVolumes))
+	for _, volume := range s.volumes.encVolumes {
+		names = append(names, path.Base(volume.Name))
+	}
+	for _, volume := range s.volumes.qosVolumes {
+		names = append(names, volume.GetVolumeId().GetValue())
+	}
+	for _, volume := range s.volumes.raidVolumes {
+		names = append(names, path.Base(volume.Name))
+	}
+	return names
+}
+
+// VolumeConsumers returns kinds of middleend resources using volumes
+func (s *Server) VolumeConsumers() []server.VolumeConsumer {
+	return []server.VolumeConsumer{
+		{
+			Kind: "EncryptedVolume",
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				return volumeUses(s.volumes.encVolumes)
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteEncryptedVolume(ctx, &pb.DeleteEncryptedVolumeRequest{Name: name})
+				return err
+			},
+		},
+		{
+			Kind: "QosVolume",
+			Uses: func() map[string][]string {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				return volumeUses(s.volumes.qosVolumes)
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteQosVolume(ctx, &pb.DeleteQosVolumeRequest{Name: name})
+				return err
+			},
+		},
+		{
+			Kind: "RaidVolume",
+			Uses: func() map[string][]string {
+				uses := make(map[string][]string)
+				for member, raid := range s.raidMembers() {
+					uses[member] = append(uses[member], raid)
+				}
+				return uses
+			},
+			Delete: func(ctx context.Context, name string) error {
+				_, err := s.DeleteRaidVolume(ctx, &px.DeleteRaidVolumeRequest{Name: name})
+				return err
+			},
+		},
+	}
+}
+
+// VolumeProviders returns kinds of middleend resources other resources can
+// be built on. QoS volumes limit bdevs of volumes they are created on.
+func (s *Server) VolumeProviders() []server.VolumeProvider {
+	return []server.VolumeProvider{
+		{
+			Kind: "EncryptedVolume",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, ok := s.volumes.encVolumes[name]
+				return path.Base(name), ok
+			},
+		},
+		{
+			Kind: "QosVolume",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				volume, ok := s.volumes.qosVolumes[name]
+				return volume.GetVolumeId().GetValue(), ok
+			},
+		},
+		{
+			Kind: "RaidVolume",
+			Bdev: func(name string) (string, bool) {
+				s.mu.RLock()
+				defer s.mu.RUnlock()
+				_, ok := s.volumes.raidVolumes[name]
+				return path.Base(name), ok
+			},
+		},
+	}
+}
+
+// volumeUses maps names of resources to bdevs in their volume_id,
+// must be called with s.mu held
+func volumeUses[T interface {
+	comparable
+	GetName() string
+	GetVolumeId() *pc.ObjectKey
+}](volumes map[string]T) map[string][]string {
+	uses := make(map[string][]string)
+	for _, volume := range volumes {
+		bdev := volume.GetVolumeId().GetValue()
+		uses[bdev] = append(uses[bdev], volume.GetName())
 	}
+	return uses
 }
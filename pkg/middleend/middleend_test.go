@@ -18,7 +18,9 @@ import (
 	"github.com/opiproject/gospdk/spdk"
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
 )
 
 // TODO: move test infrastructure code to a separate (test/server) package to avoid duplication
@@ -26,6 +28,7 @@ import (
 type middleendClient struct {
 	pb.MiddleendEncryptionServiceClient
 	pb.MiddleendQosVolumeServiceClient
+	px.MiddleendRaidVolumeServiceClient
 }
 
 type testEnv struct {
@@ -50,7 +53,7 @@ func createTestEnvironment(spdkResponses []string) *testEnv {
 	env := &testEnv{}
 	env.testSocket = server.GenerateSocketName("middleend")
 	env.ln, env.jsonRPC = server.CreateTestSpdkServer(env.testSocket, spdkResponses)
-	env.opiSpdkServer = NewServer(env.jsonRPC)
+	env.opiSpdkServer = NewServer(env.jsonRPC, store.NewMemoryStore())
 
 	ctx := context.Background()
 	conn, err := grpc.DialContext(ctx,
@@ -66,6 +69,7 @@ func createTestEnvironment(spdkResponses []string) *testEnv {
 	env.client = &middleendClient{
 		pb.NewMiddleendEncryptionServiceClient(env.conn),
 		pb.NewMiddleendQosVolumeServiceClient(env.conn),
+		px.NewMiddleendRaidVolumeServiceClient(env.conn),
 	}
 
 	return env
@@ -76,6 +80,7 @@ func dialer(opiSpdkServer *Server) func(context.Context, string) (net.Conn, erro
 	server := grpc.NewServer()
 	pb.RegisterMiddleendEncryptionServiceServer(server, opiSpdkServer)
 	pb.RegisterMiddleendQosVolumeServiceServer(server, opiSpdkServer)
+	px.RegisterMiddleendRaidVolumeServiceServer(server, opiSpdkServer)
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
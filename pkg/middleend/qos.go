@@ -7,13 +7,12 @@ package middleend
 import (
 	"context"
 	"fmt"
-	"log"
-	"sort"
 
-	"github.com/google/uuid"
 	"github.com/opiproject/gospdk/spdk"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
 
 	"go.einride.tech/aip/fieldbehavior"
 	"go.einride.tech/aip/resourceid"
@@ -23,18 +22,17 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func sortQosVolumes(volumes []*pb.QosVolume) {
-	sort.Slice(volumes, func(i int, j int) bool {
-		return volumes[i].Name < volumes[j].Name
-	})
-}
+// qosVolumeKinds are kinds of volumes QoS volumes can be created on, QoS
+// volumes share bdevs with the volumes they limit, so they cannot be nested
+var qosVolumeKinds = []string{"AioController", "NullDebug", "MallocVolume", "Lvol", "Snapshot",
+	"NvmeRemoteNamespace", "EncryptedVolume", "RaidVolume"}
 
 // CreateQosVolume creates a QoS volume
-func (s *Server) CreateQosVolume(_ context.Context, in *pb.CreateQosVolumeRequest) (*pb.QosVolume, error) {
-	log.Printf("CreateQosVolume: Received from client: %v", in)
+func (s *Server) CreateQosVolume(ctx context.Context, in *pb.CreateQosVolumeRequest) (*pb.QosVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// see https://google.aip.dev/133#user-specified-ids
@@ -42,162 +40,209 @@ func (s *Server) CreateQosVolume(_ context.Context, in *pb.CreateQosVolumeReques
 	if in.QosVolumeId != "" {
 		err := resourceid.ValidateUserSettable(in.QosVolumeId)
 		if err != nil {
-			log.Printf("error: %v", err)
+			slog.ErrorContext(ctx, "Request failed", "err", err)
 			return nil, err
 		}
-		log.Printf("client provided the ID of a resource %v, ignoring the name field %v", in.QosVolumeId, in.QosVolume.Name)
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.QosVolumeId, "name", in.QosVolume.Name)
 		resourceID = in.QosVolumeId
 	}
 	in.QosVolume.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.QosVolume.Name)
+	defer unlock()
 
 	if err := s.verifyQosVolume(in.QosVolume); err != nil {
-		log.Println("error:", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if volume, ok := s.volumes.qosVolumes[in.QosVolume.Name]; ok {
-		log.Printf("Already existing QosVolume with name %v", in.QosVolume.Name)
+	s.mu.RLock()
+	volume, ok := s.volumes.qosVolumes[in.QosVolume.Name]
+	s.mu.RUnlock()
+	if ok {
+		slog.InfoContext(ctx, "Already existing QosVolume", "name", in.QosVolume.Name)
 		return volume, nil
 	}
+	if err := s.VolumeIndex.ResolveKey(in.QosVolume.VolumeId, qosVolumeKinds...); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.QosVolume.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
 
-	if err := s.setMaxLimit(in.QosVolume.VolumeId.Value, in.QosVolume.MaxLimit); err != nil {
+	if err := s.setMaxLimit(ctx, in.QosVolume.VolumeId.Value, in.QosVolume.MaxLimit); err != nil {
 		return nil, err
 	}
 
 	response := server.ProtoClone(in.QosVolume)
+	if err := store.Save(s.store, in.QosVolume.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.volumes.qosVolumes[in.QosVolume.Name] = response
-	log.Printf("CreateQosVolume: Sending to client: %v", response)
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
 	return response, nil
 }
 
 // DeleteQosVolume deletes a QoS volume
-func (s *Server) DeleteQosVolume(_ context.Context, in *pb.DeleteQosVolumeRequest) (*emptypb.Empty, error) {
-	log.Printf("DeleteQosVolume: Received from client: %v", in)
+func (s *Server) DeleteQosVolume(ctx context.Context, in *pb.DeleteQosVolumeRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
 	// fetch object from the database
+	s.mu.RLock()
 	qosVolume, ok := s.volumes.qosVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		if in.AllowMissing {
 			return &emptypb.Empty{}, nil
 		}
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
-	if err := s.cleanMaxLimit(qosVolume.VolumeId.Value); err != nil {
+	if err := s.cleanMaxLimit(ctx, qosVolume.VolumeId.Value); err != nil {
 		return nil, err
 	}
 
+	if err := store.Remove(s.store, in.Name, qosVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	delete(s.volumes.qosVolumes, in.Name)
+	s.mu.Unlock()
 	return &emptypb.Empty{}, nil
 }
 
 // UpdateQosVolume updates a QoS volume
-func (s *Server) UpdateQosVolume(_ context.Context, in *pb.UpdateQosVolumeRequest) (*pb.QosVolume, error) {
-	log.Printf("UpdateQosVolume: Received from client: %v", in)
+func (s *Server) UpdateQosVolume(ctx context.Context, in *pb.UpdateQosVolumeRequest) (*pb.QosVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	// fetch object from the database
 	if err := s.verifyQosVolume(in.QosVolume); err != nil {
-		log.Println("error:", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	name := in.QosVolume.Name
+	unlock := s.names.Lock(name)
+	defer unlock()
+	s.mu.RLock()
 	volume, ok := s.volumes.qosVolumes[name]
+	s.mu.RUnlock()
 	if !ok {
-		log.Printf("Non-existing QoS volume with name %v", name)
+		slog.ErrorContext(ctx, "Non-existing QoS volume", "name", name)
 		return nil, status.Errorf(codes.NotFound, "unable to find key %s", name)
 	}
+	if err := s.VolumeIndex.ResolveKey(in.QosVolume.VolumeId, qosVolumeKinds...); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unclaim, err := s.Consumers.Claim(in.QosVolume.VolumeId.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
 
 	if volume.VolumeId.Value != in.QosVolume.VolumeId.Value {
 		msg := fmt.Sprintf("Change of underlying volume %v to a new one %v is forbidden",
 			volume.VolumeId.Value, in.QosVolume.VolumeId.Value)
-		log.Println("error:", msg)
+		slog.ErrorContext(ctx, "Request failed", "err", msg)
 		return nil, status.Errorf(codes.InvalidArgument, msg)
 	}
-	log.Println("Set new max limit values")
-	if err := s.setMaxLimit(in.QosVolume.VolumeId.Value, in.QosVolume.MaxLimit); err != nil {
+	slog.DebugContext(ctx, "Set new max limit values")
+	if err := s.setMaxLimit(ctx, in.QosVolume.VolumeId.Value, in.QosVolume.MaxLimit); err != nil {
 		return nil, err
 	}
 
+	if err := store.Save(s.store, name, in.QosVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
 	s.volumes.qosVolumes[name] = in.QosVolume
+	s.mu.Unlock()
 	return in.QosVolume, nil
 }
 
 // ListQosVolumes lists QoS volumes
-func (s *Server) ListQosVolumes(_ context.Context, in *pb.ListQosVolumesRequest) (*pb.ListQosVolumesResponse, error) {
-	log.Printf("ListQosVolume: Received from client: %v", in)
+func (s *Server) ListQosVolumes(ctx context.Context, in *pb.ListQosVolumesRequest) (*pb.ListQosVolumesResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
-	size, offset, err := server.ExtractPagination(in.PageSize, in.PageToken, s.Pagination)
+	opts, err := server.ParseListOptions(ctx, in, s.Pagination, &pb.QosVolume{})
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 
 	volumes := []*pb.QosVolume{}
+	s.mu.RLock()
 	for _, qosVolume := range s.volumes.qosVolumes {
 		volumes = append(volumes, server.ProtoClone(qosVolume))
 	}
-	sortQosVolumes(volumes)
-
-	token := ""
-	log.Printf("Limiting result len(%d) to [%d:%d]", len(volumes), offset, size)
-	volumes, hasMoreElements := server.LimitPagination(volumes, offset, size)
-	if hasMoreElements {
-		token = uuid.New().String()
-		s.Pagination[token] = offset + size
-	}
+	s.mu.RUnlock()
+	volumes, token := server.Paginate(opts, volumes, (*pb.QosVolume).GetName)
 
 	return &pb.ListQosVolumesResponse{QosVolumes: volumes, NextPageToken: token}, nil
 }
 
 // GetQosVolume gets a QoS volume
-func (s *Server) GetQosVolume(_ context.Context, in *pb.GetQosVolumeRequest) (*pb.QosVolume, error) {
-	log.Printf("GetQosVolume: Received from client: %v", in)
+func (s *Server) GetQosVolume(ctx context.Context, in *pb.GetQosVolumeRequest) (*pb.QosVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.Name); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.volumes.qosVolumes[in.Name]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	return volume, nil
 }
 
 // QosVolumeStats gets a QoS volume stats
-func (s *Server) QosVolumeStats(_ context.Context, in *pb.QosVolumeStatsRequest) (*pb.QosVolumeStatsResponse, error) {
-	log.Printf("QosVolumeStats: Received from client: %v", in)
+func (s *Server) QosVolumeStats(ctx context.Context, in *pb.QosVolumeStatsRequest) (*pb.QosVolumeStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
 	// check required fields
 	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	if in.VolumeId == nil || in.VolumeId.Value == "" {
@@ -205,28 +250,30 @@ func (s *Server) QosVolumeStats(_ context.Context, in *pb.QosVolumeStatsRequest)
 	}
 	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
 	if err := resourcename.Validate(in.VolumeId.Value); err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	// fetch object from the database
+	s.mu.RLock()
 	volume, ok := s.volumes.qosVolumes[in.VolumeId.Value]
+	s.mu.RUnlock()
 	if !ok {
 		err := status.Errorf(codes.NotFound, "unable to find key %s", in.VolumeId.Value)
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
 		return nil, err
 	}
 	params := spdk.BdevGetIostatParams{
 		Name: volume.VolumeId.Value,
 	}
 	var result spdk.BdevGetIostatResult
-	err := s.rpc.Call("bdev_get_iostat", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
 		return nil, spdk.ErrFailedSpdkCall
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if len(result.Bdevs) != 1 {
-		log.Printf("error: expect to find one bdev in response")
+		slog.ErrorContext(ctx, "Expected to find one bdev in response", "bdevs", len(result.Bdevs))
 		return nil, spdk.ErrUnexpectedSpdkCallResult
 	}
 
@@ -292,7 +339,7 @@ func (s *Server) verifyQosVolume(volume *pb.QosVolume) error {
 	return nil
 }
 
-func (s *Server) setMaxLimit(underlyingVolume string, limit *pb.QosLimit) error {
+func (s *Server) setMaxLimit(ctx context.Context, underlyingVolume string, limit *pb.QosLimit) error {
 	params := spdk.BdevQoSParams{
 		Name:           underlyingVolume,
 		RwIosPerSec:    int(limit.RwIopsKiops * 1000),
@@ -301,21 +348,21 @@ func (s *Server) setMaxLimit(underlyingVolume string, limit *pb.QosLimit) error
 		WMbytesPerSec:  int(limit.WrBandwidthMbs),
 	}
 	var result spdk.BdevQoSResult
-	err := s.rpc.Call("bdev_set_qos_limit", &params, &result)
+	err := server.Call(ctx, s.rpc, "bdev_set_qos_limit", &params, &result)
 	if err != nil {
-		log.Printf("error: %v", err)
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_set_qos_limit", "err", err)
 		return spdk.ErrFailedSpdkCall
 	}
-	log.Printf("Received from SPDK: %v", result)
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
 	if !result {
 		msg := fmt.Sprintf("Could not set max QoS limit: %s on %v", limit, underlyingVolume)
-		log.Print(msg)
+		slog.ErrorContext(ctx, msg)
 		return spdk.ErrUnexpectedSpdkCallResult
 	}
 
 	return nil
 }
 
-func (s *Server) cleanMaxLimit(underlyingVolume string) error {
-	return s.setMaxLimit(underlyingVolume, &pb.QosLimit{})
+func (s *Server) cleanMaxLimit(ctx context.Context, underlyingVolume string) error {
+	return s.setMaxLimit(ctx, underlyingVolume, &pb.QosLimit{})
 }
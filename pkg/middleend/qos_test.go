@@ -14,6 +14,7 @@ import (
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/opiproject/opi-spdk-bridge/pkg/server"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -258,6 +259,48 @@ func TestMiddleEnd_CreateQosVolume(t *testing.T) {
 			existBefore: false,
 			existAfter:  true,
 		},
+		"encrypted volume name": {
+			id: testQosVolumeID,
+			in: &pb.QosVolume{
+				VolumeId: &_go.ObjectKey{Value: encryptedVolumeName},
+				MaxLimit: &pb.QosLimit{RwBandwidthMbs: 1},
+			},
+			out: &pb.QosVolume{
+				VolumeId: &_go.ObjectKey{Value: encryptedVolumeID},
+				MaxLimit: &pb.QosLimit{RwBandwidthMbs: 1},
+			},
+			spdk:        []string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			errCode:     codes.OK,
+			errMsg:      "",
+			existBefore: false,
+			existAfter:  true,
+		},
+		"unknown volume name": {
+			id: testQosVolumeID,
+			in: &pb.QosVolume{
+				VolumeId: &_go.ObjectKey{Value: server.ResourceIDToVolumeName("unknown-id")},
+				MaxLimit: &pb.QosLimit{RwBandwidthMbs: 1},
+			},
+			out:         nil,
+			spdk:        []string{},
+			errCode:     codes.NotFound,
+			errMsg:      fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			existBefore: false,
+			existAfter:  false,
+		},
+		"qos volume name": {
+			id: testQosVolumeID,
+			in: &pb.QosVolume{
+				VolumeId: &_go.ObjectKey{Value: server.ResourceIDToVolumeName("qos-other")},
+				MaxLimit: &pb.QosLimit{RwBandwidthMbs: 1},
+			},
+			out:         nil,
+			spdk:        []string{},
+			errCode:     codes.InvalidArgument,
+			errMsg:      fmt.Sprintf("volume %v is QosVolume, expected one of %v", server.ResourceIDToVolumeName("qos-other"), qosVolumeKinds),
+			existBefore: false,
+			existAfter:  false,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -270,6 +313,10 @@ func TestMiddleEnd_CreateQosVolume(t *testing.T) {
 			if tt.out != nil {
 				tt.out.Name = testQosVolumeName
 			}
+			testEnv.opiSpdkServer.VolumeIndex = &server.VolumeIndex{}
+			testEnv.opiSpdkServer.VolumeIndex.Register(testEnv.opiSpdkServer.VolumeProviders()...)
+			testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName] = &encryptedVolume
+			testEnv.opiSpdkServer.volumes.qosVolumes[server.ResourceIDToVolumeName("qos-other")] = testQosVolume
 
 			request := &pb.CreateQosVolumeRequest{QosVolume: tt.in, QosVolumeId: tt.id}
 			response, err := testEnv.client.CreateQosVolume(testEnv.ctx, request)
@@ -764,6 +811,8 @@ func TestMiddleEnd_ListQosVolume(t *testing.T) {
 		errMsg          string
 		size            int32
 		token           string
+		filter          string
+		orderBy         string
 	}{
 		"no qos volumes were created": {
 			out:             []*pb.QosVolume{},
@@ -828,6 +877,42 @@ func TestMiddleEnd_ListQosVolume(t *testing.T) {
 			size:    0,
 			token:   "unknown-pagination-token",
 		},
+		"filter": {
+			out: []*pb.QosVolume{qosVolume1},
+			existingVolumes: map[string]*pb.QosVolume{
+				qosVolume0.Name: qosVolume0,
+				qosVolume1.Name: qosVolume1,
+			},
+			errCode: codes.OK,
+			errMsg:  "",
+			size:    0,
+			token:   "",
+			filter:  "max_limit.rw_bandwidth_mbs > 1",
+		},
+		"order by": {
+			out: []*pb.QosVolume{qosVolume1, qosVolume0},
+			existingVolumes: map[string]*pb.QosVolume{
+				qosVolume0.Name: qosVolume0,
+				qosVolume1.Name: qosVolume1,
+			},
+			errCode: codes.OK,
+			errMsg:  "",
+			size:    0,
+			token:   "",
+			orderBy: "volume_id desc",
+		},
+		"invalid filter": {
+			out: nil,
+			existingVolumes: map[string]*pb.QosVolume{
+				qosVolume0.Name: qosVolume0,
+				qosVolume1.Name: qosVolume1,
+			},
+			errCode: codes.InvalidArgument,
+			errMsg:  `invalid filter "unknown = 1": unknown field unknown`,
+			size:    0,
+			token:   "",
+			filter:  "unknown = 1",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -838,9 +923,15 @@ func TestMiddleEnd_ListQosVolume(t *testing.T) {
 			request.Parent = "todo"
 			request.PageSize = tt.size
 			request.PageToken = tt.token
-			testEnv.opiSpdkServer.Pagination[existingToken] = 1
+			if tt.token == existingToken {
+				request.PageToken = testEnv.opiSpdkServer.Pagination.Issue(testEnv.ctx, request,
+					qosVolume0, qosVolume0.Name)
+			}
+
+			ctx := metadata.AppendToOutgoingContext(testEnv.ctx,
+				server.FilterMetadataKey, tt.filter, server.OrderByMetadataKey, tt.orderBy)
 
-			response, err := testEnv.client.ListQosVolumes(testEnv.ctx, request)
+			response, err := testEnv.client.ListQosVolumes(ctx, request)
 
 			if !server.EqualProtoSlices(response.GetQosVolumes(), tt.out) {
 				t.Error("response: expected", tt.out, "received", response.GetQosVolumes())
@@ -0,0 +1,445 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"golang.org/x/exp/slog"
+
+	"go.einride.tech/aip/fieldbehavior"
+	"go.einride.tech/aip/resourceid"
+	"go.einride.tech/aip/resourcename"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateRaidVolume creates a RAID volume of existing volumes
+func (s *Server) CreateRaidVolume(ctx context.Context, in *px.CreateRaidVolumeRequest) (*px.RaidVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// see https://google.aip.dev/133#user-specified-ids
+	resourceID := resourceid.NewSystemGenerated()
+	if in.RaidVolumeId != "" {
+		err := resourceid.ValidateUserSettable(in.RaidVolumeId)
+		if err != nil {
+			slog.ErrorContext(ctx, "Request failed", "err", err)
+			return nil, err
+		}
+		slog.WarnContext(ctx, "Client provided the ID of a resource, ignoring the name field", "id", in.RaidVolumeId, "name", in.RaidVolume.Name)
+		resourceID = in.RaidVolumeId
+	}
+	in.RaidVolume.Name = server.ResourceIDToVolumeName(resourceID)
+	unlock := s.names.Lock(in.RaidVolume.Name)
+	defer unlock()
+
+	if err := verifyRaidVolume(in.RaidVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// idempotent API when called with same key, should return same object
+	s.mu.RLock()
+	volume, ok := s.volumes.raidVolumes[in.RaidVolume.Name]
+	s.mu.RUnlock()
+	if ok {
+		slog.InfoContext(ctx, "Already existing RaidVolume", "name", in.RaidVolume.Name)
+		return volume, nil
+	}
+
+	if err := s.resolveRaidMembers(in.RaidVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := bdevRaidCreateParams{
+		Name:        resourceID,
+		RaidLevel:   opiRaidLevelToSpdk(in.RaidVolume.RaidLevel),
+		StripSizeKb: in.RaidVolume.StripSizeKb,
+	}
+	for _, member := range in.RaidVolume.MemberVolumeIds {
+		params.BaseBdevs = append(params.BaseBdevs, member.Value)
+	}
+	// members are claimed before they are checked, so they cannot be
+	// deleted between the check and the creation
+	unclaim, err := s.Consumers.Claim(params.BaseBdevs...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer unclaim()
+	if err := s.verifyRaidMembers(ctx, in.RaidVolume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	var result bdevRaidCreateResult
+	err = server.Call(ctx, s.rpc, "bdev_raid_create", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_raid_create", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not create Raid: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	response := server.ProtoClone(in.RaidVolume)
+	clearRaidVolumeStatus(response)
+	if err := store.Save(s.store, in.RaidVolume.Name, response); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	s.volumes.raidVolumes[in.RaidVolume.Name] = response
+	s.mu.Unlock()
+	slog.DebugContext(ctx, "Sending to client", "response", response)
+	return response, nil
+}
+
+// DeleteRaidVolume deletes a RAID volume, its members are left intact
+func (s *Server) DeleteRaidVolume(ctx context.Context, in *px.DeleteRaidVolumeRequest) (*emptypb.Empty, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	unlock := s.names.Lock(in.Name)
+	defer unlock()
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.volumes.raidVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		if in.AllowMissing {
+			return &emptypb.Empty{}, nil
+		}
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	released, err := s.Consumers.ReleaseBdev(ctx, in.Name, path.Base(volume.Name))
+	if err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	defer released()
+	if err := s.deleteRaidBdev(ctx, path.Base(volume.Name)); err != nil {
+		return nil, err
+	}
+	if err := store.Remove(s.store, volume.Name, volume); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.volumes.raidVolumes, volume.Name)
+	s.mu.Unlock()
+	return &emptypb.Empty{}, nil
+}
+
+// ListRaidVolumes lists RAID volumes with their member health
+func (s *Server) ListRaidVolumes(ctx context.Context, in *px.ListRaidVolumesRequest) (*px.ListRaidVolumesResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	opts, perr := server.ParseListOptions(ctx, in, s.Pagination, &px.RaidVolume{})
+	if perr != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", perr)
+		return nil, perr
+	}
+	raids, err := s.getRaidBdevs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	Blobarray := make([]*px.RaidVolume, 0, len(s.volumes.raidVolumes))
+	for _, volume := range s.volumes.raidVolumes {
+		Blobarray = append(Blobarray, server.ProtoClone(volume))
+	}
+	s.mu.RUnlock()
+	for _, volume := range Blobarray {
+		if raid, ok := raids[path.Base(volume.Name)]; ok {
+			setRaidVolumeStatus(volume, raid)
+		}
+	}
+	Blobarray, token := server.Paginate(opts, Blobarray, (*px.RaidVolume).GetName)
+	return &px.ListRaidVolumesResponse{RaidVolumes: Blobarray, NextPageToken: token}, nil
+}
+
+// GetRaidVolume gets a RAID volume with health of its members and
+// progress of a running rebuild
+func (s *Server) GetRaidVolume(ctx context.Context, in *px.GetRaidVolumeRequest) (*px.RaidVolume, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.Name); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.volumes.raidVolumes[in.Name]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.Name)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	raids, err := s.getRaidBdevs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raid, ok := raids[path.Base(volume.Name)]
+	if !ok {
+		msg := fmt.Sprintf("Could not find Raid: %s", path.Base(volume.Name))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.NotFound, msg)
+	}
+	response := server.ProtoClone(volume)
+	setRaidVolumeStatus(response, raid)
+	return response, nil
+}
+
+// RaidVolumeStats gets a RAID volume stats
+func (s *Server) RaidVolumeStats(ctx context.Context, in *px.RaidVolumeStatsRequest) (*px.RaidVolumeStatsResponse, error) {
+	slog.DebugContext(ctx, "Received from client", "request", in)
+	// check required fields
+	if err := fieldbehavior.ValidateRequiredFields(in); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// Validate that a resource name conforms to the restrictions outlined in AIP-122.
+	if err := resourcename.Validate(in.RaidVolumeId.Value); err != nil {
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	// fetch object from the database
+	s.mu.RLock()
+	volume, ok := s.volumes.raidVolumes[in.RaidVolumeId.Value]
+	s.mu.RUnlock()
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find key %s", in.RaidVolumeId.Value)
+		slog.ErrorContext(ctx, "Request failed", "err", err)
+		return nil, err
+	}
+	params := spdk.BdevGetIostatParams{
+		Name: path.Base(volume.Name),
+	}
+	var result spdk.BdevGetIostatResult
+	err := server.Call(ctx, s.rpc, "bdev_get_iostat", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_iostat", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if len(result.Bdevs) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
+		slog.ErrorContext(ctx, msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	return &px.RaidVolumeStatsResponse{Stats: &pb.VolumeStats{
+		ReadBytesCount:    int32(result.Bdevs[0].BytesRead),
+		ReadOpsCount:      int32(result.Bdevs[0].NumReadOps),
+		WriteBytesCount:   int32(result.Bdevs[0].BytesWritten),
+		WriteOpsCount:     int32(result.Bdevs[0].NumWriteOps),
+		UnmapBytesCount:   int32(result.Bdevs[0].BytesUnmapped),
+		UnmapOpsCount:     int32(result.Bdevs[0].NumUnmapOps),
+		ReadLatencyTicks:  int32(result.Bdevs[0].ReadLatencyTicks),
+		WriteLatencyTicks: int32(result.Bdevs[0].WriteLatencyTicks),
+		UnmapLatencyTicks: int32(result.Bdevs[0].UnmapLatencyTicks),
+	}}, nil
+}
+
+// raidMembers returns bdevs being members of RAID volumes, mapped to
+// names of the RAID volumes
+func (s *Server) raidMembers() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	members := make(map[string]string)
+	for _, volume := range s.volumes.raidVolumes {
+		for _, member := range volume.MemberVolumeIds {
+			members[member.Value] = volume.Name
+		}
+	}
+	return members
+}
+
+func verifyRaidVolume(volume *px.RaidVolume) error {
+	if volume.RaidLevel == px.RaidLevel_RAID_LEVEL_UNSPECIFIED {
+		return fmt.Errorf("raid_level of RaidVolume has to be set")
+	}
+	if len(volume.MemberVolumeIds) < 2 {
+		return fmt.Errorf("RaidVolume needs at least 2 members, got %d", len(volume.MemberVolumeIds))
+	}
+	seen := make(map[string]bool)
+	for _, member := range volume.MemberVolumeIds {
+		if member.GetValue() == "" {
+			return fmt.Errorf("member_volume_ids of RaidVolume cannot be empty")
+		}
+		if seen[member.Value] {
+			return fmt.Errorf("member %s of RaidVolume is repeated", member.Value)
+		}
+		seen[member.Value] = true
+	}
+	switch {
+	case volume.StripSizeKb < 0:
+		return fmt.Errorf("strip_size_kb cannot be negative, got %d", volume.StripSizeKb)
+	case volume.RaidLevel == px.RaidLevel_RAID_LEVEL_RAID1 && volume.StripSizeKb != 0:
+		return fmt.Errorf("strip_size_kb cannot be set for RAID1")
+	case volume.RaidLevel != px.RaidLevel_RAID_LEVEL_RAID1 && volume.StripSizeKb == 0:
+		return fmt.Errorf("strip_size_kb of %v has to be set", volume.RaidLevel)
+	}
+	return nil
+}
+
+// resolveRaidMembers resolves members of volume named after OPI volumes to
+// their bdevs and checks that no member is repeated
+func (s *Server) resolveRaidMembers(volume *px.RaidVolume) error {
+	seen := make(map[string]bool)
+	for _, member := range volume.MemberVolumeIds {
+		if err := s.VolumeIndex.ResolveKey(member); err != nil {
+			return err
+		}
+		if seen[member.Value] {
+			return status.Errorf(codes.InvalidArgument, "member %s of RaidVolume is repeated", member.Value)
+		}
+		seen[member.Value] = true
+	}
+	return nil
+}
+
+// verifyRaidMembers checks that resolved members of volume exist in SPDK
+// and are not members of another RAID volume. SPDK would otherwise create
+// a RAID waiting for missing members.
+func (s *Server) verifyRaidMembers(ctx context.Context, volume *px.RaidVolume) error {
+	members := s.raidMembers()
+	for _, member := range volume.MemberVolumeIds {
+		if raid, ok := members[member.Value]; ok {
+			return status.Errorf(codes.FailedPrecondition, "volume %s is already a member of %s", member.Value, raid)
+		}
+	}
+	var result []spdk.BdevGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_get_bdevs", nil, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	present := make(map[string]bool, len(result))
+	for i := range result {
+		present[result[i].Name] = true
+	}
+	for _, member := range volume.MemberVolumeIds {
+		if !present[member.Value] {
+			return status.Errorf(codes.NotFound, "unable to find key %s", member.Value)
+		}
+	}
+	return nil
+}
+
+// getRaidBdevs gets all RAID bdevs from SPDK, mapped by name
+func (s *Server) getRaidBdevs(ctx context.Context) (map[string]*bdevRaidGetBdevsResult, error) {
+	params := bdevRaidGetBdevsParams{
+		Category: "all",
+	}
+	var result []bdevRaidGetBdevsResult
+	err := server.Call(ctx, s.rpc, "bdev_raid_get_bdevs", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_raid_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	raids := make(map[string]*bdevRaidGetBdevsResult, len(result))
+	for i := range result {
+		raids[result[i].Name] = &result[i]
+	}
+	return raids, nil
+}
+
+func (s *Server) deleteRaidBdev(ctx context.Context, name string) error {
+	params := bdevRaidDeleteParams{
+		Name: name,
+	}
+	var result bdevRaidDeleteResult
+	err := server.Call(ctx, s.rpc, "bdev_raid_delete", &params, &result)
+	if err != nil {
+		slog.ErrorContext(ctx, "SPDK call failed", "method", "bdev_raid_delete", "err", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Received from SPDK", "result", result)
+	if !result {
+		msg := fmt.Sprintf("Could not delete Raid: %s", params.Name)
+		slog.ErrorContext(ctx, msg)
+		return status.Errorf(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+func opiRaidLevelToSpdk(level px.RaidLevel) string {
+	return strings.ToLower(strings.TrimPrefix(level.String(), "RAID_LEVEL_"))
+}
+
+func spdkRaidLevelToOpi(level string) px.RaidLevel {
+	return px.RaidLevel(px.RaidLevel_value["RAID_LEVEL_"+strings.ToUpper(level)])
+}
+
+// setRaidVolumeStatus fills output only fields of volume from its RAID bdev
+func setRaidVolumeStatus(volume *px.RaidVolume, raid *bdevRaidGetBdevsResult) {
+	volume.Uuid = &pc.Uuid{Value: raid.UUID}
+	volume.State = px.RaidVolumeState(px.RaidVolumeState_value["RAID_VOLUME_STATE_"+strings.ToUpper(raid.State)])
+	configured := make(map[string]bool, len(raid.BaseBdevList))
+	for _, base := range raid.BaseBdevList {
+		configured[base.Name] = base.IsConfigured
+	}
+	volume.Members = make([]*px.RaidMemberStatus, 0, len(volume.MemberVolumeIds))
+	for _, member := range volume.MemberVolumeIds {
+		volume.Members = append(volume.Members, &px.RaidMemberStatus{
+			VolumeId:   &pc.ObjectKey{Value: member.Value},
+			Configured: configured[member.Value],
+		})
+	}
+	volume.Rebuild = nil
+	if raid.Process != nil && raid.Process.Type == "rebuild" {
+		volume.Rebuild = &px.RaidRebuildStatus{
+			TargetVolumeId: &pc.ObjectKey{Value: raid.Process.Target},
+			Percent:        raid.Process.Progress.Percent,
+		}
+	}
+}
+
+// clearRaidVolumeStatus drops output only fields, which are not stored
+func clearRaidVolumeStatus(volume *px.RaidVolume) {
+	volume.Uuid = nil
+	volume.State = px.RaidVolumeState_RAID_VOLUME_STATE_UNSPECIFIED
+	volume.Members = nil
+	volume.Rebuild = nil
+}
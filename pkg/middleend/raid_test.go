@@ -0,0 +1,465 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+var (
+	testRaidVolumeID   = "raid-test"
+	testRaidVolumeName = server.ResourceIDToVolumeName(testRaidVolumeID)
+	testRaidVolume     = px.RaidVolume{
+		Name:            testRaidVolumeName,
+		RaidLevel:       px.RaidLevel_RAID_LEVEL_RAID1,
+		MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc2"}, {Value: "Malloc3"}},
+	}
+	testMemberBdevsResponse = `{"id":%d,"error":{"code":0,"message":""},"result":[{"name":"Malloc2"},{"name":"Malloc3"}]}`
+	testRaidBdevsResponse   = `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"raid-test","uuid":"8f5c3e0a-1b2d-4c6e-9a7f-0d1e2f3a4b5c","strip_size_kb":0,"state":"online","raid_level":"raid1",` +
+		`"base_bdevs_list":[{"name":"Malloc2","is_configured":true},{"name":"Malloc3","is_configured":false}],` +
+		`"process":{"type":"rebuild","target":"Malloc3","progress":{"blocks":1024,"percent":42}}}]}`
+)
+
+func TestMiddleEnd_CreateRaidVolume(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		in      *px.RaidVolume
+		out     *px.RaidVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		exist   bool
+	}{
+		"illegal resource_id": {
+			"CapitalLettersNotAllowed",
+			&testRaidVolume,
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("user-settable ID must only contain lowercase, numbers and hyphens (%v)", "got: 'C' in position 0"),
+			false,
+		},
+		"single member": {
+			testRaidVolumeID,
+			&px.RaidVolume{RaidLevel: px.RaidLevel_RAID_LEVEL_RAID1, MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc2"}}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"RaidVolume needs at least 2 members, got 1",
+			false,
+		},
+		"repeated member": {
+			testRaidVolumeID,
+			&px.RaidVolume{RaidLevel: px.RaidLevel_RAID_LEVEL_RAID1, MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc2"}, {Value: "Malloc2"}}},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"member Malloc2 of RaidVolume is repeated",
+			false,
+		},
+		"strip size for RAID1": {
+			testRaidVolumeID,
+			&px.RaidVolume{RaidLevel: px.RaidLevel_RAID_LEVEL_RAID1, MemberVolumeIds: testRaidVolume.MemberVolumeIds, StripSizeKb: 64},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"strip_size_kb cannot be set for RAID1",
+			false,
+		},
+		"missing strip size for RAID0": {
+			testRaidVolumeID,
+			&px.RaidVolume{RaidLevel: px.RaidLevel_RAID_LEVEL_RAID0, MemberVolumeIds: testRaidVolume.MemberVolumeIds},
+			nil,
+			[]string{},
+			codes.InvalidArgument,
+			"strip_size_kb of RAID_LEVEL_RAID0 has to be set",
+			false,
+		},
+		"unknown member": {
+			testRaidVolumeID,
+			&px.RaidVolume{RaidLevel: px.RaidLevel_RAID_LEVEL_RAID1, MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc2"}, {Value: "Malloc9"}}},
+			nil,
+			[]string{testMemberBdevsResponse},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", "Malloc9"),
+			false,
+		},
+		"valid request with invalid SPDK response": {
+			testRaidVolumeID,
+			&testRaidVolume,
+			nil,
+			[]string{testMemberBdevsResponse, `{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not create Raid: %v", testRaidVolumeID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testRaidVolumeID,
+			&testRaidVolume,
+			nil,
+			[]string{testMemberBdevsResponse, `{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_raid_create: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testRaidVolumeID,
+			&testRaidVolume,
+			&testRaidVolume,
+			[]string{testMemberBdevsResponse, `{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"already exists": {
+			testRaidVolumeID,
+			&testRaidVolume,
+			&testRaidVolume,
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			if tt.exist {
+				testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+			}
+
+			request := &px.CreateRaidVolumeRequest{RaidVolume: server.ProtoClone(tt.in), RaidVolumeId: tt.id}
+			response, err := testEnv.client.CreateRaidVolume(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestMiddleEnd_CreateRaidVolumeOfMember(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+
+	testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+	request := &px.CreateRaidVolumeRequest{
+		RaidVolume: &px.RaidVolume{
+			RaidLevel:       px.RaidLevel_RAID_LEVEL_CONCAT,
+			MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc1"}, {Value: "Malloc3"}},
+			StripSizeKb:     64,
+		},
+		RaidVolumeId: "raid-other",
+	}
+	_, err := testEnv.client.CreateRaidVolume(testEnv.ctx, request)
+	if er, _ := status.FromError(err); er.Code() != codes.FailedPrecondition {
+		t.Error("error code: expected", codes.FailedPrecondition, "received", er.Code())
+	}
+	if members := testEnv.opiSpdkServer.raidMembers(); members["Malloc3"] != testRaidVolumeName {
+		t.Error("members: unexpected", members)
+	}
+}
+
+func TestMiddleEnd_CreateRaidVolumeOfReleasedMember(t *testing.T) {
+	testEnv := createTestEnvironment([]string{})
+	defer testEnv.Close()
+
+	testEnv.opiSpdkServer.Consumers = &server.VolumeConsumers{}
+	testEnv.opiSpdkServer.Consumers.Register(testEnv.opiSpdkServer.VolumeConsumers()...)
+	done, err := testEnv.opiSpdkServer.Consumers.ReleaseBdev(testEnv.ctx, "malloc3", "Malloc3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	request := &px.CreateRaidVolumeRequest{RaidVolume: server.ProtoClone(&testRaidVolume), RaidVolumeId: testRaidVolumeID}
+	_, err = testEnv.client.CreateRaidVolume(testEnv.ctx, request)
+
+	// members are claimed before they are looked up in SPDK
+	expectedMsg := "volume Malloc3 of malloc3 is being deleted"
+	if er, ok := status.FromError(err); ok {
+		if er.Code() != codes.FailedPrecondition {
+			t.Error("error code: expected", codes.FailedPrecondition, "received", er.Code())
+		}
+		if er.Message() != expectedMsg {
+			t.Error("error message: expected", expectedMsg, "received", er.Message())
+		}
+	} else {
+		t.Error("expected grpc error status")
+	}
+	if _, ok := testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName]; ok {
+		t.Error("expected no raid volume to be created")
+	}
+}
+
+func TestMiddleEnd_DeleteRaidVolume(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *emptypb.Empty
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+		missing bool
+	}{
+		"valid request with invalid SPDK response": {
+			testRaidVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not delete Raid: %s", testRaidVolumeID),
+			false,
+		},
+		"valid request with error code from SPDK response": {
+			testRaidVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":false}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_raid_delete: %v", "json response error: myopierr"),
+			false,
+		},
+		"valid request with valid SPDK response": {
+			testRaidVolumeID,
+			&emptypb.Empty{},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			codes.OK,
+			"",
+			false,
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+			false,
+		},
+		"unknown key with missing allowed": {
+			"unknown-id",
+			&emptypb.Empty{},
+			[]string{},
+			codes.OK,
+			"",
+			true,
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+			request := &px.DeleteRaidVolumeRequest{Name: server.ResourceIDToVolumeName(tt.in), AllowMissing: tt.missing}
+			response, err := testEnv.client.DeleteRaidVolume(testEnv.ctx, request)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+
+			if reflect.TypeOf(response) != reflect.TypeOf(tt.out) {
+				t.Error("response: expected", reflect.TypeOf(tt.out), "received", reflect.TypeOf(response))
+			}
+			if _, ok := testEnv.opiSpdkServer.raidMembers()["Malloc2"]; ok != (tt.errCode != codes.OK || tt.in != testRaidVolumeID) {
+				t.Error("members: unexpected", testEnv.opiSpdkServer.raidMembers())
+			}
+		})
+	}
+}
+
+func TestMiddleEnd_GetRaidVolume(t *testing.T) {
+	withStatus := server.ProtoClone(&testRaidVolume)
+	withStatus.Uuid = &pc.Uuid{Value: "8f5c3e0a-1b2d-4c6e-9a7f-0d1e2f3a4b5c"}
+	withStatus.State = px.RaidVolumeState_RAID_VOLUME_STATE_ONLINE
+	withStatus.Members = []*px.RaidMemberStatus{
+		{VolumeId: &pc.ObjectKey{Value: "Malloc2"}, Configured: true},
+		{VolumeId: &pc.ObjectKey{Value: "Malloc3"}, Configured: false},
+	}
+	withStatus.Rebuild = &px.RaidRebuildStatus{TargetVolumeId: &pc.ObjectKey{Value: "Malloc3"}, Percent: 42}
+	tests := map[string]struct {
+		in      string
+		out     *px.RaidVolume
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with error code from SPDK response": {
+			testRaidVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			codes.Unknown,
+			fmt.Sprintf("bdev_raid_get_bdevs: %v", "json response error: myopierr"),
+		},
+		"valid request with empty result SPDK response": {
+			testRaidVolumeID,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`},
+			codes.NotFound,
+			fmt.Sprintf("Could not find Raid: %s", testRaidVolumeID),
+		},
+		"valid request with valid SPDK response": {
+			testRaidVolumeID,
+			withStatus,
+			[]string{testRaidBdevsResponse},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			"unknown-id",
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+		"malformed name": {
+			"-ABC-DEF",
+			nil,
+			[]string{},
+			codes.Unknown,
+			fmt.Sprintf("segment '%s': not a valid DNS name", "-ABC-DEF"),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+			request := &px.GetRaidVolumeRequest{Name: server.ResourceIDToVolumeName(tt.in)}
+			response, err := testEnv.client.GetRaidVolume(testEnv.ctx, request)
+
+			if !proto.Equal(response, tt.out) {
+				t.Error("response: expected", tt.out, "received", response)
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
+
+func TestMiddleEnd_ListRaidVolumes(t *testing.T) {
+	testEnv := createTestEnvironment([]string{`{"id":%d,"error":{"code":0,"message":""},"result":[]}`})
+	defer testEnv.Close()
+
+	testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+	request := &px.ListRaidVolumesRequest{Parent: "todo"}
+	response, err := testEnv.client.ListRaidVolumes(testEnv.ctx, request)
+	if err != nil {
+		t.Fatalf("expected no error, received: %v", err)
+	}
+	if !server.EqualProtoSlices(response.GetRaidVolumes(), []*px.RaidVolume{&testRaidVolume}) {
+		t.Error("response: expected", &testRaidVolume, "received", response.GetRaidVolumes())
+	}
+}
+
+func TestMiddleEnd_RaidVolumeStats(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		out     *pb.VolumeStats
+		spdk    []string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"valid request with empty result SPDK response": {
+			testRaidVolumeName,
+			nil,
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":{"tick_rate":0,"ticks":0,"bdevs":[]}}`},
+			codes.InvalidArgument,
+			fmt.Sprintf("expecting exactly 1 result, got %d", 0),
+		},
+		"valid request with valid SPDK response": {
+			testRaidVolumeName,
+			&pb.VolumeStats{ReadBytesCount: 1, ReadOpsCount: 2, WriteBytesCount: 3, WriteOpsCount: 4},
+			[]string{`{"id":%d,"error":{"code":0,"message":""},"result":{"tick_rate":0,"ticks":0,"bdevs":[{"name":"raid-test","bytes_read":1,"num_read_ops":2,"bytes_written":3,"num_write_ops":4}]}}`},
+			codes.OK,
+			"",
+		},
+		"valid request with unknown key": {
+			server.ResourceIDToVolumeName("unknown-id"),
+			nil,
+			[]string{},
+			codes.NotFound,
+			fmt.Sprintf("unable to find key %v", server.ResourceIDToVolumeName("unknown-id")),
+		},
+	}
+
+	// run tests
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+			request := &px.RaidVolumeStatsRequest{RaidVolumeId: &pc.ObjectKey{Value: tt.in}}
+			response, err := testEnv.client.RaidVolumeStats(testEnv.ctx, request)
+
+			if !proto.Equal(response.GetStats(), tt.out) {
+				t.Error("response: expected", tt.out, "received", response.GetStats())
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status")
+			}
+		})
+	}
+}
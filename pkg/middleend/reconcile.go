@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/opiproject/gospdk/spdk"
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+	"golang.org/x/exp/slog"
+)
+
+// product names reported by SPDK for bdevs of the middleend
+const (
+	cryptoProductName = "crypto"
+	raidProductName   = "Raid Volume"
+)
+
+// Reconcile compares middleend resources with bdevs configured in SPDK
+// and resolves found differences according to mode.
+// Keys of adopted encrypted volumes cannot be read back from SPDK,
+// so such volumes can only be deleted.
+func (s *Server) Reconcile(mode server.ReconcileMode) (*server.ReconcileResult, error) {
+	slog.Info("Reconcile: middleend", "mode", mode)
+	// resources are compared and adopted as a whole, so no request may interleave
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bdevs []server.Bdev
+	err := s.rpc.Call("bdev_get_bdevs", nil, &bdevs)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "bdev_get_bdevs", "err", err)
+		return nil, err
+	}
+	slog.Debug("Received from SPDK", "result", bdevs)
+
+	result := &server.ReconcileResult{}
+	present := make(map[string]bool)
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		present[bdev.Name] = true
+		if bdev.ProductName != cryptoProductName {
+			continue
+		}
+		if _, ok := s.volumes.encVolumes[server.ResourceIDToVolumeName(bdev.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("crypto bdev", bdev.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(bdev.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			volume := &pb.EncryptedVolume{
+				Name:     name,
+				VolumeId: &pc.ObjectKey{Value: bdev.DriverSpecific.Crypto.BaseBdevName},
+			}
+			if err := server.Adopt(s.store, s.volumes.encVolumes, name, volume); err != nil {
+				slog.Error("Request failed", "err", err)
+				return nil, err
+			}
+		case server.ReconcileCleanup:
+			if err := s.deleteOrphanedCryptoBdev(bdev); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// RAIDs are reconciled after crypto bdevs, which may be built on them
+	if err := s.reconcileRaids(mode, bdevs, result); err != nil {
+		return nil, err
+	}
+
+	err = server.ReconcileMissing(mode, s.store, s.volumes.encVolumes,
+		func(volume *pb.EncryptedVolume) bool { return present[path.Base(volume.Name)] }, result)
+	if err != nil {
+		return nil, err
+	}
+	err = server.ReconcileMissing(mode, s.store, s.volumes.raidVolumes,
+		func(volume *px.RaidVolume) bool { return present[path.Base(volume.Name)] }, result)
+	if err != nil {
+		return nil, err
+	}
+	// QoS limits are attributes of other bdevs, so only QosVolumes which
+	// lost the underlying bdev can be detected
+	err = server.ReconcileMissing(mode, s.store, s.volumes.qosVolumes,
+		func(volume *pb.QosVolume) bool { return present[volume.VolumeId.GetValue()] }, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reconcileRaids adopts or deletes RAID bdevs not managed by the bridge,
+// must be called with s.mu held
+func (s *Server) reconcileRaids(mode server.ReconcileMode, bdevs []server.Bdev, result *server.ReconcileResult) error {
+	for i := range bdevs {
+		bdev := &bdevs[i]
+		if bdev.ProductName != raidProductName {
+			continue
+		}
+		if _, ok := s.volumes.raidVolumes[server.ResourceIDToVolumeName(bdev.Name)]; ok {
+			continue
+		}
+		result.AddOrphaned("raid bdev", bdev.Name)
+		switch mode {
+		case server.ReconcileAdopt:
+			name, err := server.AdoptableName(bdev.Name)
+			if err != nil {
+				slog.Error("Request failed", "err", err)
+				continue
+			}
+			volume := &px.RaidVolume{
+				Name:        name,
+				RaidLevel:   spdkRaidLevelToOpi(bdev.DriverSpecific.Raid.RaidLevel),
+				StripSizeKb: bdev.DriverSpecific.Raid.StripSizeKb,
+			}
+			for _, base := range bdev.DriverSpecific.Raid.BaseBdevsList {
+				volume.MemberVolumeIds = append(volume.MemberVolumeIds, &pc.ObjectKey{Value: base.Name})
+			}
+			if err := server.Adopt(s.store, s.volumes.raidVolumes, name, volume); err != nil {
+				slog.Error("Request failed", "err", err)
+				return err
+			}
+		case server.ReconcileCleanup:
+			if err := s.deleteRaidBdev(context.Background(), bdev.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteOrphanedCryptoBdev(bdev *server.Bdev) error {
+	params := spdk.BdevCryptoDeleteParams{
+		Name: bdev.Name,
+	}
+	var result spdk.BdevCryptoDeleteResult
+	err := s.rpc.Call("bdev_crypto_delete", &params, &result)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "bdev_crypto_delete", "err", err)
+		return err
+	}
+	slog.Debug("Received from SPDK", "result", result)
+	if !result {
+		return fmt.Errorf("could not delete Crypto: %s", params.Name)
+	}
+	if bdev.DriverSpecific.Crypto.KeyName == "" {
+		return nil
+	}
+	keyDestroyParams := spdk.AccelCryptoKeyDestroyParams{
+		KeyName: bdev.DriverSpecific.Crypto.KeyName,
+	}
+	var keyDestroyResult spdk.AccelCryptoKeyDestroyResult
+	err = s.rpc.Call("accel_crypto_key_destroy", &keyDestroyParams, &keyDestroyResult)
+	if err != nil {
+		slog.Error("SPDK call failed", "method", "accel_crypto_key_destroy", "err", err)
+		return err
+	}
+	slog.Debug("Received from SPDK", "result", keyDestroyResult)
+	if !keyDestroyResult {
+		return fmt.Errorf("could not destroy Crypto Key: %v", keyDestroyParams.KeyName)
+	}
+	return nil
+}
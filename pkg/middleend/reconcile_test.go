@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	px "github.com/opiproject/opi-spdk-bridge/api/storage/v1alpha1/gen/go"
+	"github.com/opiproject/opi-spdk-bridge/pkg/server"
+)
+
+func TestMiddleEnd_Reconcile(t *testing.T) {
+	bdevsResponse := `{"id":%d,"error":{"code":0,"message":""},"result":[` +
+		`{"name":"volume-test","product_name":"Malloc disk"},` +
+		`{"name":"crypto-test","product_name":"crypto","driver_specific":{"crypto":{"base_bdev_name":"volume-test","key_name":"crypto-test"}}},` +
+		`{"name":"crypto-orphan","product_name":"crypto","driver_specific":{"crypto":{"base_bdev_name":"Malloc1","key_name":"crypto-orphan"}}},` +
+		`{"name":"raid-test","product_name":"Raid Volume","driver_specific":{"raid":{"strip_size_kb":0,"raid_level":"raid1","base_bdevs_list":[{"name":"Malloc2"},{"name":"Malloc3"}]}}},` +
+		`{"name":"raid-orphan","product_name":"Raid Volume","driver_specific":{"raid":{"strip_size_kb":64,"raid_level":"raid0","base_bdevs_list":[{"name":"Malloc4"},{"name":"Malloc5"}]}}}]}`
+	orphanName := server.ResourceIDToVolumeName("crypto-orphan")
+	raidOrphanName := server.ResourceIDToVolumeName("raid-orphan")
+
+	tests := map[string]struct {
+		mode    server.ReconcileMode
+		spdk    []string
+		out     *server.ReconcileResult
+		errMsg  string
+		adopted bool
+		qos     bool
+	}{
+		"report only": {
+			mode: server.ReconcileReport,
+			spdk: []string{bdevsResponse},
+			out: &server.ReconcileResult{
+				Missing:  []string{testQosVolumeName},
+				Orphaned: []string{"crypto bdev crypto-orphan", "raid bdev raid-orphan"},
+			},
+			qos: true,
+		},
+		"adopt": {
+			mode: server.ReconcileAdopt,
+			spdk: []string{bdevsResponse},
+			out: &server.ReconcileResult{
+				Missing:  []string{testQosVolumeName},
+				Orphaned: []string{"crypto bdev crypto-orphan", "raid bdev raid-orphan"},
+			},
+			adopted: true,
+		},
+		"cleanup": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{bdevsResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`},
+			out: &server.ReconcileResult{
+				Missing:  []string{testQosVolumeName},
+				Orphaned: []string{"crypto bdev crypto-orphan", "raid bdev raid-orphan"},
+			},
+		},
+		"cleanup with invalid key destroy response": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{bdevsResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			errMsg: "could not destroy Crypto Key: crypto-orphan",
+		},
+		"cleanup with invalid raid delete response": {
+			mode: server.ReconcileCleanup,
+			spdk: []string{bdevsResponse,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":true}`,
+				`{"id":%d,"error":{"code":0,"message":""},"result":false}`},
+			errMsg: "rpc error: code = InvalidArgument desc = Could not delete Raid: raid-orphan",
+		},
+		"valid request with error code from SPDK": {
+			mode:   server.ReconcileReport,
+			spdk:   []string{`{"id":%d,"error":{"code":1,"message":"myopierr"},"result":[]}`},
+			errMsg: "bdev_get_bdevs: json response error: myopierr",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testEnv := createTestEnvironment(tt.spdk)
+			defer testEnv.Close()
+
+			encryptedVolume.Name = encryptedVolumeName
+			testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName] = &encryptedVolume
+			testEnv.opiSpdkServer.volumes.qosVolumes[testQosVolumeName] = server.ProtoClone(testQosVolume)
+			testEnv.opiSpdkServer.volumes.raidVolumes[testRaidVolumeName] = server.ProtoClone(&testRaidVolume)
+
+			result, err := testEnv.opiSpdkServer.Reconcile(tt.mode)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("expected error %v, received: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, received: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.out) {
+				t.Errorf("expected %v, received: %v", tt.out, result)
+			}
+
+			if _, ok := testEnv.opiSpdkServer.volumes.encVolumes[encryptedVolumeName]; !ok {
+				t.Errorf("expected %v to stay", encryptedVolumeName)
+			}
+			expectedOrphan := &pb.EncryptedVolume{Name: orphanName, VolumeId: &pc.ObjectKey{Value: "Malloc1"}}
+			orphan, ok := testEnv.opiSpdkServer.volumes.encVolumes[orphanName]
+			if ok != tt.adopted || (ok && !proto.Equal(orphan, expectedOrphan)) {
+				t.Errorf("expected adopted %v as %v, received: %v", tt.adopted, expectedOrphan, orphan)
+			}
+			expectedRaid := &px.RaidVolume{
+				Name:            raidOrphanName,
+				RaidLevel:       px.RaidLevel_RAID_LEVEL_RAID0,
+				MemberVolumeIds: []*pc.ObjectKey{{Value: "Malloc4"}, {Value: "Malloc5"}},
+				StripSizeKb:     64,
+			}
+			raid, ok := testEnv.opiSpdkServer.volumes.raidVolumes[raidOrphanName]
+			if ok != tt.adopted || (ok && !proto.Equal(raid, expectedRaid)) {
+				t.Errorf("expected adopted %v as %v, received: %v", tt.adopted, expectedRaid, raid)
+			}
+			if _, ok := testEnv.opiSpdkServer.volumes.qosVolumes[testQosVolumeName]; ok != tt.qos {
+				t.Errorf("expected %v to be kept %v", testQosVolumeName, tt.qos)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package middleend implements the MiddleEnd APIs (service) of the storage Server
+package middleend
+
+// SPDK JSON-RPC models used by the middleend, which are not provided by gospdk
+
+// bdevRaidCreateParams is the parameters required to create a RAID bdev
+type bdevRaidCreateParams struct {
+	Name        string   `json:"name"`
+	RaidLevel   string   `json:"raid_level"`
+	StripSizeKb int32    `json:"strip_size_kb,omitempty"`
+	BaseBdevs   []string `json:"base_bdevs"`
+}
+
+// bdevRaidCreateResult is the result of creating a RAID bdev
+type bdevRaidCreateResult bool
+
+// bdevRaidDeleteParams is the parameters required to delete a RAID bdev
+type bdevRaidDeleteParams struct {
+	Name string `json:"name"`
+}
+
+// bdevRaidDeleteResult is the result of deleting a RAID bdev
+type bdevRaidDeleteResult bool
+
+// bdevRaidGetBdevsParams is the parameters required to get RAID bdevs
+type bdevRaidGetBdevsParams struct {
+	Category string `json:"category"`
+}
+
+// bdevRaidBaseBdev is a member of a RAID bdev
+type bdevRaidBaseBdev struct {
+	Name         string `json:"name"`
+	UUID         string `json:"uuid"`
+	IsConfigured bool   `json:"is_configured"`
+}
+
+// bdevRaidGetBdevsResult is the result of getting RAID bdevs
+type bdevRaidGetBdevsResult struct {
+	Name         string             `json:"name"`
+	UUID         string             `json:"uuid"`
+	StripSizeKb  int32              `json:"strip_size_kb"`
+	State        string             `json:"state"`
+	RaidLevel    string             `json:"raid_level"`
+	BaseBdevList []bdevRaidBaseBdev `json:"base_bdevs_list"`
+	// Process is set while a background process, e.g. rebuild, runs
+	Process *struct {
+		Type     string `json:"type"`
+		Target   string `json:"target"`
+		Progress struct {
+			Percent int32 `json:"percent"`
+		} `json:"progress"`
+	} `json:"process,omitempty"`
+}
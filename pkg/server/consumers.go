@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CascadeMetadataKey is gRPC metadata key of the cascade flag of Delete
+// and Update requests. OPI requests have no such field yet, so clients send
+// "true" to have resources using a volume deleted together with the volume
+// instead of the request failing.
+const CascadeMetadataKey = "x-cascade"
+
+// VolumeConsumer describes a kind of resources using volumes of other
+// resources, e.g. EncryptedVolumes built on Aio controllers
+type VolumeConsumer struct {
+	// Kind is the resource kind reported to clients, e.g. EncryptedVolume
+	Kind string
+	// Uses returns names of resources of the kind, mapped by names of
+	// SPDK bdevs they use
+	Uses func() map[string][]string
+	// Delete deletes a resource of the kind, ctx is passed on so cascading
+	// continues down to resources using the deleted one. Resources of kinds
+	// without Delete are never deleted by cascading.
+	Delete func(ctx context.Context, name string) error
+}
+
+// Dependent is a resource using a volume
+type Dependent struct {
+	Kind string
+	Name string
+}
+
+func (d Dependent) String() string {
+	return d.Kind + " " + d.Name
+}
+
+// VolumeConsumers is a registry of resources using volumes shared by
+// backend, middleend and frontend, so a volume cannot be deleted from under
+// resources of other services. Consumers are queried on every check rather
+// than tracked, so the registry never gets out of sync with their resources.
+// Resources being created claim bdevs they use until they are visible to
+// Uses, so they cannot slip in between the check and the volume deletion.
+// The zero value is ready to use and a nil registry has no consumers.
+type VolumeConsumers struct {
+	mu        sync.RWMutex
+	consumers []VolumeConsumer
+
+	// claimsMu guards bdevs claimed by resources being created and
+	// volumes being released
+	claimsMu sync.Mutex
+	claims   map[string]int
+	releases map[*volumeRelease]bool
+}
+
+// volumeRelease is a volume being deleted or destructively updated
+type volumeRelease struct {
+	name string
+	owns func(bdev string) bool
+}
+
+// Register adds kinds of consumers to the registry
+func (c *VolumeConsumers) Register(consumers ...VolumeConsumer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.consumers = append(c.consumers, consumers...)
+}
+
+// Dependents returns resources using any bdev owns reports true for,
+// sorted by kind and name
+func (c *VolumeConsumers) Dependents(owns func(bdev string) bool) []Dependent {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	consumers := c.consumers
+	c.mu.RUnlock()
+	var dependents []Dependent
+	for _, consumer := range consumers {
+		for bdev, names := range consumer.Uses() {
+			if !owns(bdev) {
+				continue
+			}
+			for _, name := range names {
+				dependents = append(dependents, Dependent{Kind: consumer.Kind, Name: name})
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Kind != dependents[j].Kind {
+			return dependents[i].Kind < dependents[j].Kind
+		}
+		return dependents[i].Name < dependents[j].Name
+	})
+	return dependents
+}
+
+// Claim reserves bdevs for a resource being created on them, so volumes
+// owning them cannot be released meanwhile. A FailedPrecondition error is
+// returned if any of them is being released. unclaim has to be called once
+// the resource is visible to Uses of its consumer or its creation failed.
+func (c *VolumeConsumers) Claim(bdevs ...string) (unclaim func(), err error) {
+	if c == nil {
+		return func() {}, nil
+	}
+	c.claimsMu.Lock()
+	defer c.claimsMu.Unlock()
+	for release := range c.releases {
+		for _, bdev := range bdevs {
+			if release.owns(bdev) {
+				return nil, status.Errorf(codes.FailedPrecondition, "volume %s of %s is being deleted", bdev, release.name)
+			}
+		}
+	}
+	if c.claims == nil {
+		c.claims = make(map[string]int)
+	}
+	for _, bdev := range bdevs {
+		c.claims[bdev]++
+	}
+	return func() {
+		c.claimsMu.Lock()
+		defer c.claimsMu.Unlock()
+		for _, bdev := range bdevs {
+			c.claims[bdev]--
+			if c.claims[bdev] == 0 {
+				delete(c.claims, bdev)
+			}
+		}
+	}, nil
+}
+
+// Release makes sure no resource uses any bdev owned by resource name
+// before it is deleted or destructively updated. Without the cascade flag
+// in ctx a FailedPrecondition error listing the dependents is returned,
+// with it the dependents are deleted first. Until done is called, claims
+// of the bdevs fail, so the caller has to call it after the volume is
+// deleted or updated.
+// Resources of a consumer must never lock names of volumes they use,
+// as the caller keeps name locked while dependents are deleted.
+func (c *VolumeConsumers) Release(ctx context.Context, name string, owns func(bdev string) bool) (done func(), err error) {
+	if c == nil {
+		return func() {}, nil
+	}
+	release := &volumeRelease{name: name, owns: owns}
+	c.claimsMu.Lock()
+	for bdev := range c.claims {
+		if owns(bdev) {
+			c.claimsMu.Unlock()
+			return nil, status.Errorf(codes.FailedPrecondition, "%s is used by a resource being created", name)
+		}
+	}
+	if c.releases == nil {
+		c.releases = make(map[*volumeRelease]bool)
+	}
+	c.releases[release] = true
+	c.claimsMu.Unlock()
+	done = func() {
+		c.claimsMu.Lock()
+		defer c.claimsMu.Unlock()
+		delete(c.releases, release)
+	}
+	if err := c.release(ctx, name, owns); err != nil {
+		done()
+		return nil, err
+	}
+	return done, nil
+}
+
+// ReleaseBdev is Release of resource name owning a single bdev
+func (c *VolumeConsumers) ReleaseBdev(ctx context.Context, name string, bdev string) (done func(), err error) {
+	return c.Release(ctx, name, func(b string) bool { return b == bdev })
+}
+
+// release checks and deletes dependents for Release
+func (c *VolumeConsumers) release(ctx context.Context, name string, owns func(bdev string) bool) error {
+	dependents := c.Dependents(owns)
+	if len(dependents) == 0 {
+		return nil
+	}
+	cascade, err := CascadeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !cascade {
+		return status.Errorf(codes.FailedPrecondition, "%s is used by %v", name, dependents)
+	}
+	for _, dependent := range dependents {
+		slog.InfoContext(ctx, "Cascading delete", "name", name, "dependent", dependent.String())
+		if err := c.deleter(dependent.Kind)(ctx, dependent.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *VolumeConsumers) deleter(kind string) func(ctx context.Context, name string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, consumer := range c.consumers {
+		if consumer.Kind == kind && consumer.Delete != nil {
+			return consumer.Delete
+		}
+	}
+	return func(ctx context.Context, name string) error {
+		return status.Errorf(codes.FailedPrecondition, "cannot cascade delete %s %s", kind, name)
+	}
+}
+
+// CascadeFromContext returns cascade flag sent by the client in request
+// metadata, false if none is sent
+func CascadeFromContext(ctx context.Context) (bool, error) {
+	values := metadata.ValueFromIncomingContext(ctx, CascadeMetadataKey)
+	if len(values) == 0 {
+		return false, nil
+	}
+	cascade, err := strconv.ParseBool(values[len(values)-1])
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid %v: %v", CascadeMetadataKey, err)
+	}
+	return cascade, nil
+}
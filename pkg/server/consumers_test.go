@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestVolumeConsumers_Release(t *testing.T) {
+	tests := map[string]struct {
+		cascade   []string
+		bdev      string
+		deleteErr error
+		errCode   codes.Code
+		errMsg    string
+		deleted   []string
+	}{
+		"unused volume": {
+			bdev:    "Malloc9",
+			errCode: codes.OK,
+		},
+		"used volume": {
+			bdev:    "Malloc0",
+			errCode: codes.FailedPrecondition,
+			errMsg:  "aio0 is used by [EncryptedVolume crypto0 QosVolume qos0 QosVolume qos1]",
+		},
+		"cascade false": {
+			cascade: []string{"false"},
+			bdev:    "Malloc0",
+			errCode: codes.FailedPrecondition,
+			errMsg:  "aio0 is used by [EncryptedVolume crypto0 QosVolume qos0 QosVolume qos1]",
+		},
+		"cascade": {
+			cascade: []string{"true"},
+			bdev:    "Malloc0",
+			errCode: codes.OK,
+			deleted: []string{"EncryptedVolume crypto0", "QosVolume qos0", "QosVolume qos1"},
+		},
+		"cascade delete failure": {
+			cascade:   []string{"true"},
+			bdev:      "Malloc0",
+			deleteErr: status.Error(codes.Internal, "delete failed"),
+			errCode:   codes.Internal,
+			errMsg:    "delete failed",
+			deleted:   []string{"EncryptedVolume crypto0"},
+		},
+		"cascade unknown kind": {
+			cascade: []string{"true"},
+			bdev:    "Malloc1",
+			errCode: codes.FailedPrecondition,
+			errMsg:  "cannot cascade delete NvmeNamespace ns0",
+		},
+		"invalid cascade": {
+			cascade: []string{"maybe"},
+			bdev:    "Malloc0",
+			errCode: codes.InvalidArgument,
+			errMsg:  "invalid x-cascade: strconv.ParseBool: parsing \"maybe\": invalid syntax",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var deleted []string
+			deleter := func(kind string) func(ctx context.Context, name string) error {
+				return func(_ context.Context, name string) error {
+					deleted = append(deleted, kind+" "+name)
+					return tt.deleteErr
+				}
+			}
+			consumers := &VolumeConsumers{}
+			consumers.Register(
+				VolumeConsumer{
+					Kind:   "QosVolume",
+					Uses:   func() map[string][]string { return map[string][]string{"Malloc0": {"qos1", "qos0"}} },
+					Delete: deleter("QosVolume"),
+				},
+				VolumeConsumer{
+					Kind:   "EncryptedVolume",
+					Uses:   func() map[string][]string { return map[string][]string{"Malloc0": {"crypto0"}} },
+					Delete: deleter("EncryptedVolume"),
+				},
+			)
+			consumers.Register(VolumeConsumer{
+				Kind: "NvmeNamespace",
+				Uses: func() map[string][]string { return map[string][]string{"Malloc1": {"ns0"}} },
+			})
+
+			ctx := context.Background()
+			for _, value := range tt.cascade {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(CascadeMetadataKey, value))
+			}
+			done, err := consumers.ReleaseBdev(ctx, "aio0", tt.bdev)
+			if err == nil {
+				done()
+			}
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status, received", err)
+			}
+			if !reflect.DeepEqual(deleted, tt.deleted) {
+				t.Error("deleted: expected", tt.deleted, "received", deleted)
+			}
+		})
+	}
+}
+
+func TestVolumeConsumers_Nil(t *testing.T) {
+	var consumers *VolumeConsumers
+	if dependents := consumers.Dependents(func(string) bool { return true }); dependents != nil {
+		t.Error("Expected no dependents, received", dependents)
+	}
+	done, err := consumers.ReleaseBdev(context.Background(), "aio0", "Malloc0")
+	if err != nil {
+		t.Error("Expected no error, received", err)
+	}
+	done()
+	unclaim, err := consumers.Claim("Malloc0")
+	if err != nil {
+		t.Error("Expected no error, received", err)
+	}
+	unclaim()
+}
+
+func TestVolumeConsumers_Claim(t *testing.T) {
+	ctx := context.Background()
+	consumers := &VolumeConsumers{}
+
+	unclaim, err := consumers.Claim("Malloc0", "Malloc1")
+	if err != nil {
+		t.Fatal("Expected no error, received", err)
+	}
+	_, err = consumers.ReleaseBdev(ctx, "aio1", "Malloc1")
+	if status.Code(err) != codes.FailedPrecondition || status.Convert(err).Message() != "aio1 is used by a resource being created" {
+		t.Error("Expected release of claimed volume to fail, received", err)
+	}
+	done, err := consumers.ReleaseBdev(ctx, "aio2", "Malloc2")
+	if err != nil {
+		t.Fatal("Expected no error, received", err)
+	}
+	unclaim()
+
+	_, err = consumers.Claim("Malloc2")
+	if status.Code(err) != codes.FailedPrecondition || status.Convert(err).Message() != "volume Malloc2 of aio2 is being deleted" {
+		t.Error("Expected claim of released volume to fail, received", err)
+	}
+	done()
+
+	unclaim, err = consumers.Claim("Malloc2")
+	if err != nil {
+		t.Fatal("Expected no error, received", err)
+	}
+	unclaim()
+	done, err = consumers.ReleaseBdev(ctx, "aio1", "Malloc1")
+	if err != nil {
+		t.Fatal("Expected no error, received", err)
+	}
+	done()
+}
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// matcher reports whether a listed resource satisfies a filter
+type matcher func(protoreflect.Message) bool
+
+// fieldPath leads from a listed resource to one of its scalar fields
+type fieldPath []protoreflect.FieldDescriptor
+
+// resolveFieldPath resolves dot separated path e.g. spec.nqn in md.
+// Messages holding a single field e.g. ObjectKey or Uuid stand for the
+// value of the field, so volume_id refers to volume_id.value.
+func resolveFieldPath(md protoreflect.MessageDescriptor, path string) (fieldPath, error) {
+	var fields fieldPath
+	for _, name := range strings.Split(path, ".") {
+		if md == nil {
+			return nil, fmt.Errorf("field %s has no subfield %s", path, name)
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(name)
+		}
+		if fd == nil {
+			return nil, fmt.Errorf("unknown field %s", path)
+		}
+		fields = append(fields, fd)
+		md = fd.Message()
+	}
+	for md != nil && md.Fields().Len() == 1 {
+		fd := md.Fields().Get(0)
+		fields = append(fields, fd)
+		md = fd.Message()
+	}
+	leaf := fields[len(fields)-1]
+	switch {
+	case leaf.IsList() || leaf.IsMap():
+		return nil, fmt.Errorf("repeated field %s is not supported", path)
+	case md != nil:
+		return nil, fmt.Errorf("message field %s is not supported", path)
+	case leaf.Kind() == protoreflect.BytesKind:
+		// bytes hold secrets like keys, which must not be guessable by filtering
+		return nil, fmt.Errorf("bytes field %s is not supported", path)
+	}
+	return fields, nil
+}
+
+func (p fieldPath) leaf() protoreflect.FieldDescriptor {
+	return p[len(p)-1]
+}
+
+// get returns the value of the field in m, default one when unset
+func (p fieldPath) get(m protoreflect.Message) protoreflect.Value {
+	for _, fd := range p[:len(p)-1] {
+		m = m.Get(fd).Message()
+	}
+	return m.Get(p.leaf())
+}
+
+// compareValues compares values of field fd
+func compareValues(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) int {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case protoreflect.EnumKind:
+		return compareOrdered(a.Enum(), b.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return compareOrdered(a.Int(), b.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return compareOrdered(a.Uint(), b.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return compareOrdered(a.Float(), b.Float())
+	default:
+		return strings.Compare(a.String(), b.String())
+	}
+}
+
+func compareOrdered[T ~int32 | int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseFilter compiles AIP-160 filter for resources described by md
+func parseFilter(filter string, md protoreflect.MessageDescriptor) (matcher, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	var parser filtering.Parser
+	parser.Init(filter)
+	parsed, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return compileFilter(parsed.Expr, md)
+}
+
+func compileFilter(e *expr.Expr, md protoreflect.MessageDescriptor) (matcher, error) {
+	call := e.GetCallExpr()
+	if call == nil {
+		return nil, fmt.Errorf("unsupported restriction %s, a field has to be compared", literalText(e))
+	}
+	switch call.Function {
+	case filtering.FunctionAnd, filtering.FunctionFuzzyAnd, filtering.FunctionOr:
+		args := make([]matcher, len(call.Args))
+		for i, arg := range call.Args {
+			m, err := compileFilter(arg, md)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = m
+		}
+		// all args have to match for AND, any for OR
+		all := call.Function != filtering.FunctionOr
+		return func(m protoreflect.Message) bool {
+			for _, arg := range args {
+				if arg(m) != all {
+					return !all
+				}
+			}
+			return all
+		}, nil
+	case filtering.FunctionNot:
+		if len(call.Args) != 1 {
+			return nil, fmt.Errorf("%s expects one argument", call.Function)
+		}
+		arg, err := compileFilter(call.Args[0], md)
+		if err != nil {
+			return nil, err
+		}
+		return func(m protoreflect.Message) bool { return !arg(m) }, nil
+	case filtering.FunctionEquals, filtering.FunctionNotEquals, filtering.FunctionHas,
+		filtering.FunctionLessThan, filtering.FunctionLessEquals,
+		filtering.FunctionGreaterThan, filtering.FunctionGreaterEquals:
+		return compileRestriction(call, md)
+	default:
+		return nil, fmt.Errorf("unsupported function %s", call.Function)
+	}
+}
+
+func compileRestriction(call *expr.Expr_Call, md protoreflect.MessageDescriptor) (matcher, error) {
+	if len(call.Args) != 2 || call.Args[0].GetConstExpr() != nil {
+		return nil, fmt.Errorf("%s expects a field and a value", call.Function)
+	}
+	path, err := resolveFieldPath(md, literalText(call.Args[0]))
+	if err != nil {
+		return nil, err
+	}
+	fd := path.leaf()
+	arg := literalText(call.Args[1])
+	isString := fd.Kind() == protoreflect.StringKind
+	switch {
+	case call.Function == filtering.FunctionHas && arg == "*":
+		return func(m protoreflect.Message) bool {
+			return compareValues(fd, path.get(m), fd.Default()) != 0
+		}, nil
+	case call.Function == filtering.FunctionHas && isString:
+		return func(m protoreflect.Message) bool {
+			return strings.Contains(path.get(m).String(), arg)
+		}, nil
+	case (call.Function == filtering.FunctionEquals || call.Function == filtering.FunctionNotEquals) &&
+		isString && strings.Contains(arg, "*"):
+		equals := call.Function == filtering.FunctionEquals
+		return func(m protoreflect.Message) bool {
+			return matchWildcard(arg, path.get(m).String()) == equals
+		}, nil
+	}
+	value, err := parseValue(fd, arg)
+	if err != nil {
+		return nil, err
+	}
+	test := map[string]func(int) bool{
+		filtering.FunctionEquals:        func(c int) bool { return c == 0 },
+		filtering.FunctionHas:           func(c int) bool { return c == 0 },
+		filtering.FunctionNotEquals:     func(c int) bool { return c != 0 },
+		filtering.FunctionLessThan:      func(c int) bool { return c < 0 },
+		filtering.FunctionLessEquals:    func(c int) bool { return c <= 0 },
+		filtering.FunctionGreaterThan:   func(c int) bool { return c > 0 },
+		filtering.FunctionGreaterEquals: func(c int) bool { return c >= 0 },
+	}[call.Function]
+	return func(m protoreflect.Message) bool {
+		return test(compareValues(fd, path.get(m), value))
+	}, nil
+}
+
+// literalText returns filter value or member e.g. spec.nqn as text
+func literalText(e *expr.Expr) string {
+	switch kind := e.ExprKind.(type) {
+	case *expr.Expr_IdentExpr:
+		return kind.IdentExpr.Name
+	case *expr.Expr_SelectExpr:
+		return literalText(kind.SelectExpr.Operand) + "." + kind.SelectExpr.Field
+	case *expr.Expr_ConstExpr:
+		switch c := kind.ConstExpr.ConstantKind.(type) {
+		case *expr.Constant_StringValue:
+			return c.StringValue
+		case *expr.Constant_Int64Value:
+			return strconv.FormatInt(c.Int64Value, 10)
+		case *expr.Constant_DoubleValue:
+			return strconv.FormatFloat(c.DoubleValue, 'g', -1, 64)
+		}
+	}
+	return fmt.Sprintf("%v", e)
+}
+
+// parseValue converts filter value text into a value of field fd
+func parseValue(fd protoreflect.FieldDescriptor, text string) (protoreflect.Value, error) {
+	invalid := fmt.Errorf("invalid value %q for field %s", text, fd.Name())
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(text), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(text)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfBool(v), nil
+	case protoreflect.EnumKind:
+		if v := fd.Enum().Values().ByName(protoreflect.Name(text)); v != nil {
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+		v, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(text, 0, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfInt64(v), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(text, 0, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfUint64(v), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfFloat64(v), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("field %s of kind %v is not supported", fd.Name(), fd.Kind())
+	}
+}
+
+// matchWildcard matches s against pattern, where * stands for any text
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	first, last := parts[0], parts[len(parts)-1]
+	if !strings.HasPrefix(s, first) {
+		return false
+	}
+	s = s[len(first):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
+// orderField is a field listed resources are ordered by
+type orderField struct {
+	path fieldPath
+	desc bool
+}
+
+// parseOrderBy compiles AIP-132 order_by for resources described by md
+func parseOrderBy(orderBy string, md protoreflect.MessageDescriptor) ([]orderField, error) {
+	var parsed ordering.OrderBy
+	if err := parsed.UnmarshalString(orderBy); err != nil {
+		return nil, err
+	}
+	fields := make([]orderField, len(parsed.Fields))
+	for i, f := range parsed.Fields {
+		path, err := resolveFieldPath(md, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = orderField{path: path, desc: f.Desc}
+	}
+	return fields, nil
+}
+
+// compareOrder compares resources by fields, 0 when they are equal in all of them
+func compareOrder(fields []orderField, a, b protoreflect.Message) int {
+	for _, f := range fields {
+		c := compareValues(f.path.leaf(), f.path.get(a), f.path.get(b))
+		if f.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+)
+
+var testFilterPaths = []*pb.NvmePath{
+	{
+		Name:         "path0",
+		ControllerId: &pc.ObjectKey{Value: "Malloc0"},
+		Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Traddr:       "127.0.0.1",
+		Trsvcid:      4420,
+		Subnqn:       "nqn.2016-06.io.spdk:cnode0",
+	},
+	{
+		Name:         "path1",
+		ControllerId: &pc.ObjectKey{Value: "Malloc1"},
+		Trtype:       pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Traddr:       "127.0.0.2",
+		Trsvcid:      4421,
+		Subnqn:       "nqn.2016-06.io.spdk:cnode1",
+	},
+	{
+		Name:    "path2",
+		Trtype:  pb.NvmeTransportType_NVME_TRANSPORT_PCIE,
+		Traddr:  "0000:01:00.0",
+		Trsvcid: 0,
+	},
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := map[string]struct {
+		filter string
+		out    []string
+		err    bool
+	}{
+		"empty": {
+			filter: "",
+			out:    []string{"path0", "path1", "path2"},
+		},
+		"equals": {
+			filter: `name = "path1"`,
+			out:    []string{"path1"},
+		},
+		"equals unquoted": {
+			filter: `name = path1`,
+			out:    []string{"path1"},
+		},
+		"not equals": {
+			filter: `name != "path1"`,
+			out:    []string{"path0", "path2"},
+		},
+		"object key": {
+			filter: `controller_id = "Malloc0"`,
+			out:    []string{"path0"},
+		},
+		"object key value": {
+			filter: `controller_id.value = "Malloc1"`,
+			out:    []string{"path1"},
+		},
+		"has substring": {
+			filter: `subnqn : "cnode"`,
+			out:    []string{"path0", "path1"},
+		},
+		"has presence": {
+			filter: `controller_id:*`,
+			out:    []string{"path0", "path1"},
+		},
+		"wildcard": {
+			filter: `traddr = "127.*"`,
+			out:    []string{"path0", "path1"},
+		},
+		"enum": {
+			filter: `trtype = NVME_TRANSPORT_PCIE`,
+			out:    []string{"path2"},
+		},
+		"integer comparison": {
+			filter: `trsvcid >= 4421`,
+			out:    []string{"path1"},
+		},
+		"json name": {
+			filter: `controllerId = "Malloc1"`,
+			out:    []string{"path1"},
+		},
+		"and": {
+			filter: `trtype = NVME_TRANSPORT_TCP AND trsvcid < 4421`,
+			out:    []string{"path0"},
+		},
+		"sequence": {
+			filter: `trtype = NVME_TRANSPORT_TCP trsvcid > 4420`,
+			out:    []string{"path1"},
+		},
+		"or": {
+			filter: `name = path0 OR name = path2`,
+			out:    []string{"path0", "path2"},
+		},
+		"not": {
+			filter: `NOT (name = path0 OR name = path2)`,
+			out:    []string{"path1"},
+		},
+		"minus": {
+			filter: `-name = path0`,
+			out:    []string{"path1", "path2"},
+		},
+		"unknown field": {
+			filter: `unknown = 1`,
+			err:    true,
+		},
+		"invalid value": {
+			filter: `trsvcid = abc`,
+			err:    true,
+		},
+		"unknown enum value": {
+			filter: `trtype = NVME_TRANSPORT_UNKNOWN`,
+			err:    true,
+		},
+		"bare value": {
+			filter: `path0`,
+			err:    true,
+		},
+		"syntax error": {
+			filter: `name = (`,
+			err:    true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			match, err := parseFilter(tt.filter, (&pb.NvmePath{}).ProtoReflect().Descriptor())
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error %v, received: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			out := []string{}
+			for _, path := range testFilterPaths {
+				if match == nil || match(path.ProtoReflect()) {
+					out = append(out, path.Name)
+				}
+			}
+			if !reflect.DeepEqual(out, tt.out) {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+		})
+	}
+}
+
+func TestParseFilter_BytesNotSupported(t *testing.T) {
+	_, err := parseFilter(`key = "secret"`, (&pb.EncryptedVolume{}).ProtoReflect().Descriptor())
+	if err == nil {
+		t.Error("Expected filtering by key to be rejected")
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := map[string]struct {
+		orderBy string
+		out     []string
+		err     bool
+	}{
+		"ascending": {
+			orderBy: "trsvcid",
+			out:     []string{"path2", "path0", "path1"},
+		},
+		"descending": {
+			orderBy: "trsvcid desc",
+			out:     []string{"path1", "path0", "path2"},
+		},
+		"enum then string": {
+			orderBy: "trtype, traddr desc",
+			out:     []string{"path2", "path1", "path0"},
+		},
+		"object key": {
+			orderBy: "controller_id desc",
+			out:     []string{"path1", "path0", "path2"},
+		},
+		"unknown field": {
+			orderBy: "unknown",
+			err:     true,
+		},
+		"invalid direction": {
+			orderBy: "name up",
+			err:     true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fields, err := parseOrderBy(tt.orderBy, (&pb.NvmePath{}).ProtoReflect().Descriptor())
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error %v, received: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			paths := append([]*pb.NvmePath{}, testFilterPaths...)
+			sort.SliceStable(paths, func(i, j int) bool {
+				return compareOrder(fields, paths[i].ProtoReflect(), paths[j].ProtoReflect()) < 0
+			})
+			out := []string{}
+			for _, path := range paths {
+				out = append(out, path.Name)
+			}
+			if !reflect.DeepEqual(out, tt.out) {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+		})
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		"prefix":         {"Malloc*", "Malloc0", true},
+		"suffix":         {"*0", "Malloc0", true},
+		"infix":          {"M*c*0", "Malloc0", true},
+		"no match":       {"Null*", "Malloc0", false},
+		"overlapping":    {"ab*ba", "aba", false},
+		"only wildcard":  {"*", "", true},
+		"longer pattern": {"Malloc0*1", "Malloc0", false},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if match := matchWildcard(tt.pattern, tt.s); match != tt.match {
+				t.Errorf("Expected %v, received: %v", tt.match, match)
+			}
+		})
+	}
+}
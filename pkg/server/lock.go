@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"sort"
+	"sync"
+)
+
+// NameLocker serializes operations on resources with the same name, so
+// concurrent requests for one resource never reach SPDK at the same time,
+// while requests for different resources still run in parallel.
+// The zero value is ready to use.
+type NameLocker struct {
+	mu    sync.Mutex
+	locks map[string]*nameLock
+}
+
+type nameLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock blocks until all names are available and returns a function releasing them.
+// Names are always acquired in sorted order, so callers locking several names
+// (e.g. a resource and its parent) cannot deadlock each other.
+// Lock must not be called again while holding names returned by a previous call.
+func (l *NameLocker) Lock(names ...string) (unlock func()) {
+	sorted := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	locks := make([]*nameLock, len(sorted))
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*nameLock)
+	}
+	for i, name := range sorted {
+		lock, ok := l.locks[name]
+		if !ok {
+			lock = &nameLock{}
+			l.locks[name] = lock
+		}
+		lock.refs++
+		locks[i] = lock
+	}
+	l.mu.Unlock()
+
+	for _, lock := range locks {
+		lock.mu.Lock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].mu.Unlock()
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, name := range sorted {
+			locks[i].refs--
+			if locks[i].refs == 0 {
+				delete(l.locks, name)
+			}
+		}
+	}
+}
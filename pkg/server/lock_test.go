@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNameLocker_SerializesSameName(t *testing.T) {
+	var locker NameLocker
+	var wg sync.WaitGroup
+	active := 0
+	maxActive := 0
+	var mu sync.Mutex
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locker.Lock("volume-test")
+			defer unlock()
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if maxActive != 1 {
+		t.Errorf("expected at most 1 holder of the same name, received: %v", maxActive)
+	}
+	if len(locker.locks) != 0 {
+		t.Errorf("expected released names to be forgotten, received: %v", locker.locks)
+	}
+}
+
+func TestNameLocker_DifferentNamesInParallel(t *testing.T) {
+	var locker NameLocker
+	unlock := locker.Lock("volume-a")
+	defer unlock()
+
+	done := make(chan struct{})
+	go func() {
+		locker.Lock("volume-b")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected different name to be locked while volume-a is held")
+	}
+}
+
+func TestNameLocker_MultipleNamesNoDeadlock(t *testing.T) {
+	var locker NameLocker
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			locker.Lock("path", "controller")()
+		}()
+		go func() {
+			defer wg.Done()
+			locker.Lock("controller", "path", "controller")()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022-2023 Dell Inc, or its subsidiaries.
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultPageTokenTTL is how long an issued page token stays valid by default
+	DefaultPageTokenTTL = 10 * time.Minute
+	// DefaultPageTokenLimit is how many page tokens are kept by default
+	DefaultPageTokenLimit = 10000
+)
+
+// ListRequest is implemented by all OPI List requests
+type ListRequest interface {
+	proto.Message
+	GetParent() string
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+// List requests of OPI do not have filter and order_by fields yet, until they
+// are added, clients can provide them as gRPC metadata of the List call
+const (
+	// FilterMetadataKey is gRPC metadata key of AIP-160 filter of List requests
+	FilterMetadataKey = "filter"
+	// OrderByMetadataKey is gRPC metadata key of AIP-132 order_by of List requests
+	OrderByMetadataKey = "order_by"
+)
+
+// listParameter returns filter or order_by of List request in,
+// taken from gRPC metadata when the request has no such field
+func listParameter(ctx context.Context, in ListRequest, key string) string {
+	if r, ok := in.(filtering.Request); ok && key == FilterMetadataKey {
+		return r.GetFilter()
+	}
+	if r, ok := in.(ordering.Request); ok && key == OrderByMetadataKey {
+		return r.GetOrderBy()
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// pageScope identifies the List request a page token was issued for,
+// so a token cannot be used to page through a different list
+type pageScope struct {
+	kind    string
+	parent  string
+	filter  string
+	orderBy string
+}
+
+func pageScopeOf(ctx context.Context, in ListRequest) pageScope {
+	return pageScope{
+		kind:    string(in.ProtoReflect().Descriptor().FullName()),
+		parent:  in.GetParent(),
+		filter:  listParameter(ctx, in, FilterMetadataKey),
+		orderBy: listParameter(ctx, in, OrderByMetadataKey),
+	}
+}
+
+// pageCursor is the last element returned by previous page and its key
+type pageCursor struct {
+	last proto.Message
+	key  string
+}
+
+type pageToken struct {
+	scope   pageScope
+	cursor  pageCursor
+	expires time.Time
+}
+
+// PageTokens issues opaque List page tokens. A token remembers the last
+// element returned, so following pages stay stable when elements are
+// added or removed in between. Tokens expire after a TTL and at most
+// limit tokens are kept, the oldest ones are dropped first.
+// PageTokens is safe for concurrent use and can be shared by all services.
+type PageTokens struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	limit  int
+	now    func() time.Time
+	tokens map[string]*pageToken
+	// issued keeps tokens in order of expiration, since TTL is the same for all
+	issued []string
+}
+
+// NewPageTokens creates a PageTokens keeping at most limit tokens for ttl
+func NewPageTokens(ttl time.Duration, limit int) *PageTokens {
+	if ttl <= 0 {
+		log.Panicf("page token TTL has to be positive, got %v", ttl)
+	}
+	if limit <= 0 {
+		log.Panicf("page token limit has to be positive, got %v", limit)
+	}
+	return &PageTokens{
+		ttl:    ttl,
+		limit:  limit,
+		now:    time.Now,
+		tokens: make(map[string]*pageToken),
+	}
+}
+
+// Issue creates a token for the page of in following element last with key
+func (p *PageTokens) Issue(ctx context.Context, in ListRequest, last proto.Message, key string) string {
+	return p.issue(pageScopeOf(ctx, in), pageCursor{last: proto.Clone(last), key: key})
+}
+
+func (p *PageTokens) issue(scope pageScope, cursor pageCursor) string {
+	token := uuid.New().String()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune()
+	for len(p.issued) >= p.limit {
+		p.drop()
+	}
+	p.tokens[token] = &pageToken{
+		scope:   scope,
+		cursor:  cursor,
+		expires: p.now().Add(p.ttl),
+	}
+	p.issued = append(p.issued, token)
+	return token
+}
+
+// find returns the cursor of token issued for scope
+func (p *PageTokens) find(scope pageScope, token string) (*pageCursor, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune()
+	t, ok := p.tokens[token]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unable to find pagination token %s", token)
+	}
+	if t.scope != scope {
+		return nil, status.Errorf(codes.InvalidArgument, "pagination token %s does not match the request", token)
+	}
+	return &t.cursor, nil
+}
+
+// Len returns number of tokens currently kept
+func (p *PageTokens) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune()
+	return len(p.tokens)
+}
+
+// prune drops expired tokens, p.mu must be held
+func (p *PageTokens) prune() {
+	now := p.now()
+	for len(p.issued) > 0 && !now.Before(p.tokens[p.issued[0]].expires) {
+		p.drop()
+	}
+}
+
+// drop forgets the oldest token, p.mu must be held
+func (p *PageTokens) drop() {
+	delete(p.tokens, p.issued[0])
+	p.issued[0] = ""
+	p.issued = p.issued[1:]
+}
+
+// ListOptions holds page size, filter, ordering and position of the
+// previous page of a List request
+type ListOptions struct {
+	tokens  *PageTokens
+	scope   pageScope
+	size    int
+	match   matcher
+	orderBy []orderField
+	after   *pageCursor
+}
+
+// ParseListOptions is a helper function for List to parse PageSize, PageToken,
+// AIP-160 filter and AIP-132 order_by of in listing resources like resource
+func ParseListOptions(ctx context.Context, in ListRequest, tokens *PageTokens, resource proto.Message) (*ListOptions, error) {
+	const (
+		maxPageSize     = 250
+		defaultPageSize = 50
+	)
+	opts := &ListOptions{tokens: tokens, scope: pageScopeOf(ctx, in)}
+	switch pageSize := in.GetPageSize(); {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "negative PageSize is not allowed")
+	case pageSize == 0:
+		opts.size = defaultPageSize
+	case pageSize > maxPageSize:
+		opts.size = maxPageSize
+	default:
+		opts.size = int(pageSize)
+	}
+	md := resource.ProtoReflect().Descriptor()
+	var err error
+	opts.match, err = parseFilter(opts.scope.filter, md)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", opts.scope.filter, err)
+	}
+	opts.orderBy, err = parseOrderBy(opts.scope.orderBy, md)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by %q: %v", opts.scope.orderBy, err)
+	}
+	if token := in.GetPageToken(); token != "" {
+		opts.after, err = tokens.find(opts.scope, token)
+		if err != nil {
+			return nil, err
+		}
+		slog.Debug("Found last key from pagination token", "key", opts.after.key)
+	}
+	return opts, nil
+}
+
+// Paginate is a helper function to filter result, order it and slice a page
+// out of it according to opts. Elements are ordered by key when order_by is
+// not provided or they are equal in order_by fields. Token of the next page
+// is empty when there are no more elements.
+func Paginate[T proto.Message](opts *ListOptions, result []T, key func(T) string) ([]T, string) {
+	filtered := make([]T, 0, len(result))
+	for _, r := range result {
+		if opts.match == nil || opts.match(r.ProtoReflect()) {
+			filtered = append(filtered, r)
+		}
+	}
+	compare := func(a proto.Message, aKey string, b proto.Message, bKey string) int {
+		if c := compareOrder(opts.orderBy, a.ProtoReflect(), b.ProtoReflect()); c != 0 {
+			return c
+		}
+		return strings.Compare(aKey, bKey)
+	}
+	sort.SliceStable(filtered, func(i int, j int) bool {
+		return compare(filtered[i], key(filtered[i]), filtered[j], key(filtered[j])) < 0
+	})
+	start := 0
+	if opts.after != nil {
+		start = sort.Search(len(filtered), func(i int) bool {
+			return compare(filtered[i], key(filtered[i]), opts.after.last, opts.after.key) > 0
+		})
+	}
+	slog.Debug("Limiting result", "len", len(filtered), "start", start, "size", opts.size)
+	end := start + opts.size
+	if end >= len(filtered) {
+		return filtered[start:], ""
+	}
+	page := filtered[start:end]
+	last := page[len(page)-1]
+	return page, opts.tokens.issue(opts.scope, pageCursor{last: proto.Clone(last), key: key(last)})
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseListOptions(t *testing.T) {
+	withFilter := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(FilterMetadataKey, `name = "Malloc0"`))
+	tests := map[string]struct {
+		ctx     context.Context
+		in      *pb.ListNullDebugsRequest
+		elapsed time.Duration
+		size    int
+		after   string
+		errCode codes.Code
+	}{
+		"default page size": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo"},
+			size:    50,
+			errCode: codes.OK,
+		},
+		"page size overflow": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageSize: 1000},
+			size:    250,
+			errCode: codes.OK,
+		},
+		"negative page size": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageSize: -10},
+			errCode: codes.InvalidArgument,
+		},
+		"page token": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageSize: 5, PageToken: "issued"},
+			elapsed: time.Minute,
+			size:    5,
+			after:   "Malloc0",
+			errCode: codes.OK,
+		},
+		"expired page token": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageToken: "issued"},
+			elapsed: 2 * time.Minute,
+			errCode: codes.NotFound,
+		},
+		"unknown page token": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageToken: "unknown"},
+			errCode: codes.NotFound,
+		},
+		"page token of different parent": {
+			ctx:     context.Background(),
+			in:      &pb.ListNullDebugsRequest{Parent: "other", PageToken: "issued"},
+			errCode: codes.InvalidArgument,
+		},
+		"page token of different filter": {
+			ctx:     withFilter,
+			in:      &pb.ListNullDebugsRequest{Parent: "todo", PageToken: "issued"},
+			errCode: codes.InvalidArgument,
+		},
+		"invalid filter": {
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(FilterMetadataKey, `unknown = 1`)),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo"},
+			errCode: codes.InvalidArgument,
+		},
+		"invalid order_by": {
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(OrderByMetadataKey, `name up`)),
+			in:      &pb.ListNullDebugsRequest{Parent: "todo"},
+			errCode: codes.InvalidArgument,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			now := time.Now()
+			tokens := NewPageTokens(2*time.Minute, 10)
+			tokens.now = func() time.Time { return now }
+			issued := tokens.Issue(context.Background(), &pb.ListNullDebugsRequest{Parent: "todo"},
+				&pb.NullDebug{Name: "Malloc0"}, "Malloc0")
+			if tt.in.PageToken == "issued" {
+				tt.in.PageToken = issued
+			}
+			now = now.Add(tt.elapsed)
+
+			opts, err := ParseListOptions(tt.ctx, tt.in, tokens, &pb.NullDebug{})
+
+			if status.Code(err) != tt.errCode {
+				t.Fatalf("Expected error code %v, received: %v", tt.errCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if opts.size != tt.size {
+				t.Errorf("Expected size %v, received: %v", tt.size, opts.size)
+			}
+			after := ""
+			if opts.after != nil {
+				after = opts.after.key
+			}
+			if after != tt.after {
+				t.Errorf("Expected page after %v, received: %v", tt.after, after)
+			}
+		})
+	}
+}
+
+func TestPageTokens_Limit(t *testing.T) {
+	ctx := context.Background()
+	in := &pb.ListNullDebugsRequest{Parent: "todo"}
+	tokens := NewPageTokens(time.Minute, 2)
+	oldest := tokens.Issue(ctx, in, &pb.NullDebug{Name: "a"}, "a")
+	tokens.Issue(ctx, in, &pb.NullDebug{Name: "b"}, "b")
+	tokens.Issue(ctx, in, &pb.NullDebug{Name: "c"}, "c")
+
+	if tokens.Len() != 2 {
+		t.Errorf("Expected 2 tokens kept, received: %v", tokens.Len())
+	}
+	if _, err := tokens.find(pageScopeOf(ctx, in), oldest); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected oldest token to be dropped, received: %v", err)
+	}
+}
+
+func TestPageTokens_Expiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	tokens := NewPageTokens(time.Minute, 10)
+	tokens.now = func() time.Time { return now }
+	in := &pb.ListNullDebugsRequest{Parent: "todo"}
+	tokens.Issue(ctx, in, &pb.NullDebug{Name: "a"}, "a")
+	now = now.Add(30 * time.Second)
+	tokens.Issue(ctx, in, &pb.NullDebug{Name: "b"}, "b")
+
+	now = now.Add(45 * time.Second)
+	if tokens.Len() != 1 {
+		t.Errorf("Expected 1 token kept, received: %v", tokens.Len())
+	}
+	now = now.Add(time.Minute)
+	if tokens.Len() != 0 {
+		t.Errorf("Expected no tokens kept, received: %v", tokens.Len())
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := map[string]struct {
+		filter  string
+		orderBy string
+		size    int32
+		after   *pb.NullDebug
+		out     []string
+		next    bool
+	}{
+		"first page": {
+			size: 2,
+			out:  []string{"a", "b"},
+			next: true,
+		},
+		"last page": {
+			size:  2,
+			after: &pb.NullDebug{Name: "b", BlockSize: 512},
+			out:   []string{"c"},
+		},
+		"exact page": {
+			size:  2,
+			after: &pb.NullDebug{Name: "a", BlockSize: 4096},
+			out:   []string{"b", "c"},
+		},
+		"last element removed": {
+			size:  1,
+			after: &pb.NullDebug{Name: "aa", BlockSize: 512},
+			out:   []string{"b"},
+			next:  true,
+		},
+		"past the end": {
+			size:  1,
+			after: &pb.NullDebug{Name: "z", BlockSize: 512},
+			out:   []string{},
+		},
+		"filter": {
+			filter: "block_size = 512",
+			size:   5,
+			out:    []string{"b", "c"},
+		},
+		"order by": {
+			orderBy: "block_size desc",
+			size:    2,
+			out:     []string{"a", "b"},
+			next:    true,
+		},
+		"order by after": {
+			orderBy: "block_size",
+			size:    2,
+			after:   &pb.NullDebug{Name: "b", BlockSize: 512},
+			out:     []string{"c", "a"},
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(FilterMetadataKey, tt.filter, OrderByMetadataKey, tt.orderBy))
+			in := &pb.ListNullDebugsRequest{Parent: "todo", PageSize: tt.size}
+			tokens := NewPageTokens(time.Minute, 10)
+			if tt.after != nil {
+				in.PageToken = tokens.Issue(ctx, in, tt.after, tt.after.Name)
+			}
+			opts, err := ParseListOptions(ctx, in, tokens, &pb.NullDebug{})
+			if err != nil {
+				t.Fatalf("Expected no error, received: %v", err)
+			}
+			result := []*pb.NullDebug{
+				{Name: "c", BlockSize: 512},
+				{Name: "a", BlockSize: 4096},
+				{Name: "b", BlockSize: 512},
+			}
+
+			page, next := Paginate(opts, result, (*pb.NullDebug).GetName)
+
+			out := []string{}
+			for _, r := range page {
+				out = append(out, r.Name)
+			}
+			if !reflect.DeepEqual(out, tt.out) {
+				t.Errorf("Expected %v, received: %v", tt.out, out)
+			}
+			if (next != "") != tt.next {
+				t.Errorf("Expected next page %v, received token: %q", tt.next, next)
+			}
+		})
+	}
+}
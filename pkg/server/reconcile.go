@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opiproject/opi-spdk-bridge/pkg/store"
+	"go.einride.tech/aip/resourcename"
+	"golang.org/x/exp/slog"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReconcileMode defines how differences between resources known to the bridge
+// and objects configured in SPDK are resolved
+type ReconcileMode int
+
+const (
+	// ReconcileReport only reports differences, nothing is changed
+	ReconcileReport ReconcileMode = iota
+	// ReconcileAdopt imports objects found only in SPDK as bridge resources
+	// and forgets bridge resources missing in SPDK
+	ReconcileAdopt
+	// ReconcileCleanup deletes objects found only in SPDK
+	// and forgets bridge resources missing in SPDK
+	ReconcileCleanup
+)
+
+func (m ReconcileMode) String() string {
+	switch m {
+	case ReconcileReport:
+		return "report"
+	case ReconcileAdopt:
+		return "adopt"
+	case ReconcileCleanup:
+		return "cleanup"
+	default:
+		return fmt.Sprintf("ReconcileMode(%d)", int(m))
+	}
+}
+
+// ParseReconcileMode converts reconcile mode name into ReconcileMode
+func ParseReconcileMode(name string) (ReconcileMode, error) {
+	for _, mode := range []ReconcileMode{ReconcileReport, ReconcileAdopt, ReconcileCleanup} {
+		if mode.String() == name {
+			return mode, nil
+		}
+	}
+	return ReconcileReport, fmt.Errorf("unknown reconcile mode %q", name)
+}
+
+// ReconcileResult lists differences found between bridge and SPDK
+type ReconcileResult struct {
+	// Missing contains names of bridge resources without SPDK objects
+	Missing []string
+	// Orphaned contains SPDK objects not managed by the bridge
+	Orphaned []string
+}
+
+// AddMissing records bridge resource without SPDK object
+func (r *ReconcileResult) AddMissing(name string) {
+	slog.Warn("Reconcile: resource is missing in SPDK", "name", name)
+	r.Missing = append(r.Missing, name)
+}
+
+// AddOrphaned records SPDK object not managed by the bridge
+func (r *ReconcileResult) AddOrphaned(kind string, name string) {
+	orphan := fmt.Sprintf("%s %s", kind, name)
+	slog.Info("Reconcile: resource is not managed by the bridge", "name", orphan)
+	r.Orphaned = append(r.Orphaned, orphan)
+}
+
+// Merge appends differences found by other to r
+func (r *ReconcileResult) Merge(other *ReconcileResult) {
+	r.Missing = append(r.Missing, other.Missing...)
+	r.Orphaned = append(r.Orphaned, other.Orphaned...)
+}
+
+// Bdev is a subset of bdev_get_bdevs result used for reconciliation.
+// spdk.BdevGetBdevsResult lacks fields needed to tell bdev kinds apart.
+type Bdev struct {
+	Name           string   `json:"name"`
+	ProductName    string   `json:"product_name"`
+	BlockSize      int64    `json:"block_size"`
+	NumBlocks      int64    `json:"num_blocks"`
+	UUID           string   `json:"uuid"`
+	Aliases        []string `json:"aliases"`
+	DriverSpecific struct {
+		Aio struct {
+			Filename string `json:"filename"`
+		} `json:"aio"`
+		Uring struct {
+			Filename string `json:"filename"`
+		} `json:"uring"`
+		Crypto struct {
+			BaseBdevName string `json:"base_bdev_name"`
+			KeyName      string `json:"key_name"`
+		} `json:"crypto"`
+		Lvol struct {
+			LvolStoreUUID string `json:"lvol_store_uuid"`
+			ThinProvision bool   `json:"thin_provision"`
+			Snapshot      bool   `json:"snapshot"`
+			BaseSnapshot  string `json:"base_snapshot"`
+		} `json:"lvol"`
+		Raid struct {
+			StripSizeKb   int32  `json:"strip_size_kb"`
+			RaidLevel     string `json:"raid_level"`
+			BaseBdevsList []struct {
+				Name string `json:"name"`
+			} `json:"base_bdevs_list"`
+		} `json:"raid"`
+	} `json:"driver_specific"`
+}
+
+// AdoptableName returns resource name for an SPDK object to be adopted.
+// SPDK names which cannot be used as OPI resource IDs are rejected.
+func AdoptableName(spdkName string) (string, error) {
+	// SPDK objects are found by the last segment of resource name
+	if strings.Contains(spdkName, "/") {
+		return "", fmt.Errorf("cannot adopt %v: name contains '/'", spdkName)
+	}
+	name := ResourceIDToVolumeName(spdkName)
+	if err := resourcename.Validate(name); err != nil {
+		return "", fmt.Errorf("cannot adopt %v: %w", spdkName, err)
+	}
+	return name, nil
+}
+
+// ReconcileMissing reports resources from managed which are not present in SPDK.
+// Unless only reporting, such resources are dropped from managed and st.
+func ReconcileMissing[T proto.Message](mode ReconcileMode, st store.Store, managed map[string]T,
+	present func(T) bool, result *ReconcileResult) error {
+	names := make([]string, 0, len(managed))
+	for name := range managed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resource := managed[name]
+		if present(resource) {
+			continue
+		}
+		result.AddMissing(name)
+		if mode == ReconcileReport {
+			continue
+		}
+		if err := store.Remove(st, name, resource); err != nil {
+			return err
+		}
+		delete(managed, name)
+	}
+	return nil
+}
+
+// Adopt stores resource found in SPDK as a bridge resource
+func Adopt[T proto.Message](st store.Store, managed map[string]T, name string, resource T) error {
+	if err := store.Save(st, name, resource); err != nil {
+		return err
+	}
+	slog.Info("Reconcile: adopted", "name", name)
+	managed[name] = resource
+	return nil
+}
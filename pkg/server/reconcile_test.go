@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"testing"
+)
+
+func TestParseReconcileMode(t *testing.T) {
+	tests := map[string]struct {
+		in    string
+		out   ReconcileMode
+		isErr bool
+	}{
+		"report":  {in: "report", out: ReconcileReport},
+		"adopt":   {in: "adopt", out: ReconcileAdopt},
+		"cleanup": {in: "cleanup", out: ReconcileCleanup},
+		"unknown": {in: "delete", out: ReconcileReport, isErr: true},
+		"empty":   {in: "", out: ReconcileReport, isErr: true},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			mode, err := ParseReconcileMode(tt.in)
+			if (err != nil) != tt.isErr {
+				t.Errorf("Expected error %v, received: %v", tt.isErr, err)
+			}
+			if mode != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, mode)
+			}
+		})
+	}
+}
+
+func TestAdoptableName(t *testing.T) {
+	tests := map[string]struct {
+		in    string
+		out   string
+		isErr bool
+	}{
+		"bdev name":           {in: "Malloc0", out: ResourceIDToVolumeName("Malloc0")},
+		"lvol name with path": {in: "lvs0/lvol0", isErr: true},
+		"name with colon":     {in: "nqn.2016-06.io.spdk:cnode1", isErr: true},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			name, err := AdoptableName(tt.in)
+			if (err != nil) != tt.isErr {
+				t.Errorf("Expected error %v, received: %v", tt.isErr, err)
+			}
+			if name != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+
+	"github.com/opiproject/gospdk/spdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer spans of the bridge are created by
+const TracerName = "github.com/opiproject/opi-spdk-bridge"
+
+// StartSpan starts a span named name as a child of the span in ctx, if any.
+// Spans are recorded by the global TracerProvider, which drops them
+// unless tracing is configured
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends span, marking it failed if err is not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Call calls SPDK method of rpc in a span of the request ctx belongs to
+func Call(ctx context.Context, rpc spdk.JSONRPC, method string, args, result interface{}) error {
+	_, span := otel.Tracer(TracerName).Start(ctx, "spdk/"+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.service", "spdk"),
+			attribute.String("rpc.method", method),
+		))
+	err := rpc.Call(method, args, result)
+	EndSpan(span, err)
+	return err
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCall(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	tests := map[string]struct {
+		method string
+		status codes.Code
+	}{
+		"succeeded": {
+			method: "bdev_get_bdevs",
+			status: codes.Unset,
+		},
+		"failed": {
+			method: "bdev_aio_create",
+			status: codes.Error,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rpc := NewTestSpdkStub(map[string]string{"bdev_get_bdevs": `[]`})
+			ctx, parent := otel.Tracer(TracerName).Start(context.Background(), "request")
+
+			var result []Bdev
+			_ = Call(ctx, rpc, tt.method, nil, &result)
+			parent.End()
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-2]
+			if span.Name() != "spdk/"+tt.method {
+				t.Errorf("Expected span spdk/%v, received: %v", tt.method, span.Name())
+			}
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("Expected span to be child of request span")
+			}
+			if span.Status().Code != tt.status {
+				t.Errorf("Expected status %v, received: %v", tt.status, span.Status().Code)
+			}
+			if calls := rpc.Calls(tt.method); calls != 1 {
+				t.Errorf("Expected %v called once, received: %v", tt.method, calls)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package server
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
@@ -14,66 +15,78 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/opiproject/gospdk/spdk"
 )
 
-// ExtractPagination is a helper function for List pagination to fetch PageSize and PageToken
-func ExtractPagination(pageSize int32, pageToken string, pagination map[string]int) (size int, offset int, err error) {
-	const (
-		maxPageSize     = 250
-		defaultPageSize = 50
-	)
-	switch {
-	case pageSize < 0:
-		return -1, -1, status.Error(codes.InvalidArgument, "negative PageSize is not allowed")
-	case pageSize == 0:
-		size = defaultPageSize
-	case pageSize > maxPageSize:
-		size = maxPageSize
-	default:
-		size = int(pageSize)
-	}
-	// fetch offset from the database using opaque token
-	offset = 0
-	if pageToken != "" {
-		var ok bool
-		offset, ok = pagination[pageToken]
-		if !ok {
-			return -1, -1, status.Errorf(codes.NotFound, "unable to find pagination token %s", pageToken)
-		}
-		log.Printf("Found offset %d from pagination token: %s", offset, pageToken)
-	}
-	return size, offset, nil
-}
-
-// LimitPagination is a helper function for slice the result by offset and size
-func LimitPagination[T any](result []T, offset int, size int) ([]T, bool) {
-	end := offset + size
-	hasMoreElements := false
-	if end < len(result) {
-		hasMoreElements = true
-	} else {
-		end = len(result)
-	}
-	return result[offset:end], hasMoreElements
-}
-
 // CreateTestSpdkServer creates a mock spdk server for testing
 func CreateTestSpdkServer(socket string, spdkResponses []string) (net.Listener, spdk.JSONRPC) {
 	jsonRPC := spdk.NewSpdkJSONRPC(socket)
 	ln := jsonRPC.StartUnixListener()
 	if len(spdkResponses) > 0 {
-		go spdkMockServerCommunicate(jsonRPC, ln, spdkResponses)
+		go spdkMockServerCommunicate(ln, spdkResponses)
 	}
 	return ln, jsonRPC.(*spdk.SpdkJSONRPC)
 }
 
+// TestSpdkStub is a JSONRPC replying to every call of a method with the same
+// result. Unlike CreateTestSpdkServer it is safe for concurrent use, so it is
+// used by tests issuing requests in parallel.
+type TestSpdkStub struct {
+	// Delay simulates SPDK processing time, widening windows for races
+	Delay time.Duration
+
+	results map[string]string
+	mu      sync.Mutex
+	calls   map[string]int
+}
+
+// NewTestSpdkStub creates a TestSpdkStub with JSON encoded results per SPDK method
+func NewTestSpdkStub(results map[string]string) *TestSpdkStub {
+	return &TestSpdkStub{results: results, calls: make(map[string]int)}
+}
+
+// GetID returns fixed ID, since no requests are sent
+func (s *TestSpdkStub) GetID() uint64 {
+	return 0
+}
+
+// StartUnixListener returns nil, since no requests are sent
+func (s *TestSpdkStub) StartUnixListener() net.Listener {
+	return nil
+}
+
+// GetVersion returns empty version
+func (s *TestSpdkStub) GetVersion() string {
+	return ""
+}
+
+// Call decodes result configured for method into result
+func (s *TestSpdkStub) Call(method string, _ interface{}, result interface{}) error {
+	s.mu.Lock()
+	s.calls[method]++
+	s.mu.Unlock()
+	time.Sleep(s.Delay)
+	response, ok := s.results[method]
+	if !ok {
+		return fmt.Errorf("%s: no response configured", method)
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+// Calls returns how many times method was called
+func (s *TestSpdkStub) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
 // CloseGrpcConnection is utility function used to defer grpc connection close is tests
 func CloseGrpcConnection(conn *grpc.ClientConn) {
 	err := conn.Close()
@@ -100,30 +113,30 @@ func GenerateSocketName(testType string) string {
 	return filepath.Join(os.TempDir(), "opi-spdk-"+testType+"-test-"+fmt.Sprint(n)+".sock")
 }
 
-func spdkMockServerCommunicate(rpc spdk.JSONRPC, l net.Listener, toSend []string) {
+func spdkMockServerCommunicate(l net.Listener, toSend []string) {
 	for _, spdk := range toSend {
 		// wait for client to connect (accept stage)
 		fd, err := l.Accept()
 		if err != nil {
 			log.Fatal("accept error:", err)
 		}
-		log.Printf("SPDK mockup Server: client connected [%s]", fd.RemoteAddr().Network())
-		id := rpc.GetID()
-		log.Printf("SPDK ID [%d]", id)
+		slog.Debug("SPDK mockup Server: client connected", "network", fd.RemoteAddr().Network())
 		// read from client
-		// we just read to extract ID, rest of the data is discarded here
-		buf := make([]byte, 512)
-		nr, err := fd.Read(buf)
-		if err != nil {
+		// we just decode the ID, rest of the request is discarded here.
+		// The ID is not taken from rpc, as the client increments it
+		// concurrently with this goroutine
+		var request struct {
+			ID uint64 `json:"id"`
+		}
+		if err := json.NewDecoder(fd).Decode(&request); err != nil {
 			log.Panic("Read: ", err)
 		}
+		slog.Debug("SPDK mockup Server", "id", request.ID)
 		// fill in ID, since client expects the same ID in the response
-		data := buf[0:nr]
 		if strings.Contains(spdk, "%") {
-			spdk = fmt.Sprintf(spdk, id)
+			spdk = fmt.Sprintf(spdk, request.ID)
 		}
-		log.Printf("SPDK mockup Server: got : %s", string(data))
-		log.Printf("SPDK mockup Server: snd : %s", spdk)
+		slog.Debug("SPDK mockup Server: snd", "data", spdk)
 		// send data back to client
 		_, err = fd.Write([]byte(spdk))
 		if err != nil {
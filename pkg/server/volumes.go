@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"strings"
+	"sync"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Volume is an OPI resource other resources can be built on, backed by an
+// SPDK bdev
+type Volume struct {
+	Kind string
+	Name string
+	Bdev string
+}
+
+// VolumeProvider describes a kind of volume resources, e.g. AioControllers
+type VolumeProvider struct {
+	// Kind is the resource kind reported to clients, e.g. AioController
+	Kind string
+	// Bdev returns SPDK bdev of resource name of the kind, ok is false if
+	// there is no such resource
+	Bdev func(name string) (bdev string, ok bool)
+}
+
+// VolumeIndex maps names of volume resources of backend and middleend to
+// their SPDK bdevs, so volume_id of requests can refer to OPI resources
+// instead of bdevs. Providers are queried on every lookup rather than
+// tracked, so the index never gets out of sync with their resources.
+// The zero value is ready to use and a nil index has no volumes.
+type VolumeIndex struct {
+	mu        sync.RWMutex
+	providers []VolumeProvider
+}
+
+// Register adds kinds of volumes to the index
+func (i *VolumeIndex) Register(providers ...VolumeProvider) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.providers = append(i.providers, providers...)
+}
+
+// Lookup returns volume resource name, ok is false if there is no such
+// volume or the name is ambiguous
+func (i *VolumeIndex) Lookup(name string) (Volume, bool) {
+	volumes := i.lookupAll(name)
+	if len(volumes) != 1 {
+		return Volume{}, false
+	}
+	return volumes[0], true
+}
+
+// lookupAll returns volumes of all kinds named name. Resources of different
+// kinds may have the same name, e.g. an Lvol and an AioController.
+func (i *VolumeIndex) lookupAll(name string) []Volume {
+	if i == nil {
+		return nil
+	}
+	i.mu.RLock()
+	providers := i.providers
+	i.mu.RUnlock()
+	var volumes []Volume
+	for _, provider := range providers {
+		if bdev, ok := provider.Bdev(name); ok {
+			volumes = append(volumes, Volume{Kind: provider.Kind, Name: name, Bdev: bdev})
+		}
+	}
+	return volumes
+}
+
+// Resolve returns SPDK bdev of volume key refers to. A key with a resource
+// name, i.e. starting with //, has to name exactly one existing volume of
+// one of kinds, any kind if none is given. Other keys are SPDK bdev names
+// and are returned as they are.
+func (i *VolumeIndex) Resolve(key *pc.ObjectKey, kinds ...string) (string, error) {
+	name := key.GetValue()
+	if !strings.HasPrefix(name, "//") {
+		return name, nil
+	}
+	volumes := i.lookupAll(name)
+	if len(volumes) == 0 {
+		return "", status.Errorf(codes.NotFound, "unable to find key %s", name)
+	}
+	var found []string
+	var matching []Volume
+	for _, volume := range volumes {
+		found = append(found, volume.Kind)
+		if len(kinds) == 0 || slices.Contains(kinds, volume.Kind) {
+			matching = append(matching, volume)
+		}
+	}
+	switch {
+	case len(matching) == 0:
+		return "", status.Errorf(codes.InvalidArgument, "volume %s is %s, expected one of %v", name, strings.Join(found, ", "), kinds)
+	case len(matching) > 1:
+		return "", status.Errorf(codes.FailedPrecondition, "volume %s is ambiguous, it names %v", name, found)
+	}
+	return matching[0].Bdev, nil
+}
+
+// ResolveKey is Resolve replacing the volume name in key with its SPDK
+// bdev, so resources built on the volume keep the bdev they use
+func (i *VolumeIndex) ResolveKey(key *pc.ObjectKey, kinds ...string) error {
+	if key == nil {
+		return nil
+	}
+	bdev, err := i.Resolve(key, kinds...)
+	if err != nil {
+		return err
+	}
+	key.Value = bdev
+	return nil
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package server implements the server
+package server
+
+import (
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVolumeIndex_Resolve(t *testing.T) {
+	volumes := &VolumeIndex{}
+	volumes.Register(VolumeProvider{
+		Kind: "AioController",
+		Bdev: func(name string) (string, bool) {
+			return "aio0", name == ResourceIDToVolumeName("aio0")
+		},
+	})
+	volumes.Register(VolumeProvider{
+		Kind: "QosVolume",
+		Bdev: func(name string) (string, bool) {
+			return "aio0", name == ResourceIDToVolumeName("qos0")
+		},
+	})
+	volumes.Register(VolumeProvider{
+		Kind: "Lvol",
+		Bdev: func(name string) (string, bool) {
+			return "lvs0/aio0", name == ResourceIDToVolumeName("aio0")
+		},
+	})
+	tests := map[string]struct {
+		in      *pc.ObjectKey
+		kinds   []string
+		out     string
+		errCode codes.Code
+		errMsg  string
+	}{
+		"bdev name": {
+			in:  &pc.ObjectKey{Value: "Malloc0"},
+			out: "Malloc0",
+		},
+		"ambiguous volume name": {
+			in:      &pc.ObjectKey{Value: ResourceIDToVolumeName("aio0")},
+			errCode: codes.FailedPrecondition,
+			errMsg:  "volume //storage.opiproject.org/volumes/aio0 is ambiguous, it names [AioController Lvol]",
+		},
+		"volume name of other provider": {
+			in:  &pc.ObjectKey{Value: ResourceIDToVolumeName("qos0")},
+			out: "aio0",
+		},
+		"volume of allowed kind": {
+			in:    &pc.ObjectKey{Value: ResourceIDToVolumeName("aio0")},
+			kinds: []string{"NullDebug", "AioController"},
+			out:   "aio0",
+		},
+		"ambiguous volume of allowed kind": {
+			in:    &pc.ObjectKey{Value: ResourceIDToVolumeName("aio0")},
+			kinds: []string{"Lvol"},
+			out:   "lvs0/aio0",
+		},
+		"volume of other kind": {
+			in:      &pc.ObjectKey{Value: ResourceIDToVolumeName("qos0")},
+			kinds:   []string{"AioController"},
+			errCode: codes.InvalidArgument,
+			errMsg:  "volume //storage.opiproject.org/volumes/qos0 is QosVolume, expected one of [AioController]",
+		},
+		"unknown volume": {
+			in:      &pc.ObjectKey{Value: ResourceIDToVolumeName("unknown")},
+			errCode: codes.NotFound,
+			errMsg:  "unable to find key //storage.opiproject.org/volumes/unknown",
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bdev, err := volumes.Resolve(tt.in, tt.kinds...)
+
+			if er, ok := status.FromError(err); ok {
+				if er.Code() != tt.errCode {
+					t.Error("error code: expected", tt.errCode, "received", er.Code())
+				}
+				if er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", er.Message())
+				}
+			} else {
+				t.Error("expected grpc error status, received", err)
+			}
+			if bdev != tt.out {
+				t.Errorf("Expected %v, received: %v", tt.out, bdev)
+			}
+		})
+	}
+}
+
+func TestVolumeIndex_ResolveKey(t *testing.T) {
+	var volumes *VolumeIndex
+	key := &pc.ObjectKey{Value: "Malloc0"}
+	if err := volumes.ResolveKey(key); err != nil || key.Value != "Malloc0" {
+		t.Errorf("Expected bdev name kept, received: %v %v", key.Value, err)
+	}
+	key = &pc.ObjectKey{Value: ResourceIDToVolumeName("aio0")}
+	if err := volumes.ResolveKey(key); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected %v, received: %v", codes.NotFound, err)
+	}
+	volumes = &VolumeIndex{}
+	volumes.Register(VolumeProvider{
+		Kind: "AioController",
+		Bdev: func(name string) (string, bool) { return "aio0", true },
+	})
+	if err := volumes.ResolveKey(key); err != nil || key.Value != "aio0" {
+		t.Errorf("Expected %v, received: %v %v", "aio0", key.Value, err)
+	}
+	if err := volumes.ResolveKey(nil); err != nil {
+		t.Errorf("Expected no error, received: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package store implements persistence of OPI resources created by the bridge
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const fileExtension = ".json"
+
+// fileStore keeps every resource in its own file <dir>/<kind>/<name>.json.
+// Files can contain secrets (e.g. encryption keys), so they are accessible
+// by owner only.
+type fileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStore creates a Store keeping resources as JSON files under dir
+func NewFileStore(dir string) (Store, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("store directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("cannot create store directory %v: %w", dir, err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (f *fileStore) Put(kind string, name string, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kindDir := filepath.Join(f.dir, kind)
+	if err := os.MkdirAll(kindDir, 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first and rename it, so a crash
+	// never leaves a partially written resource behind
+	tmp, err := os.CreateTemp(kindDir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(kind, name))
+}
+
+func (f *fileStore) Delete(kind string, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.path(kind, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *fileStore) List(kind string) (map[string][]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make(map[string][]byte)
+	files, err := os.ReadDir(filepath.Join(f.dir, kind))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileExtension) {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(file.Name(), fileExtension))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected file %v in store: %w", file.Name(), err)
+		}
+		data, err := os.ReadFile(filepath.Join(f.dir, kind, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = data
+	}
+	return result, nil
+}
+
+func (f *fileStore) path(kind string, name string) string {
+	// resource names contain slashes, escape them to get a flat file name
+	return filepath.Join(f.dir, kind, url.PathEscape(name)+fileExtension)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package store implements persistence of OPI resources created by the bridge
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFileStore_SurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	volume := &pb.EncryptedVolume{Name: "//storage.opiproject.org/volumes/encrypted", Key: []byte("0123456789abcdef0123456789abcdef")}
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(s, volume.Name, volume); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumes := make(map[string]*pb.EncryptedVolume)
+	if err := Load(reopened, volumes); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(volumes[volume.Name], volume) {
+		t.Errorf("Expected %v, received: %v", volume, volumes)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"+fileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected exactly one file in store, received: %v", files)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected file permissions 0600, received: %v", info.Mode().Perm())
+	}
+}
+
+func TestFileStore_EmptyDir(t *testing.T) {
+	if _, err := NewFileStore(""); err == nil {
+		t.Error("Expected error for empty store directory")
+	}
+}
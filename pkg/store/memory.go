@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package store implements persistence of OPI resources created by the bridge
+package store
+
+import (
+	"sync"
+)
+
+type memoryStore struct {
+	mu    sync.Mutex
+	kinds map[string]map[string][]byte
+}
+
+// NewMemoryStore creates a Store keeping resources in memory only,
+// so nothing survives a bridge restart
+func NewMemoryStore() Store {
+	return &memoryStore{
+		kinds: make(map[string]map[string][]byte),
+	}
+}
+
+func (m *memoryStore) Put(kind string, name string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries, ok := m.kinds[kind]
+	if !ok {
+		entries = make(map[string][]byte)
+		m.kinds[kind] = entries
+	}
+	entries[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *memoryStore) Delete(kind string, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.kinds[kind], name)
+	return nil
+}
+
+func (m *memoryStore) List(kind string) (map[string][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string][]byte, len(m.kinds[kind]))
+	for name, data := range m.kinds[kind] {
+		result[name] = append([]byte(nil), data...)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package store implements persistence of OPI resources created by the bridge
+package store
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Store is a persistent key-value storage for OPI resources. Resources are
+// grouped by kind, since resource names are not unique across kinds.
+type Store interface {
+	// Put writes data of the resource with given kind and name
+	Put(kind string, name string, data []byte) error
+	// Delete removes the resource with given kind and name. Deleting
+	// a missing resource is not an error
+	Delete(kind string, name string) error
+	// List returns data of all resources of given kind keyed by name
+	List(kind string) (map[string][]byte, error)
+}
+
+// Save writes a resource to the store under the provided name
+func Save(s Store, name string, msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal %s: %v", name, err)
+	}
+	if err := s.Put(kindOf(msg), name, data); err != nil {
+		return status.Errorf(codes.Internal, "unable to store %s: %v", name, err)
+	}
+	return nil
+}
+
+// Remove deletes a resource of the same kind as msg from the store
+func Remove(s Store, name string, msg proto.Message) error {
+	if err := s.Delete(kindOf(msg), name); err != nil {
+		return status.Errorf(codes.Internal, "unable to remove %s from store: %v", name, err)
+	}
+	return nil
+}
+
+// Load fills dst with all resources of type T kept in the store
+func Load[T proto.Message](s Store, dst map[string]T) error {
+	var zero T
+	entries, err := s.List(kindOf(zero))
+	if err != nil {
+		return err
+	}
+	for name, data := range entries {
+		msg := zero.ProtoReflect().Type().New().Interface().(T)
+		if err := protojson.Unmarshal(data, msg); err != nil {
+			return err
+		}
+		dst[name] = msg
+	}
+	return nil
+}
+
+func kindOf(msg proto.Message) string {
+	return string(msg.ProtoReflect().Descriptor().FullName())
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package store implements persistence of OPI resources created by the bridge
+package store
+
+import (
+	"testing"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStore_SaveLoadRemove(t *testing.T) {
+	tests := map[string]struct {
+		newStore func(t *testing.T) Store
+	}{
+		"memory store": {
+			newStore: func(_ *testing.T) Store {
+				return NewMemoryStore()
+			},
+		},
+		"file store": {
+			newStore: func(t *testing.T) Store {
+				s, err := NewFileStore(t.TempDir())
+				if err != nil {
+					t.Fatal(err)
+				}
+				return s
+			},
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			s := tt.newStore(t)
+			aio := &pb.AioController{Name: "//storage.opiproject.org/volumes/myvolume", BlockSize: 512, Filename: "/tmp/aio_bdev_file"}
+			null := &pb.NullDebug{Name: "//storage.opiproject.org/volumes/myvolume", BlockSize: 4096, BlocksCount: 64}
+
+			if err := Save(s, aio.Name, aio); err != nil {
+				t.Fatal(err)
+			}
+			if err := Save(s, null.Name, null); err != nil {
+				t.Fatal(err)
+			}
+
+			aios := make(map[string]*pb.AioController)
+			if err := Load(s, aios); err != nil {
+				t.Fatal(err)
+			}
+			if len(aios) != 1 || !proto.Equal(aios[aio.Name], aio) {
+				t.Errorf("Expected %v, received: %v", aio, aios)
+			}
+			nulls := make(map[string]*pb.NullDebug)
+			if err := Load(s, nulls); err != nil {
+				t.Fatal(err)
+			}
+			if len(nulls) != 1 || !proto.Equal(nulls[null.Name], null) {
+				t.Errorf("Expected %v, received: %v", null, nulls)
+			}
+
+			if err := Remove(s, aio.Name, aio); err != nil {
+				t.Fatal(err)
+			}
+			if err := Remove(s, aio.Name, aio); err != nil {
+				t.Errorf("Expected removal of missing resource to succeed, received: %v", err)
+			}
+			aios = make(map[string]*pb.AioController)
+			if err := Load(s, aios); err != nil {
+				t.Fatal(err)
+			}
+			if len(aios) != 0 {
+				t.Errorf("Expected no AioControllers, received: %v", aios)
+			}
+			nulls = make(map[string]*pb.NullDebug)
+			if err := Load(s, nulls); err != nil {
+				t.Fatal(err)
+			}
+			if len(nulls) != 1 {
+				t.Errorf("Expected NullDebug to stay, received: %v", nulls)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package tlsconfig secures the gRPC API of the bridge with TLS
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/local"
+)
+
+// Options point to PEM files securing the API
+type Options struct {
+	// CertFile holds the server certificate chain
+	CertFile string
+	// KeyFile holds the private key of the server certificate
+	KeyFile string
+	// ClientCAFile holds CAs client certificates are verified with,
+	// clients are not authenticated if empty
+	ClientCAFile string
+}
+
+// Enabled reports whether TLS is configured
+func (o Options) Enabled() bool {
+	return o.CertFile != "" || o.KeyFile != "" || o.ClientCAFile != ""
+}
+
+func (o Options) validate() error {
+	if o.CertFile == "" || o.KeyFile == "" {
+		return errors.New("both certificate and key files have to be specified")
+	}
+	return nil
+}
+
+// fileStamp identifies a version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader keeps TLS configuration up to date with files it is loaded from,
+// so that rotated certificates are used for new connections without restart.
+// Files are checked for changes on every handshake and if they fail to load,
+// the last valid configuration is kept.
+type Reloader struct {
+	opts Options
+
+	mu     sync.Mutex
+	stamps map[string]fileStamp
+	config *tls.Config
+}
+
+// NewReloader loads TLS configuration from files of opts
+func NewReloader(opts Options) (*Reloader, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	r := &Reloader{opts: opts}
+	stamps, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if r.config, err = r.load(); err != nil {
+		return nil, err
+	}
+	r.stamps = stamps
+	return r, nil
+}
+
+// Config returns configuration of a TLS server using the latest certificates
+func (r *Reloader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// current returns configuration loaded from files, reloading it if they
+// changed since the last attempt
+func (r *Reloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stamps, err := r.stat()
+	if err != nil || !r.changed(stamps) {
+		return r.config
+	}
+	// a failed attempt is not retried until files change again, e.g.
+	// when a key is written after its certificate
+	r.stamps = stamps
+	config, err := r.load()
+	if err != nil {
+		slog.Error("Unable to reload TLS configuration, keeping the previous one", "err", err)
+		return r.config
+	}
+	slog.Info("Reloaded TLS configuration", "cert", r.opts.CertFile)
+	r.config = config
+	return r.config
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) stat() (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		stamps[file] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
+
+func (r *Reloader) changed(stamps map[string]fileStamp) bool {
+	for file, stamp := range stamps {
+		if r.stamps[file] != stamp {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads configuration from files
+func (r *Reloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load certificate: %w", err)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if r.opts.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client CAs: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no client CA certificates found in %v", r.opts.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// NewServerCredentials returns credentials of a gRPC server securing TCP
+// connections by TLS configured by r. Connections accepted on unix sockets
+// are not encrypted, they are restricted to local callers by socket
+// permissions instead.
+func NewServerCredentials(r *Reloader) credentials.TransportCredentials {
+	return &serverCredentials{
+		TransportCredentials: credentials.NewTLS(r.Config()),
+		local:                local.NewCredentials(),
+	}
+}
+
+type serverCredentials struct {
+	credentials.TransportCredentials
+	local credentials.TransportCredentials
+}
+
+func (c *serverCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if conn.LocalAddr().Network() == "unix" {
+		return c.local.ServerHandshake(conn)
+	}
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c *serverCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("server credentials used by a client")
+}
+
+func (c *serverCredentials) Clone() credentials.TransportCredentials {
+	return &serverCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		local:                c.local.Clone(),
+	}
+}
+
+// ListenUnix listens on unix socket path accessible by the owner and group
+// only, a socket left behind by a previous run is replaced
+func ListenUnix(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o660); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
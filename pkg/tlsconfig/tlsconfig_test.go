@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package tlsconfig secures the gRPC API of the bridge with TLS
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by parent, self-signed if nil
+func newTestCert(t *testing.T, serial int64, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "opi-test"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM(), c.keyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// writeFile writes data to file of dir, making its modification time differ
+// from the previous version
+func writeFile(t *testing.T, dir, name string, data []byte, version int) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Duration(version) * time.Minute)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestNewReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil)
+	server := newTestCert(t, 2, ca)
+	certFile := writeFile(t, dir, "tls.crt", server.certPEM(), 0)
+	keyFile := writeFile(t, dir, "tls.key", server.keyPEM(t), 0)
+	caFile := writeFile(t, dir, "ca.crt", ca.certPEM(), 0)
+	garbage := writeFile(t, dir, "garbage", []byte("garbage"), 0)
+
+	tests := map[string]struct {
+		opts Options
+		err  bool
+	}{
+		"tls": {
+			opts: Options{CertFile: certFile, KeyFile: keyFile},
+			err:  false,
+		},
+		"mutual tls": {
+			opts: Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile},
+			err:  false,
+		},
+		"missing key": {
+			opts: Options{CertFile: certFile, ClientCAFile: caFile},
+			err:  true,
+		},
+		"nonexistent certificate": {
+			opts: Options{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyFile},
+			err:  true,
+		},
+		"key of another certificate": {
+			opts: Options{CertFile: caFile, KeyFile: keyFile},
+			err:  true,
+		},
+		"invalid client CA": {
+			opts: Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: garbage},
+			err:  true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			r, err := NewReloader(tt.opts)
+
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+			if err == nil && tt.opts.ClientCAFile != "" && r.current().ClientAuth != tls.RequireAndVerifyClientCert {
+				t.Errorf("Expected client certificates required")
+			}
+		})
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil)
+	first := newTestCert(t, 2, ca)
+	second := newTestCert(t, 3, ca)
+	opts := Options{
+		CertFile: writeFile(t, dir, "tls.crt", first.certPEM(), 0),
+		KeyFile:  writeFile(t, dir, "tls.key", first.keyPEM(t), 0),
+	}
+	r, err := NewReloader(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial := func() int64 {
+		leaf, err := x509.ParseCertificate(r.current().Certificates[0].Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return leaf.SerialNumber.Int64()
+	}
+	if s := serial(); s != 2 {
+		t.Errorf("Expected certificate 2, received: %v", s)
+	}
+
+	// certificate rotated before its key does not match, so the previous one is kept
+	writeFile(t, dir, "tls.crt", second.certPEM(), 1)
+	if s := serial(); s != 2 {
+		t.Errorf("Expected certificate 2 kept, received: %v", s)
+	}
+
+	writeFile(t, dir, "tls.key", second.keyPEM(t), 1)
+	if s := serial(); s != 3 {
+		t.Errorf("Expected certificate 3 reloaded, received: %v", s)
+	}
+}
+
+func TestNewServerCredentials(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil)
+	server := newTestCert(t, 2, ca)
+	client := newTestCert(t, 3, ca)
+	r, err := NewReloader(Options{
+		CertFile:     writeFile(t, dir, "tls.crt", server.certPEM(), 0),
+		KeyFile:      writeFile(t, dir, "tls.key", server.keyPEM(t), 0),
+		ClientCAFile: writeFile(t, dir, "ca.crt", ca.certPEM(), 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer(grpc.Creds(NewServerCredentials(r)))
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	tcpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unixSocket := filepath.Join(dir, "opi.sock")
+	unixLis, err := ListenUnix(unixSocket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.Serve(tcpLis) }()
+	go func() { _ = s.Serve(unixLis) }()
+	defer s.Stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	tests := map[string]struct {
+		target string
+		creds  credentials.TransportCredentials
+		err    bool
+	}{
+		"client certificate": {
+			target: tcpLis.Addr().String(),
+			creds: credentials.NewTLS(&tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				RootCAs:      roots,
+				Certificates: []tls.Certificate{client.tlsCertificate(t)},
+			}),
+			err: false,
+		},
+		"no client certificate": {
+			target: tcpLis.Addr().String(),
+			creds:  credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots}),
+			err:    true,
+		},
+		"plaintext over tcp": {
+			target: tcpLis.Addr().String(),
+			creds:  insecure.NewCredentials(),
+			err:    true,
+		},
+		"plaintext over unix socket": {
+			target: "unix://" + unixSocket,
+			creds:  insecure.NewCredentials(),
+			err:    false,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			conn, err := grpc.Dial(tt.target, grpc.WithTransportCredentials(tt.creds))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+			if (err != nil) != tt.err {
+				t.Errorf("Expected error %v, received: %v", tt.err, err)
+			}
+		})
+	}
+
+	if info, err := os.Stat(unixSocket); err != nil || info.Mode().Perm() != 0o660 {
+		t.Errorf("Expected socket accessible by owner and group only, received: %v, %v", info, err)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package tracing exports OpenTelemetry traces of the bridge
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	// ExporterOtlp exports spans to an OTLP collector over gRPC
+	ExporterOtlp = "otlp"
+	// ExporterStdout writes spans as JSON, for local testing
+	ExporterStdout = "stdout"
+
+	serviceName = "opi-spdk-bridge"
+)
+
+// Options configure where spans are exported to
+type Options struct {
+	// Exporter is ExporterOtlp or ExporterStdout
+	Exporter string
+	// Endpoint is host:port of OTLP collector, the default one if empty
+	Endpoint string
+	// Insecure disables TLS of connection to OTLP collector
+	Insecure bool
+	// Writer receives spans of ExporterStdout
+	Writer io.Writer
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case ExporterOtlp:
+		var clientOpts []otlptracegrpc.Option
+		if opts.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, clientOpts...)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(opts.Writer))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, expected %v or %v", opts.Exporter, ExporterOtlp, ExporterStdout)
+	}
+}
+
+// NewTracerProvider creates TracerProvider exporting spans in batches as
+// configured by opts. It has to be shut down to flush pending spans.
+func NewTracerProvider(ctx context.Context, opts Options) (*sdktrace.TracerProvider, error) {
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	), nil
+}
+
+// Install makes tp record spans of the bridge and trace context of
+// incoming requests be propagated to them
+func Install(tp *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// UnaryServerInterceptor returns gRPC interceptor starting a span for each
+// request, a child of the span the client propagated, if any
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 Intel Corporation
+
+// Package tracing exports OpenTelemetry traces of the bridge
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	tests := map[string]struct {
+		opts Options
+		err  bool
+	}{
+		"stdout": {
+			opts: Options{Exporter: ExporterStdout},
+			err:  false,
+		},
+		"otlp": {
+			opts: Options{Exporter: ExporterOtlp, Endpoint: "localhost:4317", Insecure: true},
+			err:  false,
+		},
+		"unknown exporter": {
+			opts: Options{Exporter: "jaeger"},
+			err:  true,
+		},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.opts.Writer = &out
+
+			tp, err := NewTracerProvider(context.Background(), tt.opts)
+
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error %v, received: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if tt.opts.Exporter != ExporterStdout {
+				_ = tp.Shutdown(context.Background())
+				return
+			}
+			_, span := tp.Tracer("test").Start(context.Background(), "CreateNvmeController")
+			span.End()
+			if err := tp.Shutdown(context.Background()); err != nil {
+				t.Errorf("Expected no error, received: %v", err)
+			}
+			if !strings.Contains(out.String(), `"Name":"CreateNvmeController"`) {
+				t.Errorf("Expected span exported, received: %v", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+#!/bin/bash
+# SPDX-License-Identifier: Apache-2.0
+# Copyright (C) 2023 Intel Corporation
+
+# Generates Go code of the bridge API in api/storage/v1alpha1/gen/go.
+# protoc has to be in PATH, its plugins are installed in the pinned versions
+# the checked in code is generated with. Imported OPI protos are taken from
+# the opi-api version in go.mod, google/api protos from GOOGLEAPIS, which
+# are downloaded if it is not set.
+
+set -euxo pipefail
+
+PROTOC_GEN_GO_VERSION=v1.31.0
+PROTOC_GEN_GO_GRPC_VERSION=v1.3.0
+
+cd "$(dirname "$0")/.."
+API=api/storage/v1alpha1
+
+TMP=$(mktemp -d)
+trap 'rm -rf "${TMP}"' EXIT
+
+GOBIN="${TMP}/bin" go install "google.golang.org/protobuf/cmd/protoc-gen-go@${PROTOC_GEN_GO_VERSION}"
+GOBIN="${TMP}/bin" go install "google.golang.org/grpc/cmd/protoc-gen-go-grpc@${PROTOC_GEN_GO_GRPC_VERSION}"
+
+OPI_API=$(go list -m -f '{{.Dir}}' github.com/opiproject/opi-api)
+if [ -z "${GOOGLEAPIS:-}" ]; then
+    GOOGLEAPIS="${TMP}/googleapis"
+    mkdir -p "${GOOGLEAPIS}"
+    curl -fsSL https://github.com/googleapis/googleapis/archive/master.tar.gz | tar -C "${GOOGLEAPIS}" --strip=1 -zxf - googleapis-master/google/api
+fi
+
+rm -f "${API}"/gen/go/*.pb.go
+PATH="${TMP}/bin:${PATH}" protoc -I "${API}" -I "${OPI_API}/storage/v1alpha1" -I "${OPI_API}/common/v1" -I "${GOOGLEAPIS}" \
+    --go_out="${API}/gen/go" --go_opt=paths=source_relative \
+    --go-grpc_out="${API}/gen/go" --go-grpc_opt=paths=source_relative \
+    "${API}"/*.proto